@@ -5,12 +5,16 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"github.com/joho/godotenv"
 	sentryUtil "github.com/metorial/metorial/services/code-bucket/pkg/sentry-util"
 	"github.com/metorial/metorial/services/code-bucket/internal/service"
 	"github.com/metorial/metorial/services/code-bucket/pkg/fs"
+	"github.com/metorial/metorial/services/code-bucket/pkg/git"
+	"github.com/metorial/metorial/services/code-bucket/pkg/keyring"
+	zipImporter "github.com/metorial/metorial/services/code-bucket/pkg/zip-importer"
 )
 
 func main() {
@@ -26,7 +30,30 @@ func main() {
 	rpcAddress := getEnvOrDefault("CODE_BUCKET_RPC_ADDRESS", ":5050")
 	workspaceAddress := getEnvOrDefault("CODE_BUCKET_WORKSPACE_ADDRESS", ":52092")
 
-	jwtSecret := mustGetEnv("CODE_BUCKET_JWT_SECRET")
+	// Tokens are signed with a key from the keys directory, the shared secret
+	// is only needed to keep accepting tokens issued before the switch
+	keys, err := keyring.New(
+		os.Getenv("CODE_BUCKET_JWT_SECRET"),
+		os.Getenv("CODE_BUCKET_JWT_KEYS_DIR"),
+		os.Getenv("CODE_BUCKET_JWT_SIGNING_KEY_ID"),
+	)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+
+	httpLimits := service.DefaultHttpLimits()
+	httpLimits.Token.Rate = getFloatEnvOrDefault("CODE_BUCKET_RATE_LIMIT_TOKEN_RPS", httpLimits.Token.Rate)
+	httpLimits.Token.Burst = int(getIntEnvOrDefault("CODE_BUCKET_RATE_LIMIT_TOKEN_BURST", int64(httpLimits.Token.Burst)))
+	httpLimits.Bucket.Rate = getFloatEnvOrDefault("CODE_BUCKET_RATE_LIMIT_BUCKET_RPS", httpLimits.Bucket.Rate)
+	httpLimits.Bucket.Burst = int(getIntEnvOrDefault("CODE_BUCKET_RATE_LIMIT_BUCKET_BURST", int64(httpLimits.Bucket.Burst)))
+	httpLimits.MaxBodySize = getIntEnvOrDefault("CODE_BUCKET_MAX_BODY_SIZE", httpLimits.MaxBodySize)
+
+	// X-Forwarded-For is only read from these, comma separated CIDRs
+	trustedProxies, err := service.ParseTrustedProxies(os.Getenv("CODE_BUCKET_TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("Failed to parse trusted proxies: %v", err)
+	}
+
 	objectStorageEndpoint := mustGetEnv("CODE_BUCKET_OBJECT_STORAGE_ENDPOINT")
 	objectStorageBucket := mustGetEnv("CODE_BUCKET_OBJECT_STORAGE_BUCKET")
 	redisURL := os.Getenv("CODE_BUCKET_REDIS_URL")
@@ -51,10 +78,15 @@ func main() {
 		}
 	}
 
-	service := service.NewService(jwtSecret,
+	maxFileSize := getIntEnvOrDefault("CODE_BUCKET_MAX_FILE_SIZE", fs.DefaultMaxFileSize)
+	zipImporter.DefaultLimits.MaxFileSize = maxFileSize
+	git.DefaultLimits.MaxFileSize = maxFileSize
+
+	service := service.NewService(keys, httpLimits, trustedProxies, os.Getenv("CODE_BUCKET_PRESIGN_SECRET"),
 		fs.WithObjectStorageEndpoint(objectStorageEndpoint),
 		fs.WithObjectStorageBucket(objectStorageBucket),
 		fs.WithRedisURL(redisURL),
+		fs.WithMaxFileSize(maxFileSize),
 	)
 
 	service.Start(httpAddress, rpcAddress, workspaceAddress)
@@ -86,3 +118,27 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getIntEnvOrDefault(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Fatalf("Environment variable %s must be an integer", key)
+	}
+	return parsed
+}
+
+func getFloatEnvOrDefault(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Fatalf("Environment variable %s must be a number", key)
+	}
+	return parsed
+}
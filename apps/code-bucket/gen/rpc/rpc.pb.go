@@ -27,6 +27,7 @@ type FileInfo struct {
 	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	ModifiedAt    int64                  `protobuf:"varint,4,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	Hash          string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"` // Hex encoded SHA-256 of the content
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +90,13 @@ func (x *FileInfo) GetModifiedAt() int64 {
 	return 0
 }
 
+func (x *FileInfo) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
 type FileContent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
@@ -145,6 +153,8 @@ type CloneBucketRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	SourceBucketId string                 `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
 	NewBucketId    string                 `protobuf:"bytes,2,opt,name=new_bucket_id,json=newBucketId,proto3" json:"new_bucket_id,omitempty"`
+	Include        []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"` // Gitignore-style globs, e.g. "src/**"
+	Exclude        []string               `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"` // Gitignore-style globs, e.g. "node_modules/**"
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -193,10 +203,24 @@ func (x *CloneBucketRequest) GetNewBucketId() string {
 	return ""
 }
 
+func (x *CloneBucketRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *CloneBucketRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
 type CreateBucketFromZipRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NewBucketId   string                 `protobuf:"bytes,1,opt,name=new_bucket_id,json=newBucketId,proto3" json:"new_bucket_id,omitempty"`
-	ZipUrl        string                 `protobuf:"bytes,2,opt,name=zip_url,json=zipUrl,proto3" json:"zip_url,omitempty"`
+	ZipUrl        string                 `protobuf:"bytes,2,opt,name=zip_url,json=zipUrl,proto3" json:"zip_url,omitempty"` // A zip, tar, tar.gz or tar.zst archive, detected by its content
 	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
 	Headers       map[string]string      `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
@@ -490,6 +514,8 @@ type GetBucketTokenRequest struct {
 	BucketId         string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
 	ExpiresInSeconds int64                  `protobuf:"varint,2,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"`
 	IsReadOnly       bool                   `protobuf:"varint,3,opt,name=is_read_only,json=isReadOnly,proto3" json:"is_read_only,omitempty"`
+	Actor            string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`   // Recorded on changes made with the token
+	Scopes           []*TokenScope          `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"` // Unrestricted if empty
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -545,9 +571,25 @@ func (x *GetBucketTokenRequest) GetIsReadOnly() bool {
 	return false
 }
 
+func (x *GetBucketTokenRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *GetBucketTokenRequest) GetScopes() []*TokenScope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 type GetBucketTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TokenId       string                 `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"` // jti of the token, used to revoke it
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -589,6 +631,20 @@ func (x *GetBucketTokenResponse) GetToken() string {
 	return ""
 }
 
+func (x *GetBucketTokenResponse) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *GetBucketTokenResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 type GetBucketFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
@@ -688,7 +744,9 @@ func (x *GetBucketFileResponse) GetContent() *FileContent {
 type GetBucketFilesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
-	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"` // Optional filter
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`   // Optional filter
+	Include       []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"` // Gitignore-style globs, e.g. "src/**"
+	Exclude       []string               `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"` // Gitignore-style globs, e.g. "node_modules/**"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -737,6 +795,20 @@ func (x *GetBucketFilesRequest) GetPrefix() string {
 	return ""
 }
 
+func (x *GetBucketFilesRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *GetBucketFilesRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
 type GetBucketFilesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Files         []*FileInfo            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
@@ -828,7 +900,9 @@ func (x *GetBucketFilesWithContentResponse) GetFiles() []*FileContent {
 type GetBucketFilesAsZipRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
-	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"` // Optional filter
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`   // Optional filter
+	Include       []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"` // Gitignore-style globs, e.g. "src/**"
+	Exclude       []string               `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"` // Gitignore-style globs, e.g. "node_modules/**"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -877,9 +951,23 @@ func (x *GetBucketFilesAsZipRequest) GetPrefix() string {
 	return ""
 }
 
+func (x *GetBucketFilesAsZipRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *GetBucketFilesAsZipRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
 type GetBucketFilesAsZipResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Relative to the HTTP API. Presigned if presigned urls are enabled, otherwise it takes a token
 	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1202,14 +1290,26 @@ func (*DeleteBucketFileResponse) Descriptor() ([]byte, []int) {
 }
 
 type ExportBucketToGithubRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
-	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
-	Repo          string                 `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
-	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
-	Token         string                 `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BucketId          string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Owner             string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Repo              string                 `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+	Path              string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Token             string                 `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	Include           []string               `protobuf:"bytes,6,rep,name=include,proto3" json:"include,omitempty"`                         // Gitignore-style globs, e.g. "src/**"
+	Exclude           []string               `protobuf:"bytes,7,rep,name=exclude,proto3" json:"exclude,omitempty"`                         // Gitignore-style globs, e.g. "node_modules/**"
+	Branch            string                 `protobuf:"bytes,8,opt,name=branch,proto3" json:"branch,omitempty"`                           // Defaults to main
+	BaseBranch        string                 `protobuf:"bytes,9,opt,name=base_branch,json=baseBranch,proto3" json:"base_branch,omitempty"` // Branch a missing branch is created from, defaults to the default branch
+	Message           string                 `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`                        // Optional commit message
+	AuthorName        string                 `protobuf:"bytes,11,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
+	AuthorEmail       string                 `protobuf:"bytes,12,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`
+	CreatePullRequest bool                   `protobuf:"varint,13,opt,name=create_pull_request,json=createPullRequest,proto3" json:"create_pull_request,omitempty"` // Opens a pull request from the branch into the base branch, or returns the open one
+	PullRequestTitle  string                 `protobuf:"bytes,14,opt,name=pull_request_title,json=pullRequestTitle,proto3" json:"pull_request_title,omitempty"`     // Defaults to the first line of the commit message
+	PullRequestBody   string                 `protobuf:"bytes,15,opt,name=pull_request_body,json=pullRequestBody,proto3" json:"pull_request_body,omitempty"`
+	Sync              bool                   `protobuf:"varint,16,opt,name=sync,proto3" json:"sync,omitempty"`                                     // Deletes files under path that aren't in the bucket, in the same commit
+	MaxDeletions      int32                  `protobuf:"varint,17,opt,name=max_deletions,json=maxDeletions,proto3" json:"max_deletions,omitempty"` // Sync fails instead of deleting more files, defaults to 100
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ExportBucketToGithubRequest) Reset() {
@@ -1277,10 +1377,97 @@ func (x *ExportBucketToGithubRequest) GetToken() string {
 	return ""
 }
 
+func (x *ExportBucketToGithubRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *ExportBucketToGithubRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *ExportBucketToGithubRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubRequest) GetBaseBranch() string {
+	if x != nil {
+		return x.BaseBranch
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubRequest) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubRequest) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubRequest) GetCreatePullRequest() bool {
+	if x != nil {
+		return x.CreatePullRequest
+	}
+	return false
+}
+
+func (x *ExportBucketToGithubRequest) GetPullRequestTitle() string {
+	if x != nil {
+		return x.PullRequestTitle
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubRequest) GetPullRequestBody() string {
+	if x != nil {
+		return x.PullRequestBody
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+func (x *ExportBucketToGithubRequest) GetMaxDeletions() int32 {
+	if x != nil {
+		return x.MaxDeletions
+	}
+	return 0
+}
+
 type ExportBucketToGithubResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CommitSha      string                 `protobuf:"bytes,1,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"` // Unchanged head of the branch if no file changed
+	PullRequestUrl string                 `protobuf:"bytes,2,opt,name=pull_request_url,json=pullRequestUrl,proto3" json:"pull_request_url,omitempty"`
+	DeletedPaths   []string               `protobuf:"bytes,3,rep,name=deleted_paths,json=deletedPaths,proto3" json:"deleted_paths,omitempty"` // Files deleted by the sync
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ExportBucketToGithubResponse) Reset() {
@@ -1313,6 +1500,27 @@ func (*ExportBucketToGithubResponse) Descriptor() ([]byte, []int) {
 	return file_rpc_proto_rawDescGZIP(), []int{24}
 }
 
+func (x *ExportBucketToGithubResponse) GetCommitSha() string {
+	if x != nil {
+		return x.CommitSha
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubResponse) GetPullRequestUrl() string {
+	if x != nil {
+		return x.PullRequestUrl
+	}
+	return ""
+}
+
+func (x *ExportBucketToGithubResponse) GetDeletedPaths() []string {
+	if x != nil {
+		return x.DeletedPaths
+	}
+	return nil
+}
+
 type CreateBucketFromGitlabRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NewBucketId   string                 `protobuf:"bytes,1,opt,name=new_bucket_id,json=newBucketId,proto3" json:"new_bucket_id,omitempty"`
@@ -1404,6 +1612,10 @@ type ExportBucketToGitlabRequest struct {
 	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
 	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
 	GitlabApiUrl  string                 `protobuf:"bytes,5,opt,name=gitlab_api_url,json=gitlabApiUrl,proto3" json:"gitlab_api_url,omitempty"`
+	Include       []string               `protobuf:"bytes,6,rep,name=include,proto3" json:"include,omitempty"`                                // Gitignore-style globs, e.g. "src/**"
+	Exclude       []string               `protobuf:"bytes,7,rep,name=exclude,proto3" json:"exclude,omitempty"`                                // Gitignore-style globs, e.g. "node_modules/**"
+	Sync          bool                   `protobuf:"varint,8,opt,name=sync,proto3" json:"sync,omitempty"`                                     // Deletes files under path that aren't in the bucket, in the same commit
+	MaxDeletions  int32                  `protobuf:"varint,9,opt,name=max_deletions,json=maxDeletions,proto3" json:"max_deletions,omitempty"` // Sync fails instead of deleting more files, defaults to 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1473,8 +1685,37 @@ func (x *ExportBucketToGitlabRequest) GetGitlabApiUrl() string {
 	return ""
 }
 
+func (x *ExportBucketToGitlabRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *ExportBucketToGitlabRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *ExportBucketToGitlabRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+func (x *ExportBucketToGitlabRequest) GetMaxDeletions() int32 {
+	if x != nil {
+		return x.MaxDeletions
+	}
+	return 0
+}
+
 type ExportBucketToGitlabResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedPaths  []string               `protobuf:"bytes,1,rep,name=deleted_paths,json=deletedPaths,proto3" json:"deleted_paths,omitempty"` // Files deleted by the sync
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1509,125 +1750,4752 @@ func (*ExportBucketToGitlabResponse) Descriptor() ([]byte, []int) {
 	return file_rpc_proto_rawDescGZIP(), []int{27}
 }
 
-var File_rpc_proto protoreflect.FileDescriptor
+func (x *ExportBucketToGitlabResponse) GetDeletedPaths() []string {
+	if x != nil {
+		return x.DeletedPaths
+	}
+	return nil
+}
 
-const file_rpc_proto_rawDesc = "" +
-	"\n" +
-	"\trpc.proto\x12\arpc.rpc\"v\n" +
-	"\bFileInfo\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\x03R\x04size\x12!\n" +
-	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x1f\n" +
-	"\vmodified_at\x18\x04 \x01(\x03R\n" +
-	"modifiedAt\"W\n" +
-	"\vFileContent\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\fR\acontent\x12.\n" +
-	"\tfile_info\x18\x02 \x01(\v2\x11.rpc.rpc.FileInfoR\bfileInfo\"b\n" +
-	"\x12CloneBucketRequest\x12(\n" +
-	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\x12\"\n" +
-	"\rnew_bucket_id\x18\x02 \x01(\tR\vnewBucketId\"\xf5\x01\n" +
-	"\x1aCreateBucketFromZipRequest\x12\"\n" +
-	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x17\n" +
-	"\azip_url\x18\x02 \x01(\tR\x06zipUrl\x12\x12\n" +
-	"\x04path\x18\x03 \x01(\tR\x04path\x12J\n" +
-	"\aheaders\x18\x04 \x03(\v20.rpc.rpc.CreateBucketFromZipRequest.HeadersEntryR\aheaders\x1a:\n" +
-	"\fHeadersEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
-	"\x10FileContentsBase\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\fR\acontent\"|\n" +
-	"\x1fCreateBucketFromContentsRequest\x12\"\n" +
-	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x125\n" +
-	"\bcontents\x18\x02 \x03(\v2\x19.rpc.rpc.FileContentsBaseR\bcontents\"\xa9\x01\n" +
-	"\x1dCreateBucketFromGithubRequest\x12\"\n" +
-	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x14\n" +
-	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x12\n" +
-	"\x04repo\x18\x03 \x01(\tR\x04repo\x12\x12\n" +
-	"\x04path\x18\x04 \x01(\tR\x04path\x12\x10\n" +
-	"\x03ref\x18\x05 \x01(\tR\x03ref\x12\x14\n" +
-	"\x05token\x18\x06 \x01(\tR\x05token\"\x16\n" +
-	"\x14CreateBucketResponse\"\x84\x01\n" +
-	"\x15GetBucketTokenRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12,\n" +
-	"\x12expires_in_seconds\x18\x02 \x01(\x03R\x10expiresInSeconds\x12 \n" +
-	"\fis_read_only\x18\x03 \x01(\bR\n" +
-	"isReadOnly\".\n" +
-	"\x16GetBucketTokenResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"G\n" +
-	"\x14GetBucketFileRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
-	"\x04path\x18\x02 \x01(\tR\x04path\"G\n" +
-	"\x15GetBucketFileResponse\x12.\n" +
-	"\acontent\x18\x01 \x01(\v2\x14.rpc.rpc.FileContentR\acontent\"L\n" +
-	"\x15GetBucketFilesRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
-	"\x06prefix\x18\x02 \x01(\tR\x06prefix\"A\n" +
-	"\x16GetBucketFilesResponse\x12'\n" +
-	"\x05files\x18\x01 \x03(\v2\x11.rpc.rpc.FileInfoR\x05files\"O\n" +
-	"!GetBucketFilesWithContentResponse\x12*\n" +
-	"\x05files\x18\x01 \x03(\v2\x14.rpc.rpc.FileContentR\x05files\"Q\n" +
-	"\x1aGetBucketFilesAsZipRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
-	"\x06prefix\x18\x02 \x01(\tR\x06prefix\"_\n" +
-	"\x1bGetBucketFilesAsZipResponse\x12!\n" +
-	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\x12\x1d\n" +
-	"\n" +
-	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"e\n" +
-	"\x15SetBucketFilesRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12/\n" +
-	"\x05files\x18\x02 \x03(\v2\x19.rpc.rpc.FileContentsBaseR\x05files\"\x18\n" +
-	"\x16SetBucketFilesResponse\"a\n" +
-	"\x14SetBucketFileRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
-	"\x04path\x18\x02 \x01(\tR\x04path\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\fR\acontent\"\x17\n" +
-	"\x15SetBucketFileResponse\"J\n" +
-	"\x17DeleteBucketFileRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
-	"\x04path\x18\x02 \x01(\tR\x04path\"\x1a\n" +
-	"\x18DeleteBucketFileResponse\"\x8e\x01\n" +
-	"\x1bExportBucketToGithubRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x14\n" +
-	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x12\n" +
-	"\x04repo\x18\x03 \x01(\tR\x04repo\x12\x12\n" +
-	"\x04path\x18\x04 \x01(\tR\x04path\x12\x14\n" +
-	"\x05token\x18\x05 \x01(\tR\x05token\"\x1e\n" +
-	"\x1cExportBucketToGithubResponse\"\xc4\x01\n" +
-	"\x1dCreateBucketFromGitlabRequest\x12\"\n" +
-	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x1d\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x12\n" +
-	"\x04path\x18\x03 \x01(\tR\x04path\x12\x10\n" +
-	"\x03ref\x18\x04 \x01(\tR\x03ref\x12\x14\n" +
-	"\x05token\x18\x05 \x01(\tR\x05token\x12$\n" +
-	"\x0egitlab_api_url\x18\x06 \x01(\tR\fgitlabApiUrl\"\xa9\x01\n" +
-	"\x1bExportBucketToGitlabRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1d\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x12\n" +
-	"\x04path\x18\x03 \x01(\tR\x04path\x12\x14\n" +
-	"\x05token\x18\x04 \x01(\tR\x05token\x12$\n" +
-	"\x0egitlab_api_url\x18\x05 \x01(\tR\fgitlabApiUrl\"\x1e\n" +
-	"\x1cExportBucketToGitlabResponse2\xe0\n" +
-	"\n" +
-	"\n" +
+type CreateBucketOverlayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseBucketId  string                 `protobuf:"bytes,1,opt,name=base_bucket_id,json=baseBucketId,proto3" json:"base_bucket_id,omitempty"`
+	NewBucketId   string                 `protobuf:"bytes,2,opt,name=new_bucket_id,json=newBucketId,proto3" json:"new_bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBucketOverlayRequest) Reset() {
+	*x = CreateBucketOverlayRequest{}
+	mi := &file_rpc_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBucketOverlayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketOverlayRequest) ProtoMessage() {}
+
+func (x *CreateBucketOverlayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketOverlayRequest.ProtoReflect.Descriptor instead.
+func (*CreateBucketOverlayRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateBucketOverlayRequest) GetBaseBucketId() string {
+	if x != nil {
+		return x.BaseBucketId
+	}
+	return ""
+}
+
+func (x *CreateBucketOverlayRequest) GetNewBucketId() string {
+	if x != nil {
+		return x.NewBucketId
+	}
+	return ""
+}
+
+type OverlayChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	ChangeType    string                 `protobuf:"bytes,2,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"` // "added", "modified" or "deleted"
+	FileInfo      *FileInfo              `protobuf:"bytes,3,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`       // Unset for deletions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverlayChange) Reset() {
+	*x = OverlayChange{}
+	mi := &file_rpc_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverlayChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverlayChange) ProtoMessage() {}
+
+func (x *OverlayChange) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverlayChange.ProtoReflect.Descriptor instead.
+func (*OverlayChange) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OverlayChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *OverlayChange) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *OverlayChange) GetFileInfo() *FileInfo {
+	if x != nil {
+		return x.FileInfo
+	}
+	return nil
+}
+
+type GetBucketOverlayChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketOverlayChangesRequest) Reset() {
+	*x = GetBucketOverlayChangesRequest{}
+	mi := &file_rpc_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketOverlayChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketOverlayChangesRequest) ProtoMessage() {}
+
+func (x *GetBucketOverlayChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketOverlayChangesRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketOverlayChangesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetBucketOverlayChangesRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+type GetBucketOverlayChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*OverlayChange       `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketOverlayChangesResponse) Reset() {
+	*x = GetBucketOverlayChangesResponse{}
+	mi := &file_rpc_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketOverlayChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketOverlayChangesResponse) ProtoMessage() {}
+
+func (x *GetBucketOverlayChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketOverlayChangesResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketOverlayChangesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetBucketOverlayChangesResponse) GetChanges() []*OverlayChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type DiscardBucketOverlayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardBucketOverlayRequest) Reset() {
+	*x = DiscardBucketOverlayRequest{}
+	mi := &file_rpc_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardBucketOverlayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardBucketOverlayRequest) ProtoMessage() {}
+
+func (x *DiscardBucketOverlayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardBucketOverlayRequest.ProtoReflect.Descriptor instead.
+func (*DiscardBucketOverlayRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DiscardBucketOverlayRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+type DiscardBucketOverlayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardBucketOverlayResponse) Reset() {
+	*x = DiscardBucketOverlayResponse{}
+	mi := &file_rpc_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardBucketOverlayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardBucketOverlayResponse) ProtoMessage() {}
+
+func (x *DiscardBucketOverlayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardBucketOverlayResponse.ProtoReflect.Descriptor instead.
+func (*DiscardBucketOverlayResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{33}
+}
+
+type CommitBucketOverlayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitBucketOverlayRequest) Reset() {
+	*x = CommitBucketOverlayRequest{}
+	mi := &file_rpc_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitBucketOverlayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitBucketOverlayRequest) ProtoMessage() {}
+
+func (x *CommitBucketOverlayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitBucketOverlayRequest.ProtoReflect.Descriptor instead.
+func (*CommitBucketOverlayRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CommitBucketOverlayRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+type CommitBucketOverlayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitBucketOverlayResponse) Reset() {
+	*x = CommitBucketOverlayResponse{}
+	mi := &file_rpc_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitBucketOverlayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitBucketOverlayResponse) ProtoMessage() {}
+
+func (x *CommitBucketOverlayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitBucketOverlayResponse.ProtoReflect.Descriptor instead.
+func (*CommitBucketOverlayResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{35}
+}
+
+type DiffBucketsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BaseBucketId     string                 `protobuf:"bytes,1,opt,name=base_bucket_id,json=baseBucketId,proto3" json:"base_bucket_id,omitempty"`
+	BasePrefix       string                 `protobuf:"bytes,2,opt,name=base_prefix,json=basePrefix,proto3" json:"base_prefix,omitempty"`               // Optional filter
+	TargetBucketId   string                 `protobuf:"bytes,3,opt,name=target_bucket_id,json=targetBucketId,proto3" json:"target_bucket_id,omitempty"` // Defaults to base_bucket_id
+	TargetPrefix     string                 `protobuf:"bytes,4,opt,name=target_prefix,json=targetPrefix,proto3" json:"target_prefix,omitempty"`         // Optional filter
+	IncludeTextDiffs bool                   `protobuf:"varint,5,opt,name=include_text_diffs,json=includeTextDiffs,proto3" json:"include_text_diffs,omitempty"`
+	ContextLines     int32                  `protobuf:"varint,6,opt,name=context_lines,json=contextLines,proto3" json:"context_lines,omitempty"` // Defaults to 3
+	MaxFileSize      int64                  `protobuf:"varint,7,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`  // Larger files are compared by size only, defaults to 10 MiB
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DiffBucketsRequest) Reset() {
+	*x = DiffBucketsRequest{}
+	mi := &file_rpc_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffBucketsRequest) ProtoMessage() {}
+
+func (x *DiffBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffBucketsRequest.ProtoReflect.Descriptor instead.
+func (*DiffBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DiffBucketsRequest) GetBaseBucketId() string {
+	if x != nil {
+		return x.BaseBucketId
+	}
+	return ""
+}
+
+func (x *DiffBucketsRequest) GetBasePrefix() string {
+	if x != nil {
+		return x.BasePrefix
+	}
+	return ""
+}
+
+func (x *DiffBucketsRequest) GetTargetBucketId() string {
+	if x != nil {
+		return x.TargetBucketId
+	}
+	return ""
+}
+
+func (x *DiffBucketsRequest) GetTargetPrefix() string {
+	if x != nil {
+		return x.TargetPrefix
+	}
+	return ""
+}
+
+func (x *DiffBucketsRequest) GetIncludeTextDiffs() bool {
+	if x != nil {
+		return x.IncludeTextDiffs
+	}
+	return false
+}
+
+func (x *DiffBucketsRequest) GetContextLines() int32 {
+	if x != nil {
+		return x.ContextLines
+	}
+	return 0
+}
+
+func (x *DiffBucketsRequest) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+type FileDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	ChangeType    string                 `protobuf:"bytes,2,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"` // "added", "modified" or "deleted"
+	IsBinary      bool                   `protobuf:"varint,3,opt,name=is_binary,json=isBinary,proto3" json:"is_binary,omitempty"`
+	OldSize       int64                  `protobuf:"varint,4,opt,name=old_size,json=oldSize,proto3" json:"old_size,omitempty"`
+	NewSize       int64                  `protobuf:"varint,5,opt,name=new_size,json=newSize,proto3" json:"new_size,omitempty"`
+	UnifiedDiff   string                 `protobuf:"bytes,6,opt,name=unified_diff,json=unifiedDiff,proto3" json:"unified_diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDiff) Reset() {
+	*x = FileDiff{}
+	mi := &file_rpc_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDiff) ProtoMessage() {}
+
+func (x *FileDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDiff.ProtoReflect.Descriptor instead.
+func (*FileDiff) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *FileDiff) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileDiff) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *FileDiff) GetIsBinary() bool {
+	if x != nil {
+		return x.IsBinary
+	}
+	return false
+}
+
+func (x *FileDiff) GetOldSize() int64 {
+	if x != nil {
+		return x.OldSize
+	}
+	return 0
+}
+
+func (x *FileDiff) GetNewSize() int64 {
+	if x != nil {
+		return x.NewSize
+	}
+	return 0
+}
+
+func (x *FileDiff) GetUnifiedDiff() string {
+	if x != nil {
+		return x.UnifiedDiff
+	}
+	return ""
+}
+
+type DiffBucketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*FileDiff            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffBucketsResponse) Reset() {
+	*x = DiffBucketsResponse{}
+	mi := &file_rpc_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffBucketsResponse) ProtoMessage() {}
+
+func (x *DiffBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffBucketsResponse.ProtoReflect.Descriptor instead.
+func (*DiffBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DiffBucketsResponse) GetFiles() []*FileDiff {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type ApplyPatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Patch         string                 `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"` // Unified diff or git format-patch output
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Strict        bool                   `protobuf:"varint,4,opt,name=strict,proto3" json:"strict,omitempty"`                          // Require hunks to apply at their stated line
+	MaxFuzz       int32                  `protobuf:"varint,5,opt,name=max_fuzz,json=maxFuzz,proto3" json:"max_fuzz,omitempty"`         // Context lines that may be ignored, defaults to 2, negative disables fuzz
+	PathPrefix    string                 `protobuf:"bytes,6,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"` // Prepended to every path in the patch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyPatchRequest) Reset() {
+	*x = ApplyPatchRequest{}
+	mi := &file_rpc_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPatchRequest) ProtoMessage() {}
+
+func (x *ApplyPatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPatchRequest.ProtoReflect.Descriptor instead.
+func (*ApplyPatchRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ApplyPatchRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *ApplyPatchRequest) GetPatch() string {
+	if x != nil {
+		return x.Patch
+	}
+	return ""
+}
+
+func (x *ApplyPatchRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ApplyPatchRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *ApplyPatchRequest) GetMaxFuzz() int32 {
+	if x != nil {
+		return x.MaxFuzz
+	}
+	return 0
+}
+
+func (x *ApplyPatchRequest) GetPathPrefix() string {
+	if x != nil {
+		return x.PathPrefix
+	}
+	return ""
+}
+
+type PatchHunkResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applied       bool                   `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Fuzz          int32                  `protobuf:"varint,3,opt,name=fuzz,proto3" json:"fuzz,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchHunkResult) Reset() {
+	*x = PatchHunkResult{}
+	mi := &file_rpc_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchHunkResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchHunkResult) ProtoMessage() {}
+
+func (x *PatchHunkResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchHunkResult.ProtoReflect.Descriptor instead.
+func (*PatchHunkResult) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PatchHunkResult) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *PatchHunkResult) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PatchHunkResult) GetFuzz() int32 {
+	if x != nil {
+		return x.Fuzz
+	}
+	return 0
+}
+
+func (x *PatchHunkResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PatchFileResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	OldPath       string                 `protobuf:"bytes,2,opt,name=old_path,json=oldPath,proto3" json:"old_path,omitempty"`          // Set for renames and copies
+	ChangeType    string                 `protobuf:"bytes,3,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"` // "added", "modified", "deleted" or "renamed"
+	Hunks         []*PatchHunkResult     `protobuf:"bytes,4,rep,name=hunks,proto3" json:"hunks,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchFileResult) Reset() {
+	*x = PatchFileResult{}
+	mi := &file_rpc_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchFileResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchFileResult) ProtoMessage() {}
+
+func (x *PatchFileResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchFileResult.ProtoReflect.Descriptor instead.
+func (*PatchFileResult) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PatchFileResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PatchFileResult) GetOldPath() string {
+	if x != nil {
+		return x.OldPath
+	}
+	return ""
+}
+
+func (x *PatchFileResult) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *PatchFileResult) GetHunks() []*PatchHunkResult {
+	if x != nil {
+		return x.Hunks
+	}
+	return nil
+}
+
+func (x *PatchFileResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ApplyPatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applied       bool                   `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"` // False if any file failed, in which case nothing was written
+	Files         []*PatchFileResult     `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyPatchResponse) Reset() {
+	*x = ApplyPatchResponse{}
+	mi := &file_rpc_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPatchResponse) ProtoMessage() {}
+
+func (x *ApplyPatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPatchResponse.ProtoReflect.Descriptor instead.
+func (*ApplyPatchResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ApplyPatchResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ApplyPatchResponse) GetFiles() []*PatchFileResult {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type MergeBucketsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BaseBucketId    string                 `protobuf:"bytes,1,opt,name=base_bucket_id,json=baseBucketId,proto3" json:"base_bucket_id,omitempty"` // Common ancestor of ours and theirs
+	OursBucketId    string                 `protobuf:"bytes,2,opt,name=ours_bucket_id,json=oursBucketId,proto3" json:"ours_bucket_id,omitempty"`
+	TheirsBucketId  string                 `protobuf:"bytes,3,opt,name=theirs_bucket_id,json=theirsBucketId,proto3" json:"theirs_bucket_id,omitempty"`
+	TargetBucketId  string                 `protobuf:"bytes,4,opt,name=target_bucket_id,json=targetBucketId,proto3" json:"target_bucket_id,omitempty"`   // Receives the result, defaults to ours_bucket_id
+	ConflictMarkers bool                   `protobuf:"varint,5,opt,name=conflict_markers,json=conflictMarkers,proto3" json:"conflict_markers,omitempty"` // Write conflicts with markers instead of our side
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergeBucketsRequest) Reset() {
+	*x = MergeBucketsRequest{}
+	mi := &file_rpc_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeBucketsRequest) ProtoMessage() {}
+
+func (x *MergeBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeBucketsRequest.ProtoReflect.Descriptor instead.
+func (*MergeBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MergeBucketsRequest) GetBaseBucketId() string {
+	if x != nil {
+		return x.BaseBucketId
+	}
+	return ""
+}
+
+func (x *MergeBucketsRequest) GetOursBucketId() string {
+	if x != nil {
+		return x.OursBucketId
+	}
+	return ""
+}
+
+func (x *MergeBucketsRequest) GetTheirsBucketId() string {
+	if x != nil {
+		return x.TheirsBucketId
+	}
+	return ""
+}
+
+func (x *MergeBucketsRequest) GetTargetBucketId() string {
+	if x != nil {
+		return x.TargetBucketId
+	}
+	return ""
+}
+
+func (x *MergeBucketsRequest) GetConflictMarkers() bool {
+	if x != nil {
+		return x.ConflictMarkers
+	}
+	return false
+}
+
+type MergeConflictHunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseStart     int32                  `protobuf:"varint,1,opt,name=base_start,json=baseStart,proto3" json:"base_start,omitempty"`
+	OursStart     int32                  `protobuf:"varint,2,opt,name=ours_start,json=oursStart,proto3" json:"ours_start,omitempty"`
+	TheirsStart   int32                  `protobuf:"varint,3,opt,name=theirs_start,json=theirsStart,proto3" json:"theirs_start,omitempty"`
+	Base          string                 `protobuf:"bytes,4,opt,name=base,proto3" json:"base,omitempty"`
+	Ours          string                 `protobuf:"bytes,5,opt,name=ours,proto3" json:"ours,omitempty"`
+	Theirs        string                 `protobuf:"bytes,6,opt,name=theirs,proto3" json:"theirs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeConflictHunk) Reset() {
+	*x = MergeConflictHunk{}
+	mi := &file_rpc_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeConflictHunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeConflictHunk) ProtoMessage() {}
+
+func (x *MergeConflictHunk) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeConflictHunk.ProtoReflect.Descriptor instead.
+func (*MergeConflictHunk) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MergeConflictHunk) GetBaseStart() int32 {
+	if x != nil {
+		return x.BaseStart
+	}
+	return 0
+}
+
+func (x *MergeConflictHunk) GetOursStart() int32 {
+	if x != nil {
+		return x.OursStart
+	}
+	return 0
+}
+
+func (x *MergeConflictHunk) GetTheirsStart() int32 {
+	if x != nil {
+		return x.TheirsStart
+	}
+	return 0
+}
+
+func (x *MergeConflictHunk) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *MergeConflictHunk) GetOurs() string {
+	if x != nil {
+		return x.Ours
+	}
+	return ""
+}
+
+func (x *MergeConflictHunk) GetTheirs() string {
+	if x != nil {
+		return x.Theirs
+	}
+	return ""
+}
+
+type MergeFileResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Resolution    string                 `protobuf:"bytes,2,opt,name=resolution,proto3" json:"resolution,omitempty"` // "ours", "theirs", "merged" or "conflict"
+	Deleted       bool                   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	ConflictType  string                 `protobuf:"bytes,4,opt,name=conflict_type,json=conflictType,proto3" json:"conflict_type,omitempty"` // "content", "modify_delete" or "binary"
+	Hunks         []*MergeConflictHunk   `protobuf:"bytes,5,rep,name=hunks,proto3" json:"hunks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeFileResult) Reset() {
+	*x = MergeFileResult{}
+	mi := &file_rpc_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeFileResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeFileResult) ProtoMessage() {}
+
+func (x *MergeFileResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeFileResult.ProtoReflect.Descriptor instead.
+func (*MergeFileResult) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MergeFileResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MergeFileResult) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *MergeFileResult) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *MergeFileResult) GetConflictType() string {
+	if x != nil {
+		return x.ConflictType
+	}
+	return ""
+}
+
+func (x *MergeFileResult) GetHunks() []*MergeConflictHunk {
+	if x != nil {
+		return x.Hunks
+	}
+	return nil
+}
+
+type MergeBucketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HasConflicts  bool                   `protobuf:"varint,1,opt,name=has_conflicts,json=hasConflicts,proto3" json:"has_conflicts,omitempty"`
+	Files         []*MergeFileResult     `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"` // Files changed on at least one side
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeBucketsResponse) Reset() {
+	*x = MergeBucketsResponse{}
+	mi := &file_rpc_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeBucketsResponse) ProtoMessage() {}
+
+func (x *MergeBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeBucketsResponse.ProtoReflect.Descriptor instead.
+func (*MergeBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MergeBucketsResponse) GetHasConflicts() bool {
+	if x != nil {
+		return x.HasConflicts
+	}
+	return false
+}
+
+func (x *MergeBucketsResponse) GetFiles() []*MergeFileResult {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type SearchBucketRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BucketId        string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Query           string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	IsRegex         bool                   `protobuf:"varint,3,opt,name=is_regex,json=isRegex,proto3" json:"is_regex,omitempty"` // RE2 syntax, otherwise the query is matched literally
+	CaseInsensitive bool                   `protobuf:"varint,4,opt,name=case_insensitive,json=caseInsensitive,proto3" json:"case_insensitive,omitempty"`
+	Prefix          string                 `protobuf:"bytes,5,opt,name=prefix,proto3" json:"prefix,omitempty"`                                  // Optional filter
+	Glob            string                 `protobuf:"bytes,6,opt,name=glob,proto3" json:"glob,omitempty"`                                      // Optional filter, e.g. "src/**/*.ts"
+	ContextLines    int32                  `protobuf:"varint,7,opt,name=context_lines,json=contextLines,proto3" json:"context_lines,omitempty"` // Up to 20
+	MaxResults      int32                  `protobuf:"varint,8,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`       // Defaults to 1000
+	MaxFileSize     int64                  `protobuf:"varint,9,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`  // Larger files are skipped, defaults to 1 MiB
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchBucketRequest) Reset() {
+	*x = SearchBucketRequest{}
+	mi := &file_rpc_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchBucketRequest) ProtoMessage() {}
+
+func (x *SearchBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchBucketRequest.ProtoReflect.Descriptor instead.
+func (*SearchBucketRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SearchBucketRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *SearchBucketRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchBucketRequest) GetIsRegex() bool {
+	if x != nil {
+		return x.IsRegex
+	}
+	return false
+}
+
+func (x *SearchBucketRequest) GetCaseInsensitive() bool {
+	if x != nil {
+		return x.CaseInsensitive
+	}
+	return false
+}
+
+func (x *SearchBucketRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SearchBucketRequest) GetGlob() string {
+	if x != nil {
+		return x.Glob
+	}
+	return ""
+}
+
+func (x *SearchBucketRequest) GetContextLines() int32 {
+	if x != nil {
+		return x.ContextLines
+	}
+	return 0
+}
+
+func (x *SearchBucketRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *SearchBucketRequest) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+type SearchMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	LineNumber    int32                  `protobuf:"varint,2,opt,name=line_number,json=lineNumber,proto3" json:"line_number,omitempty"`
+	Column        int32                  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"` // 1-based byte offset of the first match in the line
+	Line          string                 `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	ContextBefore []string               `protobuf:"bytes,5,rep,name=context_before,json=contextBefore,proto3" json:"context_before,omitempty"`
+	ContextAfter  []string               `protobuf:"bytes,6,rep,name=context_after,json=contextAfter,proto3" json:"context_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMatch) Reset() {
+	*x = SearchMatch{}
+	mi := &file_rpc_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMatch) ProtoMessage() {}
+
+func (x *SearchMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMatch.ProtoReflect.Descriptor instead.
+func (*SearchMatch) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SearchMatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SearchMatch) GetLineNumber() int32 {
+	if x != nil {
+		return x.LineNumber
+	}
+	return 0
+}
+
+func (x *SearchMatch) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *SearchMatch) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *SearchMatch) GetContextBefore() []string {
+	if x != nil {
+		return x.ContextBefore
+	}
+	return nil
+}
+
+func (x *SearchMatch) GetContextAfter() []string {
+	if x != nil {
+		return x.ContextAfter
+	}
+	return nil
+}
+
+type SearchBucketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         *SearchMatch           `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"` // Unset on the final message if the limit was reached
+	LimitReached  bool                   `protobuf:"varint,2,opt,name=limit_reached,json=limitReached,proto3" json:"limit_reached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchBucketResponse) Reset() {
+	*x = SearchBucketResponse{}
+	mi := &file_rpc_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchBucketResponse) ProtoMessage() {}
+
+func (x *SearchBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchBucketResponse.ProtoReflect.Descriptor instead.
+func (*SearchBucketResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SearchBucketResponse) GetMatch() *SearchMatch {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *SearchBucketResponse) GetLimitReached() bool {
+	if x != nil {
+		return x.LimitReached
+	}
+	return false
+}
+
+type RebuildBucketSearchIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildBucketSearchIndexRequest) Reset() {
+	*x = RebuildBucketSearchIndexRequest{}
+	mi := &file_rpc_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildBucketSearchIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildBucketSearchIndexRequest) ProtoMessage() {}
+
+func (x *RebuildBucketSearchIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildBucketSearchIndexRequest.ProtoReflect.Descriptor instead.
+func (*RebuildBucketSearchIndexRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RebuildBucketSearchIndexRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+type RebuildBucketSearchIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IndexedFiles  int32                  `protobuf:"varint,1,opt,name=indexed_files,json=indexedFiles,proto3" json:"indexed_files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildBucketSearchIndexResponse) Reset() {
+	*x = RebuildBucketSearchIndexResponse{}
+	mi := &file_rpc_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildBucketSearchIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildBucketSearchIndexResponse) ProtoMessage() {}
+
+func (x *RebuildBucketSearchIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildBucketSearchIndexResponse.ProtoReflect.Descriptor instead.
+func (*RebuildBucketSearchIndexResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RebuildBucketSearchIndexResponse) GetIndexedFiles() int32 {
+	if x != nil {
+		return x.IndexedFiles
+	}
+	return 0
+}
+
+type GetBucketDigestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"` // Optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketDigestRequest) Reset() {
+	*x = GetBucketDigestRequest{}
+	mi := &file_rpc_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketDigestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketDigestRequest) ProtoMessage() {}
+
+func (x *GetBucketDigestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketDigestRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketDigestRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetBucketDigestRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetBucketDigestRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type GetBucketDigestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Digest        string                 `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"` // Hex encoded SHA-256 over the sorted paths and file hashes
+	FileCount     int32                  `protobuf:"varint,2,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketDigestResponse) Reset() {
+	*x = GetBucketDigestResponse{}
+	mi := &file_rpc_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketDigestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketDigestResponse) ProtoMessage() {}
+
+func (x *GetBucketDigestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketDigestResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketDigestResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetBucketDigestResponse) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *GetBucketDigestResponse) GetFileCount() int32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+// A directory's hash is the SHA-256 of one "<blob|tree> <hash> <name>\n" line
+// per child, sorted by name. File hashes are the SHA-256 of their content.
+type GetBucketSyncTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                            // Directory to return, defaults to the root
+	KnownHash     string                 `protobuf:"bytes,3,opt,name=known_hash,json=knownHash,proto3" json:"known_hash,omitempty"` // The client's hash of the directory, if it matches no entries are returned
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketSyncTreeRequest) Reset() {
+	*x = GetBucketSyncTreeRequest{}
+	mi := &file_rpc_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketSyncTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketSyncTreeRequest) ProtoMessage() {}
+
+func (x *GetBucketSyncTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketSyncTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketSyncTreeRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetBucketSyncTreeRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetBucketSyncTreeRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetBucketSyncTreeRequest) GetKnownHash() string {
+	if x != nil {
+		return x.KnownHash
+	}
+	return ""
+}
+
+type SyncTreeEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // Full path, usable with GetBucketFile for files
+	IsDir         bool                   `protobuf:"varint,3,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	Hash          string                 `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"` // Unset for directories
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncTreeEntry) Reset() {
+	*x = SyncTreeEntry{}
+	mi := &file_rpc_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncTreeEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncTreeEntry) ProtoMessage() {}
+
+func (x *SyncTreeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncTreeEntry.ProtoReflect.Descriptor instead.
+func (*SyncTreeEntry) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SyncTreeEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SyncTreeEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SyncTreeEntry) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *SyncTreeEntry) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *SyncTreeEntry) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetBucketSyncTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Unchanged     bool                   `protobuf:"varint,3,opt,name=unchanged,proto3" json:"unchanged,omitempty"` // True if hash equals known_hash
+	Entries       []*SyncTreeEntry       `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketSyncTreeResponse) Reset() {
+	*x = GetBucketSyncTreeResponse{}
+	mi := &file_rpc_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketSyncTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketSyncTreeResponse) ProtoMessage() {}
+
+func (x *GetBucketSyncTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketSyncTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketSyncTreeResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetBucketSyncTreeResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetBucketSyncTreeResponse) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *GetBucketSyncTreeResponse) GetUnchanged() bool {
+	if x != nil {
+		return x.Unchanged
+	}
+	return false
+}
+
+func (x *GetBucketSyncTreeResponse) GetEntries() []*SyncTreeEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ApplyBucketFileDeltaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	BaseHash      string                 `protobuf:"bytes,3,opt,name=base_hash,json=baseHash,proto3" json:"base_hash,omitempty"` // Hash of the content the delta was computed against
+	Delta         []byte                 `protobuf:"bytes,4,opt,name=delta,proto3" json:"delta,omitempty"`                       // See pkg/delta for the format
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBucketFileDeltaRequest) Reset() {
+	*x = ApplyBucketFileDeltaRequest{}
+	mi := &file_rpc_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBucketFileDeltaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBucketFileDeltaRequest) ProtoMessage() {}
+
+func (x *ApplyBucketFileDeltaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBucketFileDeltaRequest.ProtoReflect.Descriptor instead.
+func (*ApplyBucketFileDeltaRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ApplyBucketFileDeltaRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *ApplyBucketFileDeltaRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ApplyBucketFileDeltaRequest) GetBaseHash() string {
+	if x != nil {
+		return x.BaseHash
+	}
+	return ""
+}
+
+func (x *ApplyBucketFileDeltaRequest) GetDelta() []byte {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+type ApplyBucketFileDeltaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileInfo      *FileInfo              `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBucketFileDeltaResponse) Reset() {
+	*x = ApplyBucketFileDeltaResponse{}
+	mi := &file_rpc_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBucketFileDeltaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBucketFileDeltaResponse) ProtoMessage() {}
+
+func (x *ApplyBucketFileDeltaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBucketFileDeltaResponse.ProtoReflect.Descriptor instead.
+func (*ApplyBucketFileDeltaResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ApplyBucketFileDeltaResponse) GetFileInfo() *FileInfo {
+	if x != nil {
+		return x.FileInfo
+	}
+	return nil
+}
+
+type MoveBucketFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	FromPath      string                 `protobuf:"bytes,2,opt,name=from_path,json=fromPath,proto3" json:"from_path,omitempty"`
+	ToPath        string                 `protobuf:"bytes,3,opt,name=to_path,json=toPath,proto3" json:"to_path,omitempty"` // Overwritten if it exists
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveBucketFileRequest) Reset() {
+	*x = MoveBucketFileRequest{}
+	mi := &file_rpc_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveBucketFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveBucketFileRequest) ProtoMessage() {}
+
+func (x *MoveBucketFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveBucketFileRequest.ProtoReflect.Descriptor instead.
+func (*MoveBucketFileRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *MoveBucketFileRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *MoveBucketFileRequest) GetFromPath() string {
+	if x != nil {
+		return x.FromPath
+	}
+	return ""
+}
+
+func (x *MoveBucketFileRequest) GetToPath() string {
+	if x != nil {
+		return x.ToPath
+	}
+	return ""
+}
+
+type MoveBucketFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileInfo      *FileInfo              `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveBucketFileResponse) Reset() {
+	*x = MoveBucketFileResponse{}
+	mi := &file_rpc_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveBucketFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveBucketFileResponse) ProtoMessage() {}
+
+func (x *MoveBucketFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveBucketFileResponse.ProtoReflect.Descriptor instead.
+func (*MoveBucketFileResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *MoveBucketFileResponse) GetFileInfo() *FileInfo {
+	if x != nil {
+		return x.FileInfo
+	}
+	return nil
+}
+
+type WatchBucketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"` // Only changes to paths with this prefix are sent
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // Last cursor received, replays the changes since. Empty watches new changes only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBucketRequest) Reset() {
+	*x = WatchBucketRequest{}
+	mi := &file_rpc_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBucketRequest) ProtoMessage() {}
+
+func (x *WatchBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBucketRequest.ProtoReflect.Descriptor instead.
+func (*WatchBucketRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *WatchBucketRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *WatchBucketRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *WatchBucketRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type BucketEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"` // Resume point for WatchBucket
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`     // put, delete, move or reset
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	OldPath       string                 `protobuf:"bytes,4,opt,name=old_path,json=oldPath,proto3" json:"old_path,omitempty"` // Source path of a move
+	Hash          string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`                      // Unset for deletes
+	Size          int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	Actor         string                 `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	Time          int64                  `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketEvent) Reset() {
+	*x = BucketEvent{}
+	mi := &file_rpc_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketEvent) ProtoMessage() {}
+
+func (x *BucketEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketEvent.ProtoReflect.Descriptor instead.
+func (*BucketEvent) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *BucketEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *BucketEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BucketEvent) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *BucketEvent) GetOldPath() string {
+	if x != nil {
+		return x.OldPath
+	}
+	return ""
+}
+
+func (x *BucketEvent) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *BucketEvent) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BucketEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *BucketEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type Webhook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BucketId      string                 `protobuf:"bytes,2,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"` // Empty for global webhooks
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Events        []string               `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_rpc_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Webhook) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"` // Empty to receive the events of every bucket
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Secret        string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"` // Used to sign deliveries, generated if empty
+	Events        []string               `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"` // e.g. file.put or file.*, empty for all events
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_rpc_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *CreateWebhookRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_rpc_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CreateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *CreateWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"` // Empty to list global webhooks
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_rpc_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListWebhooksRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_rpc_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_rpc_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_rpc_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{69}
+}
+
+type WebhookDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Same for all attempts of a delivery
+	WebhookId     string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Event         string                 `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	Attempt       int32                  `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Success       bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"` // Unset if no response was received
+	Response      string                 `protobuf:"bytes,7,opt,name=response,proto3" json:"response,omitempty"`                        // Start of the response body
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,9,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Time          int64                  `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_rpc_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WebhookDelivery) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type GetWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to and is capped at 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveriesRequest) Reset() {
+	*x = GetWebhookDeliveriesRequest{}
+	mi := &file_rpc_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetWebhookDeliveriesRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *GetWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"` // Most recent first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveriesResponse) Reset() {
+	*x = GetWebhookDeliveriesResponse{}
+	mi := &file_rpc_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type TestWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestWebhookRequest) Reset() {
+	*x = TestWebhookRequest{}
+	mi := &file_rpc_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestWebhookRequest) ProtoMessage() {}
+
+func (x *TestWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestWebhookRequest.ProtoReflect.Descriptor instead.
+func (*TestWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *TestWebhookRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+type TestWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *WebhookDelivery       `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestWebhookResponse) Reset() {
+	*x = TestWebhookResponse{}
+	mi := &file_rpc_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestWebhookResponse) ProtoMessage() {}
+
+func (x *TestWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestWebhookResponse.ProtoReflect.Descriptor instead.
+func (*TestWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *TestWebhookResponse) GetDelivery() *WebhookDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`            // Starts at 1 and has no gaps
+	Time          int64                  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`          // Unix milliseconds
+	Operation     string                 `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"` // put, delete, move or discard_overlay
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	OldPath       string                 `protobuf:"bytes,5,opt,name=old_path,json=oldPath,proto3" json:"old_path,omitempty"` // Source path of a move
+	ContentHash   string                 `protobuf:"bytes,6,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	Actor         string                 `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`                    // Token subject, or the caller given in x-code-bucket-actor
+	TokenId       string                 `protobuf:"bytes,8,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"` // jti of the token used
+	SourceIp      string                 `protobuf:"bytes,9,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	Method        string                 `protobuf:"bytes,10,opt,name=method,proto3" json:"method,omitempty"` // gRPC method or HTTP route
+	PrevHash      string                 `protobuf:"bytes,11,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash          string                 `protobuf:"bytes,12,opt,name=hash,proto3" json:"hash,omitempty"` // sha256 over the entry and prev_hash
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_rpc_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *AuditEntry) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOldPath() string {
+	if x != nil {
+		return x.OldPath
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type GetAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // Defaults to 100, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogRequest) Reset() {
+	*x = GetAuditLogRequest{}
+	mi := &file_rpc_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogRequest) ProtoMessage() {}
+
+func (x *GetAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetAuditLogRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`                                    // Oldest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogResponse) Reset() {
+	*x = GetAuditLogResponse{}
+	mi := &file_rpc_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogResponse) ProtoMessage() {}
+
+func (x *GetAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*GetAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetAuditLogResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAuditLogResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type VerifyAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAuditLogRequest) Reset() {
+	*x = VerifyAuditLogRequest{}
+	mi := &file_rpc_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditLogRequest) ProtoMessage() {}
+
+func (x *VerifyAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *VerifyAuditLogRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+type VerifyAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	EntryCount    int64                  `protobuf:"varint,2,opt,name=entry_count,json=entryCount,proto3" json:"entry_count,omitempty"` // Entries verified before the first invalid one
+	HeadHash      string                 `protobuf:"bytes,3,opt,name=head_hash,json=headHash,proto3" json:"head_hash,omitempty"`
+	InvalidSeq    int64                  `protobuf:"varint,4,opt,name=invalid_seq,json=invalidSeq,proto3" json:"invalid_seq,omitempty"` // First entry that doesn't verify
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAuditLogResponse) Reset() {
+	*x = VerifyAuditLogResponse{}
+	mi := &file_rpc_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditLogResponse) ProtoMessage() {}
+
+func (x *VerifyAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*VerifyAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *VerifyAuditLogResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyAuditLogResponse) GetEntryCount() int64 {
+	if x != nil {
+		return x.EntryCount
+	}
+	return 0
+}
+
+func (x *VerifyAuditLogResponse) GetHeadHash() string {
+	if x != nil {
+		return x.HeadHash
+	}
+	return ""
+}
+
+func (x *VerifyAuditLogResponse) GetInvalidSeq() int64 {
+	if x != nil {
+		return x.InvalidSeq
+	}
+	return 0
+}
+
+func (x *VerifyAuditLogResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RevokeBucketTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       string                 `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Expiry of the token, the revocation is kept until then. Kept forever if unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeBucketTokenRequest) Reset() {
+	*x = RevokeBucketTokenRequest{}
+	mi := &file_rpc_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeBucketTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeBucketTokenRequest) ProtoMessage() {}
+
+func (x *RevokeBucketTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeBucketTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeBucketTokenRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *RevokeBucketTokenRequest) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *RevokeBucketTokenRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type RevokeBucketTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeBucketTokenResponse) Reset() {
+	*x = RevokeBucketTokenResponse{}
+	mi := &file_rpc_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeBucketTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeBucketTokenResponse) ProtoMessage() {}
+
+func (x *RevokeBucketTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeBucketTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeBucketTokenResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{81}
+}
+
+type RevokeAllBucketTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	IssuedBefore  int64                  `protobuf:"varint,2,opt,name=issued_before,json=issuedBefore,proto3" json:"issued_before,omitempty"` // Unix seconds, defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllBucketTokensRequest) Reset() {
+	*x = RevokeAllBucketTokensRequest{}
+	mi := &file_rpc_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllBucketTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllBucketTokensRequest) ProtoMessage() {}
+
+func (x *RevokeAllBucketTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllBucketTokensRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllBucketTokensRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *RevokeAllBucketTokensRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *RevokeAllBucketTokensRequest) GetIssuedBefore() int64 {
+	if x != nil {
+		return x.IssuedBefore
+	}
+	return 0
+}
+
+type RevokeAllBucketTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllBucketTokensResponse) Reset() {
+	*x = RevokeAllBucketTokensResponse{}
+	mi := &file_rpc_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllBucketTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllBucketTokensResponse) ProtoMessage() {}
+
+func (x *RevokeAllBucketTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllBucketTokensResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllBucketTokensResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{83}
+}
+
+type TokenScope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []string               `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`           // Include patterns like /src/ or *.md, empty for all paths
+	Operations    []string               `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"` // read, write, delete or list, empty for all operations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenScope) Reset() {
+	*x = TokenScope{}
+	mi := &file_rpc_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenScope) ProtoMessage() {}
+
+func (x *TokenScope) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenScope.ProtoReflect.Descriptor instead.
+func (*TokenScope) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *TokenScope) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *TokenScope) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type GetPresignedFileUrlRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BucketId         string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Path             string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Method           string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"` // GET or PUT
+	ExpiresInSeconds int64                  `protobuf:"varint,4,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"`
+	ContentType      string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Optional, required content type of the upload
+	MaxSize          int64                  `protobuf:"varint,6,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`            // Optional, maximum size of the upload in bytes
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetPresignedFileUrlRequest) Reset() {
+	*x = GetPresignedFileUrlRequest{}
+	mi := &file_rpc_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresignedFileUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresignedFileUrlRequest) ProtoMessage() {}
+
+func (x *GetPresignedFileUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresignedFileUrlRequest.ProtoReflect.Descriptor instead.
+func (*GetPresignedFileUrlRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetPresignedFileUrlRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetPresignedFileUrlRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetPresignedFileUrlRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *GetPresignedFileUrlRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+func (x *GetPresignedFileUrlRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetPresignedFileUrlRequest) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+type GetPresignedFileUrlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"` // Relative to the HTTP API
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresignedFileUrlResponse) Reset() {
+	*x = GetPresignedFileUrlResponse{}
+	mi := &file_rpc_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresignedFileUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresignedFileUrlResponse) ProtoMessage() {}
+
+func (x *GetPresignedFileUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresignedFileUrlResponse.ProtoReflect.Descriptor instead.
+func (*GetPresignedFileUrlResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GetPresignedFileUrlResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GetPresignedFileUrlResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type GetBucketFilesAsArchiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`   // Optional filter
+	Include       []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"` // Gitignore-style globs, e.g. "src/**"
+	Exclude       []string               `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"` // Gitignore-style globs, e.g. "node_modules/**"
+	Format        string                 `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`   // zip or tar.gz, defaults to zip
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketFilesAsArchiveRequest) Reset() {
+	*x = GetBucketFilesAsArchiveRequest{}
+	mi := &file_rpc_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketFilesAsArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketFilesAsArchiveRequest) ProtoMessage() {}
+
+func (x *GetBucketFilesAsArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketFilesAsArchiveRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketFilesAsArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetBucketFilesAsArchiveRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetBucketFilesAsArchiveRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *GetBucketFilesAsArchiveRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *GetBucketFilesAsArchiveRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *GetBucketFilesAsArchiveRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type GetBucketFilesAsArchiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Relative to the HTTP API. Presigned if presigned urls are enabled, otherwise it takes a token
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketFilesAsArchiveResponse) Reset() {
+	*x = GetBucketFilesAsArchiveResponse{}
+	mi := &file_rpc_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketFilesAsArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketFilesAsArchiveResponse) ProtoMessage() {}
+
+func (x *GetBucketFilesAsArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketFilesAsArchiveResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketFilesAsArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GetBucketFilesAsArchiveResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *GetBucketFilesAsArchiveResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CreateBucketFromGitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewBucketId   string                 `protobuf:"bytes,1,opt,name=new_bucket_id,json=newBucketId,proto3" json:"new_bucket_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`           // http(s) url of the repository
+	Ref           string                 `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`           // Branch or tag, defaults to the default branch
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`         // Optional directory of the repository to import
+	Username      string                 `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"` // Optional, defaults to x-access-token if a password is set
+	Password      string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"` // Password or access token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBucketFromGitRequest) Reset() {
+	*x = CreateBucketFromGitRequest{}
+	mi := &file_rpc_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBucketFromGitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketFromGitRequest) ProtoMessage() {}
+
+func (x *CreateBucketFromGitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketFromGitRequest.ProtoReflect.Descriptor instead.
+func (*CreateBucketFromGitRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *CreateBucketFromGitRequest) GetNewBucketId() string {
+	if x != nil {
+		return x.NewBucketId
+	}
+	return ""
+}
+
+func (x *CreateBucketFromGitRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateBucketFromGitRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *CreateBucketFromGitRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateBucketFromGitRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateBucketFromGitRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ExportBucketToGitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`           // http(s) url of the repository
+	Branch        string                 `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`     // Created from the default branch if missing
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`         // Directory of the repository the files are written to
+	Username      string                 `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"` // Optional, defaults to x-access-token if a password is set
+	Password      string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"` // Password or access token
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`   // Optional commit message
+	AuthorName    string                 `protobuf:"bytes,8,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
+	AuthorEmail   string                 `protobuf:"bytes,9,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`
+	Include       []string               `protobuf:"bytes,10,rep,name=include,proto3" json:"include,omitempty"` // Gitignore-style globs, e.g. "src/**"
+	Exclude       []string               `protobuf:"bytes,11,rep,name=exclude,proto3" json:"exclude,omitempty"` // Gitignore-style globs, e.g. "node_modules/**"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBucketToGitRequest) Reset() {
+	*x = ExportBucketToGitRequest{}
+	mi := &file_rpc_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBucketToGitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBucketToGitRequest) ProtoMessage() {}
+
+func (x *ExportBucketToGitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBucketToGitRequest.ProtoReflect.Descriptor instead.
+func (*ExportBucketToGitRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ExportBucketToGitRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *ExportBucketToGitRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *ExportBucketToGitRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+type ExportBucketToGitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommitSha     string                 `protobuf:"bytes,1,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBucketToGitResponse) Reset() {
+	*x = ExportBucketToGitResponse{}
+	mi := &file_rpc_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBucketToGitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBucketToGitResponse) ProtoMessage() {}
+
+func (x *ExportBucketToGitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBucketToGitResponse.ProtoReflect.Descriptor instead.
+func (*ExportBucketToGitResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ExportBucketToGitResponse) GetCommitSha() string {
+	if x != nil {
+		return x.CommitSha
+	}
+	return ""
+}
+
+type CreateBucketFromScmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewBucketId   string                 `protobuf:"bytes,1,opt,name=new_bucket_id,json=newBucketId,proto3" json:"new_bucket_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // github, gitlab, gitea, forgejo or bitbucket
+	Repo          string                 `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`         // owner/name, or the id or full path of a GitLab project
+	Ref           string                 `protobuf:"bytes,4,opt,name=ref,proto3" json:"ref,omitempty"`           // Branch, tag or commit, defaults to the default branch
+	Path          string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`         // Optional directory of the repository to import
+	Token         string                 `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	ApiUrl        string                 `protobuf:"bytes,7,opt,name=api_url,json=apiUrl,proto3" json:"api_url,omitempty"` // Optional, for self-hosted instances
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBucketFromScmRequest) Reset() {
+	*x = CreateBucketFromScmRequest{}
+	mi := &file_rpc_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBucketFromScmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketFromScmRequest) ProtoMessage() {}
+
+func (x *CreateBucketFromScmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketFromScmRequest.ProtoReflect.Descriptor instead.
+func (*CreateBucketFromScmRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *CreateBucketFromScmRequest) GetNewBucketId() string {
+	if x != nil {
+		return x.NewBucketId
+	}
+	return ""
+}
+
+func (x *CreateBucketFromScmRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *CreateBucketFromScmRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *CreateBucketFromScmRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *CreateBucketFromScmRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateBucketFromScmRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateBucketFromScmRequest) GetApiUrl() string {
+	if x != nil {
+		return x.ApiUrl
+	}
+	return ""
+}
+
+type ExportBucketToScmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketId      string                 `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // github, gitlab, gitea, forgejo or bitbucket
+	Repo          string                 `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`         // owner/name, or the id or full path of a GitLab project
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`         // Directory of the repository the files are written to
+	Branch        string                 `protobuf:"bytes,5,opt,name=branch,proto3" json:"branch,omitempty"`     // Defaults to main
+	Token         string                 `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	ApiUrl        string                 `protobuf:"bytes,7,opt,name=api_url,json=apiUrl,proto3" json:"api_url,omitempty"` // Optional, for self-hosted instances
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`             // Optional commit message
+	AuthorName    string                 `protobuf:"bytes,9,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
+	AuthorEmail   string                 `protobuf:"bytes,10,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`
+	Include       []string               `protobuf:"bytes,11,rep,name=include,proto3" json:"include,omitempty"`                                // Gitignore-style globs, e.g. "src/**"
+	Exclude       []string               `protobuf:"bytes,12,rep,name=exclude,proto3" json:"exclude,omitempty"`                                // Gitignore-style globs, e.g. "node_modules/**"
+	Sync          bool                   `protobuf:"varint,13,opt,name=sync,proto3" json:"sync,omitempty"`                                     // Deletes files under path that aren't in the bucket, in the same commit. Not supported by bitbucket
+	MaxDeletions  int32                  `protobuf:"varint,14,opt,name=max_deletions,json=maxDeletions,proto3" json:"max_deletions,omitempty"` // Sync fails instead of deleting more files, defaults to 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBucketToScmRequest) Reset() {
+	*x = ExportBucketToScmRequest{}
+	mi := &file_rpc_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBucketToScmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBucketToScmRequest) ProtoMessage() {}
+
+func (x *ExportBucketToScmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBucketToScmRequest.ProtoReflect.Descriptor instead.
+func (*ExportBucketToScmRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ExportBucketToScmRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetApiUrl() string {
+	if x != nil {
+		return x.ApiUrl
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *ExportBucketToScmRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *ExportBucketToScmRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *ExportBucketToScmRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+func (x *ExportBucketToScmRequest) GetMaxDeletions() int32 {
+	if x != nil {
+		return x.MaxDeletions
+	}
+	return 0
+}
+
+type ExportBucketToScmResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommitSha     string                 `protobuf:"bytes,1,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBucketToScmResponse) Reset() {
+	*x = ExportBucketToScmResponse{}
+	mi := &file_rpc_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBucketToScmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBucketToScmResponse) ProtoMessage() {}
+
+func (x *ExportBucketToScmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBucketToScmResponse.ProtoReflect.Descriptor instead.
+func (*ExportBucketToScmResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ExportBucketToScmResponse) GetCommitSha() string {
+	if x != nil {
+		return x.CommitSha
+	}
+	return ""
+}
+
+var File_rpc_proto protoreflect.FileDescriptor
+
+const file_rpc_proto_rawDesc = "" +
+	"\n" +
+	"\trpc.proto\x12\arpc.rpc\"\x8a\x01\n" +
+	"\bFileInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x1f\n" +
+	"\vmodified_at\x18\x04 \x01(\x03R\n" +
+	"modifiedAt\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\tR\x04hash\"W\n" +
+	"\vFileContent\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x12.\n" +
+	"\tfile_info\x18\x02 \x01(\v2\x11.rpc.rpc.FileInfoR\bfileInfo\"\x96\x01\n" +
+	"\x12CloneBucketRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\x12\"\n" +
+	"\rnew_bucket_id\x18\x02 \x01(\tR\vnewBucketId\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x04 \x03(\tR\aexclude\"\xf5\x01\n" +
+	"\x1aCreateBucketFromZipRequest\x12\"\n" +
+	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x17\n" +
+	"\azip_url\x18\x02 \x01(\tR\x06zipUrl\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12J\n" +
+	"\aheaders\x18\x04 \x03(\v20.rpc.rpc.CreateBucketFromZipRequest.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
+	"\x10FileContentsBase\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"|\n" +
+	"\x1fCreateBucketFromContentsRequest\x12\"\n" +
+	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x125\n" +
+	"\bcontents\x18\x02 \x03(\v2\x19.rpc.rpc.FileContentsBaseR\bcontents\"\xa9\x01\n" +
+	"\x1dCreateBucketFromGithubRequest\x12\"\n" +
+	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x12\n" +
+	"\x04repo\x18\x03 \x01(\tR\x04repo\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x10\n" +
+	"\x03ref\x18\x05 \x01(\tR\x03ref\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\"\x16\n" +
+	"\x14CreateBucketResponse\"\xc7\x01\n" +
+	"\x15GetBucketTokenRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12,\n" +
+	"\x12expires_in_seconds\x18\x02 \x01(\x03R\x10expiresInSeconds\x12 \n" +
+	"\fis_read_only\x18\x03 \x01(\bR\n" +
+	"isReadOnly\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12+\n" +
+	"\x06scopes\x18\x05 \x03(\v2\x13.rpc.rpc.TokenScopeR\x06scopes\"h\n" +
+	"\x16GetBucketTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\btoken_id\x18\x02 \x01(\tR\atokenId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\"G\n" +
+	"\x14GetBucketFileRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"G\n" +
+	"\x15GetBucketFileResponse\x12.\n" +
+	"\acontent\x18\x01 \x01(\v2\x14.rpc.rpc.FileContentR\acontent\"\x80\x01\n" +
+	"\x15GetBucketFilesRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x04 \x03(\tR\aexclude\"A\n" +
+	"\x16GetBucketFilesResponse\x12'\n" +
+	"\x05files\x18\x01 \x03(\v2\x11.rpc.rpc.FileInfoR\x05files\"O\n" +
+	"!GetBucketFilesWithContentResponse\x12*\n" +
+	"\x05files\x18\x01 \x03(\v2\x14.rpc.rpc.FileContentR\x05files\"\x85\x01\n" +
+	"\x1aGetBucketFilesAsZipRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x04 \x03(\tR\aexclude\"_\n" +
+	"\x1bGetBucketFilesAsZipResponse\x12!\n" +
+	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"e\n" +
+	"\x15SetBucketFilesRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12/\n" +
+	"\x05files\x18\x02 \x03(\v2\x19.rpc.rpc.FileContentsBaseR\x05files\"\x18\n" +
+	"\x16SetBucketFilesResponse\"a\n" +
+	"\x14SetBucketFileRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"\x17\n" +
+	"\x15SetBucketFileResponse\"J\n" +
+	"\x17DeleteBucketFileRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"\x1a\n" +
+	"\x18DeleteBucketFileResponse\"\x9c\x04\n" +
+	"\x1bExportBucketToGithubRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x12\n" +
+	"\x04repo\x18\x03 \x01(\tR\x04repo\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\x12\x18\n" +
+	"\ainclude\x18\x06 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\a \x03(\tR\aexclude\x12\x16\n" +
+	"\x06branch\x18\b \x01(\tR\x06branch\x12\x1f\n" +
+	"\vbase_branch\x18\t \x01(\tR\n" +
+	"baseBranch\x12\x18\n" +
+	"\amessage\x18\n" +
+	" \x01(\tR\amessage\x12\x1f\n" +
+	"\vauthor_name\x18\v \x01(\tR\n" +
+	"authorName\x12!\n" +
+	"\fauthor_email\x18\f \x01(\tR\vauthorEmail\x12.\n" +
+	"\x13create_pull_request\x18\r \x01(\bR\x11createPullRequest\x12,\n" +
+	"\x12pull_request_title\x18\x0e \x01(\tR\x10pullRequestTitle\x12*\n" +
+	"\x11pull_request_body\x18\x0f \x01(\tR\x0fpullRequestBody\x12\x12\n" +
+	"\x04sync\x18\x10 \x01(\bR\x04sync\x12#\n" +
+	"\rmax_deletions\x18\x11 \x01(\x05R\fmaxDeletions\"\x8c\x01\n" +
+	"\x1cExportBucketToGithubResponse\x12\x1d\n" +
+	"\n" +
+	"commit_sha\x18\x01 \x01(\tR\tcommitSha\x12(\n" +
+	"\x10pull_request_url\x18\x02 \x01(\tR\x0epullRequestUrl\x12#\n" +
+	"\rdeleted_paths\x18\x03 \x03(\tR\fdeletedPaths\"\xc4\x01\n" +
+	"\x1dCreateBucketFromGitlabRequest\x12\"\n" +
+	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x10\n" +
+	"\x03ref\x18\x04 \x01(\tR\x03ref\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\x12$\n" +
+	"\x0egitlab_api_url\x18\x06 \x01(\tR\fgitlabApiUrl\"\x96\x02\n" +
+	"\x1bExportBucketToGitlabRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x12$\n" +
+	"\x0egitlab_api_url\x18\x05 \x01(\tR\fgitlabApiUrl\x12\x18\n" +
+	"\ainclude\x18\x06 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\a \x03(\tR\aexclude\x12\x12\n" +
+	"\x04sync\x18\b \x01(\bR\x04sync\x12#\n" +
+	"\rmax_deletions\x18\t \x01(\x05R\fmaxDeletions\"C\n" +
+	"\x1cExportBucketToGitlabResponse\x12#\n" +
+	"\rdeleted_paths\x18\x01 \x03(\tR\fdeletedPaths\"f\n" +
+	"\x1aCreateBucketOverlayRequest\x12$\n" +
+	"\x0ebase_bucket_id\x18\x01 \x01(\tR\fbaseBucketId\x12\"\n" +
+	"\rnew_bucket_id\x18\x02 \x01(\tR\vnewBucketId\"t\n" +
+	"\rOverlayChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1f\n" +
+	"\vchange_type\x18\x02 \x01(\tR\n" +
+	"changeType\x12.\n" +
+	"\tfile_info\x18\x03 \x01(\v2\x11.rpc.rpc.FileInfoR\bfileInfo\"=\n" +
+	"\x1eGetBucketOverlayChangesRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"S\n" +
+	"\x1fGetBucketOverlayChangesResponse\x120\n" +
+	"\achanges\x18\x01 \x03(\v2\x16.rpc.rpc.OverlayChangeR\achanges\":\n" +
+	"\x1bDiscardBucketOverlayRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"\x1e\n" +
+	"\x1cDiscardBucketOverlayResponse\"9\n" +
+	"\x1aCommitBucketOverlayRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"\x1d\n" +
+	"\x1bCommitBucketOverlayResponse\"\xa1\x02\n" +
+	"\x12DiffBucketsRequest\x12$\n" +
+	"\x0ebase_bucket_id\x18\x01 \x01(\tR\fbaseBucketId\x12\x1f\n" +
+	"\vbase_prefix\x18\x02 \x01(\tR\n" +
+	"basePrefix\x12(\n" +
+	"\x10target_bucket_id\x18\x03 \x01(\tR\x0etargetBucketId\x12#\n" +
+	"\rtarget_prefix\x18\x04 \x01(\tR\ftargetPrefix\x12,\n" +
+	"\x12include_text_diffs\x18\x05 \x01(\bR\x10includeTextDiffs\x12#\n" +
+	"\rcontext_lines\x18\x06 \x01(\x05R\fcontextLines\x12\"\n" +
+	"\rmax_file_size\x18\a \x01(\x03R\vmaxFileSize\"\xb5\x01\n" +
+	"\bFileDiff\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1f\n" +
+	"\vchange_type\x18\x02 \x01(\tR\n" +
+	"changeType\x12\x1b\n" +
+	"\tis_binary\x18\x03 \x01(\bR\bisBinary\x12\x19\n" +
+	"\bold_size\x18\x04 \x01(\x03R\aoldSize\x12\x19\n" +
+	"\bnew_size\x18\x05 \x01(\x03R\anewSize\x12!\n" +
+	"\funified_diff\x18\x06 \x01(\tR\vunifiedDiff\">\n" +
+	"\x13DiffBucketsResponse\x12'\n" +
+	"\x05files\x18\x01 \x03(\v2\x11.rpc.rpc.FileDiffR\x05files\"\xb3\x01\n" +
+	"\x11ApplyPatchRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x14\n" +
+	"\x05patch\x18\x02 \x01(\tR\x05patch\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x16\n" +
+	"\x06strict\x18\x04 \x01(\bR\x06strict\x12\x19\n" +
+	"\bmax_fuzz\x18\x05 \x01(\x05R\amaxFuzz\x12\x1f\n" +
+	"\vpath_prefix\x18\x06 \x01(\tR\n" +
+	"pathPrefix\"m\n" +
+	"\x0fPatchHunkResult\x12\x18\n" +
+	"\aapplied\x18\x01 \x01(\bR\aapplied\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04fuzz\x18\x03 \x01(\x05R\x04fuzz\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xa7\x01\n" +
+	"\x0fPatchFileResult\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x19\n" +
+	"\bold_path\x18\x02 \x01(\tR\aoldPath\x12\x1f\n" +
+	"\vchange_type\x18\x03 \x01(\tR\n" +
+	"changeType\x12.\n" +
+	"\x05hunks\x18\x04 \x03(\v2\x18.rpc.rpc.PatchHunkResultR\x05hunks\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"^\n" +
+	"\x12ApplyPatchResponse\x12\x18\n" +
+	"\aapplied\x18\x01 \x01(\bR\aapplied\x12.\n" +
+	"\x05files\x18\x02 \x03(\v2\x18.rpc.rpc.PatchFileResultR\x05files\"\xe0\x01\n" +
+	"\x13MergeBucketsRequest\x12$\n" +
+	"\x0ebase_bucket_id\x18\x01 \x01(\tR\fbaseBucketId\x12$\n" +
+	"\x0eours_bucket_id\x18\x02 \x01(\tR\foursBucketId\x12(\n" +
+	"\x10theirs_bucket_id\x18\x03 \x01(\tR\x0etheirsBucketId\x12(\n" +
+	"\x10target_bucket_id\x18\x04 \x01(\tR\x0etargetBucketId\x12)\n" +
+	"\x10conflict_markers\x18\x05 \x01(\bR\x0fconflictMarkers\"\xb4\x01\n" +
+	"\x11MergeConflictHunk\x12\x1d\n" +
+	"\n" +
+	"base_start\x18\x01 \x01(\x05R\tbaseStart\x12\x1d\n" +
+	"\n" +
+	"ours_start\x18\x02 \x01(\x05R\toursStart\x12!\n" +
+	"\ftheirs_start\x18\x03 \x01(\x05R\vtheirsStart\x12\x12\n" +
+	"\x04base\x18\x04 \x01(\tR\x04base\x12\x12\n" +
+	"\x04ours\x18\x05 \x01(\tR\x04ours\x12\x16\n" +
+	"\x06theirs\x18\x06 \x01(\tR\x06theirs\"\xb6\x01\n" +
+	"\x0fMergeFileResult\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\tR\n" +
+	"resolution\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\x12#\n" +
+	"\rconflict_type\x18\x04 \x01(\tR\fconflictType\x120\n" +
+	"\x05hunks\x18\x05 \x03(\v2\x1a.rpc.rpc.MergeConflictHunkR\x05hunks\"k\n" +
+	"\x14MergeBucketsResponse\x12#\n" +
+	"\rhas_conflicts\x18\x01 \x01(\bR\fhasConflicts\x12.\n" +
+	"\x05files\x18\x02 \x03(\v2\x18.rpc.rpc.MergeFileResultR\x05files\"\xa4\x02\n" +
+	"\x13SearchBucketRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x19\n" +
+	"\bis_regex\x18\x03 \x01(\bR\aisRegex\x12)\n" +
+	"\x10case_insensitive\x18\x04 \x01(\bR\x0fcaseInsensitive\x12\x16\n" +
+	"\x06prefix\x18\x05 \x01(\tR\x06prefix\x12\x12\n" +
+	"\x04glob\x18\x06 \x01(\tR\x04glob\x12#\n" +
+	"\rcontext_lines\x18\a \x01(\x05R\fcontextLines\x12\x1f\n" +
+	"\vmax_results\x18\b \x01(\x05R\n" +
+	"maxResults\x12\"\n" +
+	"\rmax_file_size\x18\t \x01(\x03R\vmaxFileSize\"\xba\x01\n" +
+	"\vSearchMatch\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1f\n" +
+	"\vline_number\x18\x02 \x01(\x05R\n" +
+	"lineNumber\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\x12\x12\n" +
+	"\x04line\x18\x04 \x01(\tR\x04line\x12%\n" +
+	"\x0econtext_before\x18\x05 \x03(\tR\rcontextBefore\x12#\n" +
+	"\rcontext_after\x18\x06 \x03(\tR\fcontextAfter\"g\n" +
+	"\x14SearchBucketResponse\x12*\n" +
+	"\x05match\x18\x01 \x01(\v2\x14.rpc.rpc.SearchMatchR\x05match\x12#\n" +
+	"\rlimit_reached\x18\x02 \x01(\bR\flimitReached\">\n" +
+	"\x1fRebuildBucketSearchIndexRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"G\n" +
+	" RebuildBucketSearchIndexResponse\x12#\n" +
+	"\rindexed_files\x18\x01 \x01(\x05R\findexedFiles\"M\n" +
+	"\x16GetBucketDigestRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\"P\n" +
+	"\x17GetBucketDigestResponse\x12\x16\n" +
+	"\x06digest\x18\x01 \x01(\tR\x06digest\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x02 \x01(\x05R\tfileCount\"j\n" +
+	"\x18GetBucketSyncTreeRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"known_hash\x18\x03 \x01(\tR\tknownHash\"v\n" +
+	"\rSyncTreeEntry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x15\n" +
+	"\x06is_dir\x18\x03 \x01(\bR\x05isDir\x12\x12\n" +
+	"\x04hash\x18\x04 \x01(\tR\x04hash\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\"\x93\x01\n" +
+	"\x19GetBucketSyncTreeResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x1c\n" +
+	"\tunchanged\x18\x03 \x01(\bR\tunchanged\x120\n" +
+	"\aentries\x18\x04 \x03(\v2\x16.rpc.rpc.SyncTreeEntryR\aentries\"\x81\x01\n" +
+	"\x1bApplyBucketFileDeltaRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1b\n" +
+	"\tbase_hash\x18\x03 \x01(\tR\bbaseHash\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\fR\x05delta\"N\n" +
+	"\x1cApplyBucketFileDeltaResponse\x12.\n" +
+	"\tfile_info\x18\x01 \x01(\v2\x11.rpc.rpc.FileInfoR\bfileInfo\"j\n" +
+	"\x15MoveBucketFileRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1b\n" +
+	"\tfrom_path\x18\x02 \x01(\tR\bfromPath\x12\x17\n" +
+	"\ato_path\x18\x03 \x01(\tR\x06toPath\"H\n" +
+	"\x16MoveBucketFileResponse\x12.\n" +
+	"\tfile_info\x18\x01 \x01(\v2\x11.rpc.rpc.FileInfoR\bfileInfo\"a\n" +
+	"\x12WatchBucketRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\xba\x01\n" +
+	"\vBucketEvent\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x19\n" +
+	"\bold_path\x18\x04 \x01(\tR\aoldPath\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\tR\x04hash\x12\x12\n" +
+	"\x04size\x18\x06 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x12\n" +
+	"\x04time\x18\b \x01(\x03R\x04time\"\x7f\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tbucket_id\x18\x02 \x01(\tR\bbucketId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x04 \x03(\tR\x06events\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"u\n" +
+	"\x14CreateWebhookRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x16\n" +
+	"\x06events\x18\x04 \x03(\tR\x06events\"[\n" +
+	"\x15CreateWebhookResponse\x12*\n" +
+	"\awebhook\x18\x01 \x01(\v2\x10.rpc.rpc.WebhookR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"2\n" +
+	"\x13ListWebhooksRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"D\n" +
+	"\x14ListWebhooksResponse\x12,\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x10.rpc.rpc.WebhookR\bwebhooks\"5\n" +
+	"\x14DeleteWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\"\x17\n" +
+	"\x15DeleteWebhookResponse\"\x92\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x14\n" +
+	"\x05event\x18\x03 \x01(\tR\x05event\x12\x18\n" +
+	"\aattempt\x18\x04 \x01(\x05R\aattempt\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x1f\n" +
+	"\vstatus_code\x18\x06 \x01(\x05R\n" +
+	"statusCode\x12\x1a\n" +
+	"\bresponse\x18\a \x01(\tR\bresponse\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\t \x01(\x03R\n" +
+	"durationMs\x12\x12\n" +
+	"\x04time\x18\n" +
+	" \x01(\x03R\x04time\"R\n" +
+	"\x1bGetWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"X\n" +
+	"\x1cGetWebhookDeliveriesResponse\x128\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x18.rpc.rpc.WebhookDeliveryR\n" +
+	"deliveries\"3\n" +
+	"\x12TestWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\"K\n" +
+	"\x13TestWebhookResponse\x124\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x18.rpc.rpc.WebhookDeliveryR\bdelivery\"\xb9\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x19\n" +
+	"\bold_path\x18\x05 \x01(\tR\aoldPath\x12!\n" +
+	"\fcontent_hash\x18\x06 \x01(\tR\vcontentHash\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x19\n" +
+	"\btoken_id\x18\b \x01(\tR\atokenId\x12\x1b\n" +
+	"\tsource_ip\x18\t \x01(\tR\bsourceIp\x12\x16\n" +
+	"\x06method\x18\n" +
+	" \x01(\tR\x06method\x12\x1b\n" +
+	"\tprev_hash\x18\v \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\f \x01(\tR\x04hash\"f\n" +
+	"\x12GetAuditLogRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"l\n" +
+	"\x13GetAuditLogResponse\x12-\n" +
+	"\aentries\x18\x01 \x03(\v2\x13.rpc.rpc.AuditEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"4\n" +
+	"\x15VerifyAuditLogRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"\xa3\x01\n" +
+	"\x16VerifyAuditLogResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x1f\n" +
+	"\ventry_count\x18\x02 \x01(\x03R\n" +
+	"entryCount\x12\x1b\n" +
+	"\thead_hash\x18\x03 \x01(\tR\bheadHash\x12\x1f\n" +
+	"\vinvalid_seq\x18\x04 \x01(\x03R\n" +
+	"invalidSeq\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"T\n" +
+	"\x18RevokeBucketTokenRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\tR\atokenId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"\x1b\n" +
+	"\x19RevokeBucketTokenResponse\"`\n" +
+	"\x1cRevokeAllBucketTokensRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12#\n" +
+	"\rissued_before\x18\x02 \x01(\x03R\fissuedBefore\"\x1f\n" +
+	"\x1dRevokeAllBucketTokensResponse\"B\n" +
+	"\n" +
+	"TokenScope\x12\x14\n" +
+	"\x05paths\x18\x01 \x03(\tR\x05paths\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x02 \x03(\tR\n" +
+	"operations\"\xd1\x01\n" +
+	"\x1aGetPresignedFileUrlRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12,\n" +
+	"\x12expires_in_seconds\x18\x04 \x01(\x03R\x10expiresInSeconds\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x19\n" +
+	"\bmax_size\x18\x06 \x01(\x03R\amaxSize\"N\n" +
+	"\x1bGetPresignedFileUrlResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"\xa1\x01\n" +
+	"\x1eGetBucketFilesAsArchiveRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x04 \x03(\tR\aexclude\x12\x16\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\"c\n" +
+	"\x1fGetBucketFilesAsArchiveResponse\x12!\n" +
+	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"\xb0\x01\n" +
+	"\x1aCreateBucketFromGitRequest\x12\"\n" +
+	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x10\n" +
+	"\x03ref\x18\x03 \x01(\tR\x03ref\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\"\xbf\x02\n" +
+	"\x18ExportBucketToGitRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06branch\x18\x03 \x01(\tR\x06branch\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12\x1f\n" +
+	"\vauthor_name\x18\b \x01(\tR\n" +
+	"authorName\x12!\n" +
+	"\fauthor_email\x18\t \x01(\tR\vauthorEmail\x12\x18\n" +
+	"\ainclude\x18\n" +
+	" \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\v \x03(\tR\aexclude\":\n" +
+	"\x19ExportBucketToGitResponse\x12\x1d\n" +
+	"\n" +
+	"commit_sha\x18\x01 \x01(\tR\tcommitSha\"\xc5\x01\n" +
+	"\x1aCreateBucketFromScmRequest\x12\"\n" +
+	"\rnew_bucket_id\x18\x01 \x01(\tR\vnewBucketId\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04repo\x18\x03 \x01(\tR\x04repo\x12\x10\n" +
+	"\x03ref\x18\x04 \x01(\tR\x03ref\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\x12\x17\n" +
+	"\aapi_url\x18\a \x01(\tR\x06apiUrl\"\x8d\x03\n" +
+	"\x18ExportBucketToScmRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04repo\x18\x03 \x01(\tR\x04repo\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x16\n" +
+	"\x06branch\x18\x05 \x01(\tR\x06branch\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\x12\x17\n" +
+	"\aapi_url\x18\a \x01(\tR\x06apiUrl\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\x12\x1f\n" +
+	"\vauthor_name\x18\t \x01(\tR\n" +
+	"authorName\x12!\n" +
+	"\fauthor_email\x18\n" +
+	" \x01(\tR\vauthorEmail\x12\x18\n" +
+	"\ainclude\x18\v \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\f \x03(\tR\aexclude\x12\x12\n" +
+	"\x04sync\x18\r \x01(\bR\x04sync\x12#\n" +
+	"\rmax_deletions\x18\x0e \x01(\x05R\fmaxDeletions\":\n" +
+	"\x19ExportBucketToScmResponse\x12\x1d\n" +
+	"\n" +
+	"commit_sha\x18\x01 \x01(\tR\tcommitSha2\xf7\x1e\n" +
+	"\n" +
 	"CodeBucket\x12I\n" +
 	"\vCloneBucket\x12\x1b.rpc.rpc.CloneBucketRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12c\n" +
 	"\x18CreateBucketFromContents\x12(.rpc.rpc.CreateBucketFromContentsRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12Y\n" +
 	"\x13CreateBucketFromZip\x12#.rpc.rpc.CreateBucketFromZipRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12_\n" +
 	"\x16CreateBucketFromGithub\x12&.rpc.rpc.CreateBucketFromGithubRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12_\n" +
-	"\x16CreateBucketFromGitlab\x12&.rpc.rpc.CreateBucketFromGitlabRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12Q\n" +
-	"\x0eGetBucketToken\x12\x1e.rpc.rpc.GetBucketTokenRequest\x1a\x1f.rpc.rpc.GetBucketTokenResponse\x12N\n" +
+	"\x16CreateBucketFromGitlab\x12&.rpc.rpc.CreateBucketFromGitlabRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12Y\n" +
+	"\x13CreateBucketFromGit\x12#.rpc.rpc.CreateBucketFromGitRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12Y\n" +
+	"\x13CreateBucketFromScm\x12#.rpc.rpc.CreateBucketFromScmRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12Y\n" +
+	"\x13CreateBucketOverlay\x12#.rpc.rpc.CreateBucketOverlayRequest\x1a\x1d.rpc.rpc.CreateBucketResponse\x12Q\n" +
+	"\x0eGetBucketToken\x12\x1e.rpc.rpc.GetBucketTokenRequest\x1a\x1f.rpc.rpc.GetBucketTokenResponse\x12Z\n" +
+	"\x11RevokeBucketToken\x12!.rpc.rpc.RevokeBucketTokenRequest\x1a\".rpc.rpc.RevokeBucketTokenResponse\x12f\n" +
+	"\x15RevokeAllBucketTokens\x12%.rpc.rpc.RevokeAllBucketTokensRequest\x1a&.rpc.rpc.RevokeAllBucketTokensResponse\x12`\n" +
+	"\x13GetPresignedFileUrl\x12#.rpc.rpc.GetPresignedFileUrlRequest\x1a$.rpc.rpc.GetPresignedFileUrlResponse\x12N\n" +
 	"\rGetBucketFile\x12\x1d.rpc.rpc.GetBucketFileRequest\x1a\x1e.rpc.rpc.GetBucketFileResponse\x12Q\n" +
 	"\x0eGetBucketFiles\x12\x1e.rpc.rpc.GetBucketFilesRequest\x1a\x1f.rpc.rpc.GetBucketFilesResponse\x12g\n" +
 	"\x19GetBucketFilesWithContent\x12\x1e.rpc.rpc.GetBucketFilesRequest\x1a*.rpc.rpc.GetBucketFilesWithContentResponse\x12`\n" +
-	"\x13GetBucketFilesAsZip\x12#.rpc.rpc.GetBucketFilesAsZipRequest\x1a$.rpc.rpc.GetBucketFilesAsZipResponse\x12Q\n" +
+	"\x13GetBucketFilesAsZip\x12#.rpc.rpc.GetBucketFilesAsZipRequest\x1a$.rpc.rpc.GetBucketFilesAsZipResponse\x12l\n" +
+	"\x17GetBucketFilesAsArchive\x12'.rpc.rpc.GetBucketFilesAsArchiveRequest\x1a(.rpc.rpc.GetBucketFilesAsArchiveResponse\x12T\n" +
+	"\x0fGetBucketDigest\x12\x1f.rpc.rpc.GetBucketDigestRequest\x1a .rpc.rpc.GetBucketDigestResponse\x12Z\n" +
+	"\x11GetBucketSyncTree\x12!.rpc.rpc.GetBucketSyncTreeRequest\x1a\".rpc.rpc.GetBucketSyncTreeResponse\x12H\n" +
+	"\vDiffBuckets\x12\x1b.rpc.rpc.DiffBucketsRequest\x1a\x1c.rpc.rpc.DiffBucketsResponse\x12K\n" +
+	"\fMergeBuckets\x12\x1c.rpc.rpc.MergeBucketsRequest\x1a\x1d.rpc.rpc.MergeBucketsResponse\x12M\n" +
+	"\fSearchBucket\x12\x1c.rpc.rpc.SearchBucketRequest\x1a\x1d.rpc.rpc.SearchBucketResponse0\x01\x12o\n" +
+	"\x18RebuildBucketSearchIndex\x12(.rpc.rpc.RebuildBucketSearchIndexRequest\x1a).rpc.rpc.RebuildBucketSearchIndexResponse\x12B\n" +
+	"\vWatchBucket\x12\x1b.rpc.rpc.WatchBucketRequest\x1a\x14.rpc.rpc.BucketEvent0\x01\x12Q\n" +
 	"\x0eSetBucketFiles\x12\x1e.rpc.rpc.SetBucketFilesRequest\x1a\x1f.rpc.rpc.SetBucketFilesResponse\x12N\n" +
-	"\rSetBucketFile\x12\x1d.rpc.rpc.SetBucketFileRequest\x1a\x1e.rpc.rpc.SetBucketFileResponse\x12W\n" +
-	"\x10DeleteBucketFile\x12 .rpc.rpc.DeleteBucketFileRequest\x1a!.rpc.rpc.DeleteBucketFileResponse\x12c\n" +
+	"\rSetBucketFile\x12\x1d.rpc.rpc.SetBucketFileRequest\x1a\x1e.rpc.rpc.SetBucketFileResponse\x12c\n" +
+	"\x14ApplyBucketFileDelta\x12$.rpc.rpc.ApplyBucketFileDeltaRequest\x1a%.rpc.rpc.ApplyBucketFileDeltaResponse\x12W\n" +
+	"\x10DeleteBucketFile\x12 .rpc.rpc.DeleteBucketFileRequest\x1a!.rpc.rpc.DeleteBucketFileResponse\x12Q\n" +
+	"\x0eMoveBucketFile\x12\x1e.rpc.rpc.MoveBucketFileRequest\x1a\x1f.rpc.rpc.MoveBucketFileResponse\x12E\n" +
+	"\n" +
+	"ApplyPatch\x12\x1a.rpc.rpc.ApplyPatchRequest\x1a\x1b.rpc.rpc.ApplyPatchResponse\x12c\n" +
 	"\x14ExportBucketToGithub\x12$.rpc.rpc.ExportBucketToGithubRequest\x1a%.rpc.rpc.ExportBucketToGithubResponse\x12c\n" +
-	"\x14ExportBucketToGitlab\x12$.rpc.rpc.ExportBucketToGitlabRequest\x1a%.rpc.rpc.ExportBucketToGitlabResponseB7Z5github.com/metorial/metorial/services/rpc/gen/rpc;rpcb\x06proto3"
+	"\x14ExportBucketToGitlab\x12$.rpc.rpc.ExportBucketToGitlabRequest\x1a%.rpc.rpc.ExportBucketToGitlabResponse\x12Z\n" +
+	"\x11ExportBucketToGit\x12!.rpc.rpc.ExportBucketToGitRequest\x1a\".rpc.rpc.ExportBucketToGitResponse\x12Z\n" +
+	"\x11ExportBucketToScm\x12!.rpc.rpc.ExportBucketToScmRequest\x1a\".rpc.rpc.ExportBucketToScmResponse\x12l\n" +
+	"\x17GetBucketOverlayChanges\x12'.rpc.rpc.GetBucketOverlayChangesRequest\x1a(.rpc.rpc.GetBucketOverlayChangesResponse\x12c\n" +
+	"\x14DiscardBucketOverlay\x12$.rpc.rpc.DiscardBucketOverlayRequest\x1a%.rpc.rpc.DiscardBucketOverlayResponse\x12`\n" +
+	"\x13CommitBucketOverlay\x12#.rpc.rpc.CommitBucketOverlayRequest\x1a$.rpc.rpc.CommitBucketOverlayResponse\x12N\n" +
+	"\rCreateWebhook\x12\x1d.rpc.rpc.CreateWebhookRequest\x1a\x1e.rpc.rpc.CreateWebhookResponse\x12K\n" +
+	"\fListWebhooks\x12\x1c.rpc.rpc.ListWebhooksRequest\x1a\x1d.rpc.rpc.ListWebhooksResponse\x12N\n" +
+	"\rDeleteWebhook\x12\x1d.rpc.rpc.DeleteWebhookRequest\x1a\x1e.rpc.rpc.DeleteWebhookResponse\x12c\n" +
+	"\x14GetWebhookDeliveries\x12$.rpc.rpc.GetWebhookDeliveriesRequest\x1a%.rpc.rpc.GetWebhookDeliveriesResponse\x12H\n" +
+	"\vTestWebhook\x12\x1b.rpc.rpc.TestWebhookRequest\x1a\x1c.rpc.rpc.TestWebhookResponse\x12H\n" +
+	"\vGetAuditLog\x12\x1b.rpc.rpc.GetAuditLogRequest\x1a\x1c.rpc.rpc.GetAuditLogResponse\x12Q\n" +
+	"\x0eVerifyAuditLog\x12\x1e.rpc.rpc.VerifyAuditLogRequest\x1a\x1f.rpc.rpc.VerifyAuditLogResponseB7Z5github.com/metorial/metorial/services/rpc/gen/rpc;rpcb\x06proto3"
 
 var (
 	file_rpc_proto_rawDescOnce sync.Once
@@ -1641,7 +6509,7 @@ func file_rpc_proto_rawDescGZIP() []byte {
 	return file_rpc_proto_rawDescData
 }
 
-var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 96)
 var file_rpc_proto_goTypes = []any{
 	(*FileInfo)(nil),                          // 0: rpc.rpc.FileInfo
 	(*FileContent)(nil),                       // 1: rpc.rpc.FileContent
@@ -1671,51 +6539,193 @@ var file_rpc_proto_goTypes = []any{
 	(*CreateBucketFromGitlabRequest)(nil),     // 25: rpc.rpc.CreateBucketFromGitlabRequest
 	(*ExportBucketToGitlabRequest)(nil),       // 26: rpc.rpc.ExportBucketToGitlabRequest
 	(*ExportBucketToGitlabResponse)(nil),      // 27: rpc.rpc.ExportBucketToGitlabResponse
-	nil,                                       // 28: rpc.rpc.CreateBucketFromZipRequest.HeadersEntry
+	(*CreateBucketOverlayRequest)(nil),        // 28: rpc.rpc.CreateBucketOverlayRequest
+	(*OverlayChange)(nil),                     // 29: rpc.rpc.OverlayChange
+	(*GetBucketOverlayChangesRequest)(nil),    // 30: rpc.rpc.GetBucketOverlayChangesRequest
+	(*GetBucketOverlayChangesResponse)(nil),   // 31: rpc.rpc.GetBucketOverlayChangesResponse
+	(*DiscardBucketOverlayRequest)(nil),       // 32: rpc.rpc.DiscardBucketOverlayRequest
+	(*DiscardBucketOverlayResponse)(nil),      // 33: rpc.rpc.DiscardBucketOverlayResponse
+	(*CommitBucketOverlayRequest)(nil),        // 34: rpc.rpc.CommitBucketOverlayRequest
+	(*CommitBucketOverlayResponse)(nil),       // 35: rpc.rpc.CommitBucketOverlayResponse
+	(*DiffBucketsRequest)(nil),                // 36: rpc.rpc.DiffBucketsRequest
+	(*FileDiff)(nil),                          // 37: rpc.rpc.FileDiff
+	(*DiffBucketsResponse)(nil),               // 38: rpc.rpc.DiffBucketsResponse
+	(*ApplyPatchRequest)(nil),                 // 39: rpc.rpc.ApplyPatchRequest
+	(*PatchHunkResult)(nil),                   // 40: rpc.rpc.PatchHunkResult
+	(*PatchFileResult)(nil),                   // 41: rpc.rpc.PatchFileResult
+	(*ApplyPatchResponse)(nil),                // 42: rpc.rpc.ApplyPatchResponse
+	(*MergeBucketsRequest)(nil),               // 43: rpc.rpc.MergeBucketsRequest
+	(*MergeConflictHunk)(nil),                 // 44: rpc.rpc.MergeConflictHunk
+	(*MergeFileResult)(nil),                   // 45: rpc.rpc.MergeFileResult
+	(*MergeBucketsResponse)(nil),              // 46: rpc.rpc.MergeBucketsResponse
+	(*SearchBucketRequest)(nil),               // 47: rpc.rpc.SearchBucketRequest
+	(*SearchMatch)(nil),                       // 48: rpc.rpc.SearchMatch
+	(*SearchBucketResponse)(nil),              // 49: rpc.rpc.SearchBucketResponse
+	(*RebuildBucketSearchIndexRequest)(nil),   // 50: rpc.rpc.RebuildBucketSearchIndexRequest
+	(*RebuildBucketSearchIndexResponse)(nil),  // 51: rpc.rpc.RebuildBucketSearchIndexResponse
+	(*GetBucketDigestRequest)(nil),            // 52: rpc.rpc.GetBucketDigestRequest
+	(*GetBucketDigestResponse)(nil),           // 53: rpc.rpc.GetBucketDigestResponse
+	(*GetBucketSyncTreeRequest)(nil),          // 54: rpc.rpc.GetBucketSyncTreeRequest
+	(*SyncTreeEntry)(nil),                     // 55: rpc.rpc.SyncTreeEntry
+	(*GetBucketSyncTreeResponse)(nil),         // 56: rpc.rpc.GetBucketSyncTreeResponse
+	(*ApplyBucketFileDeltaRequest)(nil),       // 57: rpc.rpc.ApplyBucketFileDeltaRequest
+	(*ApplyBucketFileDeltaResponse)(nil),      // 58: rpc.rpc.ApplyBucketFileDeltaResponse
+	(*MoveBucketFileRequest)(nil),             // 59: rpc.rpc.MoveBucketFileRequest
+	(*MoveBucketFileResponse)(nil),            // 60: rpc.rpc.MoveBucketFileResponse
+	(*WatchBucketRequest)(nil),                // 61: rpc.rpc.WatchBucketRequest
+	(*BucketEvent)(nil),                       // 62: rpc.rpc.BucketEvent
+	(*Webhook)(nil),                           // 63: rpc.rpc.Webhook
+	(*CreateWebhookRequest)(nil),              // 64: rpc.rpc.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),             // 65: rpc.rpc.CreateWebhookResponse
+	(*ListWebhooksRequest)(nil),               // 66: rpc.rpc.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),              // 67: rpc.rpc.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),              // 68: rpc.rpc.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),             // 69: rpc.rpc.DeleteWebhookResponse
+	(*WebhookDelivery)(nil),                   // 70: rpc.rpc.WebhookDelivery
+	(*GetWebhookDeliveriesRequest)(nil),       // 71: rpc.rpc.GetWebhookDeliveriesRequest
+	(*GetWebhookDeliveriesResponse)(nil),      // 72: rpc.rpc.GetWebhookDeliveriesResponse
+	(*TestWebhookRequest)(nil),                // 73: rpc.rpc.TestWebhookRequest
+	(*TestWebhookResponse)(nil),               // 74: rpc.rpc.TestWebhookResponse
+	(*AuditEntry)(nil),                        // 75: rpc.rpc.AuditEntry
+	(*GetAuditLogRequest)(nil),                // 76: rpc.rpc.GetAuditLogRequest
+	(*GetAuditLogResponse)(nil),               // 77: rpc.rpc.GetAuditLogResponse
+	(*VerifyAuditLogRequest)(nil),             // 78: rpc.rpc.VerifyAuditLogRequest
+	(*VerifyAuditLogResponse)(nil),            // 79: rpc.rpc.VerifyAuditLogResponse
+	(*RevokeBucketTokenRequest)(nil),          // 80: rpc.rpc.RevokeBucketTokenRequest
+	(*RevokeBucketTokenResponse)(nil),         // 81: rpc.rpc.RevokeBucketTokenResponse
+	(*RevokeAllBucketTokensRequest)(nil),      // 82: rpc.rpc.RevokeAllBucketTokensRequest
+	(*RevokeAllBucketTokensResponse)(nil),     // 83: rpc.rpc.RevokeAllBucketTokensResponse
+	(*TokenScope)(nil),                        // 84: rpc.rpc.TokenScope
+	(*GetPresignedFileUrlRequest)(nil),        // 85: rpc.rpc.GetPresignedFileUrlRequest
+	(*GetPresignedFileUrlResponse)(nil),       // 86: rpc.rpc.GetPresignedFileUrlResponse
+	(*GetBucketFilesAsArchiveRequest)(nil),    // 87: rpc.rpc.GetBucketFilesAsArchiveRequest
+	(*GetBucketFilesAsArchiveResponse)(nil),   // 88: rpc.rpc.GetBucketFilesAsArchiveResponse
+	(*CreateBucketFromGitRequest)(nil),        // 89: rpc.rpc.CreateBucketFromGitRequest
+	(*ExportBucketToGitRequest)(nil),          // 90: rpc.rpc.ExportBucketToGitRequest
+	(*ExportBucketToGitResponse)(nil),         // 91: rpc.rpc.ExportBucketToGitResponse
+	(*CreateBucketFromScmRequest)(nil),        // 92: rpc.rpc.CreateBucketFromScmRequest
+	(*ExportBucketToScmRequest)(nil),          // 93: rpc.rpc.ExportBucketToScmRequest
+	(*ExportBucketToScmResponse)(nil),         // 94: rpc.rpc.ExportBucketToScmResponse
+	nil,                                       // 95: rpc.rpc.CreateBucketFromZipRequest.HeadersEntry
 }
 var file_rpc_proto_depIdxs = []int32{
 	0,  // 0: rpc.rpc.FileContent.file_info:type_name -> rpc.rpc.FileInfo
-	28, // 1: rpc.rpc.CreateBucketFromZipRequest.headers:type_name -> rpc.rpc.CreateBucketFromZipRequest.HeadersEntry
+	95, // 1: rpc.rpc.CreateBucketFromZipRequest.headers:type_name -> rpc.rpc.CreateBucketFromZipRequest.HeadersEntry
 	4,  // 2: rpc.rpc.CreateBucketFromContentsRequest.contents:type_name -> rpc.rpc.FileContentsBase
-	1,  // 3: rpc.rpc.GetBucketFileResponse.content:type_name -> rpc.rpc.FileContent
-	0,  // 4: rpc.rpc.GetBucketFilesResponse.files:type_name -> rpc.rpc.FileInfo
-	1,  // 5: rpc.rpc.GetBucketFilesWithContentResponse.files:type_name -> rpc.rpc.FileContent
-	4,  // 6: rpc.rpc.SetBucketFilesRequest.files:type_name -> rpc.rpc.FileContentsBase
-	2,  // 7: rpc.rpc.CodeBucket.CloneBucket:input_type -> rpc.rpc.CloneBucketRequest
-	5,  // 8: rpc.rpc.CodeBucket.CreateBucketFromContents:input_type -> rpc.rpc.CreateBucketFromContentsRequest
-	3,  // 9: rpc.rpc.CodeBucket.CreateBucketFromZip:input_type -> rpc.rpc.CreateBucketFromZipRequest
-	6,  // 10: rpc.rpc.CodeBucket.CreateBucketFromGithub:input_type -> rpc.rpc.CreateBucketFromGithubRequest
-	25, // 11: rpc.rpc.CodeBucket.CreateBucketFromGitlab:input_type -> rpc.rpc.CreateBucketFromGitlabRequest
-	8,  // 12: rpc.rpc.CodeBucket.GetBucketToken:input_type -> rpc.rpc.GetBucketTokenRequest
-	10, // 13: rpc.rpc.CodeBucket.GetBucketFile:input_type -> rpc.rpc.GetBucketFileRequest
-	12, // 14: rpc.rpc.CodeBucket.GetBucketFiles:input_type -> rpc.rpc.GetBucketFilesRequest
-	12, // 15: rpc.rpc.CodeBucket.GetBucketFilesWithContent:input_type -> rpc.rpc.GetBucketFilesRequest
-	15, // 16: rpc.rpc.CodeBucket.GetBucketFilesAsZip:input_type -> rpc.rpc.GetBucketFilesAsZipRequest
-	17, // 17: rpc.rpc.CodeBucket.SetBucketFiles:input_type -> rpc.rpc.SetBucketFilesRequest
-	19, // 18: rpc.rpc.CodeBucket.SetBucketFile:input_type -> rpc.rpc.SetBucketFileRequest
-	21, // 19: rpc.rpc.CodeBucket.DeleteBucketFile:input_type -> rpc.rpc.DeleteBucketFileRequest
-	23, // 20: rpc.rpc.CodeBucket.ExportBucketToGithub:input_type -> rpc.rpc.ExportBucketToGithubRequest
-	26, // 21: rpc.rpc.CodeBucket.ExportBucketToGitlab:input_type -> rpc.rpc.ExportBucketToGitlabRequest
-	7,  // 22: rpc.rpc.CodeBucket.CloneBucket:output_type -> rpc.rpc.CreateBucketResponse
-	7,  // 23: rpc.rpc.CodeBucket.CreateBucketFromContents:output_type -> rpc.rpc.CreateBucketResponse
-	7,  // 24: rpc.rpc.CodeBucket.CreateBucketFromZip:output_type -> rpc.rpc.CreateBucketResponse
-	7,  // 25: rpc.rpc.CodeBucket.CreateBucketFromGithub:output_type -> rpc.rpc.CreateBucketResponse
-	7,  // 26: rpc.rpc.CodeBucket.CreateBucketFromGitlab:output_type -> rpc.rpc.CreateBucketResponse
-	9,  // 27: rpc.rpc.CodeBucket.GetBucketToken:output_type -> rpc.rpc.GetBucketTokenResponse
-	11, // 28: rpc.rpc.CodeBucket.GetBucketFile:output_type -> rpc.rpc.GetBucketFileResponse
-	13, // 29: rpc.rpc.CodeBucket.GetBucketFiles:output_type -> rpc.rpc.GetBucketFilesResponse
-	14, // 30: rpc.rpc.CodeBucket.GetBucketFilesWithContent:output_type -> rpc.rpc.GetBucketFilesWithContentResponse
-	16, // 31: rpc.rpc.CodeBucket.GetBucketFilesAsZip:output_type -> rpc.rpc.GetBucketFilesAsZipResponse
-	18, // 32: rpc.rpc.CodeBucket.SetBucketFiles:output_type -> rpc.rpc.SetBucketFilesResponse
-	20, // 33: rpc.rpc.CodeBucket.SetBucketFile:output_type -> rpc.rpc.SetBucketFileResponse
-	22, // 34: rpc.rpc.CodeBucket.DeleteBucketFile:output_type -> rpc.rpc.DeleteBucketFileResponse
-	24, // 35: rpc.rpc.CodeBucket.ExportBucketToGithub:output_type -> rpc.rpc.ExportBucketToGithubResponse
-	27, // 36: rpc.rpc.CodeBucket.ExportBucketToGitlab:output_type -> rpc.rpc.ExportBucketToGitlabResponse
-	22, // [22:37] is the sub-list for method output_type
-	7,  // [7:22] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	84, // 3: rpc.rpc.GetBucketTokenRequest.scopes:type_name -> rpc.rpc.TokenScope
+	1,  // 4: rpc.rpc.GetBucketFileResponse.content:type_name -> rpc.rpc.FileContent
+	0,  // 5: rpc.rpc.GetBucketFilesResponse.files:type_name -> rpc.rpc.FileInfo
+	1,  // 6: rpc.rpc.GetBucketFilesWithContentResponse.files:type_name -> rpc.rpc.FileContent
+	4,  // 7: rpc.rpc.SetBucketFilesRequest.files:type_name -> rpc.rpc.FileContentsBase
+	0,  // 8: rpc.rpc.OverlayChange.file_info:type_name -> rpc.rpc.FileInfo
+	29, // 9: rpc.rpc.GetBucketOverlayChangesResponse.changes:type_name -> rpc.rpc.OverlayChange
+	37, // 10: rpc.rpc.DiffBucketsResponse.files:type_name -> rpc.rpc.FileDiff
+	40, // 11: rpc.rpc.PatchFileResult.hunks:type_name -> rpc.rpc.PatchHunkResult
+	41, // 12: rpc.rpc.ApplyPatchResponse.files:type_name -> rpc.rpc.PatchFileResult
+	44, // 13: rpc.rpc.MergeFileResult.hunks:type_name -> rpc.rpc.MergeConflictHunk
+	45, // 14: rpc.rpc.MergeBucketsResponse.files:type_name -> rpc.rpc.MergeFileResult
+	48, // 15: rpc.rpc.SearchBucketResponse.match:type_name -> rpc.rpc.SearchMatch
+	55, // 16: rpc.rpc.GetBucketSyncTreeResponse.entries:type_name -> rpc.rpc.SyncTreeEntry
+	0,  // 17: rpc.rpc.ApplyBucketFileDeltaResponse.file_info:type_name -> rpc.rpc.FileInfo
+	0,  // 18: rpc.rpc.MoveBucketFileResponse.file_info:type_name -> rpc.rpc.FileInfo
+	63, // 19: rpc.rpc.CreateWebhookResponse.webhook:type_name -> rpc.rpc.Webhook
+	63, // 20: rpc.rpc.ListWebhooksResponse.webhooks:type_name -> rpc.rpc.Webhook
+	70, // 21: rpc.rpc.GetWebhookDeliveriesResponse.deliveries:type_name -> rpc.rpc.WebhookDelivery
+	70, // 22: rpc.rpc.TestWebhookResponse.delivery:type_name -> rpc.rpc.WebhookDelivery
+	75, // 23: rpc.rpc.GetAuditLogResponse.entries:type_name -> rpc.rpc.AuditEntry
+	2,  // 24: rpc.rpc.CodeBucket.CloneBucket:input_type -> rpc.rpc.CloneBucketRequest
+	5,  // 25: rpc.rpc.CodeBucket.CreateBucketFromContents:input_type -> rpc.rpc.CreateBucketFromContentsRequest
+	3,  // 26: rpc.rpc.CodeBucket.CreateBucketFromZip:input_type -> rpc.rpc.CreateBucketFromZipRequest
+	6,  // 27: rpc.rpc.CodeBucket.CreateBucketFromGithub:input_type -> rpc.rpc.CreateBucketFromGithubRequest
+	25, // 28: rpc.rpc.CodeBucket.CreateBucketFromGitlab:input_type -> rpc.rpc.CreateBucketFromGitlabRequest
+	89, // 29: rpc.rpc.CodeBucket.CreateBucketFromGit:input_type -> rpc.rpc.CreateBucketFromGitRequest
+	92, // 30: rpc.rpc.CodeBucket.CreateBucketFromScm:input_type -> rpc.rpc.CreateBucketFromScmRequest
+	28, // 31: rpc.rpc.CodeBucket.CreateBucketOverlay:input_type -> rpc.rpc.CreateBucketOverlayRequest
+	8,  // 32: rpc.rpc.CodeBucket.GetBucketToken:input_type -> rpc.rpc.GetBucketTokenRequest
+	80, // 33: rpc.rpc.CodeBucket.RevokeBucketToken:input_type -> rpc.rpc.RevokeBucketTokenRequest
+	82, // 34: rpc.rpc.CodeBucket.RevokeAllBucketTokens:input_type -> rpc.rpc.RevokeAllBucketTokensRequest
+	85, // 35: rpc.rpc.CodeBucket.GetPresignedFileUrl:input_type -> rpc.rpc.GetPresignedFileUrlRequest
+	10, // 36: rpc.rpc.CodeBucket.GetBucketFile:input_type -> rpc.rpc.GetBucketFileRequest
+	12, // 37: rpc.rpc.CodeBucket.GetBucketFiles:input_type -> rpc.rpc.GetBucketFilesRequest
+	12, // 38: rpc.rpc.CodeBucket.GetBucketFilesWithContent:input_type -> rpc.rpc.GetBucketFilesRequest
+	15, // 39: rpc.rpc.CodeBucket.GetBucketFilesAsZip:input_type -> rpc.rpc.GetBucketFilesAsZipRequest
+	87, // 40: rpc.rpc.CodeBucket.GetBucketFilesAsArchive:input_type -> rpc.rpc.GetBucketFilesAsArchiveRequest
+	52, // 41: rpc.rpc.CodeBucket.GetBucketDigest:input_type -> rpc.rpc.GetBucketDigestRequest
+	54, // 42: rpc.rpc.CodeBucket.GetBucketSyncTree:input_type -> rpc.rpc.GetBucketSyncTreeRequest
+	36, // 43: rpc.rpc.CodeBucket.DiffBuckets:input_type -> rpc.rpc.DiffBucketsRequest
+	43, // 44: rpc.rpc.CodeBucket.MergeBuckets:input_type -> rpc.rpc.MergeBucketsRequest
+	47, // 45: rpc.rpc.CodeBucket.SearchBucket:input_type -> rpc.rpc.SearchBucketRequest
+	50, // 46: rpc.rpc.CodeBucket.RebuildBucketSearchIndex:input_type -> rpc.rpc.RebuildBucketSearchIndexRequest
+	61, // 47: rpc.rpc.CodeBucket.WatchBucket:input_type -> rpc.rpc.WatchBucketRequest
+	17, // 48: rpc.rpc.CodeBucket.SetBucketFiles:input_type -> rpc.rpc.SetBucketFilesRequest
+	19, // 49: rpc.rpc.CodeBucket.SetBucketFile:input_type -> rpc.rpc.SetBucketFileRequest
+	57, // 50: rpc.rpc.CodeBucket.ApplyBucketFileDelta:input_type -> rpc.rpc.ApplyBucketFileDeltaRequest
+	21, // 51: rpc.rpc.CodeBucket.DeleteBucketFile:input_type -> rpc.rpc.DeleteBucketFileRequest
+	59, // 52: rpc.rpc.CodeBucket.MoveBucketFile:input_type -> rpc.rpc.MoveBucketFileRequest
+	39, // 53: rpc.rpc.CodeBucket.ApplyPatch:input_type -> rpc.rpc.ApplyPatchRequest
+	23, // 54: rpc.rpc.CodeBucket.ExportBucketToGithub:input_type -> rpc.rpc.ExportBucketToGithubRequest
+	26, // 55: rpc.rpc.CodeBucket.ExportBucketToGitlab:input_type -> rpc.rpc.ExportBucketToGitlabRequest
+	90, // 56: rpc.rpc.CodeBucket.ExportBucketToGit:input_type -> rpc.rpc.ExportBucketToGitRequest
+	93, // 57: rpc.rpc.CodeBucket.ExportBucketToScm:input_type -> rpc.rpc.ExportBucketToScmRequest
+	30, // 58: rpc.rpc.CodeBucket.GetBucketOverlayChanges:input_type -> rpc.rpc.GetBucketOverlayChangesRequest
+	32, // 59: rpc.rpc.CodeBucket.DiscardBucketOverlay:input_type -> rpc.rpc.DiscardBucketOverlayRequest
+	34, // 60: rpc.rpc.CodeBucket.CommitBucketOverlay:input_type -> rpc.rpc.CommitBucketOverlayRequest
+	64, // 61: rpc.rpc.CodeBucket.CreateWebhook:input_type -> rpc.rpc.CreateWebhookRequest
+	66, // 62: rpc.rpc.CodeBucket.ListWebhooks:input_type -> rpc.rpc.ListWebhooksRequest
+	68, // 63: rpc.rpc.CodeBucket.DeleteWebhook:input_type -> rpc.rpc.DeleteWebhookRequest
+	71, // 64: rpc.rpc.CodeBucket.GetWebhookDeliveries:input_type -> rpc.rpc.GetWebhookDeliveriesRequest
+	73, // 65: rpc.rpc.CodeBucket.TestWebhook:input_type -> rpc.rpc.TestWebhookRequest
+	76, // 66: rpc.rpc.CodeBucket.GetAuditLog:input_type -> rpc.rpc.GetAuditLogRequest
+	78, // 67: rpc.rpc.CodeBucket.VerifyAuditLog:input_type -> rpc.rpc.VerifyAuditLogRequest
+	7,  // 68: rpc.rpc.CodeBucket.CloneBucket:output_type -> rpc.rpc.CreateBucketResponse
+	7,  // 69: rpc.rpc.CodeBucket.CreateBucketFromContents:output_type -> rpc.rpc.CreateBucketResponse
+	7,  // 70: rpc.rpc.CodeBucket.CreateBucketFromZip:output_type -> rpc.rpc.CreateBucketResponse
+	7,  // 71: rpc.rpc.CodeBucket.CreateBucketFromGithub:output_type -> rpc.rpc.CreateBucketResponse
+	7,  // 72: rpc.rpc.CodeBucket.CreateBucketFromGitlab:output_type -> rpc.rpc.CreateBucketResponse
+	7,  // 73: rpc.rpc.CodeBucket.CreateBucketFromGit:output_type -> rpc.rpc.CreateBucketResponse
+	7,  // 74: rpc.rpc.CodeBucket.CreateBucketFromScm:output_type -> rpc.rpc.CreateBucketResponse
+	7,  // 75: rpc.rpc.CodeBucket.CreateBucketOverlay:output_type -> rpc.rpc.CreateBucketResponse
+	9,  // 76: rpc.rpc.CodeBucket.GetBucketToken:output_type -> rpc.rpc.GetBucketTokenResponse
+	81, // 77: rpc.rpc.CodeBucket.RevokeBucketToken:output_type -> rpc.rpc.RevokeBucketTokenResponse
+	83, // 78: rpc.rpc.CodeBucket.RevokeAllBucketTokens:output_type -> rpc.rpc.RevokeAllBucketTokensResponse
+	86, // 79: rpc.rpc.CodeBucket.GetPresignedFileUrl:output_type -> rpc.rpc.GetPresignedFileUrlResponse
+	11, // 80: rpc.rpc.CodeBucket.GetBucketFile:output_type -> rpc.rpc.GetBucketFileResponse
+	13, // 81: rpc.rpc.CodeBucket.GetBucketFiles:output_type -> rpc.rpc.GetBucketFilesResponse
+	14, // 82: rpc.rpc.CodeBucket.GetBucketFilesWithContent:output_type -> rpc.rpc.GetBucketFilesWithContentResponse
+	16, // 83: rpc.rpc.CodeBucket.GetBucketFilesAsZip:output_type -> rpc.rpc.GetBucketFilesAsZipResponse
+	88, // 84: rpc.rpc.CodeBucket.GetBucketFilesAsArchive:output_type -> rpc.rpc.GetBucketFilesAsArchiveResponse
+	53, // 85: rpc.rpc.CodeBucket.GetBucketDigest:output_type -> rpc.rpc.GetBucketDigestResponse
+	56, // 86: rpc.rpc.CodeBucket.GetBucketSyncTree:output_type -> rpc.rpc.GetBucketSyncTreeResponse
+	38, // 87: rpc.rpc.CodeBucket.DiffBuckets:output_type -> rpc.rpc.DiffBucketsResponse
+	46, // 88: rpc.rpc.CodeBucket.MergeBuckets:output_type -> rpc.rpc.MergeBucketsResponse
+	49, // 89: rpc.rpc.CodeBucket.SearchBucket:output_type -> rpc.rpc.SearchBucketResponse
+	51, // 90: rpc.rpc.CodeBucket.RebuildBucketSearchIndex:output_type -> rpc.rpc.RebuildBucketSearchIndexResponse
+	62, // 91: rpc.rpc.CodeBucket.WatchBucket:output_type -> rpc.rpc.BucketEvent
+	18, // 92: rpc.rpc.CodeBucket.SetBucketFiles:output_type -> rpc.rpc.SetBucketFilesResponse
+	20, // 93: rpc.rpc.CodeBucket.SetBucketFile:output_type -> rpc.rpc.SetBucketFileResponse
+	58, // 94: rpc.rpc.CodeBucket.ApplyBucketFileDelta:output_type -> rpc.rpc.ApplyBucketFileDeltaResponse
+	22, // 95: rpc.rpc.CodeBucket.DeleteBucketFile:output_type -> rpc.rpc.DeleteBucketFileResponse
+	60, // 96: rpc.rpc.CodeBucket.MoveBucketFile:output_type -> rpc.rpc.MoveBucketFileResponse
+	42, // 97: rpc.rpc.CodeBucket.ApplyPatch:output_type -> rpc.rpc.ApplyPatchResponse
+	24, // 98: rpc.rpc.CodeBucket.ExportBucketToGithub:output_type -> rpc.rpc.ExportBucketToGithubResponse
+	27, // 99: rpc.rpc.CodeBucket.ExportBucketToGitlab:output_type -> rpc.rpc.ExportBucketToGitlabResponse
+	91, // 100: rpc.rpc.CodeBucket.ExportBucketToGit:output_type -> rpc.rpc.ExportBucketToGitResponse
+	94, // 101: rpc.rpc.CodeBucket.ExportBucketToScm:output_type -> rpc.rpc.ExportBucketToScmResponse
+	31, // 102: rpc.rpc.CodeBucket.GetBucketOverlayChanges:output_type -> rpc.rpc.GetBucketOverlayChangesResponse
+	33, // 103: rpc.rpc.CodeBucket.DiscardBucketOverlay:output_type -> rpc.rpc.DiscardBucketOverlayResponse
+	35, // 104: rpc.rpc.CodeBucket.CommitBucketOverlay:output_type -> rpc.rpc.CommitBucketOverlayResponse
+	65, // 105: rpc.rpc.CodeBucket.CreateWebhook:output_type -> rpc.rpc.CreateWebhookResponse
+	67, // 106: rpc.rpc.CodeBucket.ListWebhooks:output_type -> rpc.rpc.ListWebhooksResponse
+	69, // 107: rpc.rpc.CodeBucket.DeleteWebhook:output_type -> rpc.rpc.DeleteWebhookResponse
+	72, // 108: rpc.rpc.CodeBucket.GetWebhookDeliveries:output_type -> rpc.rpc.GetWebhookDeliveriesResponse
+	74, // 109: rpc.rpc.CodeBucket.TestWebhook:output_type -> rpc.rpc.TestWebhookResponse
+	77, // 110: rpc.rpc.CodeBucket.GetAuditLog:output_type -> rpc.rpc.GetAuditLogResponse
+	79, // 111: rpc.rpc.CodeBucket.VerifyAuditLog:output_type -> rpc.rpc.VerifyAuditLogResponse
+	68, // [68:112] is the sub-list for method output_type
+	24, // [24:68] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_rpc_proto_init() }
@@ -1729,7 +6739,7 @@ func file_rpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_proto_rawDesc), len(file_rpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   96,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,16 +24,45 @@ const (
 	CodeBucket_CreateBucketFromZip_FullMethodName       = "/rpc.rpc.CodeBucket/CreateBucketFromZip"
 	CodeBucket_CreateBucketFromGithub_FullMethodName    = "/rpc.rpc.CodeBucket/CreateBucketFromGithub"
 	CodeBucket_CreateBucketFromGitlab_FullMethodName    = "/rpc.rpc.CodeBucket/CreateBucketFromGitlab"
+	CodeBucket_CreateBucketFromGit_FullMethodName       = "/rpc.rpc.CodeBucket/CreateBucketFromGit"
+	CodeBucket_CreateBucketFromScm_FullMethodName       = "/rpc.rpc.CodeBucket/CreateBucketFromScm"
+	CodeBucket_CreateBucketOverlay_FullMethodName       = "/rpc.rpc.CodeBucket/CreateBucketOverlay"
 	CodeBucket_GetBucketToken_FullMethodName            = "/rpc.rpc.CodeBucket/GetBucketToken"
+	CodeBucket_RevokeBucketToken_FullMethodName         = "/rpc.rpc.CodeBucket/RevokeBucketToken"
+	CodeBucket_RevokeAllBucketTokens_FullMethodName     = "/rpc.rpc.CodeBucket/RevokeAllBucketTokens"
+	CodeBucket_GetPresignedFileUrl_FullMethodName       = "/rpc.rpc.CodeBucket/GetPresignedFileUrl"
 	CodeBucket_GetBucketFile_FullMethodName             = "/rpc.rpc.CodeBucket/GetBucketFile"
 	CodeBucket_GetBucketFiles_FullMethodName            = "/rpc.rpc.CodeBucket/GetBucketFiles"
 	CodeBucket_GetBucketFilesWithContent_FullMethodName = "/rpc.rpc.CodeBucket/GetBucketFilesWithContent"
 	CodeBucket_GetBucketFilesAsZip_FullMethodName       = "/rpc.rpc.CodeBucket/GetBucketFilesAsZip"
+	CodeBucket_GetBucketFilesAsArchive_FullMethodName   = "/rpc.rpc.CodeBucket/GetBucketFilesAsArchive"
+	CodeBucket_GetBucketDigest_FullMethodName           = "/rpc.rpc.CodeBucket/GetBucketDigest"
+	CodeBucket_GetBucketSyncTree_FullMethodName         = "/rpc.rpc.CodeBucket/GetBucketSyncTree"
+	CodeBucket_DiffBuckets_FullMethodName               = "/rpc.rpc.CodeBucket/DiffBuckets"
+	CodeBucket_MergeBuckets_FullMethodName              = "/rpc.rpc.CodeBucket/MergeBuckets"
+	CodeBucket_SearchBucket_FullMethodName              = "/rpc.rpc.CodeBucket/SearchBucket"
+	CodeBucket_RebuildBucketSearchIndex_FullMethodName  = "/rpc.rpc.CodeBucket/RebuildBucketSearchIndex"
+	CodeBucket_WatchBucket_FullMethodName               = "/rpc.rpc.CodeBucket/WatchBucket"
 	CodeBucket_SetBucketFiles_FullMethodName            = "/rpc.rpc.CodeBucket/SetBucketFiles"
 	CodeBucket_SetBucketFile_FullMethodName             = "/rpc.rpc.CodeBucket/SetBucketFile"
+	CodeBucket_ApplyBucketFileDelta_FullMethodName      = "/rpc.rpc.CodeBucket/ApplyBucketFileDelta"
 	CodeBucket_DeleteBucketFile_FullMethodName          = "/rpc.rpc.CodeBucket/DeleteBucketFile"
+	CodeBucket_MoveBucketFile_FullMethodName            = "/rpc.rpc.CodeBucket/MoveBucketFile"
+	CodeBucket_ApplyPatch_FullMethodName                = "/rpc.rpc.CodeBucket/ApplyPatch"
 	CodeBucket_ExportBucketToGithub_FullMethodName      = "/rpc.rpc.CodeBucket/ExportBucketToGithub"
 	CodeBucket_ExportBucketToGitlab_FullMethodName      = "/rpc.rpc.CodeBucket/ExportBucketToGitlab"
+	CodeBucket_ExportBucketToGit_FullMethodName         = "/rpc.rpc.CodeBucket/ExportBucketToGit"
+	CodeBucket_ExportBucketToScm_FullMethodName         = "/rpc.rpc.CodeBucket/ExportBucketToScm"
+	CodeBucket_GetBucketOverlayChanges_FullMethodName   = "/rpc.rpc.CodeBucket/GetBucketOverlayChanges"
+	CodeBucket_DiscardBucketOverlay_FullMethodName      = "/rpc.rpc.CodeBucket/DiscardBucketOverlay"
+	CodeBucket_CommitBucketOverlay_FullMethodName       = "/rpc.rpc.CodeBucket/CommitBucketOverlay"
+	CodeBucket_CreateWebhook_FullMethodName             = "/rpc.rpc.CodeBucket/CreateWebhook"
+	CodeBucket_ListWebhooks_FullMethodName              = "/rpc.rpc.CodeBucket/ListWebhooks"
+	CodeBucket_DeleteWebhook_FullMethodName             = "/rpc.rpc.CodeBucket/DeleteWebhook"
+	CodeBucket_GetWebhookDeliveries_FullMethodName      = "/rpc.rpc.CodeBucket/GetWebhookDeliveries"
+	CodeBucket_TestWebhook_FullMethodName               = "/rpc.rpc.CodeBucket/TestWebhook"
+	CodeBucket_GetAuditLog_FullMethodName               = "/rpc.rpc.CodeBucket/GetAuditLog"
+	CodeBucket_VerifyAuditLog_FullMethodName            = "/rpc.rpc.CodeBucket/VerifyAuditLog"
 )
 
 // CodeBucketClient is the client API for CodeBucket service.
@@ -45,16 +74,45 @@ type CodeBucketClient interface {
 	CreateBucketFromZip(ctx context.Context, in *CreateBucketFromZipRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error)
 	CreateBucketFromGithub(ctx context.Context, in *CreateBucketFromGithubRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error)
 	CreateBucketFromGitlab(ctx context.Context, in *CreateBucketFromGitlabRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error)
+	CreateBucketFromGit(ctx context.Context, in *CreateBucketFromGitRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error)
+	CreateBucketFromScm(ctx context.Context, in *CreateBucketFromScmRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error)
+	CreateBucketOverlay(ctx context.Context, in *CreateBucketOverlayRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error)
 	GetBucketToken(ctx context.Context, in *GetBucketTokenRequest, opts ...grpc.CallOption) (*GetBucketTokenResponse, error)
+	RevokeBucketToken(ctx context.Context, in *RevokeBucketTokenRequest, opts ...grpc.CallOption) (*RevokeBucketTokenResponse, error)
+	RevokeAllBucketTokens(ctx context.Context, in *RevokeAllBucketTokensRequest, opts ...grpc.CallOption) (*RevokeAllBucketTokensResponse, error)
+	GetPresignedFileUrl(ctx context.Context, in *GetPresignedFileUrlRequest, opts ...grpc.CallOption) (*GetPresignedFileUrlResponse, error)
 	GetBucketFile(ctx context.Context, in *GetBucketFileRequest, opts ...grpc.CallOption) (*GetBucketFileResponse, error)
 	GetBucketFiles(ctx context.Context, in *GetBucketFilesRequest, opts ...grpc.CallOption) (*GetBucketFilesResponse, error)
 	GetBucketFilesWithContent(ctx context.Context, in *GetBucketFilesRequest, opts ...grpc.CallOption) (*GetBucketFilesWithContentResponse, error)
 	GetBucketFilesAsZip(ctx context.Context, in *GetBucketFilesAsZipRequest, opts ...grpc.CallOption) (*GetBucketFilesAsZipResponse, error)
+	GetBucketFilesAsArchive(ctx context.Context, in *GetBucketFilesAsArchiveRequest, opts ...grpc.CallOption) (*GetBucketFilesAsArchiveResponse, error)
+	GetBucketDigest(ctx context.Context, in *GetBucketDigestRequest, opts ...grpc.CallOption) (*GetBucketDigestResponse, error)
+	GetBucketSyncTree(ctx context.Context, in *GetBucketSyncTreeRequest, opts ...grpc.CallOption) (*GetBucketSyncTreeResponse, error)
+	DiffBuckets(ctx context.Context, in *DiffBucketsRequest, opts ...grpc.CallOption) (*DiffBucketsResponse, error)
+	MergeBuckets(ctx context.Context, in *MergeBucketsRequest, opts ...grpc.CallOption) (*MergeBucketsResponse, error)
+	SearchBucket(ctx context.Context, in *SearchBucketRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchBucketResponse], error)
+	RebuildBucketSearchIndex(ctx context.Context, in *RebuildBucketSearchIndexRequest, opts ...grpc.CallOption) (*RebuildBucketSearchIndexResponse, error)
+	WatchBucket(ctx context.Context, in *WatchBucketRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BucketEvent], error)
 	SetBucketFiles(ctx context.Context, in *SetBucketFilesRequest, opts ...grpc.CallOption) (*SetBucketFilesResponse, error)
 	SetBucketFile(ctx context.Context, in *SetBucketFileRequest, opts ...grpc.CallOption) (*SetBucketFileResponse, error)
+	ApplyBucketFileDelta(ctx context.Context, in *ApplyBucketFileDeltaRequest, opts ...grpc.CallOption) (*ApplyBucketFileDeltaResponse, error)
 	DeleteBucketFile(ctx context.Context, in *DeleteBucketFileRequest, opts ...grpc.CallOption) (*DeleteBucketFileResponse, error)
+	MoveBucketFile(ctx context.Context, in *MoveBucketFileRequest, opts ...grpc.CallOption) (*MoveBucketFileResponse, error)
+	ApplyPatch(ctx context.Context, in *ApplyPatchRequest, opts ...grpc.CallOption) (*ApplyPatchResponse, error)
 	ExportBucketToGithub(ctx context.Context, in *ExportBucketToGithubRequest, opts ...grpc.CallOption) (*ExportBucketToGithubResponse, error)
 	ExportBucketToGitlab(ctx context.Context, in *ExportBucketToGitlabRequest, opts ...grpc.CallOption) (*ExportBucketToGitlabResponse, error)
+	ExportBucketToGit(ctx context.Context, in *ExportBucketToGitRequest, opts ...grpc.CallOption) (*ExportBucketToGitResponse, error)
+	ExportBucketToScm(ctx context.Context, in *ExportBucketToScmRequest, opts ...grpc.CallOption) (*ExportBucketToScmResponse, error)
+	GetBucketOverlayChanges(ctx context.Context, in *GetBucketOverlayChangesRequest, opts ...grpc.CallOption) (*GetBucketOverlayChangesResponse, error)
+	DiscardBucketOverlay(ctx context.Context, in *DiscardBucketOverlayRequest, opts ...grpc.CallOption) (*DiscardBucketOverlayResponse, error)
+	CommitBucketOverlay(ctx context.Context, in *CommitBucketOverlayRequest, opts ...grpc.CallOption) (*CommitBucketOverlayResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error)
+	TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookResponse, error)
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error)
+	VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error)
 }
 
 type codeBucketClient struct {
@@ -115,6 +173,36 @@ func (c *codeBucketClient) CreateBucketFromGitlab(ctx context.Context, in *Creat
 	return out, nil
 }
 
+func (c *codeBucketClient) CreateBucketFromGit(ctx context.Context, in *CreateBucketFromGitRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBucketResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_CreateBucketFromGit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) CreateBucketFromScm(ctx context.Context, in *CreateBucketFromScmRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBucketResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_CreateBucketFromScm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) CreateBucketOverlay(ctx context.Context, in *CreateBucketOverlayRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBucketResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_CreateBucketOverlay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *codeBucketClient) GetBucketToken(ctx context.Context, in *GetBucketTokenRequest, opts ...grpc.CallOption) (*GetBucketTokenResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBucketTokenResponse)
@@ -125,6 +213,36 @@ func (c *codeBucketClient) GetBucketToken(ctx context.Context, in *GetBucketToke
 	return out, nil
 }
 
+func (c *codeBucketClient) RevokeBucketToken(ctx context.Context, in *RevokeBucketTokenRequest, opts ...grpc.CallOption) (*RevokeBucketTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeBucketTokenResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_RevokeBucketToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) RevokeAllBucketTokens(ctx context.Context, in *RevokeAllBucketTokensRequest, opts ...grpc.CallOption) (*RevokeAllBucketTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAllBucketTokensResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_RevokeAllBucketTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) GetPresignedFileUrl(ctx context.Context, in *GetPresignedFileUrlRequest, opts ...grpc.CallOption) (*GetPresignedFileUrlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPresignedFileUrlResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_GetPresignedFileUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *codeBucketClient) GetBucketFile(ctx context.Context, in *GetBucketFileRequest, opts ...grpc.CallOption) (*GetBucketFileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBucketFileResponse)
@@ -165,6 +283,104 @@ func (c *codeBucketClient) GetBucketFilesAsZip(ctx context.Context, in *GetBucke
 	return out, nil
 }
 
+func (c *codeBucketClient) GetBucketFilesAsArchive(ctx context.Context, in *GetBucketFilesAsArchiveRequest, opts ...grpc.CallOption) (*GetBucketFilesAsArchiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBucketFilesAsArchiveResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_GetBucketFilesAsArchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) GetBucketDigest(ctx context.Context, in *GetBucketDigestRequest, opts ...grpc.CallOption) (*GetBucketDigestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBucketDigestResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_GetBucketDigest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) GetBucketSyncTree(ctx context.Context, in *GetBucketSyncTreeRequest, opts ...grpc.CallOption) (*GetBucketSyncTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBucketSyncTreeResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_GetBucketSyncTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) DiffBuckets(ctx context.Context, in *DiffBucketsRequest, opts ...grpc.CallOption) (*DiffBucketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffBucketsResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_DiffBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) MergeBuckets(ctx context.Context, in *MergeBucketsRequest, opts ...grpc.CallOption) (*MergeBucketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeBucketsResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_MergeBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) SearchBucket(ctx context.Context, in *SearchBucketRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchBucketResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CodeBucket_ServiceDesc.Streams[0], CodeBucket_SearchBucket_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchBucketRequest, SearchBucketResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CodeBucket_SearchBucketClient = grpc.ServerStreamingClient[SearchBucketResponse]
+
+func (c *codeBucketClient) RebuildBucketSearchIndex(ctx context.Context, in *RebuildBucketSearchIndexRequest, opts ...grpc.CallOption) (*RebuildBucketSearchIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildBucketSearchIndexResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_RebuildBucketSearchIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) WatchBucket(ctx context.Context, in *WatchBucketRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BucketEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CodeBucket_ServiceDesc.Streams[1], CodeBucket_WatchBucket_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBucketRequest, BucketEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CodeBucket_WatchBucketClient = grpc.ServerStreamingClient[BucketEvent]
+
 func (c *codeBucketClient) SetBucketFiles(ctx context.Context, in *SetBucketFilesRequest, opts ...grpc.CallOption) (*SetBucketFilesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SetBucketFilesResponse)
@@ -185,6 +401,16 @@ func (c *codeBucketClient) SetBucketFile(ctx context.Context, in *SetBucketFileR
 	return out, nil
 }
 
+func (c *codeBucketClient) ApplyBucketFileDelta(ctx context.Context, in *ApplyBucketFileDeltaRequest, opts ...grpc.CallOption) (*ApplyBucketFileDeltaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyBucketFileDeltaResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_ApplyBucketFileDelta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *codeBucketClient) DeleteBucketFile(ctx context.Context, in *DeleteBucketFileRequest, opts ...grpc.CallOption) (*DeleteBucketFileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteBucketFileResponse)
@@ -195,6 +421,26 @@ func (c *codeBucketClient) DeleteBucketFile(ctx context.Context, in *DeleteBucke
 	return out, nil
 }
 
+func (c *codeBucketClient) MoveBucketFile(ctx context.Context, in *MoveBucketFileRequest, opts ...grpc.CallOption) (*MoveBucketFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveBucketFileResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_MoveBucketFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) ApplyPatch(ctx context.Context, in *ApplyPatchRequest, opts ...grpc.CallOption) (*ApplyPatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyPatchResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_ApplyPatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *codeBucketClient) ExportBucketToGithub(ctx context.Context, in *ExportBucketToGithubRequest, opts ...grpc.CallOption) (*ExportBucketToGithubResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ExportBucketToGithubResponse)
@@ -215,6 +461,126 @@ func (c *codeBucketClient) ExportBucketToGitlab(ctx context.Context, in *ExportB
 	return out, nil
 }
 
+func (c *codeBucketClient) ExportBucketToGit(ctx context.Context, in *ExportBucketToGitRequest, opts ...grpc.CallOption) (*ExportBucketToGitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportBucketToGitResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_ExportBucketToGit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) ExportBucketToScm(ctx context.Context, in *ExportBucketToScmRequest, opts ...grpc.CallOption) (*ExportBucketToScmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportBucketToScmResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_ExportBucketToScm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) GetBucketOverlayChanges(ctx context.Context, in *GetBucketOverlayChangesRequest, opts ...grpc.CallOption) (*GetBucketOverlayChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBucketOverlayChangesResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_GetBucketOverlayChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) DiscardBucketOverlay(ctx context.Context, in *DiscardBucketOverlayRequest, opts ...grpc.CallOption) (*DiscardBucketOverlayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscardBucketOverlayResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_DiscardBucketOverlay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) CommitBucketOverlay(ctx context.Context, in *CommitBucketOverlayRequest, opts ...grpc.CallOption) (*CommitBucketOverlayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitBucketOverlayResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_CommitBucketOverlay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_GetWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestWebhookResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_TestWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuditLogResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_GetAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeBucketClient) VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyAuditLogResponse)
+	err := c.cc.Invoke(ctx, CodeBucket_VerifyAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CodeBucketServer is the server API for CodeBucket service.
 // All implementations must embed UnimplementedCodeBucketServer
 // for forward compatibility.
@@ -224,16 +590,45 @@ type CodeBucketServer interface {
 	CreateBucketFromZip(context.Context, *CreateBucketFromZipRequest) (*CreateBucketResponse, error)
 	CreateBucketFromGithub(context.Context, *CreateBucketFromGithubRequest) (*CreateBucketResponse, error)
 	CreateBucketFromGitlab(context.Context, *CreateBucketFromGitlabRequest) (*CreateBucketResponse, error)
+	CreateBucketFromGit(context.Context, *CreateBucketFromGitRequest) (*CreateBucketResponse, error)
+	CreateBucketFromScm(context.Context, *CreateBucketFromScmRequest) (*CreateBucketResponse, error)
+	CreateBucketOverlay(context.Context, *CreateBucketOverlayRequest) (*CreateBucketResponse, error)
 	GetBucketToken(context.Context, *GetBucketTokenRequest) (*GetBucketTokenResponse, error)
+	RevokeBucketToken(context.Context, *RevokeBucketTokenRequest) (*RevokeBucketTokenResponse, error)
+	RevokeAllBucketTokens(context.Context, *RevokeAllBucketTokensRequest) (*RevokeAllBucketTokensResponse, error)
+	GetPresignedFileUrl(context.Context, *GetPresignedFileUrlRequest) (*GetPresignedFileUrlResponse, error)
 	GetBucketFile(context.Context, *GetBucketFileRequest) (*GetBucketFileResponse, error)
 	GetBucketFiles(context.Context, *GetBucketFilesRequest) (*GetBucketFilesResponse, error)
 	GetBucketFilesWithContent(context.Context, *GetBucketFilesRequest) (*GetBucketFilesWithContentResponse, error)
 	GetBucketFilesAsZip(context.Context, *GetBucketFilesAsZipRequest) (*GetBucketFilesAsZipResponse, error)
+	GetBucketFilesAsArchive(context.Context, *GetBucketFilesAsArchiveRequest) (*GetBucketFilesAsArchiveResponse, error)
+	GetBucketDigest(context.Context, *GetBucketDigestRequest) (*GetBucketDigestResponse, error)
+	GetBucketSyncTree(context.Context, *GetBucketSyncTreeRequest) (*GetBucketSyncTreeResponse, error)
+	DiffBuckets(context.Context, *DiffBucketsRequest) (*DiffBucketsResponse, error)
+	MergeBuckets(context.Context, *MergeBucketsRequest) (*MergeBucketsResponse, error)
+	SearchBucket(*SearchBucketRequest, grpc.ServerStreamingServer[SearchBucketResponse]) error
+	RebuildBucketSearchIndex(context.Context, *RebuildBucketSearchIndexRequest) (*RebuildBucketSearchIndexResponse, error)
+	WatchBucket(*WatchBucketRequest, grpc.ServerStreamingServer[BucketEvent]) error
 	SetBucketFiles(context.Context, *SetBucketFilesRequest) (*SetBucketFilesResponse, error)
 	SetBucketFile(context.Context, *SetBucketFileRequest) (*SetBucketFileResponse, error)
+	ApplyBucketFileDelta(context.Context, *ApplyBucketFileDeltaRequest) (*ApplyBucketFileDeltaResponse, error)
 	DeleteBucketFile(context.Context, *DeleteBucketFileRequest) (*DeleteBucketFileResponse, error)
+	MoveBucketFile(context.Context, *MoveBucketFileRequest) (*MoveBucketFileResponse, error)
+	ApplyPatch(context.Context, *ApplyPatchRequest) (*ApplyPatchResponse, error)
 	ExportBucketToGithub(context.Context, *ExportBucketToGithubRequest) (*ExportBucketToGithubResponse, error)
 	ExportBucketToGitlab(context.Context, *ExportBucketToGitlabRequest) (*ExportBucketToGitlabResponse, error)
+	ExportBucketToGit(context.Context, *ExportBucketToGitRequest) (*ExportBucketToGitResponse, error)
+	ExportBucketToScm(context.Context, *ExportBucketToScmRequest) (*ExportBucketToScmResponse, error)
+	GetBucketOverlayChanges(context.Context, *GetBucketOverlayChangesRequest) (*GetBucketOverlayChangesResponse, error)
+	DiscardBucketOverlay(context.Context, *DiscardBucketOverlayRequest) (*DiscardBucketOverlayResponse, error)
+	CommitBucketOverlay(context.Context, *CommitBucketOverlayRequest) (*CommitBucketOverlayResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error)
+	TestWebhook(context.Context, *TestWebhookRequest) (*TestWebhookResponse, error)
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error)
+	VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error)
 	mustEmbedUnimplementedCodeBucketServer()
 }
 
@@ -259,9 +654,27 @@ func (UnimplementedCodeBucketServer) CreateBucketFromGithub(context.Context, *Cr
 func (UnimplementedCodeBucketServer) CreateBucketFromGitlab(context.Context, *CreateBucketFromGitlabRequest) (*CreateBucketResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateBucketFromGitlab not implemented")
 }
+func (UnimplementedCodeBucketServer) CreateBucketFromGit(context.Context, *CreateBucketFromGitRequest) (*CreateBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBucketFromGit not implemented")
+}
+func (UnimplementedCodeBucketServer) CreateBucketFromScm(context.Context, *CreateBucketFromScmRequest) (*CreateBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBucketFromScm not implemented")
+}
+func (UnimplementedCodeBucketServer) CreateBucketOverlay(context.Context, *CreateBucketOverlayRequest) (*CreateBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBucketOverlay not implemented")
+}
 func (UnimplementedCodeBucketServer) GetBucketToken(context.Context, *GetBucketTokenRequest) (*GetBucketTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBucketToken not implemented")
 }
+func (UnimplementedCodeBucketServer) RevokeBucketToken(context.Context, *RevokeBucketTokenRequest) (*RevokeBucketTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeBucketToken not implemented")
+}
+func (UnimplementedCodeBucketServer) RevokeAllBucketTokens(context.Context, *RevokeAllBucketTokensRequest) (*RevokeAllBucketTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllBucketTokens not implemented")
+}
+func (UnimplementedCodeBucketServer) GetPresignedFileUrl(context.Context, *GetPresignedFileUrlRequest) (*GetPresignedFileUrlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPresignedFileUrl not implemented")
+}
 func (UnimplementedCodeBucketServer) GetBucketFile(context.Context, *GetBucketFileRequest) (*GetBucketFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBucketFile not implemented")
 }
@@ -274,21 +687,90 @@ func (UnimplementedCodeBucketServer) GetBucketFilesWithContent(context.Context,
 func (UnimplementedCodeBucketServer) GetBucketFilesAsZip(context.Context, *GetBucketFilesAsZipRequest) (*GetBucketFilesAsZipResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBucketFilesAsZip not implemented")
 }
+func (UnimplementedCodeBucketServer) GetBucketFilesAsArchive(context.Context, *GetBucketFilesAsArchiveRequest) (*GetBucketFilesAsArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketFilesAsArchive not implemented")
+}
+func (UnimplementedCodeBucketServer) GetBucketDigest(context.Context, *GetBucketDigestRequest) (*GetBucketDigestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketDigest not implemented")
+}
+func (UnimplementedCodeBucketServer) GetBucketSyncTree(context.Context, *GetBucketSyncTreeRequest) (*GetBucketSyncTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketSyncTree not implemented")
+}
+func (UnimplementedCodeBucketServer) DiffBuckets(context.Context, *DiffBucketsRequest) (*DiffBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffBuckets not implemented")
+}
+func (UnimplementedCodeBucketServer) MergeBuckets(context.Context, *MergeBucketsRequest) (*MergeBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeBuckets not implemented")
+}
+func (UnimplementedCodeBucketServer) SearchBucket(*SearchBucketRequest, grpc.ServerStreamingServer[SearchBucketResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SearchBucket not implemented")
+}
+func (UnimplementedCodeBucketServer) RebuildBucketSearchIndex(context.Context, *RebuildBucketSearchIndexRequest) (*RebuildBucketSearchIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildBucketSearchIndex not implemented")
+}
+func (UnimplementedCodeBucketServer) WatchBucket(*WatchBucketRequest, grpc.ServerStreamingServer[BucketEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBucket not implemented")
+}
 func (UnimplementedCodeBucketServer) SetBucketFiles(context.Context, *SetBucketFilesRequest) (*SetBucketFilesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetBucketFiles not implemented")
 }
 func (UnimplementedCodeBucketServer) SetBucketFile(context.Context, *SetBucketFileRequest) (*SetBucketFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetBucketFile not implemented")
 }
+func (UnimplementedCodeBucketServer) ApplyBucketFileDelta(context.Context, *ApplyBucketFileDeltaRequest) (*ApplyBucketFileDeltaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyBucketFileDelta not implemented")
+}
 func (UnimplementedCodeBucketServer) DeleteBucketFile(context.Context, *DeleteBucketFileRequest) (*DeleteBucketFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBucketFile not implemented")
 }
+func (UnimplementedCodeBucketServer) MoveBucketFile(context.Context, *MoveBucketFileRequest) (*MoveBucketFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveBucketFile not implemented")
+}
+func (UnimplementedCodeBucketServer) ApplyPatch(context.Context, *ApplyPatchRequest) (*ApplyPatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyPatch not implemented")
+}
 func (UnimplementedCodeBucketServer) ExportBucketToGithub(context.Context, *ExportBucketToGithubRequest) (*ExportBucketToGithubResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportBucketToGithub not implemented")
 }
 func (UnimplementedCodeBucketServer) ExportBucketToGitlab(context.Context, *ExportBucketToGitlabRequest) (*ExportBucketToGitlabResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportBucketToGitlab not implemented")
 }
+func (UnimplementedCodeBucketServer) ExportBucketToGit(context.Context, *ExportBucketToGitRequest) (*ExportBucketToGitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportBucketToGit not implemented")
+}
+func (UnimplementedCodeBucketServer) ExportBucketToScm(context.Context, *ExportBucketToScmRequest) (*ExportBucketToScmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportBucketToScm not implemented")
+}
+func (UnimplementedCodeBucketServer) GetBucketOverlayChanges(context.Context, *GetBucketOverlayChangesRequest) (*GetBucketOverlayChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketOverlayChanges not implemented")
+}
+func (UnimplementedCodeBucketServer) DiscardBucketOverlay(context.Context, *DiscardBucketOverlayRequest) (*DiscardBucketOverlayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardBucketOverlay not implemented")
+}
+func (UnimplementedCodeBucketServer) CommitBucketOverlay(context.Context, *CommitBucketOverlayRequest) (*CommitBucketOverlayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitBucketOverlay not implemented")
+}
+func (UnimplementedCodeBucketServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedCodeBucketServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedCodeBucketServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedCodeBucketServer) GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookDeliveries not implemented")
+}
+func (UnimplementedCodeBucketServer) TestWebhook(context.Context, *TestWebhookRequest) (*TestWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestWebhook not implemented")
+}
+func (UnimplementedCodeBucketServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (UnimplementedCodeBucketServer) VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuditLog not implemented")
+}
 func (UnimplementedCodeBucketServer) mustEmbedUnimplementedCodeBucketServer() {}
 func (UnimplementedCodeBucketServer) testEmbeddedByValue()                    {}
 
@@ -400,49 +882,157 @@ func _CodeBucket_CreateBucketFromGitlab_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CodeBucket_GetBucketToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBucketTokenRequest)
+func _CodeBucket_CreateBucketFromGit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBucketFromGitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CodeBucketServer).GetBucketToken(ctx, in)
+		return srv.(CodeBucketServer).CreateBucketFromGit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CodeBucket_GetBucketToken_FullMethodName,
+		FullMethod: CodeBucket_CreateBucketFromGit_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CodeBucketServer).GetBucketToken(ctx, req.(*GetBucketTokenRequest))
+		return srv.(CodeBucketServer).CreateBucketFromGit(ctx, req.(*CreateBucketFromGitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CodeBucket_GetBucketFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBucketFileRequest)
+func _CodeBucket_CreateBucketFromScm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBucketFromScmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CodeBucketServer).GetBucketFile(ctx, in)
+		return srv.(CodeBucketServer).CreateBucketFromScm(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CodeBucket_GetBucketFile_FullMethodName,
+		FullMethod: CodeBucket_CreateBucketFromScm_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CodeBucketServer).GetBucketFile(ctx, req.(*GetBucketFileRequest))
+		return srv.(CodeBucketServer).CreateBucketFromScm(ctx, req.(*CreateBucketFromScmRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CodeBucket_GetBucketFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBucketFilesRequest)
+func _CodeBucket_CreateBucketOverlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBucketOverlayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CodeBucketServer).GetBucketFiles(ctx, in)
+		return srv.(CodeBucketServer).CreateBucketOverlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_CreateBucketOverlay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).CreateBucketOverlay(ctx, req.(*CreateBucketOverlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetBucketToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetBucketToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetBucketToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetBucketToken(ctx, req.(*GetBucketTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_RevokeBucketToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeBucketTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).RevokeBucketToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_RevokeBucketToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).RevokeBucketToken(ctx, req.(*RevokeBucketTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_RevokeAllBucketTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllBucketTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).RevokeAllBucketTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_RevokeAllBucketTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).RevokeAllBucketTokens(ctx, req.(*RevokeAllBucketTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetPresignedFileUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPresignedFileUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetPresignedFileUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetPresignedFileUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetPresignedFileUrl(ctx, req.(*GetPresignedFileUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetBucketFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetBucketFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetBucketFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetBucketFile(ctx, req.(*GetBucketFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetBucketFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetBucketFiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
@@ -490,6 +1080,136 @@ func _CodeBucket_GetBucketFilesAsZip_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CodeBucket_GetBucketFilesAsArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketFilesAsArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetBucketFilesAsArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetBucketFilesAsArchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetBucketFilesAsArchive(ctx, req.(*GetBucketFilesAsArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetBucketDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketDigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetBucketDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetBucketDigest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetBucketDigest(ctx, req.(*GetBucketDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetBucketSyncTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketSyncTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetBucketSyncTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetBucketSyncTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetBucketSyncTree(ctx, req.(*GetBucketSyncTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_DiffBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffBucketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).DiffBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_DiffBuckets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).DiffBuckets(ctx, req.(*DiffBucketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_MergeBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeBucketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).MergeBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_MergeBuckets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).MergeBuckets(ctx, req.(*MergeBucketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_SearchBucket_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchBucketRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CodeBucketServer).SearchBucket(m, &grpc.GenericServerStream[SearchBucketRequest, SearchBucketResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CodeBucket_SearchBucketServer = grpc.ServerStreamingServer[SearchBucketResponse]
+
+func _CodeBucket_RebuildBucketSearchIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildBucketSearchIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).RebuildBucketSearchIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_RebuildBucketSearchIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).RebuildBucketSearchIndex(ctx, req.(*RebuildBucketSearchIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_WatchBucket_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBucketRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CodeBucketServer).WatchBucket(m, &grpc.GenericServerStream[WatchBucketRequest, BucketEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CodeBucket_WatchBucketServer = grpc.ServerStreamingServer[BucketEvent]
+
 func _CodeBucket_SetBucketFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetBucketFilesRequest)
 	if err := dec(in); err != nil {
@@ -526,6 +1246,24 @@ func _CodeBucket_SetBucketFile_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CodeBucket_ApplyBucketFileDelta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyBucketFileDeltaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).ApplyBucketFileDelta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_ApplyBucketFileDelta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).ApplyBucketFileDelta(ctx, req.(*ApplyBucketFileDeltaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CodeBucket_DeleteBucketFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteBucketFileRequest)
 	if err := dec(in); err != nil {
@@ -544,6 +1282,42 @@ func _CodeBucket_DeleteBucketFile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CodeBucket_MoveBucketFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveBucketFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).MoveBucketFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_MoveBucketFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).MoveBucketFile(ctx, req.(*MoveBucketFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_ApplyPatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyPatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).ApplyPatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_ApplyPatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).ApplyPatch(ctx, req.(*ApplyPatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CodeBucket_ExportBucketToGithub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExportBucketToGithubRequest)
 	if err := dec(in); err != nil {
@@ -580,6 +1354,222 @@ func _CodeBucket_ExportBucketToGitlab_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CodeBucket_ExportBucketToGit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportBucketToGitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).ExportBucketToGit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_ExportBucketToGit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).ExportBucketToGit(ctx, req.(*ExportBucketToGitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_ExportBucketToScm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportBucketToScmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).ExportBucketToScm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_ExportBucketToScm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).ExportBucketToScm(ctx, req.(*ExportBucketToScmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetBucketOverlayChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketOverlayChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetBucketOverlayChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetBucketOverlayChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetBucketOverlayChanges(ctx, req.(*GetBucketOverlayChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_DiscardBucketOverlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardBucketOverlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).DiscardBucketOverlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_DiscardBucketOverlay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).DiscardBucketOverlay(ctx, req.(*DiscardBucketOverlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_CommitBucketOverlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitBucketOverlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).CommitBucketOverlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_CommitBucketOverlay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).CommitBucketOverlay(ctx, req.(*CommitBucketOverlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetWebhookDeliveries(ctx, req.(*GetWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_TestWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).TestWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_TestWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).TestWebhook(ctx, req.(*TestWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_GetAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeBucket_VerifyAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeBucketServer).VerifyAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeBucket_VerifyAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeBucketServer).VerifyAuditLog(ctx, req.(*VerifyAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CodeBucket_ServiceDesc is the grpc.ServiceDesc for CodeBucket service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -607,10 +1597,34 @@ var CodeBucket_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateBucketFromGitlab",
 			Handler:    _CodeBucket_CreateBucketFromGitlab_Handler,
 		},
+		{
+			MethodName: "CreateBucketFromGit",
+			Handler:    _CodeBucket_CreateBucketFromGit_Handler,
+		},
+		{
+			MethodName: "CreateBucketFromScm",
+			Handler:    _CodeBucket_CreateBucketFromScm_Handler,
+		},
+		{
+			MethodName: "CreateBucketOverlay",
+			Handler:    _CodeBucket_CreateBucketOverlay_Handler,
+		},
 		{
 			MethodName: "GetBucketToken",
 			Handler:    _CodeBucket_GetBucketToken_Handler,
 		},
+		{
+			MethodName: "RevokeBucketToken",
+			Handler:    _CodeBucket_RevokeBucketToken_Handler,
+		},
+		{
+			MethodName: "RevokeAllBucketTokens",
+			Handler:    _CodeBucket_RevokeAllBucketTokens_Handler,
+		},
+		{
+			MethodName: "GetPresignedFileUrl",
+			Handler:    _CodeBucket_GetPresignedFileUrl_Handler,
+		},
 		{
 			MethodName: "GetBucketFile",
 			Handler:    _CodeBucket_GetBucketFile_Handler,
@@ -627,6 +1641,30 @@ var CodeBucket_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBucketFilesAsZip",
 			Handler:    _CodeBucket_GetBucketFilesAsZip_Handler,
 		},
+		{
+			MethodName: "GetBucketFilesAsArchive",
+			Handler:    _CodeBucket_GetBucketFilesAsArchive_Handler,
+		},
+		{
+			MethodName: "GetBucketDigest",
+			Handler:    _CodeBucket_GetBucketDigest_Handler,
+		},
+		{
+			MethodName: "GetBucketSyncTree",
+			Handler:    _CodeBucket_GetBucketSyncTree_Handler,
+		},
+		{
+			MethodName: "DiffBuckets",
+			Handler:    _CodeBucket_DiffBuckets_Handler,
+		},
+		{
+			MethodName: "MergeBuckets",
+			Handler:    _CodeBucket_MergeBuckets_Handler,
+		},
+		{
+			MethodName: "RebuildBucketSearchIndex",
+			Handler:    _CodeBucket_RebuildBucketSearchIndex_Handler,
+		},
 		{
 			MethodName: "SetBucketFiles",
 			Handler:    _CodeBucket_SetBucketFiles_Handler,
@@ -635,10 +1673,22 @@ var CodeBucket_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetBucketFile",
 			Handler:    _CodeBucket_SetBucketFile_Handler,
 		},
+		{
+			MethodName: "ApplyBucketFileDelta",
+			Handler:    _CodeBucket_ApplyBucketFileDelta_Handler,
+		},
 		{
 			MethodName: "DeleteBucketFile",
 			Handler:    _CodeBucket_DeleteBucketFile_Handler,
 		},
+		{
+			MethodName: "MoveBucketFile",
+			Handler:    _CodeBucket_MoveBucketFile_Handler,
+		},
+		{
+			MethodName: "ApplyPatch",
+			Handler:    _CodeBucket_ApplyPatch_Handler,
+		},
 		{
 			MethodName: "ExportBucketToGithub",
 			Handler:    _CodeBucket_ExportBucketToGithub_Handler,
@@ -647,7 +1697,66 @@ var CodeBucket_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExportBucketToGitlab",
 			Handler:    _CodeBucket_ExportBucketToGitlab_Handler,
 		},
+		{
+			MethodName: "ExportBucketToGit",
+			Handler:    _CodeBucket_ExportBucketToGit_Handler,
+		},
+		{
+			MethodName: "ExportBucketToScm",
+			Handler:    _CodeBucket_ExportBucketToScm_Handler,
+		},
+		{
+			MethodName: "GetBucketOverlayChanges",
+			Handler:    _CodeBucket_GetBucketOverlayChanges_Handler,
+		},
+		{
+			MethodName: "DiscardBucketOverlay",
+			Handler:    _CodeBucket_DiscardBucketOverlay_Handler,
+		},
+		{
+			MethodName: "CommitBucketOverlay",
+			Handler:    _CodeBucket_CommitBucketOverlay_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _CodeBucket_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _CodeBucket_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _CodeBucket_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhookDeliveries",
+			Handler:    _CodeBucket_GetWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "TestWebhook",
+			Handler:    _CodeBucket_TestWebhook_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _CodeBucket_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "VerifyAuditLog",
+			Handler:    _CodeBucket_VerifyAuditLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchBucket",
+			Handler:       _CodeBucket_SearchBucket_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBucket",
+			Handler:       _CodeBucket_WatchBucket_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
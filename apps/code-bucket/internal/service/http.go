@@ -1,46 +1,103 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"mime"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
+	"github.com/metorial/metorial/services/code-bucket/pkg/access"
 	"github.com/metorial/metorial/services/code-bucket/pkg/fs"
+	"github.com/metorial/metorial/services/code-bucket/pkg/glob"
+	"github.com/metorial/metorial/services/code-bucket/pkg/keyring"
+	"github.com/metorial/metorial/services/code-bucket/pkg/presign"
 	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const sseKeepAliveInterval = 15 * time.Second
+
 type HttpService struct {
-	fsm       *fs.FileSystemManager
-	jwtSecret []byte
+	fsm            *fs.FileSystemManager
+	keys           *keyring.Keyring
+	limits         HttpLimits
+	trustedProxies TrustedProxies
+	presignSecret  []byte
+}
+
+const tokenIssuer = "https://code-bucket.service.metorial.com"
+
+func init() {
+	// Revoking all tokens of a bucket compares issue times, with whole seconds
+	// tokens issued right after the revocation couldn't be told apart from
+	// the revoked ones
+	jwt.TimePrecision = time.Millisecond
+}
+
+// Tokens are only accepted for the bucket they were issued for
+func tokenAudience(bucketID string) string {
+	return fmt.Sprintf("%s/bucket/%s", tokenIssuer, bucketID)
 }
 
 type Claims struct {
-	BucketID   string `json:"bucket_id"`
-	IsReadOnly bool   `json:"is_read_only"`
+	BucketID   string         `json:"bucket_id"`
+	IsReadOnly bool           `json:"is_read_only"`
+	Scopes     []access.Scope `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
+
+	policy *access.Policy
+	grant  *presign.Grant // Set for presigned urls
 }
 
 func newHttpServiceRouter(service *Service) *mux.Router {
 	hs := &HttpService{
-		fsm:       service.fsm,
-		jwtSecret: service.jwtSecret,
+		fsm:            service.fsm,
+		keys:           service.keys,
+		limits:         service.httpLimits,
+		trustedProxies: service.trustedProxies,
+		presignSecret:  service.presignSecret,
 	}
 
 	httpRouter := mux.NewRouter()
 	httpRouter.HandleFunc("/files", hs.handleGetFiles).Methods("GET")
 	httpRouter.HandleFunc("/files/{path:.*}", hs.handleGetFile).Methods("GET")
 	httpRouter.HandleFunc("/files/{path:.*}", hs.handlePutFile).Methods("PUT")
+	httpRouter.HandleFunc("/files/{path:.*}", hs.handlePatchFile).Methods("PATCH")
 	httpRouter.HandleFunc("/files/{path:.*}", hs.handleDeleteFile).Methods("DELETE")
 	httpRouter.HandleFunc("/files/{path:.*}", hs.handleOptions).Methods("OPTIONS")
+	httpRouter.HandleFunc("/search", hs.handleSearch).Methods("GET")
+	httpRouter.HandleFunc("/search", hs.handleOptions).Methods("OPTIONS")
+	httpRouter.HandleFunc("/sync/tree", hs.handleGetSyncTree).Methods("GET")
+	httpRouter.HandleFunc("/sync/tree", hs.handleOptions).Methods("OPTIONS")
+	httpRouter.HandleFunc("/patch", hs.handleApplyPatch).Methods("POST")
+	httpRouter.HandleFunc("/patch", hs.handleOptions).Methods("OPTIONS")
+	httpRouter.HandleFunc("/move", hs.handleMoveFile).Methods("POST")
+	httpRouter.HandleFunc("/move", hs.handleOptions).Methods("OPTIONS")
+	httpRouter.HandleFunc("/events", hs.handleWatchEvents).Methods("GET")
+	httpRouter.HandleFunc("/events", hs.handleOptions).Methods("OPTIONS")
+	httpRouter.HandleFunc("/download/{storageBucket}/{dir:zips|archives}/{name}", hs.handleDownloadArchive).Methods("GET", "HEAD")
+	httpRouter.HandleFunc("/download/{storageBucket}/{dir:zips|archives}/{name}", hs.handleOptions).Methods("OPTIONS")
+	httpRouter.HandleFunc("/.well-known/jwks.json", hs.handleGetJwks).Methods("GET")
+	httpRouter.HandleFunc("/.well-known/jwks.json", hs.handleOptions).Methods("OPTIONS")
+
+	httpRouter.Use(hs.limitMiddleware)
 
 	return httpRouter
 }
 
-func (hs *HttpService) authenticateRequest(r *http.Request) (string, bool, error) {
+func (hs *HttpService) authenticateRequest(r *http.Request) (*Claims, error) {
+	if claims, ok := r.Context().Value(claimsContextKey{}).(*Claims); ok {
+		return claims, nil
+	}
+
 	authHeader := r.Header.Get("Authorization")
 	authQuery := r.URL.Query().Get("metorial-code-bucket-token")
 
@@ -48,56 +105,157 @@ func (hs *HttpService) authenticateRequest(r *http.Request) (string, bool, error
 
 	if authHeader != "" {
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			return "", false, fmt.Errorf("missing or invalid authorization header")
+			return nil, fmt.Errorf("missing or invalid authorization header")
 		}
 
 		tokenString = strings.TrimPrefix(authHeader, "Bearer ")
 	}
 
 	if tokenString == "" {
-		return "", false, fmt.Errorf("missing authorization token")
+		return nil, fmt.Errorf("missing authorization token")
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return hs.jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, hs.keys.Keyfunc,
+		jwt.WithValidMethods(hs.keys.ValidMethods()),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	if claims.BucketID == "" || !slices.Contains(claims.Audience, tokenAudience(claims.BucketID)) {
+		return nil, fmt.Errorf("token has an invalid audience")
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := hs.fsm.IsTokenRevoked(r.Context(), claims.BucketID, claims.ID, issuedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %v", err)
+	}
+	if revoked {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+
+	if claims.policy, err = access.NewPolicy(claims.Scopes, claims.IsReadOnly); err != nil {
+		return nil, fmt.Errorf("invalid token scopes: %v", err)
+	}
+
+	return claims, nil
+}
+
+// authenticateFileRequest accepts a token, or a url presigned for the method
+// and path of the request (see GetPresignedFileUrl)
+func (hs *HttpService) authenticateFileRequest(r *http.Request, filePath string) (*Claims, error) {
+	if presign.IsPresigned(r.URL.Query()) {
+		return hs.authenticatePresignedRequest(r, filePath)
+	}
+
+	return hs.authenticateRequest(r)
+}
 
+// presignedPath returns the path a presigned url for the request is signed
+// for: the file path on file routes, the url path elsewhere
+func presignedPath(r *http.Request) string {
+	if filePath, ok := mux.Vars(r)["path"]; ok {
+		return util.NormalizePath(filePath)
+	}
+	return r.URL.Path
+}
+
+func (hs *HttpService) authenticatePresignedRequest(r *http.Request, filePath string) (*Claims, error) {
+	// HEAD requests are covered by urls signed for GET
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+
+	grant, err := presign.Verify(hs.presignSecret, method, filePath, r.URL.Query(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := hs.fsm.IsTokenRevoked(r.Context(), grant.BucketID, "", grant.IssuedAt)
 	if err != nil {
-		return "", false, err
+		return nil, fmt.Errorf("failed to check token revocation: %v", err)
+	}
+	if revoked {
+		return nil, fmt.Errorf("presigned url has been revoked")
+	}
+
+	claims := &Claims{
+		BucketID:   grant.BucketID,
+		IsReadOnly: grant.Method != http.MethodPut,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "presigned-url",
+			IssuedAt:  jwt.NewNumericDate(grant.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(grant.ExpiresAt),
+		},
+		grant: grant,
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims.BucketID, claims.IsReadOnly, nil
+	// The signature already limits the url to one file and method
+	if claims.policy, err = access.NewPolicy(nil, claims.IsReadOnly); err != nil {
+		return nil, err
 	}
 
-	return "", false, fmt.Errorf("invalid token")
+	return claims, nil
+}
+
+// authorize responds with 403 and returns false if the token doesn't allow
+// the operation on the path
+func (hs *HttpService) authorize(w http.ResponseWriter, claims *Claims, operation, filePath string) bool {
+	if err := claims.policy.Check(operation, filePath); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+	return true
 }
 
 func (hs *HttpService) setCorsHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, If-Match, If-None-Match, Last-Event-ID, Range, If-Range")
+	w.Header().Set("Access-Control-Expose-Headers", "ETag, Retry-After, Content-Disposition, Content-Length, Content-Range, Accept-Ranges")
 }
 
 func (hs *HttpService) handleGetFiles(w http.ResponseWriter, r *http.Request) {
 	hs.setCorsHeaders(w)
 
 	// Authenticate
-	authBucketID, _, err := hs.authenticateRequest(r)
+	claims, err := hs.authenticateRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	files, err := hs.fsm.GetBucketFiles(r.Context(), authBucketID, "")
+	query := r.URL.Query()
+	filter, err := glob.NewFilter(query["include"], query["exclude"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	files, err := hs.fsm.GetBucketFiles(r.Context(), claims.BucketID, "")
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	files = util.Filter(files, func(f fs.FileInfo) bool {
+		return filter.Match(f.Path) && claims.policy.Allows(access.OperationList, f.Path)
+	})
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(files)
 }
@@ -109,13 +267,17 @@ func (hs *HttpService) handleGetFile(w http.ResponseWriter, r *http.Request) {
 	filePath := util.NormalizePath(vars["path"])
 
 	// Authenticate
-	authBucketID, _, err := hs.authenticateRequest(r)
+	claims, err := hs.authenticateFileRequest(r, filePath)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	_, content, err := hs.fsm.GetBucketFile(r.Context(), authBucketID, filePath)
+	if !hs.authorize(w, claims, access.OperationRead, filePath) {
+		return
+	}
+
+	_, content, err := hs.fsm.GetBucketFile(r.Context(), claims.BucketID, filePath)
 	if err != nil {
 		if err.Error() == "file not found" {
 			http.Error(w, "File not found", http.StatusNotFound)
@@ -126,6 +288,7 @@ func (hs *HttpService) handleGetFile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", content.ContentType)
+	w.Header().Set("ETag", fmt.Sprintf("%q", content.Hash))
 	w.Write(content.Content)
 }
 
@@ -136,21 +299,32 @@ func (hs *HttpService) handlePutFile(w http.ResponseWriter, r *http.Request) {
 	filePath := util.NormalizePath(vars["path"])
 
 	// Authenticate
-	authBucketID, isReadOnly, err := hs.authenticateRequest(r)
+	claims, err := hs.authenticateFileRequest(r, filePath)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	// Silently ignore write operations for read-only tokens
-	if isReadOnly {
-		w.WriteHeader(http.StatusCreated)
+	if !hs.authorize(w, claims, access.OperationWrite, filePath) {
 		return
 	}
 
-	content, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if grant := claims.grant; grant != nil {
+		if grant.ContentType != "" && r.Header.Get("Content-Type") != grant.ContentType {
+			http.Error(w, "content type does not match the presigned url", http.StatusForbidden)
+			return
+		}
+		if grant.MaxSize > 0 {
+			if r.ContentLength > grant.MaxSize {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, grant.MaxSize)
+		}
+	}
+
+	content, ok := readRequestBody(w, r)
+	if !ok {
 		return
 	}
 
@@ -159,7 +333,7 @@ func (hs *HttpService) handlePutFile(w http.ResponseWriter, r *http.Request) {
 		contentType = "application/octet-stream"
 	}
 
-	err = hs.fsm.PutBucketFile(r.Context(), authBucketID, filePath, content, contentType)
+	err = hs.fsm.PutBucketFile(hs.httpOriginContext(r, claims), claims.BucketID, filePath, content, contentType)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -168,6 +342,57 @@ func (hs *HttpService) handlePutFile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// handlePatchFile applies a binary delta (see pkg/delta) to a file. The hash
+// of the content the delta was computed against is sent in If-Match, the way
+// it is returned in the ETag of handleGetFile.
+func (hs *HttpService) handlePatchFile(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	vars := mux.Vars(r)
+	filePath := util.NormalizePath(vars["path"])
+
+	// Authenticate
+	claims, err := hs.authenticateRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if !hs.authorize(w, claims, access.OperationWrite, filePath) {
+		return
+	}
+
+	baseHash := strings.Trim(r.Header.Get("If-Match"), `"`)
+	if baseHash == "" {
+		http.Error(w, "If-Match header with the base hash is required", http.StatusPreconditionRequired)
+		return
+	}
+
+	patch, ok := readRequestBody(w, r)
+	if !ok {
+		return
+	}
+
+	info, err := hs.fsm.ApplyFileDelta(hs.httpOriginContext(r, claims), claims.BucketID, filePath, baseHash, patch)
+	if err != nil {
+		switch {
+		case err.Error() == "file not found":
+			http.Error(w, "File not found", http.StatusNotFound)
+		case status.Code(err) == codes.FailedPrecondition:
+			http.Error(w, status.Convert(err).Message(), http.StatusPreconditionFailed)
+		case status.Code(err) == codes.InvalidArgument:
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprintf("%q", info.Hash))
+	json.NewEncoder(w).Encode(info)
+}
+
 func (hs *HttpService) handleDeleteFile(w http.ResponseWriter, r *http.Request) {
 	hs.setCorsHeaders(w)
 
@@ -175,19 +400,17 @@ func (hs *HttpService) handleDeleteFile(w http.ResponseWriter, r *http.Request)
 	filePath := util.NormalizePath(vars["path"])
 
 	// Authenticate
-	authBucketID, isReadOnly, err := hs.authenticateRequest(r)
+	claims, err := hs.authenticateRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	// Silently ignore delete operations for read-only tokens
-	if isReadOnly {
-		w.WriteHeader(http.StatusNoContent)
+	if !hs.authorize(w, claims, access.OperationDelete, filePath) {
 		return
 	}
 
-	err = hs.fsm.DeleteBucketFile(r.Context(), authBucketID, filePath)
+	err = hs.fsm.DeleteBucketFile(hs.httpOriginContext(r, claims), claims.BucketID, filePath)
 	if err != nil {
 		if err.Error() == "file not found" {
 			http.Error(w, "File not found", http.StatusNotFound)
@@ -200,7 +423,382 @@ func (hs *HttpService) handleDeleteFile(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleGetSyncTree returns one directory of the bucket's Merkle tree. The
+// directory hash doubles as its ETag, so clients can send the hash they know
+// in If-None-Match and skip unchanged directories.
+func (hs *HttpService) handleGetSyncTree(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	// Authenticate
+	claims, err := hs.authenticateRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Directory hashes cover every file below them, so scoped tokens can't
+	// be served a partial tree
+	if !claims.policy.AllowsEverywhere(access.OperationRead) {
+		http.Error(w, "token does not allow read access to the whole bucket", http.StatusForbidden)
+		return
+	}
+
+	tree, err := hs.fsm.GetSyncTree(r.Context(), claims.BucketID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	node, ok := tree.Node(r.URL.Query().Get("path"))
+	if !ok {
+		http.Error(w, "Directory not found", http.StatusNotFound)
+		return
+	}
+
+	etag := fmt.Sprintf("%q", node.Hash)
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	entries := make([]map[string]any, 0, len(node.Entries))
+	for _, e := range node.Entries {
+		entries = append(entries, map[string]any{
+			"name":   e.Name,
+			"path":   e.Path,
+			"is_dir": e.IsDir,
+			"hash":   e.Hash,
+			"size":   e.Size,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"path":    node.Path,
+		"hash":    node.Hash,
+		"entries": entries,
+	})
+}
+
+func (hs *HttpService) handleSearch(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	// Authenticate
+	claims, err := hs.authenticateRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+
+	intParam := func(name string) (int, error) {
+		if value := query.Get(name); value != "" {
+			return strconv.Atoi(value)
+		}
+		return 0, nil
+	}
+
+	contextLines, err := intParam("context")
+	if err != nil {
+		http.Error(w, "invalid context", http.StatusBadRequest)
+		return
+	}
+
+	maxResults, err := intParam("limit")
+	if err != nil {
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+
+	prefix := ""
+	if value := query.Get("prefix"); value != "" {
+		prefix = util.NormalizePath(value)
+	}
+
+	matches := []fs.SearchMatch{}
+	limitReached, err := hs.fsm.SearchBucket(r.Context(), claims.BucketID, fs.SearchOptions{
+		Query:           query.Get("q"),
+		IsRegex:         query.Get("regex") == "true",
+		CaseInsensitive: query.Get("case_insensitive") == "true",
+		Prefix:          prefix,
+		Glob:            query.Get("glob"),
+		ContextLines:    contextLines,
+		MaxResults:      maxResults,
+		PathFilter: func(filePath string) bool {
+			return claims.policy.Allows(access.OperationRead, filePath)
+		},
+	}, func(match fs.SearchMatch) error {
+		matches = append(matches, match)
+		return nil
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"matches":       matches,
+		"limit_reached": limitReached,
+	})
+}
+
+func (hs *HttpService) handleApplyPatch(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	// Authenticate
+	claims, err := hs.authenticateRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	body, ok := readRequestBody(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	maxFuzz := 0
+	if value := query.Get("fuzz"); value != "" {
+		if maxFuzz, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "invalid fuzz", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pathPrefix := util.NormalizePath(query.Get("prefix"))
+	if pathPrefix != "/" {
+		pathPrefix += "/"
+	}
+
+	result, err := hs.fsm.ApplyPatch(hs.httpOriginContext(r, claims), claims.BucketID, string(body), fs.PatchOptions{
+		DryRun:     query.Get("dry_run") == "true",
+		Strict:     query.Get("strict") == "true",
+		MaxFuzz:    maxFuzz,
+		PathPrefix: pathPrefix,
+		Authorize:  claims.policy.Check,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		case codes.PermissionDenied:
+			http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !result.Applied {
+		w.WriteHeader(http.StatusConflict)
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+func (hs *HttpService) handleMoveFile(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	// Authenticate
+	claims, err := hs.authenticateRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.From == "" || body.To == "" {
+		http.Error(w, "from and to are required", http.StatusBadRequest)
+		return
+	}
+
+	from, to := util.NormalizePath(body.From), util.NormalizePath(body.To)
+	if !hs.authorize(w, claims, access.OperationRead, from) ||
+		!hs.authorize(w, claims, access.OperationDelete, from) ||
+		!hs.authorize(w, claims, access.OperationWrite, to) {
+		return
+	}
+
+	info, err := hs.fsm.MoveBucketFile(hs.httpOriginContext(r, claims), claims.BucketID, from, to)
+	if err != nil {
+		switch {
+		case err.Error() == "file not found":
+			http.Error(w, "File not found", http.StatusNotFound)
+		case status.Code(err) == codes.InvalidArgument:
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprintf("%q", info.Hash))
+	json.NewEncoder(w).Encode(info)
+}
+
+// handleWatchEvents streams the changes of a bucket as server-sent events.
+// The event id is the cursor, so EventSource resumes where it left off by
+// itself. Other clients can pass the last cursor in the cursor parameter.
+func (hs *HttpService) handleWatchEvents(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	// Authenticate
+	claims, err := hs.authenticateRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	cursor := r.Header.Get("Last-Event-ID")
+	if cursor == "" {
+		cursor = query.Get("cursor")
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	events := make(chan fs.Event)
+	watchErr := make(chan error, 1)
+
+	go func() {
+		watchErr <- hs.fsm.WatchBucket(ctx, claims.BucketID, query.Get("prefix"), cursor, func(event fs.Event) error {
+			// Reset events have no path and are always sent
+			if event.Path != "" && !claims.policy.Allows(access.OperationRead, event.Path) &&
+				(event.OldPath == "" || !claims.policy.Allows(access.OperationRead, event.OldPath)) {
+				return nil
+			}
+
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Comments keep proxies from closing idle connections
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %s\ndata: %s\n\n", event.Cursor, data)
+			flusher.Flush()
+
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+
+		case err := <-watchErr:
+			if err != nil && r.Context().Err() == nil {
+				data, _ := json.Marshal(map[string]string{"message": status.Convert(err).Message()})
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+				flusher.Flush()
+			}
+			return
+
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleDownloadArchive serves an archive built by GetBucketFilesAsArchive,
+// with range support. It takes a token of the bucket or the presigned url
+// returned with the archive.
+func (hs *HttpService) handleDownloadArchive(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	// Authenticate
+	var claims *Claims
+	var err error
+	if presign.IsPresigned(r.URL.Query()) {
+		claims, err = hs.authenticatePresignedRequest(r, r.URL.Path)
+	} else {
+		claims, err = hs.authenticateRequest(r)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// An archive can contain any file of the bucket
+	if !claims.policy.AllowsEverywhere(access.OperationRead) {
+		http.Error(w, "token can't read the whole bucket", http.StatusForbidden)
+		return
+	}
+
+	archive, err := hs.fsm.OpenArchiveDownload(r.Context(), claims.BucketID, r.URL.Path)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			http.Error(w, "Archive not found", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			http.Error(w, status.Convert(err).Message(), http.StatusGone)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	defer archive.Close()
+
+	w.Header().Set("Content-Type", archive.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": archive.Name}))
+	w.Header().Set("ETag", fmt.Sprintf("%q", archive.Hash))
+	w.Header().Set("Expires", archive.ExpiresAt.UTC().Format(http.TimeFormat))
+
+	// Sets Content-Length and handles Range, If-Range and HEAD
+	http.ServeContent(w, r, "", archive.CreatedAt, archive)
+}
+
 func (hs *HttpService) handleOptions(w http.ResponseWriter, r *http.Request) {
 	hs.setCorsHeaders(w)
 	w.WriteHeader(http.StatusOK)
 }
+
+// handleGetJwks publishes the public keys tokens are verified with, so other
+// services can verify bucket tokens themselves
+func (hs *HttpService) handleGetJwks(w http.ResponseWriter, r *http.Request) {
+	hs.setCorsHeaders(w)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(hs.keys.JWKS())
+}
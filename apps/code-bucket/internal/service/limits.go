@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/fs"
+	"github.com/metorial/metorial/services/code-bucket/pkg/presign"
+)
+
+type HttpLimits struct {
+	Token       fs.RateLimit // Per token
+	Bucket      fs.RateLimit // Per bucket, across all of its tokens
+	MaxBodySize int64        // In bytes, 0 disables the limit
+}
+
+func DefaultHttpLimits() HttpLimits {
+	return HttpLimits{
+		Token:       fs.RateLimit{Rate: 20, Burst: 40},
+		Bucket:      fs.RateLimit{Rate: 100, Burst: 200},
+		MaxBodySize: 64 * 1024 * 1024,
+	}
+}
+
+// The claims of a request authenticated by limitMiddleware, so handlers
+// don't verify the token twice
+type claimsContextKey struct{}
+
+// limitMiddleware caps the size of request bodies and rate limits requests by
+// token and by bucket. Requests without a valid token are passed on as is,
+// the handlers reject them.
+func (hs *HttpService) limitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if hs.limits.MaxBodySize > 0 {
+			if r.ContentLength > hs.limits.MaxBodySize {
+				hs.setCorsHeaders(w)
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, hs.limits.MaxBodySize)
+		}
+
+		if !hs.limits.Token.Enabled() && !hs.limits.Bucket.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Presigned urls are only limited by bucket. Their claims aren't passed
+		// on, handlers that don't take them must not see them.
+		var claims *Claims
+		var err error
+		if presign.IsPresigned(r.URL.Query()) {
+			claims, err = hs.authenticatePresignedRequest(r, presignedPath(r))
+		} else {
+			claims, err = hs.authenticateRequest(r)
+			if err == nil {
+				r = r.WithContext(context.WithValue(r.Context(), claimsContextKey{}, claims))
+			}
+		}
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		limits := []struct {
+			scope string
+			id    string
+			limit fs.RateLimit
+		}{
+			{"token", claims.ID, hs.limits.Token},
+			{"bucket", claims.BucketID, hs.limits.Bucket},
+		}
+
+		for _, l := range limits {
+			// Tokens issued without an id are only limited by bucket
+			if l.id == "" {
+				continue
+			}
+
+			allowed, wait, err := hs.fsm.TakeRateLimitToken(r.Context(), l.scope, l.id, l.limit)
+			if err != nil {
+				// Rather serve requests unlimited than not at all
+				log.Printf("Error checking %s rate limit: %v", l.scope, err)
+				continue
+			}
+
+			if !allowed {
+				hs.setCorsHeaders(w)
+				w.Header().Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(wait.Seconds())))))
+				http.Error(w, l.scope+" rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readRequestBody reads the whole body, responding with 413 if it exceeds
+// the size limit
+func readRequestBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return nil, false
+	}
+
+	return body, true
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/fs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Callers of the gRPC API identify themselves with this metadata key, the
+// actor ends up on the change events and audit entries of their writes.
+const actorMetadataKey = "x-code-bucket-actor"
+
+const defaultRpcActor = "rpc"
+
+// TrustedProxies are the networks of the proxies in front of the service.
+// X-Forwarded-For is anyone's to set, so it is only read from requests that
+// come from these, and only as far back as the chain of trusted proxies goes.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses a comma separated list of CIDRs or addresses
+func ParseTrustedProxies(value string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(field)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", field, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+
+	return proxies, nil
+}
+
+func (p TrustedProxies) trusts(address string) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceIP returns the address of the client: the peer itself, unless it is
+// a trusted proxy, then the last forwarded address not added by one
+func (p TrustedProxies) sourceIP(peerAddress string, forwardedFor []string) string {
+	source := hostOnly(peerAddress)
+
+	var hops []string
+	for _, header := range forwardedFor {
+		for _, hop := range strings.Split(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0 && p.trusts(source); i-- {
+		source = hops[i]
+	}
+
+	return source
+}
+
+func (p TrustedProxies) rpcOriginContext(ctx context.Context, method string) context.Context {
+	origin := fs.Origin{Actor: defaultRpcActor, Method: method}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(actorMetadataKey); len(values) > 0 && values[0] != "" {
+		origin.Actor = values[0]
+	}
+
+	if caller, ok := peer.FromContext(ctx); ok {
+		origin.SourceIP = p.sourceIP(caller.Addr.String(), md.Get("x-forwarded-for"))
+	}
+
+	return fs.WithOrigin(ctx, origin)
+}
+
+func (p TrustedProxies) originUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(p.rpcOriginContext(ctx, info.FullMethod), req)
+}
+
+type originServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *originServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (p TrustedProxies) originStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &originServerStream{ServerStream: ss, ctx: p.rpcOriginContext(ss.Context(), info.FullMethod)})
+}
+
+// httpOriginContext attributes the writes of a request to the subject of its
+// token, falling back to the token's bucket.
+func (hs *HttpService) httpOriginContext(r *http.Request, claims *Claims) context.Context {
+	origin := fs.Origin{
+		Actor:    claims.Subject,
+		TokenID:  claims.ID,
+		SourceIP: hs.trustedProxies.sourceIP(r.RemoteAddr, r.Header.Values("X-Forwarded-For")),
+		Method:   r.Method + " " + r.URL.Path,
+	}
+
+	if origin.Actor == "" {
+		origin.Actor = "token:" + claims.BucketID
+	}
+
+	return fs.WithOrigin(r.Context(), origin)
+}
+
+func hostOnly(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
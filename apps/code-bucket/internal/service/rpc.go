@@ -3,12 +3,24 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/metorial/metorial/services/code-bucket/gen/rpc"
+	"github.com/metorial/metorial/services/code-bucket/pkg/access"
 	"github.com/metorial/metorial/services/code-bucket/pkg/fs"
+	"github.com/metorial/metorial/services/code-bucket/pkg/git"
 	"github.com/metorial/metorial/services/code-bucket/pkg/github"
 	"github.com/metorial/metorial/services/code-bucket/pkg/gitlab"
+	"github.com/metorial/metorial/services/code-bucket/pkg/glob"
+	"github.com/metorial/metorial/services/code-bucket/pkg/keyring"
+	"github.com/metorial/metorial/services/code-bucket/pkg/netguard"
+	"github.com/metorial/metorial/services/code-bucket/pkg/presign"
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
 	zipImporter "github.com/metorial/metorial/services/code-bucket/pkg/zip-importer"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -18,43 +30,64 @@ import (
 
 type RcpService struct {
 	rpc.UnimplementedCodeBucketServer
-	fsm       *fs.FileSystemManager
-	jwtSecret []byte
+	fsm           *fs.FileSystemManager
+	keys          *keyring.Keyring
+	presignSecret []byte
 }
 
 func newRcpService(service *Service) *RcpService {
 	rs := &RcpService{
-		fsm:       service.fsm,
-		jwtSecret: service.jwtSecret,
+		fsm:           service.fsm,
+		keys:          service.keys,
+		presignSecret: service.presignSecret,
 	}
 
 	return rs
 }
 
+func newFileFilter(include, exclude []string) (*glob.Filter, error) {
+	filter, err := glob.NewFilter(include, exclude)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid glob pattern: %v", err)
+	}
+
+	return filter, nil
+}
+
+func (rs *RcpService) notifyImportCompleted(ctx context.Context, bucketID, source string) {
+	rs.fsm.NotifyWebhooks(ctx, bucketID, fs.WebhookEventImportCompleted, map[string]any{"source": source})
+}
+
+func (rs *RcpService) notifyExportCompleted(ctx context.Context, bucketID, target string, fileCount int) {
+	rs.fsm.NotifyWebhooks(ctx, bucketID, fs.WebhookEventExportCompleted, map[string]any{"target": target, "file_count": fileCount})
+}
+
 func (rs *RcpService) CloneBucket(ctx context.Context, req *rpc.CloneBucketRequest) (*rpc.CreateBucketResponse, error) {
-	if err := rs.fsm.Clone(ctx, req.SourceBucketId, req.NewBucketId); err != nil {
+	filter, err := newFileFilter(req.Include, req.Exclude)
+	if err != nil {
 		return nil, err
 	}
 
+	if err := rs.fsm.Clone(ctx, req.SourceBucketId, req.NewBucketId, filter); err != nil {
+		return nil, err
+	}
+
+	rs.notifyImportCompleted(ctx, req.NewBucketId, "clone")
+
 	return &rpc.CreateBucketResponse{}, nil
 }
 
 func (rs *RcpService) CreateBucketFromGithub(ctx context.Context, req *rpc.CreateBucketFromGithubRequest) (*rpc.CreateBucketResponse, error) {
-	iter, err := github.DownloadRepo(req.Owner, req.Repo, req.Path, req.Ref, req.Token)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to download GitHub repository: %v", err)
-	}
-	defer iter.Close()
-
-	if err := rs.fsm.ImportZip(ctx, req.NewBucketId, iter); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to import zip: %v", err)
+	repo := scm.Repo{Name: fmt.Sprintf("%s/%s", req.Owner, req.Repo), Token: req.Token}
+	if err := rs.importFromScm(ctx, req.NewBucketId, "github", repo, req.Ref, req.Path); err != nil {
+		return nil, err
 	}
 
 	return &rpc.CreateBucketResponse{}, nil
 }
 
 func (rs *RcpService) CreateBucketFromZip(ctx context.Context, req *rpc.CreateBucketFromZipRequest) (*rpc.CreateBucketResponse, error) {
-	iter, err := zipImporter.DownloadZip(req.ZipUrl, req.Path, req.Headers)
+	iter, err := zipImporter.DownloadArchive(req.ZipUrl, req.Path, req.Headers)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to download zip: %v", err)
 	}
@@ -64,6 +97,8 @@ func (rs *RcpService) CreateBucketFromZip(ctx context.Context, req *rpc.CreateBu
 		return nil, status.Errorf(codes.Internal, "failed to import zip: %v", err)
 	}
 
+	rs.notifyImportCompleted(ctx, req.NewBucketId, "zip")
+
 	return &rpc.CreateBucketResponse{}, nil
 }
 
@@ -80,6 +115,8 @@ func (rs *RcpService) CreateBucketFromContents(ctx context.Context, req *rpc.Cre
 		return nil, status.Errorf(codes.Internal, "failed to import contents: %v", err)
 	}
 
+	rs.notifyImportCompleted(ctx, req.NewBucketId, "contents")
+
 	return &rpc.CreateBucketResponse{}, nil
 }
 
@@ -89,24 +126,130 @@ func (rs *RcpService) GetBucketToken(ctx context.Context, req *rpc.GetBucketToke
 		return nil, status.Errorf(codes.InvalidArgument, "expires_in_seconds must be greater than 0")
 	}
 
+	scopes := make([]access.Scope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scopes = append(scopes, access.Scope{Paths: scope.Paths, Operations: scope.Operations})
+	}
+	if _, err := access.NewPolicy(scopes, req.IsReadOnly); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid scopes: %v", err)
+	}
+
+	expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second)
+
 	claims := &Claims{
 		BucketID:   req.BucketId,
 		IsReadOnly: req.IsReadOnly,
+		Scopes:     scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expiresIn) * time.Second)),
+			ID:        util.RandomID(""),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Audience:  jwt.ClaimStrings{fmt.Sprintf("https://code-bucket.service.metorial.com/bucket/%s", req.BucketId)},
-			Issuer:    "https://code-bucket.service.metorial.com",
+			Audience:  jwt.ClaimStrings{tokenAudience(req.BucketId)},
+			Issuer:    tokenIssuer,
+			Subject:   req.Actor,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(rs.jwtSecret)
+	tokenString, err := rs.keys.Sign(claims)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create token: %v", err)
 	}
 
-	return &rpc.GetBucketTokenResponse{Token: tokenString}, nil
+	return &rpc.GetBucketTokenResponse{
+		Token:     tokenString,
+		TokenId:   claims.ID,
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+func (rs *RcpService) RevokeBucketToken(ctx context.Context, req *rpc.RevokeBucketTokenRequest) (*rpc.RevokeBucketTokenResponse, error) {
+	if req.TokenId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "token_id is required")
+	}
+
+	var expiresAt time.Time
+	if req.ExpiresAt != 0 {
+		expiresAt = time.Unix(req.ExpiresAt, 0)
+	}
+
+	if err := rs.fsm.RevokeToken(ctx, req.TokenId, expiresAt); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke token: %v", err)
+	}
+
+	return &rpc.RevokeBucketTokenResponse{}, nil
+}
+
+func (rs *RcpService) RevokeAllBucketTokens(ctx context.Context, req *rpc.RevokeAllBucketTokensRequest) (*rpc.RevokeAllBucketTokensResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	// Issue times have millisecond precision, tokens from the current
+	// millisecond are revoked as well
+	issuedBefore := time.Now().Truncate(time.Millisecond).Add(time.Millisecond)
+	if req.IssuedBefore != 0 {
+		issuedBefore = time.Unix(req.IssuedBefore, 0)
+	}
+
+	if err := rs.fsm.RevokeTokensIssuedBefore(ctx, req.BucketId, issuedBefore); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke tokens: %v", err)
+	}
+
+	return &rpc.RevokeAllBucketTokensResponse{}, nil
+}
+
+// GetPresignedFileUrl signs a URL granting one method on one file, see
+// pkg/presign. Revoking all tokens of the bucket revokes it as well.
+func (rs *RcpService) GetPresignedFileUrl(ctx context.Context, req *rpc.GetPresignedFileUrlRequest) (*rpc.GetPresignedFileUrlResponse, error) {
+	if len(rs.presignSecret) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "presigned urls are not enabled")
+	}
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+	if req.ExpiresInSeconds <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "expires_in_seconds must be greater than 0")
+	}
+	if req.MaxSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max_size must not be negative")
+	}
+
+	method := strings.ToUpper(req.Method)
+	switch method {
+	case http.MethodGet:
+		if req.ContentType != "" || req.MaxSize != 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "content_type and max_size only apply to PUT")
+		}
+	case http.MethodPut:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "method must be GET or PUT")
+	}
+
+	filePath := util.NormalizePath(req.Path)
+	if filePath == "/" {
+		return nil, status.Errorf(codes.InvalidArgument, "path is required")
+	}
+
+	now := time.Now()
+	grant := &presign.Grant{
+		BucketID:    req.BucketId,
+		Path:        filePath,
+		Method:      method,
+		IssuedAt:    now,
+		ExpiresAt:   now.Add(time.Duration(req.ExpiresInSeconds) * time.Second),
+		ContentType: req.ContentType,
+		MaxSize:     req.MaxSize,
+	}
+
+	fileUrl := url.URL{
+		Path:     "/files" + filePath,
+		RawQuery: grant.Query(rs.presignSecret).Encode(),
+	}
+
+	return &rpc.GetPresignedFileUrlResponse{
+		Url:       fileUrl.String(),
+		ExpiresAt: grant.ExpiresAt.Unix(),
+	}, nil
 }
 
 func (rs *RcpService) GetBucketFile(ctx context.Context, req *rpc.GetBucketFileRequest) (*rpc.GetBucketFileResponse, error) {
@@ -115,6 +258,9 @@ func (rs *RcpService) GetBucketFile(ctx context.Context, req *rpc.GetBucketFileR
 		if err.Error() == "file not found" {
 			return nil, status.Errorf(codes.NotFound, "file not found")
 		}
+		if status.Code(err) == codes.DataLoss {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get file: %v", err)
 	}
 
@@ -126,12 +272,18 @@ func (rs *RcpService) GetBucketFile(ctx context.Context, req *rpc.GetBucketFileR
 				Size:        info.Size,
 				ContentType: info.ContentType,
 				ModifiedAt:  info.ModifiedAt.Unix(),
+				Hash:        info.Hash,
 			},
 		},
 	}, nil
 }
 
 func (rs *RcpService) GetBucketFiles(ctx context.Context, req *rpc.GetBucketFilesRequest) (*rpc.GetBucketFilesResponse, error) {
+	filter, err := newFileFilter(req.Include, req.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
 	files, err := rs.fsm.GetBucketFiles(ctx, req.BucketId, req.Prefix)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get files: %v", err)
@@ -139,11 +291,16 @@ func (rs *RcpService) GetBucketFiles(ctx context.Context, req *rpc.GetBucketFile
 
 	var pbFiles []*rpc.FileInfo
 	for _, file := range files {
+		if !filter.Match(file.Path) {
+			continue
+		}
+
 		pbFiles = append(pbFiles, &rpc.FileInfo{
 			Path:        file.Path,
 			Size:        file.Size,
 			ContentType: file.ContentType,
 			ModifiedAt:  file.ModifiedAt.Unix(),
+			Hash:        file.Hash,
 		})
 	}
 
@@ -151,18 +308,71 @@ func (rs *RcpService) GetBucketFiles(ctx context.Context, req *rpc.GetBucketFile
 }
 
 func (rs *RcpService) GetBucketFilesAsZip(ctx context.Context, req *rpc.GetBucketFilesAsZipRequest) (*rpc.GetBucketFilesAsZipResponse, error) {
-	url, expiresAt, err := rs.fsm.GetBucketFilesAsZip(ctx, req.BucketId, req.Prefix)
+	filter, err := newFileFilter(req.Include, req.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadUrl, expiresAt, err := rs.fsm.GetBucketFilesAsZip(ctx, req.BucketId, req.Prefix, filter)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get files as zip: %v", err)
 	}
 
 	return &rpc.GetBucketFilesAsZipResponse{
-		DownloadUrl: *url,
+		DownloadUrl: rs.presignDownloadUrl(req.BucketId, *downloadUrl, *expiresAt),
 		ExpiresAt:   expiresAt.Unix(),
 	}, nil
 }
 
+func (rs *RcpService) GetBucketFilesAsArchive(ctx context.Context, req *rpc.GetBucketFilesAsArchiveRequest) (*rpc.GetBucketFilesAsArchiveResponse, error) {
+	filter, err := newFileFilter(req.Include, req.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	format := fs.ArchiveFormat(req.Format)
+	if format == "" {
+		format = fs.ArchiveFormatZip
+	}
+
+	downloadUrl, expiresAt, err := rs.fsm.GetBucketFilesAsArchive(ctx, req.BucketId, req.Prefix, filter, format)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get files as archive: %v", err)
+	}
+
+	return &rpc.GetBucketFilesAsArchiveResponse{
+		DownloadUrl: rs.presignDownloadUrl(req.BucketId, *downloadUrl, *expiresAt),
+		ExpiresAt:   expiresAt.Unix(),
+	}, nil
+}
+
+// presignDownloadUrl signs an archive download path until the archive
+// expires. Without a presign secret the download needs a token of the bucket.
+func (rs *RcpService) presignDownloadUrl(bucketID, downloadUrl string, expiresAt time.Time) string {
+	if len(rs.presignSecret) == 0 {
+		return downloadUrl
+	}
+
+	grant := &presign.Grant{
+		BucketID:  bucketID,
+		Path:      downloadUrl,
+		Method:    http.MethodGet,
+		IssuedAt:  time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	return downloadUrl + "?" + grant.Query(rs.presignSecret).Encode()
+}
+
 func (rs *RcpService) GetBucketFilesWithContent(ctx context.Context, req *rpc.GetBucketFilesRequest) (*rpc.GetBucketFilesWithContentResponse, error) {
+	filter, err := newFileFilter(req.Include, req.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
 	files, err := rs.fsm.GetBucketFiles(ctx, req.BucketId, req.Prefix)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get files: %v", err)
@@ -170,8 +380,15 @@ func (rs *RcpService) GetBucketFilesWithContent(ctx context.Context, req *rpc.Ge
 
 	var pbFiles []*rpc.FileContent
 	for _, file := range files {
+		if !filter.Match(file.Path) {
+			continue
+		}
+
 		_, content, err := rs.fsm.GetBucketFile(ctx, req.BucketId, file.Path)
 		if err != nil {
+			if status.Code(err) == codes.DataLoss {
+				return nil, err
+			}
 			continue
 		}
 
@@ -181,6 +398,7 @@ func (rs *RcpService) GetBucketFilesWithContent(ctx context.Context, req *rpc.Ge
 				Size:        file.Size,
 				ContentType: file.ContentType,
 				ModifiedAt:  file.ModifiedAt.Unix(),
+				Hash:        content.Hash,
 			},
 			Content: content.Content,
 		})
@@ -190,69 +408,176 @@ func (rs *RcpService) GetBucketFilesWithContent(ctx context.Context, req *rpc.Ge
 }
 
 func (rs *RcpService) ExportBucketToGithub(ctx context.Context, req *rpc.ExportBucketToGithubRequest) (*rpc.ExportBucketToGithubResponse, error) {
-	files, err := rs.fsm.GetBucketFiles(ctx, req.BucketId, "")
+	repo := scm.Repo{Name: fmt.Sprintf("%s/%s", req.Owner, req.Repo), Token: req.Token}
+
+	files, filter, err := rs.exportFiles(ctx, req.BucketId, req.Include, req.Exclude)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get bucket files: %v", err)
+		return nil, err
 	}
 
-	filesToUpload := make([]github.FileToUpload, 0, len(files))
-	for _, file := range files {
-		_, content, err := rs.fsm.GetBucketFile(ctx, req.BucketId, file.Path)
-		if err != nil {
-			continue
-		}
-
-		filesToUpload = append(filesToUpload, github.FileToUpload{
-			Path:    file.Path,
-			Content: content.Content,
-		})
+	result, err := github.Provider{}.Export(ctx, repo, files, github.ExportOptions{
+		UploadOptions: scm.UploadOptions{
+			Path:         req.Path,
+			Branch:       req.Branch,
+			Message:      req.Message,
+			AuthorName:   req.AuthorName,
+			AuthorEmail:  req.AuthorEmail,
+			Sync:         req.Sync,
+			MaxDeletions: int(req.MaxDeletions),
+			SyncFilter:   filter.Match,
+		},
+		BaseBranch:        req.BaseBranch,
+		CreatePullRequest: req.CreatePullRequest,
+		PullRequestTitle:  req.PullRequestTitle,
+		PullRequestBody:   req.PullRequestBody,
+	})
+	if err != nil {
+		return nil, scmUploadError(err)
 	}
 
-	if err := github.UploadToRepo(req.Owner, req.Repo, req.Path, req.Token, filesToUpload); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to upload to GitHub: %v", err)
-	}
+	rs.notifyExportCompleted(ctx, req.BucketId, "github", len(files))
 
-	return &rpc.ExportBucketToGithubResponse{}, nil
+	return &rpc.ExportBucketToGithubResponse{
+		CommitSha:      result.CommitSHA,
+		PullRequestUrl: result.PullRequestUrl,
+		DeletedPaths:   result.DeletedPaths,
+	}, nil
 }
 
 func (rs *RcpService) CreateBucketFromGitlab(ctx context.Context, req *rpc.CreateBucketFromGitlabRequest) (*rpc.CreateBucketResponse, error) {
-	iter, err := gitlab.DownloadRepo(req.ProjectId, req.Path, req.Ref, req.Token, req.GitlabApiUrl)
+	repo := scm.Repo{Name: strconv.FormatInt(req.ProjectId, 10), Token: req.Token, ApiUrl: req.GitlabApiUrl}
+	if err := rs.importFromScm(ctx, req.NewBucketId, "gitlab", repo, req.Ref, req.Path); err != nil {
+		return nil, err
+	}
+
+	return &rpc.CreateBucketResponse{}, nil
+}
+
+func (rs *RcpService) ExportBucketToGitlab(ctx context.Context, req *rpc.ExportBucketToGitlabRequest) (*rpc.ExportBucketToGitlabResponse, error) {
+	repo := scm.Repo{Name: strconv.FormatInt(req.ProjectId, 10), Token: req.Token, ApiUrl: req.GitlabApiUrl}
+
+	files, filter, err := rs.exportFiles(ctx, req.BucketId, req.Include, req.Exclude)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to download GitLab repository: %v", err)
+		return nil, err
 	}
-	defer iter.Close()
 
-	if err := rs.fsm.ImportZip(ctx, req.NewBucketId, iter); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to import zip: %v", err)
+	result, err := gitlab.Provider{}.Export(ctx, repo, files, scm.UploadOptions{
+		Path:         req.Path,
+		Sync:         req.Sync,
+		MaxDeletions: int(req.MaxDeletions),
+		SyncFilter:   filter.Match,
+	})
+	if err != nil {
+		return nil, scmUploadError(err)
+	}
+
+	rs.notifyExportCompleted(ctx, req.BucketId, "gitlab", len(files))
+
+	return &rpc.ExportBucketToGitlabResponse{DeletedPaths: result.DeletedPaths}, nil
+}
+
+func (rs *RcpService) CreateBucketFromScm(ctx context.Context, req *rpc.CreateBucketFromScmRequest) (*rpc.CreateBucketResponse, error) {
+	repo := scm.Repo{Name: req.Repo, Token: req.Token, ApiUrl: req.ApiUrl}
+	if err := rs.importFromScm(ctx, req.NewBucketId, req.Provider, repo, req.Ref, req.Path); err != nil {
+		return nil, err
 	}
 
 	return &rpc.CreateBucketResponse{}, nil
 }
 
-func (rs *RcpService) ExportBucketToGitlab(ctx context.Context, req *rpc.ExportBucketToGitlabRequest) (*rpc.ExportBucketToGitlabResponse, error) {
-	files, err := rs.fsm.GetBucketFiles(ctx, req.BucketId, "")
+func (rs *RcpService) ExportBucketToScm(ctx context.Context, req *rpc.ExportBucketToScmRequest) (*rpc.ExportBucketToScmResponse, error) {
+	repo := scm.Repo{Name: req.Repo, Token: req.Token, ApiUrl: req.ApiUrl}
+	commitSha, err := rs.exportToScm(ctx, req.BucketId, req.Provider, repo, req.Include, req.Exclude, scm.UploadOptions{
+		Path:         req.Path,
+		Branch:       req.Branch,
+		Message:      req.Message,
+		AuthorName:   req.AuthorName,
+		AuthorEmail:  req.AuthorEmail,
+		Sync:         req.Sync,
+		MaxDeletions: int(req.MaxDeletions),
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get bucket files: %v", err)
+		return nil, err
 	}
 
-	filesToUpload := make([]gitlab.FileToUpload, 0, len(files))
-	for _, file := range files {
-		_, content, err := rs.fsm.GetBucketFile(ctx, req.BucketId, file.Path)
-		if err != nil {
-			continue
-		}
+	return &rpc.ExportBucketToScmResponse{CommitSha: commitSha}, nil
+}
+
+// validateGitUrl only allows public remote repositories, local paths and
+// file:// urls would expose the file system of the server, private addresses
+// its network
+func validateGitUrl(ctx context.Context, rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Errorf(codes.InvalidArgument, "url must be an http or https url")
+	}
+	if err := netguard.CheckURL(ctx, rawUrl); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid url: %v", err)
+	}
+
+	return nil
+}
+
+func (rs *RcpService) CreateBucketFromGit(ctx context.Context, req *rpc.CreateBucketFromGitRequest) (*rpc.CreateBucketResponse, error) {
+	if err := validateGitUrl(ctx, req.Url); err != nil {
+		return nil, err
+	}
+
+	files, _, err := git.Clone(ctx, req.Url, req.Ref, req.Path, git.Auth{Username: req.Username, Password: req.Password})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to clone git repository: %v", err)
+	}
 
-		filesToUpload = append(filesToUpload, gitlab.FileToUpload{
+	contents := make([]*fs.FileContentsBase, 0, len(files))
+	for _, file := range files {
+		contents = append(contents, &fs.FileContentsBase{
 			Path:    file.Path,
-			Content: content.Content,
+			Content: file.Content,
 		})
 	}
 
-	if err := gitlab.UploadToRepo(req.ProjectId, req.Path, req.Token, req.GitlabApiUrl, filesToUpload); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to upload to GitLab: %v", err)
+	if err := rs.fsm.ImportContents(ctx, req.NewBucketId, contents); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to import contents: %v", err)
+	}
+
+	rs.notifyImportCompleted(ctx, req.NewBucketId, "git")
+
+	return &rpc.CreateBucketResponse{}, nil
+}
+
+func (rs *RcpService) ExportBucketToGit(ctx context.Context, req *rpc.ExportBucketToGitRequest) (*rpc.ExportBucketToGitResponse, error) {
+	if err := validateGitUrl(ctx, req.Url); err != nil {
+		return nil, err
+	}
+
+	if req.Branch == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "branch is required")
+	}
+
+	files, _, err := rs.exportFiles(ctx, req.BucketId, req.Include, req.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	filesToPush := make([]git.File, 0, len(files))
+	for _, file := range files {
+		filesToPush = append(filesToPush, git.File{Path: file.Path, Content: file.Content})
+	}
+
+	commitSha, err := git.Push(ctx, req.Url, git.Auth{Username: req.Username, Password: req.Password}, filesToPush, git.PushOptions{
+		Branch:      req.Branch,
+		Dir:         req.Path,
+		Message:     req.Message,
+		AuthorName:  req.AuthorName,
+		AuthorEmail: req.AuthorEmail,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push to git repository: %v", err)
 	}
 
-	return &rpc.ExportBucketToGitlabResponse{}, nil
+	rs.notifyExportCompleted(ctx, req.BucketId, "git", len(filesToPush))
+
+	return &rpc.ExportBucketToGitResponse{CommitSha: commitSha}, nil
 }
 
 func (rs *RcpService) SetBucketFiles(ctx context.Context, req *rpc.SetBucketFilesRequest) (*rpc.SetBucketFilesResponse, error) {
@@ -298,6 +623,34 @@ func (rs *RcpService) SetBucketFile(ctx context.Context, req *rpc.SetBucketFileR
 	return &rpc.SetBucketFileResponse{}, nil
 }
 
+func (rs *RcpService) ApplyBucketFileDelta(ctx context.Context, req *rpc.ApplyBucketFileDeltaRequest) (*rpc.ApplyBucketFileDeltaResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	if req.Path == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "path is required")
+	}
+
+	info, err := rs.fsm.ApplyFileDelta(ctx, req.BucketId, req.Path, req.BaseHash, req.Delta)
+	if err != nil {
+		if err.Error() == "file not found" {
+			return nil, status.Errorf(codes.NotFound, "file not found")
+		}
+		return nil, err
+	}
+
+	return &rpc.ApplyBucketFileDeltaResponse{
+		FileInfo: &rpc.FileInfo{
+			Path:        info.Path,
+			Size:        info.Size,
+			ContentType: info.ContentType,
+			ModifiedAt:  info.ModifiedAt.Unix(),
+			Hash:        info.Hash,
+		},
+	}, nil
+}
+
 func (rs *RcpService) DeleteBucketFile(ctx context.Context, req *rpc.DeleteBucketFileRequest) (*rpc.DeleteBucketFileResponse, error) {
 	if req.BucketId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
@@ -316,3 +669,511 @@ func (rs *RcpService) DeleteBucketFile(ctx context.Context, req *rpc.DeleteBucke
 
 	return &rpc.DeleteBucketFileResponse{}, nil
 }
+
+func (rs *RcpService) MoveBucketFile(ctx context.Context, req *rpc.MoveBucketFileRequest) (*rpc.MoveBucketFileResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	if req.FromPath == "" || req.ToPath == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "from_path and to_path are required")
+	}
+
+	info, err := rs.fsm.MoveBucketFile(ctx, req.BucketId, req.FromPath, req.ToPath)
+	if err != nil {
+		if err.Error() == "file not found" {
+			return nil, status.Errorf(codes.NotFound, "file not found")
+		}
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to move file: %v", err)
+	}
+
+	return &rpc.MoveBucketFileResponse{
+		FileInfo: &rpc.FileInfo{
+			Path:        info.Path,
+			Size:        info.Size,
+			ContentType: info.ContentType,
+			ModifiedAt:  info.ModifiedAt.Unix(),
+			Hash:        info.Hash,
+		},
+	}, nil
+}
+
+func (rs *RcpService) CreateBucketOverlay(ctx context.Context, req *rpc.CreateBucketOverlayRequest) (*rpc.CreateBucketResponse, error) {
+	if req.BaseBucketId == "" || req.NewBucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "base_bucket_id and new_bucket_id are required")
+	}
+
+	if err := rs.fsm.CreateOverlay(ctx, req.BaseBucketId, req.NewBucketId); err != nil {
+		return nil, err
+	}
+
+	return &rpc.CreateBucketResponse{}, nil
+}
+
+func (rs *RcpService) GetBucketOverlayChanges(ctx context.Context, req *rpc.GetBucketOverlayChangesRequest) (*rpc.GetBucketOverlayChangesResponse, error) {
+	changes, err := rs.fsm.GetOverlayChanges(ctx, req.BucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbChanges := make([]*rpc.OverlayChange, 0, len(changes))
+	for _, change := range changes {
+		pbChange := &rpc.OverlayChange{
+			Path:       change.Path,
+			ChangeType: change.ChangeType,
+		}
+
+		if change.FileInfo != nil {
+			pbChange.FileInfo = &rpc.FileInfo{
+				Path:        change.FileInfo.Path,
+				Size:        change.FileInfo.Size,
+				ContentType: change.FileInfo.ContentType,
+				ModifiedAt:  change.FileInfo.ModifiedAt.Unix(),
+				Hash:        change.FileInfo.Hash,
+			}
+		}
+
+		pbChanges = append(pbChanges, pbChange)
+	}
+
+	return &rpc.GetBucketOverlayChangesResponse{Changes: pbChanges}, nil
+}
+
+func (rs *RcpService) DiscardBucketOverlay(ctx context.Context, req *rpc.DiscardBucketOverlayRequest) (*rpc.DiscardBucketOverlayResponse, error) {
+	if err := rs.fsm.DiscardOverlay(ctx, req.BucketId); err != nil {
+		return nil, err
+	}
+
+	return &rpc.DiscardBucketOverlayResponse{}, nil
+}
+
+func (rs *RcpService) CommitBucketOverlay(ctx context.Context, req *rpc.CommitBucketOverlayRequest) (*rpc.CommitBucketOverlayResponse, error) {
+	if err := rs.fsm.CommitOverlay(ctx, req.BucketId); err != nil {
+		return nil, err
+	}
+
+	return &rpc.CommitBucketOverlayResponse{}, nil
+}
+
+func (rs *RcpService) GetBucketDigest(ctx context.Context, req *rpc.GetBucketDigestRequest) (*rpc.GetBucketDigestResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	digest, count, err := rs.fsm.GetBucketDigest(ctx, req.BucketId, req.Prefix)
+	if err != nil {
+		if status.Code(err) == codes.DataLoss {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to compute bucket digest: %v", err)
+	}
+
+	return &rpc.GetBucketDigestResponse{Digest: digest, FileCount: int32(count)}, nil
+}
+
+func (rs *RcpService) GetBucketSyncTree(ctx context.Context, req *rpc.GetBucketSyncTreeRequest) (*rpc.GetBucketSyncTreeResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	tree, err := rs.fsm.GetSyncTree(ctx, req.BucketId)
+	if err != nil {
+		if status.Code(err) == codes.DataLoss {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to build sync tree: %v", err)
+	}
+
+	node, ok := tree.Node(req.Path)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "directory not found")
+	}
+
+	res := &rpc.GetBucketSyncTreeResponse{
+		Path:      node.Path,
+		Hash:      node.Hash,
+		Unchanged: req.KnownHash == node.Hash,
+	}
+	if res.Unchanged {
+		return res, nil
+	}
+
+	for _, e := range node.Entries {
+		res.Entries = append(res.Entries, &rpc.SyncTreeEntry{
+			Name:  e.Name,
+			Path:  e.Path,
+			IsDir: e.IsDir,
+			Hash:  e.Hash,
+			Size:  e.Size,
+		})
+	}
+
+	return res, nil
+}
+
+func (rs *RcpService) DiffBuckets(ctx context.Context, req *rpc.DiffBucketsRequest) (*rpc.DiffBucketsResponse, error) {
+	if req.BaseBucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "base_bucket_id is required")
+	}
+
+	diffs, err := rs.fsm.DiffBuckets(ctx, fs.DiffOptions{
+		BaseBucketID:     req.BaseBucketId,
+		BasePrefix:       req.BasePrefix,
+		TargetBucketID:   req.TargetBucketId,
+		TargetPrefix:     req.TargetPrefix,
+		IncludeTextDiffs: req.IncludeTextDiffs,
+		ContextLines:     int(req.ContextLines),
+		MaxFileSize:      req.MaxFileSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to diff buckets: %v", err)
+	}
+
+	pbFiles := make([]*rpc.FileDiff, 0, len(diffs))
+	for _, d := range diffs {
+		pbFiles = append(pbFiles, &rpc.FileDiff{
+			Path:        d.Path,
+			ChangeType:  d.ChangeType,
+			IsBinary:    d.IsBinary,
+			OldSize:     d.OldSize,
+			NewSize:     d.NewSize,
+			UnifiedDiff: d.UnifiedDiff,
+		})
+	}
+
+	return &rpc.DiffBucketsResponse{Files: pbFiles}, nil
+}
+
+func (rs *RcpService) MergeBuckets(ctx context.Context, req *rpc.MergeBucketsRequest) (*rpc.MergeBucketsResponse, error) {
+	if req.BaseBucketId == "" || req.OursBucketId == "" || req.TheirsBucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "base_bucket_id, ours_bucket_id and theirs_bucket_id are required")
+	}
+
+	result, err := rs.fsm.MergeBuckets(ctx, fs.MergeOptions{
+		BaseBucketID:    req.BaseBucketId,
+		OursBucketID:    req.OursBucketId,
+		TheirsBucketID:  req.TheirsBucketId,
+		TargetBucketID:  req.TargetBucketId,
+		ConflictMarkers: req.ConflictMarkers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pbFiles := make([]*rpc.MergeFileResult, 0, len(result.Files))
+	for _, f := range result.Files {
+		pbHunks := make([]*rpc.MergeConflictHunk, 0, len(f.Hunks))
+		for _, h := range f.Hunks {
+			pbHunks = append(pbHunks, &rpc.MergeConflictHunk{
+				BaseStart:   int32(h.BaseStart),
+				OursStart:   int32(h.OursStart),
+				TheirsStart: int32(h.TheirsStart),
+				Base:        h.Base,
+				Ours:        h.Ours,
+				Theirs:      h.Theirs,
+			})
+		}
+
+		pbFiles = append(pbFiles, &rpc.MergeFileResult{
+			Path:         f.Path,
+			Resolution:   f.Resolution,
+			Deleted:      f.Deleted,
+			ConflictType: f.ConflictType,
+			Hunks:        pbHunks,
+		})
+	}
+
+	return &rpc.MergeBucketsResponse{HasConflicts: result.HasConflicts, Files: pbFiles}, nil
+}
+
+func (rs *RcpService) ApplyPatch(ctx context.Context, req *rpc.ApplyPatchRequest) (*rpc.ApplyPatchResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	// Like the paths of the other calls the prefix is used as given, so
+	// patches apply to the files written with the same convention
+	result, err := rs.fsm.ApplyPatch(ctx, req.BucketId, req.Patch, fs.PatchOptions{
+		DryRun:     req.DryRun,
+		Strict:     req.Strict,
+		MaxFuzz:    int(req.MaxFuzz),
+		PathPrefix: req.PathPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pbFiles := make([]*rpc.PatchFileResult, 0, len(result.Files))
+	for _, f := range result.Files {
+		pbHunks := make([]*rpc.PatchHunkResult, 0, len(f.Hunks))
+		for _, h := range f.Hunks {
+			pbHunks = append(pbHunks, &rpc.PatchHunkResult{
+				Applied: h.Applied,
+				Offset:  int32(h.Offset),
+				Fuzz:    int32(h.Fuzz),
+				Error:   h.Error,
+			})
+		}
+
+		pbFiles = append(pbFiles, &rpc.PatchFileResult{
+			Path:       f.Path,
+			OldPath:    f.OldPath,
+			ChangeType: f.ChangeType,
+			Hunks:      pbHunks,
+			Error:      f.Error,
+		})
+	}
+
+	return &rpc.ApplyPatchResponse{Applied: result.Applied, Files: pbFiles}, nil
+}
+
+func (rs *RcpService) SearchBucket(req *rpc.SearchBucketRequest, stream rpc.CodeBucket_SearchBucketServer) error {
+	if req.BucketId == "" {
+		return status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	limitReached, err := rs.fsm.SearchBucket(stream.Context(), req.BucketId, fs.SearchOptions{
+		Query:           req.Query,
+		IsRegex:         req.IsRegex,
+		CaseInsensitive: req.CaseInsensitive,
+		Prefix:          req.Prefix,
+		Glob:            req.Glob,
+		ContextLines:    int(req.ContextLines),
+		MaxResults:      int(req.MaxResults),
+		MaxFileSize:     req.MaxFileSize,
+	}, func(match fs.SearchMatch) error {
+		return stream.Send(&rpc.SearchBucketResponse{
+			Match: &rpc.SearchMatch{
+				Path:          match.Path,
+				LineNumber:    int32(match.LineNumber),
+				Column:        int32(match.Column),
+				Line:          match.Line,
+				ContextBefore: match.ContextBefore,
+				ContextAfter:  match.ContextAfter,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if limitReached {
+		return stream.Send(&rpc.SearchBucketResponse{LimitReached: true})
+	}
+
+	return nil
+}
+
+func (rs *RcpService) WatchBucket(req *rpc.WatchBucketRequest, stream rpc.CodeBucket_WatchBucketServer) error {
+	if req.BucketId == "" {
+		return status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	err := rs.fsm.WatchBucket(stream.Context(), req.BucketId, req.Prefix, req.Cursor, func(event fs.Event) error {
+		return stream.Send(&rpc.BucketEvent{
+			Cursor:  event.Cursor,
+			Type:    event.Type,
+			Path:    event.Path,
+			OldPath: event.OldPath,
+			Hash:    event.Hash,
+			Size:    event.Size,
+			Actor:   event.Actor,
+			Time:    event.Time.UnixMilli(),
+		})
+	})
+	if err != nil && stream.Context().Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+func (rs *RcpService) RebuildBucketSearchIndex(ctx context.Context, req *rpc.RebuildBucketSearchIndexRequest) (*rpc.RebuildBucketSearchIndexResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	count, err := rs.fsm.RebuildSearchIndex(ctx, req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rebuild search index: %v", err)
+	}
+
+	return &rpc.RebuildBucketSearchIndexResponse{IndexedFiles: int32(count)}, nil
+}
+
+func webhookToPb(hook *fs.Webhook) *rpc.Webhook {
+	return &rpc.Webhook{
+		Id:        hook.ID,
+		BucketId:  hook.BucketID,
+		Url:       hook.URL,
+		Events:    hook.Events,
+		CreatedAt: hook.CreatedAt.Unix(),
+	}
+}
+
+func webhookDeliveryToPb(delivery *fs.WebhookDelivery) *rpc.WebhookDelivery {
+	return &rpc.WebhookDelivery{
+		Id:         delivery.ID,
+		WebhookId:  delivery.WebhookID,
+		Event:      delivery.Event,
+		Attempt:    int32(delivery.Attempt),
+		Success:    delivery.Success,
+		StatusCode: int32(delivery.StatusCode),
+		Response:   delivery.Response,
+		Error:      delivery.Error,
+		DurationMs: delivery.DurationMs,
+		Time:       delivery.Time.Unix(),
+	}
+}
+
+func (rs *RcpService) CreateWebhook(ctx context.Context, req *rpc.CreateWebhookRequest) (*rpc.CreateWebhookResponse, error) {
+	hook, err := rs.fsm.CreateWebhook(ctx, fs.Webhook{
+		BucketID: req.BucketId,
+		URL:      req.Url,
+		Secret:   req.Secret,
+		Events:   req.Events,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create webhook: %v", err)
+	}
+
+	return &rpc.CreateWebhookResponse{Webhook: webhookToPb(hook), Secret: hook.Secret}, nil
+}
+
+func (rs *RcpService) ListWebhooks(ctx context.Context, req *rpc.ListWebhooksRequest) (*rpc.ListWebhooksResponse, error) {
+	hooks, err := rs.fsm.ListWebhooks(ctx, req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhooks: %v", err)
+	}
+
+	pbHooks := make([]*rpc.Webhook, 0, len(hooks))
+	for i := range hooks {
+		pbHooks = append(pbHooks, webhookToPb(&hooks[i]))
+	}
+
+	return &rpc.ListWebhooksResponse{Webhooks: pbHooks}, nil
+}
+
+func (rs *RcpService) DeleteWebhook(ctx context.Context, req *rpc.DeleteWebhookRequest) (*rpc.DeleteWebhookResponse, error) {
+	if req.WebhookId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	if err := rs.fsm.DeleteWebhook(ctx, req.WebhookId); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook: %v", err)
+	}
+
+	return &rpc.DeleteWebhookResponse{}, nil
+}
+
+func (rs *RcpService) GetWebhookDeliveries(ctx context.Context, req *rpc.GetWebhookDeliveriesRequest) (*rpc.GetWebhookDeliveriesResponse, error) {
+	if req.WebhookId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	deliveries, err := rs.fsm.GetWebhookDeliveries(ctx, req.WebhookId, int(req.Limit))
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get webhook deliveries: %v", err)
+	}
+
+	pbDeliveries := make([]*rpc.WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		pbDeliveries = append(pbDeliveries, webhookDeliveryToPb(&deliveries[i]))
+	}
+
+	return &rpc.GetWebhookDeliveriesResponse{Deliveries: pbDeliveries}, nil
+}
+
+func (rs *RcpService) TestWebhook(ctx context.Context, req *rpc.TestWebhookRequest) (*rpc.TestWebhookResponse, error) {
+	if req.WebhookId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	delivery, err := rs.fsm.TestWebhook(ctx, req.WebhookId)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to test webhook: %v", err)
+	}
+
+	return &rpc.TestWebhookResponse{Delivery: webhookDeliveryToPb(delivery)}, nil
+}
+
+func (rs *RcpService) GetAuditLog(ctx context.Context, req *rpc.GetAuditLogRequest) (*rpc.GetAuditLogResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	var afterSeq int64
+	if req.PageToken != "" {
+		var err error
+		if afterSeq, err = strconv.ParseInt(req.PageToken, 10, 64); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+
+	entries, next, err := rs.fsm.GetAuditLog(ctx, req.BucketId, afterSeq, int(req.Limit))
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get audit log: %v", err)
+	}
+
+	pbEntries := make([]*rpc.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		pbEntries = append(pbEntries, &rpc.AuditEntry{
+			Seq:         entry.Seq,
+			Time:        entry.Time.UnixMilli(),
+			Operation:   entry.Operation,
+			Path:        entry.Path,
+			OldPath:     entry.OldPath,
+			ContentHash: entry.ContentHash,
+			Actor:       entry.Actor,
+			TokenId:     entry.TokenID,
+			SourceIp:    entry.SourceIP,
+			Method:      entry.Method,
+			PrevHash:    entry.PrevHash,
+			Hash:        entry.Hash,
+		})
+	}
+
+	response := &rpc.GetAuditLogResponse{Entries: pbEntries}
+	if next != 0 {
+		response.NextPageToken = strconv.FormatInt(next, 10)
+	}
+
+	return response, nil
+}
+
+func (rs *RcpService) VerifyAuditLog(ctx context.Context, req *rpc.VerifyAuditLogRequest) (*rpc.VerifyAuditLogResponse, error) {
+	if req.BucketId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket_id is required")
+	}
+
+	result, err := rs.fsm.VerifyAuditLog(ctx, req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify audit log: %v", err)
+	}
+
+	return &rpc.VerifyAuditLogResponse{
+		Valid:      result.Valid,
+		EntryCount: result.EntryCount,
+		HeadHash:   result.HeadHash,
+		InvalidSeq: result.InvalidSeq,
+		Error:      result.Error,
+	}, nil
+}
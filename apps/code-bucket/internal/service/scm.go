@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/bitbucket"
+	"github.com/metorial/metorial/services/code-bucket/pkg/gitea"
+	"github.com/metorial/metorial/services/code-bucket/pkg/github"
+	"github.com/metorial/metorial/services/code-bucket/pkg/gitlab"
+	"github.com/metorial/metorial/services/code-bucket/pkg/glob"
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Providers of CreateBucketFromScm and ExportBucketToScm by name. The name is
+// also the source or target of the import and export webhooks.
+var scmProviders = map[string]scm.Provider{
+	"github":    github.Provider{},
+	"gitlab":    gitlab.Provider{},
+	"gitea":     gitea.Provider{DefaultApiUrl: gitea.GiteaApiUrl},
+	"forgejo":   gitea.Provider{DefaultApiUrl: gitea.ForgejoApiUrl},
+	"bitbucket": bitbucket.Provider{},
+}
+
+func scmProvider(name string) (scm.Provider, error) {
+	provider, ok := scmProviders[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(scmProviders))
+		for name := range scmProviders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, status.Errorf(codes.InvalidArgument, "unknown provider %q, must be one of %s", name, strings.Join(names, ", "))
+	}
+
+	return provider, nil
+}
+
+// importFromScm imports the files under path at ref into a new bucket. The ref
+// is resolved first so the archive is of a single commit even if the branch
+// moves meanwhile.
+func (rs *RcpService) importFromScm(ctx context.Context, bucketID, providerName string, repo scm.Repo, ref, path string) error {
+	provider, err := scmProvider(providerName)
+	if err != nil {
+		return err
+	}
+
+	if ref != "" {
+		if ref, err = provider.ResolveRef(ctx, repo, ref); err != nil {
+			if errors.Is(err, scm.ErrRefNotFound) {
+				return status.Errorf(codes.NotFound, "%v", err)
+			}
+			return status.Errorf(codes.Internal, "failed to resolve ref: %v", err)
+		}
+	}
+
+	iter, err := provider.DownloadArchive(ctx, repo, ref, path)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to download repository: %v", err)
+	}
+	defer iter.Close()
+
+	if err := rs.fsm.ImportZip(ctx, bucketID, iter); err != nil {
+		return status.Errorf(codes.Internal, "failed to import zip: %v", err)
+	}
+
+	rs.notifyImportCompleted(ctx, bucketID, strings.ToLower(providerName))
+
+	return nil
+}
+
+// exportToScm uploads the files of a bucket matching the globs and returns the
+// commit they were written in
+func (rs *RcpService) exportToScm(ctx context.Context, bucketID, providerName string, repo scm.Repo, include, exclude []string, opts scm.UploadOptions) (string, error) {
+	provider, err := scmProvider(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	files, filter, err := rs.exportFiles(ctx, bucketID, include, exclude)
+	if err != nil {
+		return "", err
+	}
+	opts.SyncFilter = filter.Match
+
+	commitSha, err := provider.UploadFiles(ctx, repo, files, opts)
+	if err != nil {
+		return "", scmUploadError(err)
+	}
+
+	rs.notifyExportCompleted(ctx, bucketID, strings.ToLower(providerName), len(files))
+
+	return commitSha, nil
+}
+
+func scmUploadError(err error) error {
+	switch {
+	case errors.Is(err, scm.ErrTooManyDeletions):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, scm.ErrSyncNotSupported):
+		return status.Errorf(codes.Unimplemented, "%v", err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to upload files: %v", err)
+}
+
+// exportFiles reads the files of a bucket matching the globs. The filter is
+// returned as well, so a sync only deletes the remote files it matches.
+func (rs *RcpService) exportFiles(ctx context.Context, bucketID string, include, exclude []string) ([]scm.File, *glob.Filter, error) {
+	filter, err := newFileFilter(include, exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files, err := rs.fsm.GetBucketFiles(ctx, bucketID, "")
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get bucket files: %v", err)
+	}
+
+	filesToUpload := make([]scm.File, 0, len(files))
+	for _, file := range files {
+		if !filter.Match(file.Path) {
+			continue
+		}
+
+		// Files deleted since the listing are skipped, other failures abort
+		// the export so a sync doesn't delete files that couldn't be read
+		_, content, err := rs.fsm.GetBucketFile(ctx, bucketID, file.Path)
+		if err != nil {
+			if err.Error() == "file not found" {
+				continue
+			}
+			return nil, nil, status.Errorf(codes.Internal, "failed to read file %s: %v", file.Path, err)
+		}
+
+		filesToUpload = append(filesToUpload, scm.File{
+			Path:    file.Path,
+			Content: content.Content,
+		})
+	}
+
+	return filesToUpload, filter, nil
+}
@@ -9,17 +9,22 @@ import (
 	grpcUtil "github.com/metorial/metorial/services/code-bucket/pkg/grpcUtil"
 	"github.com/metorial/metorial/services/code-bucket/gen/rpc"
 	"github.com/metorial/metorial/services/code-bucket/pkg/fs"
+	"github.com/metorial/metorial/services/code-bucket/pkg/keyring"
 	"github.com/metorial/metorial/services/code-bucket/pkg/workspace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 type Service struct {
 	fsm             *fs.FileSystemManager
-	jwtSecret       []byte
+	keys            *keyring.Keyring
+	httpLimits      HttpLimits
+	trustedProxies  TrustedProxies
+	presignSecret   []byte
 	workspaceServer *workspace.Server
 }
 
-func NewService(jwtSecret string, opts ...fs.FileSystemManagerOption) *Service {
+func NewService(keys *keyring.Keyring, httpLimits HttpLimits, trustedProxies TrustedProxies, presignSecret string, opts ...fs.FileSystemManagerOption) *Service {
 	fsm := fs.NewFileSystemManager(opts...)
 
 	// Initialize workspace server
@@ -31,7 +36,10 @@ func NewService(jwtSecret string, opts ...fs.FileSystemManagerOption) *Service {
 
 	return &Service{
 		fsm:             fsm,
-		jwtSecret:       []byte(jwtSecret),
+		keys:            keys,
+		httpLimits:      httpLimits,
+		trustedProxies:  trustedProxies,
+		presignSecret:   []byte(presignSecret),
 		workspaceServer: workspaceServer,
 	}
 }
@@ -46,7 +54,10 @@ func (s *Service) Start(httpAddress, rpcAddress, workspaceAddress string) error
 	}
 
 	// gRPC Server
-	grpcServer := grpcUtil.NewGrpcServer("code-bucket")
+	grpcServer := grpcUtil.NewGrpcServer("code-bucket",
+		grpc.ChainUnaryInterceptor(s.trustedProxies.originUnaryInterceptor),
+		grpc.ChainStreamInterceptor(s.trustedProxies.originStreamInterceptor),
+	)
 	rpc.RegisterCodeBucketServer(grpcServer, rpcService)
 
 	reflection.Register(grpcServer)
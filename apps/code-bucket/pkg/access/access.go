@@ -0,0 +1,115 @@
+package access
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/glob"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+)
+
+// A token carries a list of scopes. Each scope grants some operations on the
+// paths matched by its patterns, which use the include syntax of glob.Filter:
+// "/src/" is everything below the top-level src directory, "*.md" every
+// markdown file. An operation on a path is allowed if any scope grants it.
+
+const (
+	OperationRead   = "read"
+	OperationWrite  = "write"
+	OperationDelete = "delete"
+	OperationList   = "list"
+)
+
+var Operations = []string{OperationRead, OperationWrite, OperationDelete, OperationList}
+
+type Scope struct {
+	Paths      []string `json:"paths,omitempty"`      // Empty for every path
+	Operations []string `json:"operations,omitempty"` // Empty for every operation
+}
+
+type compiledScope struct {
+	filter     *glob.Filter
+	operations []string
+}
+
+type Policy struct {
+	scopes   []compiledScope
+	readOnly bool
+}
+
+// NewPolicy compiles the scopes of a token. Tokens without scopes may do
+// everything, read-only tokens may additionally only read and list.
+func NewPolicy(scopes []Scope, readOnly bool) (*Policy, error) {
+	policy := &Policy{readOnly: readOnly}
+
+	if len(scopes) == 0 {
+		scopes = []Scope{{}}
+	}
+
+	for _, scope := range scopes {
+		for _, operation := range scope.Operations {
+			if !slices.Contains(Operations, operation) {
+				return nil, fmt.Errorf("unknown operation %q", operation)
+			}
+		}
+
+		filter, err := glob.NewFilter(scope.Paths, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.scopes = append(policy.scopes, compiledScope{filter: filter, operations: scope.Operations})
+	}
+
+	return policy, nil
+}
+
+func (s *compiledScope) grants(operation string) bool {
+	return len(s.operations) == 0 || slices.Contains(s.operations, operation)
+}
+
+func (p *Policy) allowsOperation(operation string) bool {
+	return !p.readOnly || operation == OperationRead || operation == OperationList
+}
+
+// Allows reports whether the operation is allowed on the path. Paths with
+// ".." segments are refused, they could resolve outside of the scope they
+// match, and the others are normalized before matching.
+func (p *Policy) Allows(operation, filePath string) bool {
+	if !p.allowsOperation(operation) || util.HasDotDot(filePath) {
+		return false
+	}
+	filePath = util.NormalizePath(filePath)
+
+	for _, scope := range p.scopes {
+		if scope.grants(operation) && scope.filter.Match(filePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsEverywhere reports whether the operation is allowed on every path,
+// for requests that can't be checked path by path.
+func (p *Policy) AllowsEverywhere(operation string) bool {
+	if !p.allowsOperation(operation) {
+		return false
+	}
+
+	for _, scope := range p.scopes {
+		if scope.grants(operation) && scope.filter.IsEmpty() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check returns an error describing the denied operation, or nil
+func (p *Policy) Check(operation, filePath string) error {
+	if p.Allows(operation, filePath) {
+		return nil
+	}
+	return fmt.Errorf("token does not allow %s access to %s", operation, filePath)
+}
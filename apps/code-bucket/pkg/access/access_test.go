@@ -0,0 +1,74 @@
+package access
+
+import "testing"
+
+func TestPolicy_Unscoped(t *testing.T) {
+	policy, err := NewPolicy(nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, operation := range Operations {
+		if !policy.Allows(operation, "/any/file.txt") || !policy.AllowsEverywhere(operation) {
+			t.Errorf("expected %s to be allowed", operation)
+		}
+	}
+}
+
+func TestPolicy_ReadOnly(t *testing.T) {
+	policy, err := NewPolicy(nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !policy.Allows(OperationRead, "/a.txt") || !policy.Allows(OperationList, "/a.txt") {
+		t.Error("expected reads to be allowed")
+	}
+	if policy.Allows(OperationWrite, "/a.txt") || policy.Allows(OperationDelete, "/a.txt") {
+		t.Error("expected writes to be denied")
+	}
+}
+
+func TestPolicy_Scoped(t *testing.T) {
+	policy, err := NewPolicy([]Scope{
+		{Paths: []string{"/src/"}, Operations: []string{OperationRead, OperationWrite, OperationDelete, OperationList}},
+		{Operations: []string{OperationRead, OperationList}},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		operation string
+		path      string
+		expected  bool
+	}{
+		{OperationWrite, "/src/main.go", true},
+		{OperationDelete, "/src/pkg/util.go", true},
+		{OperationWrite, "/config.json", false},
+		{OperationWrite, "/lib/src/file.go", false},
+		{OperationRead, "/config.json", true},
+		{OperationWrite, "/src/../config.json", false},
+		{OperationWrite, "/src/..", false},
+		{OperationWrite, "/src//./main.go", true},
+	}
+
+	for _, c := range cases {
+		if got := policy.Allows(c.operation, c.path); got != c.expected {
+			t.Errorf("Allows(%s, %s) = %v, expected %v", c.operation, c.path, got, c.expected)
+		}
+	}
+
+	if policy.AllowsEverywhere(OperationWrite) {
+		t.Error("write is not allowed everywhere")
+	}
+	if !policy.AllowsEverywhere(OperationRead) {
+		t.Error("read is allowed everywhere")
+	}
+}
+
+func TestPolicy_InvalidOperation(t *testing.T) {
+	if _, err := NewPolicy([]Scope{{Operations: []string{"admin"}}}, false); err == nil {
+		t.Error("expected unknown operations to be rejected")
+	}
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Entries form a hash chain: the hash of an entry covers its content and the
+// hash of the entry before it, so changing, removing or reordering an entry
+// breaks every hash after it. The first entry links to GenesisHash.
+
+const GenesisHash = ""
+
+type Entry struct {
+	Seq         int64     `json:"seq"`
+	Time        time.Time `json:"time"`
+	Operation   string    `json:"operation"`
+	Path        string    `json:"path"`
+	OldPath     string    `json:"old_path,omitempty"`
+	ContentHash string    `json:"content_hash,omitempty"`
+	Actor       string    `json:"actor,omitempty"`
+	TokenID     string    `json:"token_id,omitempty"`
+	SourceIP    string    `json:"source_ip,omitempty"`
+	Method      string    `json:"method,omitempty"`
+	PrevHash    string    `json:"prev_hash"`
+	Hash        string    `json:"hash"`
+}
+
+// ComputeHash hashes everything but the Hash field itself
+func (e *Entry) ComputeHash() string {
+	unsealed := *e
+	unsealed.Hash = ""
+	unsealed.Time = e.Time.UTC()
+
+	data, _ := json.Marshal(unsealed)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Seal links the entry to its predecessor and sets its hash
+func (e *Entry) Seal(seq int64, prevHash string) {
+	e.Seq = seq
+	e.PrevHash = prevHash
+	e.Time = e.Time.UTC()
+	e.Hash = e.ComputeHash()
+}
+
+// Verifier checks entries one at a time, in order, so a log can be verified
+// without loading it at once.
+type Verifier struct {
+	seq  int64
+	hash string
+}
+
+func NewVerifier() *Verifier {
+	return &Verifier{hash: GenesisHash}
+}
+
+// Next checks the entry following the ones seen so far
+func (v *Verifier) Next(e *Entry) error {
+	if e.Seq != v.seq+1 {
+		return fmt.Errorf("entry %d follows entry %d", e.Seq, v.seq)
+	}
+	if e.PrevHash != v.hash {
+		return fmt.Errorf("entry %d does not link to the previous entry", e.Seq)
+	}
+	if e.ComputeHash() != e.Hash {
+		return fmt.Errorf("entry %d was modified", e.Seq)
+	}
+
+	v.seq = e.Seq
+	v.hash = e.Hash
+	return nil
+}
+
+// Seq returns the number of entries verified
+func (v *Verifier) Seq() int64 {
+	return v.seq
+}
+
+// Hash returns the hash of the last entry verified
+func (v *Verifier) Hash() string {
+	return v.hash
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"testing"
+	"time"
+)
+
+func chain(n int) []Entry {
+	entries := make([]Entry, n)
+	prev := GenesisHash
+	for i := range entries {
+		entries[i] = Entry{Time: time.Unix(int64(1700000000+i), 0), Operation: "put", Path: "/file"}
+		entries[i].Seal(int64(i+1), prev)
+		prev = entries[i].Hash
+	}
+	return entries
+}
+
+func verify(entries []Entry) (int64, error) {
+	verifier := NewVerifier()
+	for i := range entries {
+		if err := verifier.Next(&entries[i]); err != nil {
+			return entries[i].Seq, err
+		}
+	}
+	return 0, nil
+}
+
+func TestVerifier_ValidChain(t *testing.T) {
+	entries := chain(5)
+
+	if seq, err := verify(entries); err != nil {
+		t.Fatalf("valid chain rejected at %d: %v", seq, err)
+	}
+}
+
+func TestVerifier_ModifiedEntry(t *testing.T) {
+	entries := chain(5)
+	entries[2].Path = "/other"
+
+	if seq, err := verify(entries); err == nil || seq != 3 {
+		t.Errorf("expected entry 3 to be rejected, got %d: %v", seq, err)
+	}
+}
+
+func TestVerifier_RemovedEntry(t *testing.T) {
+	entries := chain(5)
+	entries = append(entries[:1], entries[2:]...)
+
+	if seq, err := verify(entries); err == nil || seq != 3 {
+		t.Errorf("expected entry 3 to be rejected, got %d: %v", seq, err)
+	}
+}
+
+func TestVerifier_ResealedEntry(t *testing.T) {
+	entries := chain(5)
+
+	// Rewriting an entry and its hash still breaks the link of the next one
+	entries[1].Path = "/other"
+	entries[1].Seal(2, entries[0].Hash)
+
+	if seq, err := verify(entries); err == nil || seq != 3 {
+		t.Errorf("expected entry 3 to be rejected, got %d: %v", seq, err)
+	}
+}
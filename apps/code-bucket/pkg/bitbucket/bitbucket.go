@@ -0,0 +1,165 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+	zipImporter "github.com/metorial/metorial/services/code-bucket/pkg/zip-importer"
+)
+
+// Bitbucket Cloud. Repositories are named workspace/slug. Tokens are access
+// tokens, or username:password pairs for api tokens and app passwords.
+
+const (
+	defaultApiUrl = "https://api.bitbucket.org/2.0"
+
+	// Archives are served by the website, not the api
+	archiveUrl = "https://bitbucket.org"
+)
+
+type Provider struct{}
+
+var _ scm.Provider = Provider{}
+
+func headers(token string) map[string]string {
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if strings.Contains(token, ":") {
+		headers["Authorization"] = fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(token)))
+	} else if token != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", token)
+	}
+
+	return headers
+}
+
+func repoPath(repo scm.Repo) (string, error) {
+	workspace, slug, err := repo.SplitName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s", url.PathEscape(workspace), url.PathEscape(slug)), nil
+}
+
+func (Provider) DownloadArchive(ctx context.Context, repo scm.Repo, ref, dir string) (*zipImporter.ZipFileIterator, error) {
+	name, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Archives are only served for a named ref
+	if ref == "" {
+		var info struct {
+			MainBranch struct {
+				Name string `json:"name"`
+			} `json:"mainbranch"`
+		}
+		if _, err := scm.Do(ctx, http.MethodGet, fmt.Sprintf("%s/repositories/%s", repo.ApiUrlOr(defaultApiUrl), name), headers(repo.Token), nil, &info); err != nil {
+			return nil, fmt.Errorf("failed to get repository: %w", err)
+		}
+		ref = info.MainBranch.Name
+	}
+
+	headers := headers(repo.Token)
+	headers["Accept"] = "*/*"
+
+	return zipImporter.DownloadArchive(fmt.Sprintf("%s/%s/get/%s.zip", archiveUrl, name, url.PathEscape(ref)), dir, headers)
+}
+
+func (Provider) ResolveRef(ctx context.Context, repo scm.Repo, ref string) (string, error) {
+	name, err := repoPath(repo)
+	if err != nil {
+		return "", err
+	}
+
+	var commit struct {
+		Hash string `json:"hash"`
+	}
+	commitUrl := fmt.Sprintf("%s/repositories/%s/commit/%s", repo.ApiUrlOr(defaultApiUrl), name, url.PathEscape(ref))
+	_, err = scm.Do(ctx, http.MethodGet, commitUrl, headers(repo.Token), nil, &commit)
+	if scm.IsNotFound(err) {
+		return "", fmt.Errorf("%w: %s", scm.ErrRefNotFound, ref)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return commit.Hash, nil
+}
+
+// UploadFiles writes all files in a single commit with the src endpoint, which
+// creates the branch from the main branch if it doesn't exist
+func (Provider) UploadFiles(ctx context.Context, repo scm.Repo, files []scm.File, opts scm.UploadOptions) (string, error) {
+	if repo.Token == "" {
+		return "", fmt.Errorf("Bitbucket token is required")
+	}
+	if opts.Sync {
+		return "", scm.ErrSyncNotSupported
+	}
+
+	name, err := repoPath(repo)
+	if err != nil {
+		return "", err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	fields := map[string]string{
+		"branch":  opts.BranchOrDefault(),
+		"message": opts.MessageOr(fmt.Sprintf("Upload %d files", len(files))),
+	}
+	if opts.AuthorName != "" && opts.AuthorEmail != "" {
+		fields["author"] = fmt.Sprintf("%s <%s>", opts.AuthorName, opts.AuthorEmail)
+	}
+	for field, value := range fields {
+		if err := form.WriteField(field, value); err != nil {
+			return "", err
+		}
+	}
+
+	// Each file is a form field named by its absolute path, fields without a
+	// leading slash are taken as commit parameters
+	for _, file := range files {
+		fullPath := scm.FilePath(opts.Path, file.Path)
+
+		part, err := form.CreateFormFile("/"+fullPath, path.Base(fullPath))
+		if err != nil {
+			return "", err
+		}
+		if _, err := part.Write(file.Content); err != nil {
+			return "", err
+		}
+	}
+
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	headers := headers(repo.Token)
+	headers["Content-Type"] = form.FormDataContentType()
+
+	resp, err := scm.Do(ctx, http.MethodPost, fmt.Sprintf("%s/repositories/%s/src", repo.ApiUrlOr(defaultApiUrl), name), headers, &body, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	// The new commit is only returned as the location of the response
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", nil
+	}
+
+	return path.Base(location), nil
+}
@@ -0,0 +1,113 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+)
+
+func startFakeBitbucket(t *testing.T, handler http.HandlerFunc) scm.Repo {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return scm.Repo{Name: "workspace/repo", Token: "token", ApiUrl: server.URL}
+}
+
+func TestUploadFiles(t *testing.T) {
+	fields := map[string]string{}
+	files := map[string]string{}
+
+	repo := startFakeBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repositories/workspace/repo/src" || r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusBadRequest)
+			return
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			content, _ := io.ReadAll(part)
+			if part.FileName() != "" {
+				files[part.FormName()] = string(content)
+			} else {
+				fields[part.FormName()] = string(content)
+			}
+		}
+
+		w.Header().Set("Location", "https://api.bitbucket.org/2.0/repositories/workspace/repo/commit/abc123")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	commit, err := Provider{}.UploadFiles(context.Background(), repo, []scm.File{
+		{Path: "/index.js", Content: []byte("module.exports = 1")},
+		{Path: "/lib/utils.js", Content: []byte("exports.a = 2")},
+	}, scm.UploadOptions{Path: "web", Branch: "feature", Message: "Update", AuthorName: "Ada", AuthorEmail: "ada@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != "abc123" {
+		t.Errorf("got commit %q, want abc123", commit)
+	}
+
+	wantFields := map[string]string{"branch": "feature", "message": "Update", "author": "Ada <ada@example.com>"}
+	for name, want := range wantFields {
+		if fields[name] != want {
+			t.Errorf("field %s: got %q, want %q", name, fields[name], want)
+		}
+	}
+
+	wantFiles := map[string]string{"/web/index.js": "module.exports = 1", "/web/lib/utils.js": "exports.a = 2"}
+	if len(files) != len(wantFiles) {
+		t.Errorf("got files %v, want %v", files, wantFiles)
+	}
+	for name, want := range wantFiles {
+		if files[name] != want {
+			t.Errorf("file %s: got %q, want %q", name, files[name], want)
+		}
+	}
+}
+
+func TestUploadFiles_SyncNotSupported(t *testing.T) {
+	repo := scm.Repo{Name: "workspace/repo", Token: "token", ApiUrl: "http://unused"}
+
+	_, err := Provider{}.UploadFiles(context.Background(), repo, nil, scm.UploadOptions{Sync: true})
+	if !errors.Is(err, scm.ErrSyncNotSupported) {
+		t.Errorf("expected ErrSyncNotSupported, got %v", err)
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	repo := startFakeBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/workspace/repo/commit/main" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"hash":"abc123"}`))
+	})
+
+	commit, err := Provider{}.ResolveRef(context.Background(), repo, "main")
+	if err != nil || commit != "abc123" {
+		t.Errorf("got %q, %v", commit, err)
+	}
+
+	if _, err := (Provider{}).ResolveRef(context.Background(), repo, "missing"); !errors.Is(err, scm.ErrRefNotFound) {
+		t.Errorf("expected ErrRefNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+// Package delta implements a compact binary delta format in the spirit of
+// rsync and VCDIFF. A delta is the magic "CBD1", the uvarint length of the
+// target, and a sequence of instructions:
+//
+//	0x01 <uvarint offset> <uvarint length>  copy bytes from the base
+//	0x02 <uvarint length> <bytes>           insert literal bytes
+package delta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	opCopy   = 0x01
+	opInsert = 0x02
+)
+
+var magic = []byte("CBD1")
+
+var ErrInvalidDelta = errors.New("invalid delta")
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidDelta, fmt.Sprintf(format, args...))
+}
+
+// Apply reconstructs the target from base and delta. maxSize limits the size
+// of the target, zero means no limit. The instructions are checked against
+// the declared size before any of the target is allocated.
+func Apply(base, delta []byte, maxSize int) ([]byte, error) {
+	if !bytes.HasPrefix(delta, magic) {
+		return nil, invalid("missing header")
+	}
+	delta = delta[len(magic):]
+
+	targetSize, n := binary.Uvarint(delta)
+	if n <= 0 {
+		return nil, invalid("malformed target size")
+	}
+	if maxSize > 0 && targetSize > uint64(maxSize) {
+		return nil, invalid("target size %d exceeds the limit of %d bytes", targetSize, maxSize)
+	}
+	delta = delta[n:]
+
+	var size uint64
+	err := instructions(base, delta, func(data []byte) error {
+		if uint64(len(data)) > targetSize-size {
+			return invalid("target larger than declared")
+		}
+		size += uint64(len(data))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if size != targetSize {
+		return nil, invalid("target is %d bytes, expected %d", size, targetSize)
+	}
+
+	target := make([]byte, 0, targetSize)
+	instructions(base, delta, func(data []byte) error {
+		target = append(target, data...)
+		return nil
+	})
+
+	return target, nil
+}
+
+// instructions calls emit with the bytes each instruction adds to the target
+func instructions(base, delta []byte, emit func(data []byte) error) error {
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+
+		var data []byte
+
+		switch op {
+		case opCopy:
+			offset, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return invalid("malformed copy offset")
+			}
+			delta = delta[n:]
+
+			length, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return invalid("malformed copy length")
+			}
+			delta = delta[n:]
+
+			if offset > uint64(len(base)) || length > uint64(len(base))-offset {
+				return invalid("copy outside of base")
+			}
+
+			data = base[offset : offset+length]
+
+		case opInsert:
+			length, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return invalid("malformed insert length")
+			}
+			delta = delta[n:]
+
+			if length > uint64(len(delta)) {
+				return invalid("insert past end of delta")
+			}
+
+			data = delta[:length]
+			delta = delta[length:]
+
+		default:
+			return invalid("unknown instruction 0x%02x", op)
+		}
+
+		if err := emit(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Encoder builds a delta instruction by instruction.
+type Encoder struct {
+	buf bytes.Buffer
+}
+
+func NewEncoder(targetSize int) *Encoder {
+	e := &Encoder{}
+	e.buf.Write(magic)
+	e.buf.Write(binary.AppendUvarint(nil, uint64(targetSize)))
+	return e
+}
+
+func (e *Encoder) Copy(offset, length int) {
+	if length == 0 {
+		return
+	}
+
+	e.buf.WriteByte(opCopy)
+	e.buf.Write(binary.AppendUvarint(nil, uint64(offset)))
+	e.buf.Write(binary.AppendUvarint(nil, uint64(length)))
+}
+
+func (e *Encoder) Insert(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+
+	e.buf.WriteByte(opInsert)
+	e.buf.Write(binary.AppendUvarint(nil, uint64(len(data))))
+	e.buf.Write(data)
+}
+
+func (e *Encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
@@ -0,0 +1,86 @@
+package delta
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func randomBytes(r *rand.Rand, n int) []byte {
+	data := make([]byte, n)
+	r.Read(data)
+	return data
+}
+
+func TestDiff_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	base := randomBytes(r, 100_000)
+
+	edited := append([]byte{}, base[:40_000]...)
+	edited = append(edited, []byte("a few new lines\n")...)
+	edited = append(edited, base[40_100:]...)
+
+	cases := map[string][2][]byte{
+		"edit":        {base, edited},
+		"empty base":  {nil, []byte("hello")},
+		"empty both":  {nil, nil},
+		"truncate":    {base, base[:10]},
+		"unrelated":   {base[:1000], randomBytes(r, 1000)},
+		"append only": {base[:5000], append(append([]byte{}, base[:5000]...), 'x')},
+	}
+
+	for name, c := range cases {
+		delta := Diff(c[0], c[1])
+
+		got, err := Apply(c[0], delta, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !bytes.Equal(got, c[1]) {
+			t.Errorf("%s: reconstructed target differs", name)
+		}
+	}
+
+	if delta := Diff(base, edited); len(delta) > 1000 {
+		t.Errorf("expected a small delta for a small edit, got %d bytes", len(delta))
+	}
+}
+
+func TestApply_Invalid(t *testing.T) {
+	base := []byte("hello world")
+
+	encoder := NewEncoder(5)
+	encoder.Copy(8, 5)
+	outside := encoder.Bytes()
+
+	encoder = NewEncoder(3)
+	encoder.Insert([]byte("toolong"))
+	tooLong := encoder.Bytes()
+
+	encoder = NewEncoder(10)
+	encoder.Insert([]byte("short"))
+	tooShort := encoder.Bytes()
+
+	// Checked before the declared size is allocated
+	encoder = NewEncoder(1 << 40)
+	encoder.Copy(0, 5)
+	huge := encoder.Bytes()
+
+	for name, delta := range map[string][]byte{
+		"no header": []byte("nope"),
+		"outside":   outside,
+		"too long":  tooLong,
+		"too short": tooShort,
+		"huge":      huge,
+		"unknown":   append(NewEncoder(0).Bytes(), 0x7f),
+	} {
+		if _, err := Apply(base, delta, 0); !errors.Is(err, ErrInvalidDelta) {
+			t.Errorf("%s: expected ErrInvalidDelta, got %v", name, err)
+		}
+	}
+
+	if _, err := Apply(base, NewEncoder(1<<20).Bytes(), 1024); !errors.Is(err, ErrInvalidDelta) {
+		t.Errorf("expected size limit to be enforced, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package delta
+
+import "bytes"
+
+const blockSize = 64
+
+// Diff creates a delta turning base into target. Like rsync, it indexes the
+// base in fixed size blocks and finds them in the target with a rolling hash.
+func Diff(base, target []byte) []byte {
+	encoder := NewEncoder(len(target))
+
+	blocks := make(map[uint32][]int)
+	for offset := 0; offset+blockSize <= len(base); offset += blockSize {
+		h := newRollingHash(base[offset : offset+blockSize])
+		blocks[h.sum()] = append(blocks[h.sum()], offset)
+	}
+
+	literalStart := 0
+	position := 0
+
+	var h *rollingHash
+	for position+blockSize <= len(target) {
+		if h == nil {
+			h = newRollingHash(target[position : position+blockSize])
+		}
+
+		offset, ok := findBlock(base, target[position:position+blockSize], blocks[h.sum()])
+		if !ok {
+			if position+blockSize < len(target) {
+				h.roll(target[position], target[position+blockSize])
+			}
+			position++
+			continue
+		}
+
+		// Extend the match as far as the data agrees
+		length := blockSize
+		for offset+length < len(base) && position+length < len(target) && base[offset+length] == target[position+length] {
+			length++
+		}
+
+		encoder.Insert(target[literalStart:position])
+		encoder.Copy(offset, length)
+
+		position += length
+		literalStart = position
+		h = nil
+	}
+
+	encoder.Insert(target[literalStart:])
+
+	return encoder.Bytes()
+}
+
+func findBlock(base, block []byte, candidates []int) (int, bool) {
+	for _, offset := range candidates {
+		if bytes.Equal(base[offset:offset+blockSize], block) {
+			return offset, true
+		}
+	}
+
+	return 0, false
+}
+
+// rollingHash is the weak checksum rsync uses, which can slide along the
+// data one byte at a time.
+type rollingHash struct {
+	a, b   uint32
+	length uint32
+}
+
+func newRollingHash(data []byte) *rollingHash {
+	h := &rollingHash{length: uint32(len(data))}
+	for i, c := range data {
+		h.a += uint32(c)
+		h.b += uint32(len(data)-i) * uint32(c)
+	}
+	return h
+}
+
+func (h *rollingHash) roll(out, in byte) {
+	h.a = h.a - uint32(out) + uint32(in)
+	h.b = h.b - h.length*uint32(out) + h.a
+}
+
+func (h *rollingHash) sum() uint32 {
+	return (h.a & 0xffff) | (h.b&0xffff)<<16
+}
@@ -0,0 +1,185 @@
+package diff
+
+import "strings"
+
+type OpKind int
+
+const (
+	OpEqual OpKind = iota
+	OpDelete
+	OpInsert
+)
+
+// Edit is a single line-level operation. OldIndex and NewIndex point at the
+// line in the old and new input; only the side relevant to Kind is meaningful
+// for inserts and deletes.
+type Edit struct {
+	Kind     OpKind
+	OldIndex int
+	NewIndex int
+}
+
+// SplitLines splits text into lines, keeping the trailing newline on each
+// line so the input can be reconstructed exactly.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// Lines computes a shortest edit script turning a into b using the linear
+// space variant of Myers' algorithm: the middle snake of the edit graph is
+// found by searching from both ends, and the halves before and after it are
+// diffed recursively. Memory stays O(N+M) however different the inputs are.
+func Lines(a, b []string) []Edit {
+	d := &differ{a: a, b: b, edits: make([]Edit, 0, len(a)+len(b))}
+	d.compare(0, len(a), 0, len(b))
+	return d.edits
+}
+
+type differ struct {
+	a, b  []string
+	edits []Edit
+}
+
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	// Strip the common prefix and suffix, which is where most edits live
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.edits = append(d.edits, Edit{Kind: OpEqual, OldIndex: aLo, NewIndex: bLo})
+		aLo++
+		bLo++
+	}
+
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-1-suffix] == d.b[bHi-1-suffix] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	if aLo < aHi && bLo < bHi {
+		x, y, ok := d.middleSnake(aLo, aHi, bLo, bHi)
+		if ok && (x > aLo || y > bLo) && (x < aHi || y < bHi) {
+			d.compare(aLo, x, bLo, y)
+			d.compare(x, aHi, y, bHi)
+		} else {
+			d.replace(aLo, aHi, bLo, bHi)
+		}
+	} else {
+		d.replace(aLo, aHi, bLo, bHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.edits = append(d.edits, Edit{Kind: OpEqual, OldIndex: aHi + i, NewIndex: bHi + i})
+	}
+}
+
+// replace deletes a[aLo:aHi] and inserts b[bLo:bHi]
+func (d *differ) replace(aLo, aHi, bLo, bHi int) {
+	for x := aLo; x < aHi; x++ {
+		d.edits = append(d.edits, Edit{Kind: OpDelete, OldIndex: x, NewIndex: bLo})
+	}
+	for y := bLo; y < bHi; y++ {
+		d.edits = append(d.edits, Edit{Kind: OpInsert, OldIndex: aHi, NewIndex: y})
+	}
+}
+
+// middleSnake runs the forward and backward searches until their paths
+// overlap and returns the point where they meet. Reports false if the ranges
+// have nothing in common.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	a, b := d.a[aLo:aHi], d.b[bLo:bHi]
+	n, m := len(a), len(b)
+
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	size := 2*maxD + 2
+
+	forward := make([]int, size)
+	backward := make([]int, size)
+	for i := range forward {
+		forward[i] = -1
+		backward[i] = -1
+	}
+	forward[offset+1] = 0
+	backward[offset+1] = 0
+
+	// With an odd delta the paths meet on a forward step, otherwise on a
+	// backward one
+	delta := n - m
+	checkForward := delta%2 != 0
+
+	// Diagonals that ran off the edit graph aren't searched again
+	kStartF, kEndF, kStartB, kEndB := 0, 0, 0, 0
+
+	for step := 0; step < maxD; step++ {
+		for k := -step + kStartF; k <= step-kEndF; k += 2 {
+			i := offset + k
+			var x int
+			if k == -step || (k != step && forward[i-1] < forward[i+1]) {
+				x = forward[i+1]
+			} else {
+				x = forward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[i] = x
+
+			switch {
+			case x > n:
+				kEndF += 2
+			case y > m:
+				kStartF += 2
+			case checkForward:
+				j := offset + delta - k
+				if j >= 0 && j < size && backward[j] != -1 && x >= n-backward[j] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+
+		for k := -step + kStartB; k <= step-kEndB; k += 2 {
+			i := offset + k
+			var x int
+			if k == -step || (k != step && backward[i-1] < backward[i+1]) {
+				x = backward[i+1]
+			} else {
+				x = backward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			backward[i] = x
+
+			switch {
+			case x > n:
+				kEndB += 2
+			case y > m:
+				kStartB += 2
+			case !checkForward:
+				j := offset + delta - k
+				if j >= 0 && j < size && forward[j] != -1 {
+					fx := forward[j]
+					fy := fx - (j - offset)
+					if fx >= n-x {
+						return aLo + fx, bLo + fy, true
+					}
+				}
+			}
+		}
+	}
+
+	return 0, 0, false
+}
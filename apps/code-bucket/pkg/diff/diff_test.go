@@ -0,0 +1,162 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func applyEdits(a, b []string, edits []Edit) (string, string) {
+	var oldText, newText strings.Builder
+	for _, e := range edits {
+		switch e.Kind {
+		case OpEqual:
+			oldText.WriteString(a[e.OldIndex])
+			newText.WriteString(b[e.NewIndex])
+		case OpDelete:
+			oldText.WriteString(a[e.OldIndex])
+		case OpInsert:
+			newText.WriteString(b[e.NewIndex])
+		}
+	}
+	return oldText.String(), newText.String()
+}
+
+func TestLines_ReconstructsBothSides(t *testing.T) {
+	cases := [][2]string{
+		{"", ""},
+		{"", "a\nb\n"},
+		{"a\nb\n", ""},
+		{"a\nb\nc\n", "a\nc\n"},
+		{"a\nb\nc\nd\n", "x\nb\ny\nd\nz\n"},
+		{"one\ntwo\nthree", "one\n2\nthree"},
+	}
+
+	for _, c := range cases {
+		a, b := SplitLines(c[0]), SplitLines(c[1])
+		gotOld, gotNew := applyEdits(a, b, Lines(a, b))
+		if gotOld != c[0] || gotNew != c[1] {
+			t.Errorf("edits for %q -> %q reconstruct %q -> %q", c[0], c[1], gotOld, gotNew)
+		}
+	}
+}
+
+func TestLines_MinimalEdits(t *testing.T) {
+	a := SplitLines("a\nb\nc\nd\n")
+	b := SplitLines("a\nc\nd\ne\n")
+
+	changes := 0
+	for _, e := range Lines(a, b) {
+		if e.Kind != OpEqual {
+			changes++
+		}
+	}
+
+	if changes != 2 {
+		t.Errorf("expected 2 changed lines, got %d", changes)
+	}
+}
+
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(cur[j], prev[j+1])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func TestLines_RandomInputsAreMinimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := func() []string {
+		lines := make([]string, rng.Intn(40))
+		for i := range lines {
+			lines[i] = string(rune('a'+rng.Intn(4))) + "\n"
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		edits := Lines(a, b)
+
+		gotOld, gotNew := applyEdits(a, b, edits)
+		if gotOld != strings.Join(a, "") || gotNew != strings.Join(b, "") {
+			t.Fatalf("edits for %q -> %q don't reconstruct the inputs", a, b)
+		}
+
+		changes := 0
+		for _, e := range edits {
+			if e.Kind != OpEqual {
+				changes++
+			}
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); changes != want {
+			t.Fatalf("%q -> %q: %d changes, want %d", a, b, changes, want)
+		}
+	}
+}
+
+func TestLines_LargeDifferentInputs(t *testing.T) {
+	// Used to keep a copy of the search state per edit, quadratic here
+	a := make([]string, 20000)
+	b := make([]string, 20000)
+	for i := range a {
+		a[i] = fmt.Sprintf("old %d\n", i)
+		b[i] = fmt.Sprintf("new %d\n", i)
+	}
+
+	if edits := Lines(a, b); len(edits) != len(a)+len(b) {
+		t.Errorf("expected %d edits, got %d", len(a)+len(b), len(edits))
+	}
+}
+
+func TestUnified_Format(t *testing.T) {
+	got := Unified("a/file.txt", "b/file.txt", "a\nb\nc\n", "a\nB\nc\n", 3)
+	expected := "--- a/file.txt\n+++ b/file.txt\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestUnified_NoNewlineAtEnd(t *testing.T) {
+	got := Unified("a", "b", "x", "y", 3)
+	expected := "--- a\n+++ b\n@@ -1 +1 @@\n-x\n\\ No newline at end of file\n+y\n\\ No newline at end of file\n"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestUnified_SplitsDistantHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a'+i)) + "\n"
+		oldLines = append(oldLines, line)
+		if i == 1 || i == 18 {
+			line = "changed\n"
+		}
+		newLines = append(newLines, line)
+	}
+
+	got := Unified("a", "b", strings.Join(oldLines, ""), strings.Join(newLines, ""), 3)
+	if strings.Count(got, "@@ -") != 2 {
+		t.Errorf("expected 2 hunks, got:\n%s", got)
+	}
+	if !strings.Contains(got, "@@ -1,5 +1,5 @@") || !strings.Contains(got, "@@ -16,5 +16,5 @@") {
+		t.Errorf("unexpected hunk headers:\n%s", got)
+	}
+}
+
+func TestUnified_Equal(t *testing.T) {
+	if got := Unified("a", "b", "same\n", "same\n", 3); got != "" {
+		t.Errorf("expected empty diff, got %q", got)
+	}
+}
@@ -0,0 +1,172 @@
+package diff
+
+import "strings"
+
+// MergeChunk is a section of a three-way merge. Stable chunks are identical
+// in all three inputs. Unstable chunks differ between them and are either
+// resolved to Lines or left as a conflict.
+type MergeChunk struct {
+	Conflict bool
+	Lines    []string
+
+	// Populated for unstable chunks only. Starts are 1-based line numbers.
+	Base        []string
+	Ours        []string
+	Theirs      []string
+	BaseStart   int
+	OursStart   int
+	TheirsStart int
+}
+
+type ConflictLabels struct {
+	Ours   string
+	Base   string
+	Theirs string
+
+	// IncludeBase emits the base section, like git's diff3 conflict style
+	IncludeBase bool
+}
+
+// Merge3 performs a line-based three-way merge, using the diff3 algorithm.
+// Changes made on only one side are taken, identical changes on both sides
+// are taken once and everything else is reported as a conflict.
+func Merge3(base, ours, theirs []string) []MergeChunk {
+	oursMatch := matches(base, ours)
+	theirsMatch := matches(base, theirs)
+
+	var chunks []MergeChunk
+	i, j, k := 0, 0, 0
+
+	for i < len(base) || j < len(ours) || k < len(theirs) {
+		// Take lines that are unchanged on all sides
+		n := 0
+		for i+n < len(base) && oursMatch[i+n] == j+n && theirsMatch[i+n] == k+n {
+			n++
+		}
+		if n > 0 {
+			chunks = append(chunks, MergeChunk{Lines: base[i : i+n]})
+			i, j, k = i+n, j+n, k+n
+			continue
+		}
+
+		// Find where all three sides line up again
+		nextI, nextJ, nextK := len(base), len(ours), len(theirs)
+		for candidate := i; candidate < len(base); candidate++ {
+			if oursMatch[candidate] >= j && theirsMatch[candidate] >= k {
+				nextI, nextJ, nextK = candidate, oursMatch[candidate], theirsMatch[candidate]
+				break
+			}
+		}
+
+		chunks = append(chunks, resolveChunk(MergeChunk{
+			Base:        base[i:nextI],
+			Ours:        ours[j:nextJ],
+			Theirs:      theirs[k:nextK],
+			BaseStart:   i + 1,
+			OursStart:   j + 1,
+			TheirsStart: k + 1,
+		}))
+		i, j, k = nextI, nextJ, nextK
+	}
+
+	return chunks
+}
+
+// matches maps every line of a to the index of the line it is paired with in
+// b, or -1 if it was removed.
+func matches(a, b []string) []int {
+	result := make([]int, len(a))
+	for i := range result {
+		result[i] = -1
+	}
+
+	for _, e := range Lines(a, b) {
+		if e.Kind == OpEqual {
+			result[e.OldIndex] = e.NewIndex
+		}
+	}
+
+	return result
+}
+
+func resolveChunk(chunk MergeChunk) MergeChunk {
+	switch {
+	case equalLines(chunk.Ours, chunk.Base):
+		chunk.Lines = chunk.Theirs
+	case equalLines(chunk.Theirs, chunk.Base), equalLines(chunk.Ours, chunk.Theirs):
+		chunk.Lines = chunk.Ours
+	default:
+		chunk.Conflict = true
+	}
+
+	return chunk
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasConflicts reports whether any chunk of a merge is a conflict.
+func HasConflicts(chunks []MergeChunk) bool {
+	for _, chunk := range chunks {
+		if chunk.Conflict {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatMerge renders a merge result, writing conflicts with git-style
+// conflict markers.
+func FormatMerge(chunks []MergeChunk, labels ConflictLabels) string {
+	var sb strings.Builder
+
+	writeSection := func(marker, label string, lines []string) {
+		sb.WriteString(marker)
+		if label != "" {
+			sb.WriteString(" " + label)
+		}
+		sb.WriteString("\n")
+
+		for _, line := range lines {
+			sb.WriteString(line)
+		}
+
+		// Markers always start on a line of their own
+		if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+			sb.WriteString("\n")
+		}
+	}
+
+	for _, chunk := range chunks {
+		if !chunk.Conflict {
+			for _, line := range chunk.Lines {
+				sb.WriteString(line)
+			}
+			continue
+		}
+
+		writeSection("<<<<<<<", labels.Ours, chunk.Ours)
+		if labels.IncludeBase {
+			writeSection("|||||||", labels.Base, chunk.Base)
+		}
+		writeSection("=======", "", chunk.Theirs)
+		sb.WriteString(">>>>>>>")
+		if labels.Theirs != "" {
+			sb.WriteString(" " + labels.Theirs)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,73 @@
+package diff
+
+import "testing"
+
+func merge(base, ours, theirs string) []MergeChunk {
+	return Merge3(SplitLines(base), SplitLines(ours), SplitLines(theirs))
+}
+
+func TestMerge3_NonOverlappingChanges(t *testing.T) {
+	chunks := merge("a\nb\nc\nd\ne\n", "A\nb\nc\nd\ne\n", "a\nb\nc\nd\nE\n")
+
+	if HasConflicts(chunks) {
+		t.Fatal("expected a clean merge")
+	}
+	if got := FormatMerge(chunks, ConflictLabels{}); got != "A\nb\nc\nd\nE\n" {
+		t.Errorf("unexpected merge result %q", got)
+	}
+}
+
+func TestMerge3_IdenticalChanges(t *testing.T) {
+	chunks := merge("a\nb\n", "a\nx\n", "a\nx\n")
+
+	if HasConflicts(chunks) {
+		t.Fatal("expected a clean merge")
+	}
+	if got := FormatMerge(chunks, ConflictLabels{}); got != "a\nx\n" {
+		t.Errorf("unexpected merge result %q", got)
+	}
+}
+
+func TestMerge3_InsertionsAndDeletions(t *testing.T) {
+	chunks := merge("a\nb\nc\n", "new\na\nb\nc\n", "a\nc\n")
+
+	if HasConflicts(chunks) {
+		t.Fatal("expected a clean merge")
+	}
+	if got := FormatMerge(chunks, ConflictLabels{}); got != "new\na\nc\n" {
+		t.Errorf("unexpected merge result %q", got)
+	}
+}
+
+func TestMerge3_Conflict(t *testing.T) {
+	chunks := merge("a\nb\nc\n", "a\nours\nc\n", "a\ntheirs\nc\n")
+
+	if !HasConflicts(chunks) {
+		t.Fatal("expected a conflict")
+	}
+
+	var conflict *MergeChunk
+	for i := range chunks {
+		if chunks[i].Conflict {
+			conflict = &chunks[i]
+		}
+	}
+	if conflict.BaseStart != 2 || conflict.OursStart != 2 || conflict.TheirsStart != 2 {
+		t.Errorf("unexpected conflict position %d/%d/%d", conflict.BaseStart, conflict.OursStart, conflict.TheirsStart)
+	}
+
+	expected := "a\n<<<<<<< ours\nours\n||||||| base\nb\n=======\ntheirs\n>>>>>>> theirs\nc\n"
+	got := FormatMerge(chunks, ConflictLabels{Ours: "ours", Base: "base", Theirs: "theirs", IncludeBase: true})
+	if got != expected {
+		t.Errorf("unexpected conflict markers:\n%s", got)
+	}
+}
+
+func TestMerge3_ConflictWithoutTrailingNewline(t *testing.T) {
+	chunks := merge("a", "b", "c")
+
+	expected := "<<<<<<<\nb\n=======\nc\n>>>>>>>\n"
+	if got := FormatMerge(chunks, ConflictLabels{}); got != expected {
+		t.Errorf("unexpected conflict markers %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const DefaultContextLines = 3
+
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Edits    []Edit
+}
+
+// Hunks groups an edit script into hunks with the given amount of surrounding
+// context, the way `diff -u` does.
+func Hunks(edits []Edit, context int) []Hunk {
+	var hunks []Hunk
+
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == OpEqual {
+			i++
+			continue
+		}
+
+		start := max(i-context, 0)
+
+		// Extend the hunk while changes are close enough to share context
+		end := i
+		for end < len(edits) {
+			if edits[end].Kind != OpEqual {
+				end++
+				continue
+			}
+
+			next := end
+			for next < len(edits) && edits[next].Kind == OpEqual {
+				next++
+			}
+
+			if next == len(edits) || next-end > 2*context {
+				end = min(end+context, len(edits))
+				break
+			}
+
+			end = next
+		}
+
+		hunk := Hunk{Edits: edits[start:end]}
+		hunk.OldStart = edits[start].OldIndex + 1
+		hunk.NewStart = edits[start].NewIndex + 1
+		for _, e := range hunk.Edits {
+			if e.Kind != OpInsert {
+				hunk.OldLines++
+			}
+			if e.Kind != OpDelete {
+				hunk.NewLines++
+			}
+		}
+
+		// An empty side starts at the line before the hunk
+		if hunk.OldLines == 0 {
+			hunk.OldStart--
+		}
+		if hunk.NewLines == 0 {
+			hunk.NewStart--
+		}
+
+		hunks = append(hunks, hunk)
+		i = end
+	}
+
+	return hunks
+}
+
+// Unified renders the differences between two texts in unified diff format.
+// It returns an empty string when both texts are equal.
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	a := SplitLines(oldText)
+	b := SplitLines(newText)
+
+	hunks := Hunks(Lines(a, b), context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n", oldName)
+	fmt.Fprintf(&sb, "+++ %s\n", newName)
+
+	for _, hunk := range hunks {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(hunk.OldStart, hunk.OldLines), hunkRange(hunk.NewStart, hunk.NewLines))
+
+		for _, e := range hunk.Edits {
+			switch e.Kind {
+			case OpEqual:
+				writeLine(&sb, ' ', a[e.OldIndex])
+			case OpDelete:
+				writeLine(&sb, '-', a[e.OldIndex])
+			case OpInsert:
+				writeLine(&sb, '+', b[e.NewIndex])
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
+
+func writeLine(sb *strings.Builder, prefix byte, line string) {
+	sb.WriteByte(prefix)
+	sb.WriteString(line)
+
+	if !strings.HasSuffix(line, "\n") {
+		sb.WriteString("\n\\ No newline at end of file\n")
+	}
+}
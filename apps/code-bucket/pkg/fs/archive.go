@@ -0,0 +1,330 @@
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/glob"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	objectstorage "github.com/metorial/object-storage/clients/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Archives are named by a digest of the paths and content hashes they
+// contain, which is known before the archive is built. An archive that
+// already exists is reused, otherwise files are fetched a few at a time ahead
+// of the archive writer, which streams straight into object storage. Fetched
+// files must still have the listed hash, or the archive wouldn't match its
+// name, so a change during the build starts it over.
+
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip     ArchiveFormat = "zip"
+	ArchiveFormatTarGzip ArchiveFormat = "tar.gz"
+)
+
+const (
+	// Bump when the layout of archives changes, so old ones aren't reused
+	archiveFormatVersion = "archive-v1"
+
+	archivePrefetchCount = 8
+	archiveBuildAttempts = 3
+)
+
+var errArchiveFilesChanged = errors.New("files changed while the archive was built")
+
+type archiveFormat struct {
+	dir         string // Object storage directory
+	contentType string
+	newWriter   func(w io.Writer) archiveWriter
+}
+
+var archiveFormats = map[ArchiveFormat]archiveFormat{
+	ArchiveFormatZip: {
+		dir:         "zips",
+		contentType: "application/zip",
+		newWriter:   func(w io.Writer) archiveWriter { return &zipArchiveWriter{zw: zip.NewWriter(w)} },
+	},
+	ArchiveFormatTarGzip: {
+		dir:         "archives",
+		contentType: "application/gzip",
+		newWriter:   newTarGzipArchiveWriter,
+	},
+}
+
+func archiveObjectKey(format ArchiveFormat, digest string) string {
+	return fmt.Sprintf("%s/%s.%s", archiveFormats[format].dir, digest, format)
+}
+
+func archiveDigest(format ArchiveFormat, files []FileInfo) string {
+	digest := sha256.New()
+	digest.Write([]byte(archiveFormatVersion))
+	digest.Write([]byte{0})
+	digest.Write([]byte(format))
+	digest.Write([]byte{'\n'})
+	for _, f := range files {
+		digest.Write([]byte(f.Path))
+		digest.Write([]byte{0})
+		digest.Write([]byte(f.Hash))
+		digest.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+func (fsm *FileSystemManager) GetBucketFilesAsZip(ctx context.Context, bucketId, prefix string, filter *glob.Filter) (*string, *time.Time, error) {
+	return fsm.GetBucketFilesAsArchive(ctx, bucketId, prefix, filter, ArchiveFormatZip)
+}
+
+// GetBucketFilesAsArchive builds an archive of the files, or reuses an
+// identical one, and returns its download path and expiry.
+func (fsm *FileSystemManager) GetBucketFilesAsArchive(ctx context.Context, bucketId, prefix string, filter *glob.Filter, format ArchiveFormat) (*string, *time.Time, error) {
+	if _, ok := archiveFormats[format]; !ok {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unsupported archive format %q", format)
+	}
+
+	var objectKey string
+	for attempt := 1; ; attempt++ {
+		files, err := fsm.listFilesWithHashes(ctx, bucketId, prefix)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to get files: %v", err)
+		}
+
+		files = util.Filter(files, func(f FileInfo) bool { return filter.Match(f.Path) })
+
+		objectKey = archiveObjectKey(format, archiveDigest(format, files))
+
+		exists, err := fsm.archiveExists(ctx, objectKey)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to check for archive: %v", err)
+		}
+		if exists {
+			break
+		}
+
+		// The archive is named by the listed content, so a file changed
+		// while it is built means listing again
+		err = fsm.buildArchive(ctx, bucketId, objectKey, format, files)
+		if errors.Is(err, errArchiveFilesChanged) && attempt < archiveBuildAttempts {
+			continue
+		}
+		if errors.Is(err, errArchiveFilesChanged) {
+			return nil, nil, status.Errorf(codes.Aborted, "failed to build archive: %v", err)
+		}
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to build archive: %v", err)
+		}
+		break
+	}
+
+	url := archiveDownloadPath(fsm.bucketName, objectKey)
+
+	// Refreshes the expiry of reused archives. They are tracked like zips
+	// always were, so cleanupZipFiles removes them too.
+	redisKey := fmt.Sprintf("zip:%s", objectKey)
+	fsm.redis.Set(ctx, redisKey, time.Now().Unix(), zipExpiration*2)
+	fsm.redis.SAdd(ctx, archiveBucketsKey(objectKey), bucketId)
+	fsm.redis.Expire(ctx, archiveBucketsKey(objectKey), zipExpiration*2)
+
+	expiresAt := time.Now().Add(zipExpiration)
+
+	return &url, &expiresAt, nil
+}
+
+// archiveExists reports whether the archive is tracked and still in object
+// storage
+func (fsm *FileSystemManager) archiveExists(ctx context.Context, objectKey string) (bool, error) {
+	tracked, err := fsm.redis.Exists(ctx, fmt.Sprintf("zip:%s", objectKey)).Result()
+	if err != nil || tracked == 0 {
+		return false, err
+	}
+
+	if _, err := fsm.objectStorage.HeadObject(fsm.bucketName, objectKey); err != nil {
+		if isObjectNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (fsm *FileSystemManager) buildArchive(ctx context.Context, bucketID, objectKey string, format ArchiveFormat, files []FileInfo) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(fsm.writeArchive(ctx, bucketID, files, archiveFormats[format].newWriter(writer)))
+	}()
+
+	err := fsm.putObjectStream(ctx, objectKey, archiveFormats[format].contentType, reader)
+
+	// Stops the writer if the upload failed
+	reader.CloseWithError(fmt.Errorf("upload stopped"))
+
+	return err
+}
+
+func (fsm *FileSystemManager) writeArchive(ctx context.Context, bucketID string, files []FileInfo, archive archiveWriter) error {
+	for fetched := range fsm.prefetchFiles(ctx, bucketID, files, archivePrefetchCount) {
+		result := <-fetched
+		if result.err != nil {
+			if result.err.Error() == "file not found" {
+				return fmt.Errorf("%w: %s was deleted", errArchiveFilesChanged, result.path)
+			}
+			return result.err
+		}
+		if result.hash != result.listedHash {
+			return fmt.Errorf("%w: %s was modified", errArchiveFilesChanged, result.path)
+		}
+
+		if err := archive.Add(result.path, result.data); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+type archiveWriter interface {
+	Add(filePath string, data *FileData) error
+	Close() error
+}
+
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+func (a *zipArchiveWriter) Add(filePath string, data *FileData) error {
+	f, err := a.zw.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data.Content)
+	return err
+}
+
+func (a *zipArchiveWriter) Close() error {
+	return a.zw.Close()
+}
+
+type tarGzipArchiveWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func newTarGzipArchiveWriter(w io.Writer) archiveWriter {
+	gz := gzip.NewWriter(w)
+	return &tarGzipArchiveWriter{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+func (a *tarGzipArchiveWriter) Add(filePath string, data *FileData) error {
+	err := a.tw.WriteHeader(&tar.Header{
+		Name:     strings.TrimPrefix(filePath, "/"),
+		Mode:     0o644,
+		Size:     int64(len(data.Content)),
+		ModTime:  data.ModifiedAt,
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = a.tw.Write(data.Content)
+	return err
+}
+
+func (a *tarGzipArchiveWriter) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
+
+type prefetchedFile struct {
+	path       string
+	listedHash string
+	hash       string
+	data       *FileData
+	err        error
+}
+
+// prefetchFiles fetches files in the background, at most count ahead of the
+// reader. Results come in the order of files, each on its own channel.
+func (fsm *FileSystemManager) prefetchFiles(ctx context.Context, bucketID string, files []FileInfo, count int) <-chan chan prefetchedFile {
+	ordered := make(chan chan prefetchedFile, count)
+
+	go func() {
+		defer close(ordered)
+
+		for _, file := range files {
+			result := make(chan prefetchedFile, 1)
+
+			select {
+			case ordered <- result:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(file FileInfo) {
+				fetched := prefetchedFile{path: file.Path, listedHash: file.Hash}
+
+				info, data, err := fsm.GetBucketFile(ctx, bucketID, file.Path)
+				if err == nil {
+					fetched.hash, fetched.data = info.Hash, data
+				}
+				fetched.err = err
+
+				result <- fetched
+			}(file)
+		}
+	}()
+
+	return ordered
+}
+
+// putObjectStream uploads an object of unknown size without buffering it
+func (fsm *FileSystemManager) putObjectStream(ctx context.Context, key, contentType string, body io.Reader) error {
+	urlPath := fmt.Sprintf("%s/buckets/%s/objects/%s", fsm.objectStorageEndpoint, fsm.bucketName, key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, urlPath, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	// Uploads can take longer than the timeout of fsm.httpClient
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return &objectstorage.Error{
+			StatusCode: resp.StatusCode,
+			Message:    string(bodyBytes),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,448 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/metorial/metorial/services/code-bucket/pkg/audit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The audit log of a bucket is a hash chain of entries (see pkg/audit). New
+// entries are appended to a pending list in redis, guarded by the head of the
+// chain so concurrent writers can't fork it. The background flush moves them
+// into segments of auditSegmentSize entries in object storage, which are only
+// ever appended to. Entries that can't be chained right away, because the log
+// is too busy or redis fails, are queued unsequenced and chained by the flush.
+
+// Operations that aren't file changes, those use the event types
+const AuditOperationDiscardOverlay = "discard_overlay"
+
+const (
+	auditSegmentSize      = 1000
+	auditAppendAttempts   = 20
+	defaultAuditPageLimit = 100
+	maxAuditPageLimit     = 1000
+)
+
+// Appends an entry only if the head is still the one it was chained to.
+// Returns the current head on conflict.
+var appendAuditEntryScript = redis.NewScript(`
+local head = redis.call("GET", KEYS[1]) or ""
+if head ~= ARGV[1] then
+	return {0, head}
+end
+redis.call("RPUSH", KEYS[2], ARGV[2])
+redis.call("SET", KEYS[1], ARGV[3])
+return {1, ARGV[3]}
+`)
+
+type AuditVerification struct {
+	Valid      bool   `json:"valid"`
+	EntryCount int64  `json:"entry_count"`
+	HeadHash   string `json:"head_hash"`
+	InvalidSeq int64  `json:"invalid_seq"`
+	Error      string `json:"error"`
+}
+
+func auditHeadKey(bucketID string) string {
+	return fmt.Sprintf("audit:%s:head", bucketID)
+}
+
+func auditPendingKey(bucketID string) string {
+	return fmt.Sprintf("audit:%s:pending", bucketID)
+}
+
+func auditUnsequencedKey(bucketID string) string {
+	return fmt.Sprintf("audit:%s:unsequenced", bucketID)
+}
+
+func auditSegmentObjectKey(bucketID string, segment int64) string {
+	return fmt.Sprintf("meta/%s/audit/%010d.jsonl", bucketID, segment)
+}
+
+func auditHeadObjectKey(bucketID string) string {
+	return fmt.Sprintf("meta/%s/audit/head", bucketID)
+}
+
+func formatAuditHead(seq int64, hash string) string {
+	return fmt.Sprintf("%d %s", seq, hash)
+}
+
+func parseAuditHead(head string) (int64, string) {
+	seqStr, hash, _ := strings.Cut(head, " ")
+	seq, _ := strconv.ParseInt(seqStr, 10, 64)
+	return seq, hash
+}
+
+// loadAuditHead returns the raw head of the chain. If redis lost it, it is
+// restored from the head of the flushed log.
+func (fsm *FileSystemManager) loadAuditHead(ctx context.Context, bucketID string) (string, error) {
+	head, err := fsm.redis.Get(ctx, auditHeadKey(bucketID)).Result()
+	if err == nil {
+		return head, nil
+	}
+	if err != redis.Nil {
+		return "", err
+	}
+
+	obj, err := fsm.objectStorage.GetObject(fsm.bucketName, auditHeadObjectKey(bucketID))
+	if err != nil {
+		if isObjectNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	head = string(obj.Data)
+	fsm.redis.SetNX(ctx, auditHeadKey(bucketID), head, 0)
+
+	return fsm.redis.Get(ctx, auditHeadKey(bucketID)).Result()
+}
+
+// recordAuditEntry fills in the origin of an entry and appends it. The
+// operation has already happened, so an entry that can't be appended now is
+// queued for the background flush instead.
+func (fsm *FileSystemManager) recordAuditEntry(ctx context.Context, bucketID string, entry *audit.Entry) {
+	origin := originFromContext(ctx)
+
+	entry.Time = time.Now()
+	entry.Actor = origin.Actor
+	entry.TokenID = origin.TokenID
+	entry.SourceIP = origin.SourceIP
+	entry.Method = origin.Method
+
+	// The entry is recorded even if the request is canceled meanwhile
+	ctx = context.WithoutCancel(ctx)
+
+	appendErr := fsm.appendAuditEntry(ctx, bucketID, entry)
+	if appendErr == nil {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = fsm.redis.RPush(ctx, auditUnsequencedKey(bucketID), data).Err()
+	}
+	if err != nil {
+		log.Printf("Error recording %s of %s in the audit log of bucket %s: %v, and failed to queue it: %v", entry.Operation, entry.Path, bucketID, appendErr, err)
+	}
+}
+
+// appendUnsequencedAuditEntries chains the queued entries of a bucket, in
+// the order they were queued. Entries that still can't be appended stay
+// queued for the next flush.
+func (fsm *FileSystemManager) appendUnsequencedAuditEntries(ctx context.Context, bucketID string) error {
+	key := auditUnsequencedKey(bucketID)
+
+	for {
+		data, err := fsm.redis.LIndex(ctx, key, 0).Result()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var entry audit.Entry
+		if err := json.Unmarshal([]byte(data), &entry); err == nil {
+			if err := fsm.appendAuditEntry(ctx, bucketID, &entry); err != nil {
+				return err
+			}
+		} else {
+			log.Printf("Dropping unreadable audit entry of bucket %s: %v", bucketID, err)
+		}
+
+		if err := fsm.redis.LPop(ctx, key).Err(); err != nil {
+			return err
+		}
+	}
+}
+
+func (fsm *FileSystemManager) appendAuditEntry(ctx context.Context, bucketID string, entry *audit.Entry) error {
+	head, err := fsm.loadAuditHead(ctx, bucketID)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{auditHeadKey(bucketID), auditPendingKey(bucketID)}
+
+	for range auditAppendAttempts {
+		seq, prevHash := parseAuditHead(head)
+		if head == "" {
+			prevHash = audit.GenesisHash
+		}
+
+		entry.Seal(seq+1, prevHash)
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		result, err := appendAuditEntryScript.Run(ctx, fsm.redis, keys, head, data, formatAuditHead(entry.Seq, entry.Hash)).Slice()
+		if err != nil {
+			return err
+		}
+
+		if appended, _ := result[0].(int64); appended == 1 {
+			return nil
+		}
+		head, _ = result[1].(string)
+	}
+
+	return fmt.Errorf("audit log is busy")
+}
+
+func parseAuditEntries(data []byte) ([]audit.Entry, error) {
+	var entries []audit.Entry
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry audit.Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (fsm *FileSystemManager) readAuditSegment(bucketID string, segment int64) ([]audit.Entry, bool, error) {
+	obj, err := fsm.objectStorage.GetObject(fsm.bucketName, auditSegmentObjectKey(bucketID, segment))
+	if err != nil {
+		if isObjectNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	entries, err := parseAuditEntries(obj.Data)
+	return entries, true, err
+}
+
+func (fsm *FileSystemManager) readPendingAuditEntries(ctx context.Context, bucketID string) ([]audit.Entry, int, error) {
+	values, err := fsm.redis.LRange(ctx, auditPendingKey(bucketID), 0, -1).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := parseAuditEntries([]byte(strings.Join(values, "\n")))
+	return entries, len(values), err
+}
+
+// GetAuditLog returns up to limit entries after the given sequence number,
+// oldest first. The returned cursor is the sequence number to pass to get
+// the next page, or 0 if there are no more entries.
+func (fsm *FileSystemManager) GetAuditLog(ctx context.Context, bucketID string, afterSeq int64, limit int) ([]audit.Entry, int64, error) {
+	if afterSeq < 0 {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "invalid page token")
+	}
+	if limit <= 0 {
+		limit = defaultAuditPageLimit
+	}
+	limit = min(limit, maxAuditPageLimit)
+
+	// Pending entries are read first, entries flushed in the meantime are
+	// then found in the segments
+	pending, _, err := fsm.readPendingAuditEntries(ctx, bucketID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]audit.Entry, 0, limit)
+	last := afterSeq
+
+	add := func(candidates []audit.Entry) bool {
+		for _, entry := range candidates {
+			if entry.Seq <= last {
+				continue
+			}
+			if len(entries) == limit {
+				return false
+			}
+			entries = append(entries, entry)
+			last = entry.Seq
+		}
+		return true
+	}
+
+	for segment := afterSeq / auditSegmentSize; ; segment++ {
+		segmentEntries, found, err := fsm.readAuditSegment(bucketID, segment)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !found || !add(segmentEntries) {
+			break
+		}
+	}
+
+	more := !add(pending)
+	if len(entries) == limit && !more {
+		head, err := fsm.loadAuditHead(ctx, bucketID)
+		if err != nil {
+			return nil, 0, err
+		}
+		headSeq, _ := parseAuditHead(head)
+		more = headSeq > last
+	}
+
+	if !more {
+		return entries, 0, nil
+	}
+
+	return entries, last, nil
+}
+
+// VerifyAuditLog walks the whole chain of a bucket and checks that it is
+// unbroken and ends at the current head.
+func (fsm *FileSystemManager) VerifyAuditLog(ctx context.Context, bucketID string) (*AuditVerification, error) {
+	head, err := fsm.loadAuditHead(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+	headSeq, headHash := parseAuditHead(head)
+
+	verifier := audit.NewVerifier()
+	result := &AuditVerification{HeadHash: headHash}
+
+	var after int64
+	for {
+		entries, next, err := fsm.GetAuditLog(ctx, bucketID, after, maxAuditPageLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range entries {
+			if entries[i].Seq > headSeq {
+				break
+			}
+			if err := verifier.Next(&entries[i]); err != nil {
+				result.InvalidSeq = entries[i].Seq
+				result.Error = err.Error()
+				result.EntryCount = verifier.Seq()
+				return result, nil
+			}
+		}
+
+		if next == 0 || verifier.Seq() >= headSeq {
+			break
+		}
+		after = next
+	}
+
+	result.EntryCount = verifier.Seq()
+
+	switch {
+	case verifier.Seq() != headSeq:
+		result.InvalidSeq = verifier.Seq() + 1
+		result.Error = fmt.Sprintf("log ends at entry %d, the head is entry %d", verifier.Seq(), headSeq)
+	case verifier.Hash() != headHash:
+		result.InvalidSeq = headSeq
+		result.Error = "log does not end at the head"
+	default:
+		result.Valid = true
+	}
+
+	return result, nil
+}
+
+// flushAuditLog moves the pending entries of a bucket into its segments
+func (fsm *FileSystemManager) flushAuditLog(ctx context.Context, bucketID string) error {
+	pending, count, err := fsm.readPendingAuditEntries(ctx, bucketID)
+	if err != nil || count == 0 {
+		return err
+	}
+
+	contentType := "application/x-ndjson"
+
+	for start := 0; start < len(pending); {
+		segment := (pending[start].Seq - 1) / auditSegmentSize
+
+		end := start
+		for end < len(pending) && (pending[end].Seq-1)/auditSegmentSize == segment {
+			end++
+		}
+
+		existing, _, err := fsm.readAuditSegment(bucketID, segment)
+		if err != nil {
+			return err
+		}
+
+		// A flush that failed after writing the segment leaves duplicates
+		var buf bytes.Buffer
+		for _, entry := range existing {
+			if entry.Seq >= pending[start].Seq {
+				break
+			}
+			json.NewEncoder(&buf).Encode(entry)
+		}
+		for _, entry := range pending[start:end] {
+			json.NewEncoder(&buf).Encode(entry)
+		}
+
+		if _, err := fsm.objectStorage.PutObject(fsm.bucketName, auditSegmentObjectKey(bucketID, segment), buf.Bytes(), &contentType, nil); err != nil {
+			return err
+		}
+
+		start = end
+	}
+
+	last := pending[len(pending)-1]
+	headContentType := "text/plain"
+	if _, err := fsm.objectStorage.PutObject(fsm.bucketName, auditHeadObjectKey(bucketID), []byte(formatAuditHead(last.Seq, last.Hash)), &headContentType, nil); err != nil {
+		return err
+	}
+
+	return fsm.redis.LTrim(ctx, auditPendingKey(bucketID), int64(count), -1).Err()
+}
+
+func (fsm *FileSystemManager) flushAuditLogs() {
+	ctx := context.Background()
+
+	var bucketIDs []string
+	seen := make(map[string]bool)
+
+	for _, suffix := range []string{":pending", ":unsequenced"} {
+		iter := fsm.redis.Scan(ctx, 0, "audit:*"+suffix, 100).Iterator()
+		for iter.Next(ctx) {
+			bucketID := strings.TrimSuffix(strings.TrimPrefix(iter.Val(), "audit:"), suffix)
+			if !seen[bucketID] {
+				seen[bucketID] = true
+				bucketIDs = append(bucketIDs, bucketID)
+			}
+		}
+		if err := iter.Err(); err != nil {
+			log.Printf("Error scanning audit log keys: %v", err)
+			return
+		}
+	}
+
+	for _, bucketID := range bucketIDs {
+		lockKey := fmt.Sprintf("lock:audit:%s", bucketID)
+		if !fsm.acquireLock(ctx, lockKey) {
+			continue
+		}
+
+		if err := fsm.appendUnsequencedAuditEntries(ctx, bucketID); err != nil {
+			log.Printf("Error appending queued entries to the audit log of bucket %s: %v", bucketID, err)
+		}
+
+		if err := fsm.flushAuditLog(ctx, bucketID); err != nil {
+			log.Printf("Error flushing audit log of bucket %s: %v", bucketID, err)
+		}
+
+		fsm.releaseLock(ctx, lockKey)
+	}
+}
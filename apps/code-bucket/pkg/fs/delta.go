@@ -0,0 +1,56 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/delta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApplyFileDelta rebuilds a file from its current content and a delta. The
+// delta is rejected if the file no longer has the content it was computed
+// against.
+func (fsm *FileSystemManager) ApplyFileDelta(ctx context.Context, bucketID, filePath, baseHash string, patch []byte) (*FileInfo, error) {
+	if baseHash == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "base hash is required")
+	}
+
+	var result *FileInfo
+	err := fsm.withLock(ctx, fileLockKey(bucketID, filePath), func() error {
+		info, data, err := fsm.GetBucketFile(ctx, bucketID, filePath)
+		if err != nil {
+			return err
+		}
+
+		if info.Hash != baseHash {
+			return status.Errorf(codes.FailedPrecondition, "base hash does not match, the file is at %s", info.Hash)
+		}
+
+		content, err := delta.Apply(data.Content, patch, int(fsm.maxFileSize))
+		if err != nil {
+			if errors.Is(err, delta.ErrInvalidDelta) {
+				return status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			return err
+		}
+
+		if err := fsm.putBucketFile(ctx, bucketID, filePath, content, info.ContentType); err != nil {
+			return err
+		}
+
+		result = &FileInfo{
+			Path:        filePath,
+			Size:        int64(len(content)),
+			ContentType: info.ContentType,
+			ModifiedAt:  time.Now(),
+			Hash:        hashContent(content),
+		}
+
+		return nil
+	})
+
+	return result, err
+}
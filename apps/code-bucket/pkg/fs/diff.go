@@ -0,0 +1,184 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/diff"
+	memoryQueue "github.com/metorial/metorial/services/code-bucket/pkg/memory-queue"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+)
+
+const defaultMaxDiffFileSize = 10 * 1024 * 1024
+
+type DiffOptions struct {
+	BaseBucketID   string
+	BasePrefix     string
+	TargetBucketID string
+	TargetPrefix   string
+
+	IncludeTextDiffs bool
+	ContextLines     int
+
+	// Files larger than this are compared by hash only, and reported as
+	// binary if they differ
+	MaxFileSize int64
+}
+
+type FileDiff struct {
+	Path        string `json:"path"`
+	ChangeType  string `json:"change_type"`
+	IsBinary    bool   `json:"is_binary"`
+	OldSize     int64  `json:"old_size"`
+	NewSize     int64  `json:"new_size"`
+	UnifiedDiff string `json:"unified_diff,omitempty"`
+}
+
+func (fsm *FileSystemManager) listRelativeFiles(ctx context.Context, bucketID, prefix string) (map[string]FileInfo, error) {
+	files, err := fsm.GetBucketFiles(ctx, bucketID, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]FileInfo, len(files))
+	for _, f := range files {
+		result[strings.TrimPrefix(f.Path, prefix)] = f
+	}
+
+	return result, nil
+}
+
+// DiffBuckets compares the files under BasePrefix in the base bucket with the
+// files under TargetPrefix in the target bucket. Paths are reported relative
+// to the prefixes.
+func (fsm *FileSystemManager) DiffBuckets(ctx context.Context, opts DiffOptions) ([]FileDiff, error) {
+	if opts.TargetBucketID == "" {
+		opts.TargetBucketID = opts.BaseBucketID
+	}
+	if opts.ContextLines <= 0 {
+		opts.ContextLines = diff.DefaultContextLines
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultMaxDiffFileSize
+	}
+
+	baseFiles, err := fsm.listRelativeFiles(ctx, opts.BaseBucketID, opts.BasePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	targetFiles, err := fsm.listRelativeFiles(ctx, opts.TargetBucketID, opts.TargetPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(baseFiles)+len(targetFiles))
+	for p := range baseFiles {
+		paths = append(paths, p)
+	}
+	for p := range targetFiles {
+		if _, ok := baseFiles[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var mutex sync.Mutex
+	results := make(map[string]*FileDiff, len(paths))
+
+	queue := memoryQueue.NewBlockingJobQueue(15)
+
+	for _, relPath := range paths {
+		p := relPath
+		queue.AddAndBlockIfFull(func() error {
+			base, inBase := baseFiles[p]
+			target, inTarget := targetFiles[p]
+
+			result, err := fsm.diffFile(ctx, opts, p, util.Ternary(inBase, &base, nil), util.Ternary(inTarget, &target, nil))
+			if err != nil || result == nil {
+				return err
+			}
+
+			mutex.Lock()
+			results[p] = result
+			mutex.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := queue.Wait(); err != nil {
+		return nil, err
+	}
+
+	diffs := make([]FileDiff, 0, len(results))
+	for _, p := range paths {
+		if result, ok := results[p]; ok {
+			diffs = append(diffs, *result)
+		}
+	}
+
+	return diffs, nil
+}
+
+func (fsm *FileSystemManager) diffFile(ctx context.Context, opts DiffOptions, relPath string, base, target *FileInfo) (*FileDiff, error) {
+	result := &FileDiff{Path: relPath}
+
+	switch {
+	case base == nil:
+		result.ChangeType = ChangeTypeAdded
+		result.NewSize = target.Size
+	case target == nil:
+		result.ChangeType = ChangeTypeDeleted
+		result.OldSize = base.Size
+	default:
+		result.ChangeType = ChangeTypeModified
+		result.OldSize = base.Size
+		result.NewSize = target.Size
+	}
+
+	// Oversized files are never downloaded, without hashes to compare they
+	// are taken as changed
+	if result.OldSize > opts.MaxFileSize || result.NewSize > opts.MaxFileSize {
+		if result.ChangeType == ChangeTypeModified && base.Hash != "" && base.Hash == target.Hash {
+			return nil, nil
+		}
+
+		result.IsBinary = true
+		return result, nil
+	}
+
+	var oldContent, newContent []byte
+	if base != nil {
+		_, data, err := fsm.GetBucketFile(ctx, opts.BaseBucketID, base.Path)
+		if err != nil {
+			return nil, err
+		}
+		oldContent = data.Content
+	}
+	if target != nil {
+		_, data, err := fsm.GetBucketFile(ctx, opts.TargetBucketID, target.Path)
+		if err != nil {
+			return nil, err
+		}
+		newContent = data.Content
+	}
+
+	if base != nil && target != nil && bytes.Equal(oldContent, newContent) {
+		return nil, nil
+	}
+
+	result.IsBinary = util.IsBinary(oldContent) || util.IsBinary(newContent)
+
+	if opts.IncludeTextDiffs && !result.IsBinary {
+		oldName := util.Ternary(base != nil, "a/"+strings.TrimPrefix(relPath, "/"), "/dev/null")
+		newName := util.Ternary(target != nil, "b/"+strings.TrimPrefix(relPath, "/"), "/dev/null")
+
+		result.UnifiedDiff = diff.Unified(oldName, newName, string(oldContent), string(newContent), opts.ContextLines)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiffBuckets_LargeFiles(t *testing.T) {
+	fsm, _ := newTestManager(t)
+	ctx := context.Background()
+
+	large := strings.Repeat("a", 100)
+	putFiles(t, fsm, "base", map[string]string{"/same.bin": large, "/edited.bin": large, "/small.txt": "old\n"})
+	putFiles(t, fsm, "target", map[string]string{"/same.bin": large, "/edited.bin": strings.Repeat("b", 100), "/small.txt": "new\n"})
+
+	diffs, err := fsm.DiffBuckets(ctx, DiffOptions{BaseBucketID: "base", TargetBucketID: "target", MaxFileSize: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, d := range diffs {
+		got = append(got, fmt.Sprintf("%s %s binary=%v", d.Path, d.ChangeType, d.IsBinary))
+	}
+	want := []string{"/edited.bin modified binary=true", "/small.txt modified binary=false"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,185 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	objectstorage "github.com/metorial/object-storage/clients/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Archives built by GetBucketFilesAsArchive are served from
+// /download/<storage bucket>/<object key>. An archive is named by its
+// content, so the same archive can belong to several buckets; each bucket it
+// was built for is recorded so tokens of other buckets can't fetch it.
+
+var archiveKeyPattern = regexp.MustCompile(`^(zips/[0-9a-f]{64}\.zip|archives/[0-9a-f]{64}\.tar\.gz)$`)
+
+func archiveDownloadPath(bucketName, objectKey string) string {
+	return fmt.Sprintf("/download/%s/%s", bucketName, objectKey)
+}
+
+func archiveBucketsKey(objectKey string) string {
+	return fmt.Sprintf("zip-buckets:%s", objectKey)
+}
+
+type ArchiveDownload struct {
+	*objectReader
+	Name        string // File name of the archive
+	ContentType string
+	Hash        string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// OpenArchiveDownload opens the archive at a download path returned by
+// GetBucketFilesAsArchive. Archives that weren't built for the bucket aren't
+// found, expired ones fail with FailedPrecondition.
+func (fsm *FileSystemManager) OpenArchiveDownload(ctx context.Context, bucketID, downloadPath string) (*ArchiveDownload, error) {
+	objectKey, ok := strings.CutPrefix(downloadPath, archiveDownloadPath(fsm.bucketName, ""))
+	if !ok || !archiveKeyPattern.MatchString(objectKey) {
+		return nil, status.Errorf(codes.NotFound, "archive not found")
+	}
+
+	isMember, err := fsm.redis.SIsMember(ctx, archiveBucketsKey(objectKey), bucketID).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	createdStr, err := fsm.redis.Get(ctx, fmt.Sprintf("zip:%s", objectKey)).Result()
+	if err == redis.Nil || (err == nil && !isMember) {
+		return nil, status.Errorf(codes.NotFound, "archive not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	created, _ := strconv.ParseInt(createdStr, 10, 64)
+	createdAt := time.Unix(created, 0)
+	expiresAt := createdAt.Add(zipExpiration)
+	if !time.Now().Before(expiresAt) {
+		return nil, status.Errorf(codes.FailedPrecondition, "archive has expired")
+	}
+
+	obj, err := fsm.objectStorage.HeadObject(fsm.bucketName, objectKey)
+	if err != nil {
+		if isObjectNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "archive not found")
+		}
+		return nil, err
+	}
+
+	_, name, _ := strings.Cut(objectKey, "/")
+	hash, ext, _ := strings.Cut(name, ".")
+
+	return &ArchiveDownload{
+		objectReader: &objectReader{ctx: ctx, fsm: fsm, key: objectKey, size: int64(obj.Size)},
+		Name:         fmt.Sprintf("%s.%s", bucketID, ext),
+		ContentType:  archiveFormats[ArchiveFormat(ext)].contentType,
+		Hash:         hash,
+		CreatedAt:    createdAt,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// objectReader streams an object from object storage. Seeking reopens the
+// stream at the new offset, so ranges can be served without loading the
+// object.
+type objectReader struct {
+	ctx    context.Context
+	fsm    *FileSystemManager
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *objectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *objectReader) open() error {
+	urlPath := fmt.Sprintf("%s/buckets/%s/objects/%s", r.fsm.objectStorageEndpoint, r.fsm.bucketName, r.key)
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return err
+	}
+	if r.offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+	}
+
+	// Downloads can take longer than the timeout of fsm.httpClient
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The range was ignored, skip to the offset
+		if _, err := io.CopyN(io.Discard, resp.Body, r.offset); err != nil {
+			resp.Body.Close()
+			return err
+		}
+	default:
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return &objectstorage.Error{
+			StatusCode: resp.StatusCode,
+			Message:    string(bodyBytes),
+		}
+	}
+
+	r.body = resp.Body
+	return nil
+}
+
+func (r *objectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset")
+	}
+
+	if offset != r.offset {
+		r.Close()
+		r.offset = offset
+	}
+
+	return offset, nil
+}
+
+func (r *objectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
@@ -0,0 +1,319 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/metorial/metorial/services/code-bucket/pkg/audit"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Changes are appended to a redis stream per bucket. Stream entry ids double
+// as cursors, so a watcher that reconnects with the last id it has seen gets
+// every change it missed, as long as the entry is still retained.
+
+const (
+	EventTypePut    = "put"
+	EventTypeDelete = "delete"
+	EventTypeMove   = "move"
+
+	// EventTypeReset is sent when a cursor is too old to resume from. The
+	// watcher has to resync the whole bucket, later events continue normally.
+	EventTypeReset = "reset"
+
+	maxRetainedEvents = 10000
+	eventRetention    = 7 * 24 * time.Hour
+	eventReadTimeout  = 5 * time.Second
+	eventReadBatch    = 100
+)
+
+type Event struct {
+	Cursor  string    `json:"cursor"`
+	Type    string    `json:"type"`
+	Path    string    `json:"path"`
+	OldPath string    `json:"old_path,omitempty"`
+	Hash    string    `json:"hash,omitempty"`
+	Size    int64     `json:"size"`
+	Actor   string    `json:"actor,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Origin describes who made a change and how, for change events and the
+// audit log
+type Origin struct {
+	Actor    string
+	TokenID  string
+	SourceIP string
+	Method   string
+}
+
+type originContextKey struct{}
+
+// WithOrigin attributes the changes done with the returned context
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originContextKey{}, origin)
+}
+
+func originFromContext(ctx context.Context) Origin {
+	origin, _ := ctx.Value(originContextKey{}).(Origin)
+	return origin
+}
+
+func eventStreamKey(bucketID string) string {
+	return fmt.Sprintf("events:%s", bucketID)
+}
+
+// recordChange appends a change to the audit log and the bucket's stream and
+// notifies webhooks. The change itself has already happened, so failures are
+// only logged.
+func (fsm *FileSystemManager) recordChange(ctx context.Context, bucketID string, event Event) {
+	fsm.recordAuditEntry(ctx, bucketID, &audit.Entry{
+		Operation:   event.Type,
+		Path:        event.Path,
+		OldPath:     event.OldPath,
+		ContentHash: event.Hash,
+	})
+
+	event.Actor = originFromContext(ctx).Actor
+	fsm.publishEvent(ctx, bucketID, event)
+}
+
+func (fsm *FileSystemManager) publishEvent(ctx context.Context, bucketID string, event Event) {
+	key := eventStreamKey(bucketID)
+
+	event.Time = time.Now().UTC()
+
+	cursor, err := fsm.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: maxRetainedEvents,
+		Approx: true,
+		Values: map[string]any{
+			"type":     event.Type,
+			"path":     event.Path,
+			"old_path": event.OldPath,
+			"hash":     event.Hash,
+			"size":     event.Size,
+			"actor":    event.Actor,
+			"time":     event.Time.UnixMilli(),
+		},
+	}).Result()
+	if err == nil {
+		err = fsm.redis.Expire(ctx, key, eventRetention).Err()
+	}
+
+	if err != nil {
+		log.Printf("Error publishing %s event for %s in bucket %s: %v", event.Type, event.Path, bucketID, err)
+	}
+
+	event.Cursor = cursor
+	fsm.NotifyWebhooks(ctx, bucketID, "file."+event.Type, event)
+}
+
+func parseEvent(message redis.XMessage) Event {
+	value := func(name string) string {
+		v, _ := message.Values[name].(string)
+		return v
+	}
+
+	size, _ := strconv.ParseInt(value("size"), 10, 64)
+	millis, _ := strconv.ParseInt(value("time"), 10, 64)
+
+	return Event{
+		Cursor:  message.ID,
+		Type:    value("type"),
+		Path:    value("path"),
+		OldPath: value("old_path"),
+		Hash:    value("hash"),
+		Size:    size,
+		Actor:   value("actor"),
+		Time:    time.UnixMilli(millis).UTC(),
+	}
+}
+
+// compareStreamIDs orders two redis stream ids of the form <millis>-<seq>
+func compareStreamIDs(a, b string) int {
+	parse := func(id string) (uint64, uint64) {
+		millis, seq, _ := strings.Cut(id, "-")
+		m, _ := strconv.ParseUint(millis, 10, 64)
+		s, _ := strconv.ParseUint(seq, 10, 64)
+		return m, s
+	}
+
+	am, as := parse(a)
+	bm, bs := parse(b)
+
+	switch {
+	case am != bm:
+		return util.Ternary(am < bm, -1, 1)
+	case as != bs:
+		return util.Ternary(as < bs, -1, 1)
+	}
+	return 0
+}
+
+func isValidStreamID(id string) bool {
+	millis, seq, found := strings.Cut(id, "-")
+	if _, err := strconv.ParseUint(millis, 10, 64); err != nil {
+		return false
+	}
+	if found {
+		if _, err := strconv.ParseUint(seq, 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// latestEventCursor returns the id of the newest retained event, or "0" if
+// the bucket has none.
+func (fsm *FileSystemManager) latestEventCursor(ctx context.Context, bucketID string) (string, error) {
+	messages, err := fsm.redis.XRevRangeN(ctx, eventStreamKey(bucketID), "+", "-", 1).Result()
+	if err != nil || len(messages) == 0 {
+		return "0", err
+	}
+	return messages[0].ID, nil
+}
+
+// WatchBucket calls emit for every change to a path with the given prefix
+// until the context is done. An empty cursor only watches new changes,
+// otherwise the changes after the cursor are replayed first. If those are no
+// longer retained a reset event is emitted before watching continues.
+func (fsm *FileSystemManager) WatchBucket(ctx context.Context, bucketID, prefix, cursor string, emit func(Event) error) error {
+	key := eventStreamKey(bucketID)
+
+	if cursor != "" && !isValidStreamID(cursor) {
+		return status.Errorf(codes.InvalidArgument, "invalid cursor %q", cursor)
+	}
+
+	if cursor == "" {
+		latest, err := fsm.latestEventCursor(ctx, bucketID)
+		if err != nil {
+			return err
+		}
+		cursor = latest
+	} else if cursor != "0" {
+		oldest, err := fsm.redis.XRangeN(ctx, key, "-", "+", 1).Result()
+		if err != nil {
+			return err
+		}
+
+		// Trimmed or expired events can't be replayed
+		if len(oldest) == 0 || compareStreamIDs(cursor, oldest[0].ID) < 0 {
+			latest, err := fsm.latestEventCursor(ctx, bucketID)
+			if err != nil {
+				return err
+			}
+			cursor = latest
+
+			if err := emit(Event{Cursor: cursor, Type: EventTypeReset, Time: time.Now().UTC()}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		streams, err := fsm.redis.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{key, cursor},
+			Count:   eventReadBatch,
+			Block:   eventReadTimeout,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				cursor = message.ID
+
+				event := parseEvent(message)
+				if !strings.HasPrefix(event.Path, prefix) && !(event.OldPath != "" && strings.HasPrefix(event.OldPath, prefix)) {
+					continue
+				}
+
+				if err := emit(event); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// MoveBucketFile renames a file and publishes a single move event for it. If
+// the source can't be removed the destination is put back as it was.
+func (fsm *FileSystemManager) MoveBucketFile(ctx context.Context, bucketID, fromPath, toPath string) (*FileInfo, error) {
+	if fromPath == toPath {
+		return nil, status.Errorf(codes.InvalidArgument, "source and destination are the same")
+	}
+
+	// Both paths are locked, always in the same order so concurrent moves
+	// between them can't deadlock
+	firstPath, secondPath := min(fromPath, toPath), max(fromPath, toPath)
+
+	var result *FileInfo
+	err := fsm.withLock(ctx, fileLockKey(bucketID, firstPath), func() error {
+		return fsm.withLock(ctx, fileLockKey(bucketID, secondPath), func() error {
+			info, data, err := fsm.GetBucketFile(ctx, bucketID, fromPath)
+			if err != nil {
+				return err
+			}
+
+			oldInfo, oldData, err := fsm.GetBucketFile(ctx, bucketID, toPath)
+			if err != nil && err.Error() != "file not found" {
+				return err
+			}
+
+			if err := fsm.writeBucketFile(ctx, bucketID, toPath, data.Content, info.ContentType, info.Hash); err != nil {
+				return err
+			}
+			if err := fsm.deleteBucketFile(ctx, bucketID, fromPath); err != nil {
+				var rollbackErr error
+				if oldInfo != nil {
+					rollbackErr = fsm.writeBucketFile(ctx, bucketID, toPath, oldData.Content, oldInfo.ContentType, oldInfo.Hash)
+				} else {
+					rollbackErr = fsm.deleteBucketFile(ctx, bucketID, toPath)
+				}
+				if rollbackErr != nil {
+					return fmt.Errorf("%w, and failed to restore %s: %v", err, toPath, rollbackErr)
+				}
+				return err
+			}
+
+			fsm.recordChange(ctx, bucketID, Event{
+				Type:    EventTypeMove,
+				Path:    toPath,
+				OldPath: fromPath,
+				Hash:    info.Hash,
+				Size:    info.Size,
+			})
+
+			result = &FileInfo{
+				Path:        toPath,
+				Size:        info.Size,
+				ContentType: info.ContentType,
+				ModifiedAt:  time.Now(),
+				Hash:        info.Hash,
+			}
+
+			return nil
+		})
+	})
+
+	return result, err
+}
@@ -0,0 +1,481 @@
+package fs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRedis speaks enough of the redis protocol for the file system manager.
+// Expirations are ignored, commands it doesn't know fail like unknown
+// commands do, which the manager treats like an unavailable feature.
+type fakeRedis struct {
+	mu   sync.Mutex
+	data map[string]any // string, map[string]string, []string or map[string]bool
+}
+
+func startFakeRedis(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	r := &fakeRedis{data: map[string]any{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go r.serve(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func (r *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	var queued [][]string
+	inMulti := false
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		name := strings.ToUpper(args[0])
+		switch {
+		case name == "MULTI":
+			inMulti = true
+			writeReply(writer, "OK")
+		case name == "EXEC":
+			replies := make([]any, 0, len(queued))
+			for _, queuedArgs := range queued {
+				replies = append(replies, r.exec(queuedArgs))
+			}
+			queued, inMulti = nil, false
+			writeReply(writer, replies)
+		case name == "DISCARD":
+			queued, inMulti = nil, false
+			writeReply(writer, "OK")
+		case inMulti:
+			queued = append(queued, args)
+			writeReply(writer, "QUEUED")
+		default:
+			writeReply(writer, r.exec(args))
+		}
+
+		if reader.Buffered() == 0 {
+			if err := writer.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+type bulk []byte
+
+func (r *fakeRedis) exec(args []string) any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name, args := strings.ToUpper(args[0]), args[1:]
+	str := func(key string) (string, bool) {
+		s, ok := r.data[key].(string)
+		return s, ok
+	}
+	hash := func(key string) map[string]string {
+		h, ok := r.data[key].(map[string]string)
+		if !ok {
+			h = map[string]string{}
+		}
+		return h
+	}
+	list := func(key string) []string {
+		l, _ := r.data[key].([]string)
+		return l
+	}
+	index := func(l []string, i int) int {
+		if i < 0 {
+			i += len(l)
+		}
+		return max(0, min(i, len(l)))
+	}
+
+	switch name {
+	case "PING":
+		return "PONG"
+
+	case "GET":
+		if s, ok := str(args[0]); ok {
+			return bulk(s)
+		}
+		return nil
+
+	case "MGET":
+		values := make([]any, len(args))
+		for i, key := range args {
+			if s, ok := str(key); ok {
+				values[i] = bulk(s)
+			}
+		}
+		return values
+
+	case "SET":
+		for _, option := range args[2:] {
+			_, exists := r.data[args[0]]
+			if strings.EqualFold(option, "NX") && exists {
+				return nil
+			}
+		}
+		r.data[args[0]] = args[1]
+		return "OK"
+
+	case "SETNX":
+		if _, exists := r.data[args[0]]; exists {
+			return int64(0)
+		}
+		r.data[args[0]] = args[1]
+		return int64(1)
+
+	case "DEL", "EXISTS":
+		var count int64
+		for _, key := range args {
+			if _, ok := r.data[key]; ok {
+				count++
+				if name == "DEL" {
+					delete(r.data, key)
+				}
+			}
+		}
+		return count
+
+	case "EXPIRE":
+		if _, ok := r.data[args[0]]; ok {
+			return int64(1)
+		}
+		return int64(0)
+
+	case "SCAN":
+		pattern := "*"
+		for i := 1; i+1 < len(args); i += 2 {
+			if strings.EqualFold(args[i], "MATCH") {
+				pattern = args[i+1]
+			}
+		}
+		matcher := globRegexp(pattern)
+
+		keys := []any{}
+		for key := range r.data {
+			if matcher.MatchString(key) {
+				keys = append(keys, bulk(key))
+			}
+		}
+		return []any{bulk("0"), keys}
+
+	case "HSET":
+		h := hash(args[0])
+		var added int64
+		for i := 1; i+1 < len(args); i += 2 {
+			if _, ok := h[args[i]]; !ok {
+				added++
+			}
+			h[args[i]] = args[i+1]
+		}
+		r.data[args[0]] = h
+		return added
+
+	case "HGET":
+		if v, ok := hash(args[0])[args[1]]; ok {
+			return bulk(v)
+		}
+		return nil
+
+	case "HDEL":
+		h := hash(args[0])
+		var removed int64
+		for _, field := range args[1:] {
+			if _, ok := h[field]; ok {
+				delete(h, field)
+				removed++
+			}
+		}
+		return removed
+
+	case "HGETALL", "HKEYS":
+		h := hash(args[0])
+		fields := make([]string, 0, len(h))
+		for field := range h {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		values := []any{}
+		for _, field := range fields {
+			values = append(values, bulk(field))
+			if name == "HGETALL" {
+				values = append(values, bulk(h[field]))
+			}
+		}
+		return values
+
+	case "RPUSH":
+		l := append(list(args[0]), args[1:]...)
+		r.data[args[0]] = l
+		return int64(len(l))
+
+	case "LPOP":
+		l := list(args[0])
+		if len(l) == 0 {
+			return nil
+		}
+		r.data[args[0]] = l[1:]
+		return bulk(l[0])
+
+	case "LINDEX":
+		l := list(args[0])
+		i, _ := strconv.Atoi(args[1])
+		if i < 0 {
+			i += len(l)
+		}
+		if i < 0 || i >= len(l) {
+			return nil
+		}
+		return bulk(l[i])
+
+	case "LRANGE", "LTRIM":
+		l := list(args[0])
+		start, _ := strconv.Atoi(args[1])
+		stop, _ := strconv.Atoi(args[2])
+		start, stop = index(l, start), index(l, stop+1)
+		if stop < start {
+			stop = start
+		}
+
+		if name == "LTRIM" {
+			r.data[args[0]] = append([]string(nil), l[start:stop]...)
+			return "OK"
+		}
+
+		values := []any{}
+		for _, v := range l[start:stop] {
+			values = append(values, bulk(v))
+		}
+		return values
+
+	case "SADD":
+		s, ok := r.data[args[0]].(map[string]bool)
+		if !ok {
+			s = map[string]bool{}
+		}
+		var added int64
+		for _, member := range args[1:] {
+			if !s[member] {
+				s[member] = true
+				added++
+			}
+		}
+		r.data[args[0]] = s
+		return added
+
+	case "SISMEMBER":
+		s, _ := r.data[args[0]].(map[string]bool)
+		if s[args[1]] {
+			return int64(1)
+		}
+		return int64(0)
+	}
+
+	return fmt.Errorf("ERR unknown command '%s'", strings.ToLower(name))
+}
+
+// globRegexp translates the * and ? wildcards of a redis key pattern
+func globRegexp(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return regexp.MustCompile("^" + expr.String() + "$")
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command %q", line)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+
+	return args, nil
+}
+
+func writeReply(w *bufio.Writer, reply any) {
+	switch reply := reply.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case string:
+		fmt.Fprintf(w, "+%s\r\n", reply)
+	case bulk:
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(reply), reply)
+	case int64:
+		fmt.Fprintf(w, ":%d\r\n", reply)
+	case error:
+		fmt.Fprintf(w, "-%s\r\n", reply)
+	case []any:
+		fmt.Fprintf(w, "*%d\r\n", len(reply))
+		for _, item := range reply {
+			writeReply(w, item)
+		}
+	}
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+	metadata    map[string]string
+}
+
+// fakeObjectStorage serves the object api of a single bucket. Setting failing
+// makes every request fail like an unavailable server.
+type fakeObjectStorage struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+	failing bool
+}
+
+func (s *fakeObjectStorage) setFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+func (s *fakeObjectStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failing {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	const objectsPrefix = "/buckets/test/objects"
+	if !strings.HasPrefix(r.URL.Path, objectsPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, objectsPrefix), "/")
+
+	if key == "" && r.Method == http.MethodGet {
+		prefix := r.URL.Query().Get("prefix")
+
+		objects := []map[string]any{}
+		for objectKey, obj := range s.objects {
+			if strings.HasPrefix(objectKey, prefix) {
+				objects = append(objects, map[string]any{"key": objectKey, "size": len(obj.data), "content_type": obj.contentType, "metadata": obj.metadata})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"objects": objects})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		obj := fakeObject{data: data, contentType: r.Header.Get("Content-Type"), metadata: map[string]string{}}
+		for name, values := range r.Header {
+			if lowerName := strings.ToLower(name); strings.HasPrefix(lowerName, "x-object-meta-") {
+				obj.metadata[strings.TrimPrefix(lowerName, "x-object-meta-")] = values[0]
+			}
+		}
+		s.objects[key] = obj
+		json.NewEncoder(w).Encode(map[string]any{"key": key, "size": len(data)})
+
+	case http.MethodGet, http.MethodHead:
+		obj, ok := s.objects[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		for name, value := range obj.metadata {
+			w.Header().Set("x-object-meta-"+name, value)
+		}
+		w.Write(obj.data)
+
+	case http.MethodDelete:
+		if _, ok := s.objects[key]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// newTestManager returns a manager backed by a fake redis and a fake object
+// storage
+func newTestManager(t *testing.T) (*FileSystemManager, *fakeObjectStorage) {
+	storage := &fakeObjectStorage{objects: map[string]fakeObject{}}
+	server := httptest.NewServer(storage)
+	t.Cleanup(server.Close)
+
+	fsm := NewFileSystemManager(
+		WithRedisURL("redis://"+startFakeRedis(t)),
+		WithObjectStorageEndpoint(server.URL),
+		WithObjectStorageBucket("test"),
+	)
+	t.Cleanup(func() {
+		storage.setFailing(false)
+		fsm.Close()
+	})
+
+	return fsm, storage
+}
@@ -1,19 +1,18 @@
 package fs
 
 import (
-	"archive/zip"
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"io"
-	"os"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	objectstorage "github.com/metorial/object-storage/clients/go"
+	"github.com/metorial/metorial/services/code-bucket/pkg/glob"
 	memoryQueue "github.com/metorial/metorial/services/code-bucket/pkg/memory-queue"
+	"github.com/metorial/metorial/services/code-bucket/pkg/netguard"
 	"github.com/metorial/metorial/services/code-bucket/pkg/util"
 	zipImporter "github.com/metorial/metorial/services/code-bucket/pkg/zip-importer"
 	"google.golang.org/grpc/codes"
@@ -31,20 +30,29 @@ type FileInfo struct {
 	Size        int64     `json:"size"`
 	ContentType string    `json:"content_type"`
 	ModifiedAt  time.Time `json:"modified_at"`
+	Hash        string    `json:"hash"`
 }
 
 type FileData struct {
 	Content     []byte    `json:"content"`
 	ContentType string    `json:"content_type"`
 	ModifiedAt  time.Time `json:"modified_at"`
+	Hash        string    `json:"hash"`
 }
 
 type FileSystemManager struct {
-	redis           *redis.Client
-	objectStorage   *objectstorage.Client
-	bucketName      string
-	flushTicker     *time.Ticker
-	importSemaphore chan struct{}
+	redis                 *redis.Client
+	objectStorage         *objectstorage.Client
+	objectStorageEndpoint string
+	httpClient            *http.Client
+	bucketName            string
+	flushTicker           *time.Ticker
+	importSemaphore       chan struct{}
+	searchIndexes         *searchIndexCache
+	syncTrees             *syncTreeCache
+	maxFileSize           int64
+	webhookQueue          *memoryQueue.JobQueue
+	webhookClient         *http.Client
 }
 
 type FileContentsBase struct {
@@ -53,7 +61,7 @@ type FileContentsBase struct {
 }
 
 func NewFileSystemManager(opts ...FileSystemManagerOption) *FileSystemManager {
-	options := &FileSystemManagerOptions{}
+	options := &FileSystemManagerOptions{MaxFileSize: DefaultMaxFileSize}
 	for _, opt := range opts {
 		opt(options)
 	}
@@ -65,11 +73,18 @@ func NewFileSystemManager(opts ...FileSystemManagerOption) *FileSystemManager {
 	objectStorageClient := objectstorage.NewClient(options.ObjectStorageEndpoint)
 
 	fsm := &FileSystemManager{
-		redis:           rdb,
-		objectStorage:   objectStorageClient,
-		bucketName:      options.ObjectStorageBucket,
-		flushTicker:     time.NewTicker(60 * time.Second),
-		importSemaphore: make(chan struct{}, 15),
+		redis:                 rdb,
+		objectStorage:         objectStorageClient,
+		objectStorageEndpoint: options.ObjectStorageEndpoint,
+		httpClient:            &http.Client{Timeout: 30 * time.Second},
+		bucketName:            options.ObjectStorageBucket,
+		flushTicker:           time.NewTicker(60 * time.Second),
+		importSemaphore:       make(chan struct{}, 15),
+		searchIndexes:         newSearchIndexCache(),
+		syncTrees:             newSyncTreeCache(),
+		maxFileSize:           options.MaxFileSize,
+		webhookQueue:          memoryQueue.NewJobQueueWithBackoff(webhookQueueConcurrency, webhookBackoff),
+		webhookClient:         netguard.NewClient(webhookTimeout),
 	}
 
 	go fsm.backgroundFlush()
@@ -79,18 +94,44 @@ func NewFileSystemManager(opts ...FileSystemManagerOption) *FileSystemManager {
 }
 
 func (fsm *FileSystemManager) GetBucketFile(ctx context.Context, bucketID, filePath string) (*FileInfo, *FileData, error) {
+	info, data, err := fsm.getOwnBucketFile(ctx, bucketID, filePath)
+	if err == nil {
+		return info, data, nil
+	}
+
+	// Only missing files come from the base, a corrupt or unreachable copy in
+	// the overlay must not be replaced by the base version
+	if err.Error() != "file not found" {
+		return nil, nil, err
+	}
+
+	overlay, overlayErr := fsm.getOverlay(ctx, bucketID)
+	if overlayErr != nil || overlay == nil || overlay.isDeleted(filePath) {
+		return nil, nil, err
+	}
+
+	return fsm.GetBucketFile(ctx, overlay.BaseBucketID, filePath)
+}
+
+func (fsm *FileSystemManager) getOwnBucketFile(ctx context.Context, bucketID, filePath string) (*FileInfo, *FileData, error) {
 	redisKey := fmt.Sprintf("bucket:%s:file:%s", bucketID, filePath)
 
 	result, err := fsm.redis.Get(ctx, redisKey).Result()
 	if err == nil {
 		var fileData FileData
 		if err := json.Unmarshal([]byte(result), &fileData); err == nil {
+			hash, err := verifyContent(filePath, fileData.Content, fileData.Hash)
+			if err != nil {
+				return nil, nil, err
+			}
+			fileData.Hash = hash
 
 			info := &FileInfo{
 				Path:        filePath,
 				Size:        int64(len(fileData.Content)),
 				ContentType: fileData.ContentType,
 				ModifiedAt:  fileData.ModifiedAt,
+				Hash:        hash,
 			}
 
 			return info, &fileData, nil
@@ -98,13 +139,21 @@ func (fsm *FileSystemManager) GetBucketFile(ctx context.Context, bucketID, fileP
 	}
 
 	objectKey := fmt.Sprintf("%s/%s", bucketID, filePath)
-	obj, err := fsm.objectStorage.GetObject(fsm.bucketName, objectKey)
-	if err != nil {
+	obj, err := fsm.getObject(objectKey)
+	if isObjectNotFound(err) {
 		return nil, nil, fmt.Errorf("file not found")
 	}
+	if err != nil {
+		return nil, nil, err
+	}
 
 	content := obj.Data
 
+	hash, err := verifyContent(filePath, content, obj.Metadata.Metadata[hashMetadataKey])
+	if err != nil {
+		return nil, nil, err
+	}
+
 	contentType := "application/octet-stream"
 	if obj.Metadata.ContentType != nil {
 		contentType = *obj.Metadata.ContentType
@@ -122,6 +171,7 @@ func (fsm *FileSystemManager) GetBucketFile(ctx context.Context, bucketID, fileP
 		Content:     content,
 		ContentType: contentType,
 		ModifiedAt:  modifiedAt,
+		Hash:        hash,
 	}
 
 	if len(content) <= maxRedisCacheSize {
@@ -135,17 +185,49 @@ func (fsm *FileSystemManager) GetBucketFile(ctx context.Context, bucketID, fileP
 		Size:        int64(len(content)),
 		ContentType: contentType,
 		ModifiedAt:  modifiedAt,
+		Hash:        hash,
 	}
 
 	return info, &fileData, nil
 }
 
 func (fsm *FileSystemManager) PutBucketFile(ctx context.Context, bucketID, filePath string, content []byte, contentType string) error {
+	return fsm.withLock(ctx, fileLockKey(bucketID, filePath), func() error {
+		return fsm.putBucketFile(ctx, bucketID, filePath, content, contentType)
+	})
+}
+
+// putBucketFile writes a file for callers already holding its lock
+func (fsm *FileSystemManager) putBucketFile(ctx context.Context, bucketID, filePath string, content []byte, contentType string) error {
+	hash := hashContent(content)
+
+	if err := fsm.writeBucketFile(ctx, bucketID, filePath, content, contentType, hash); err != nil {
+		return err
+	}
+
+	fsm.recordChange(ctx, bucketID, Event{
+		Type: EventTypePut,
+		Path: filePath,
+		Hash: hash,
+		Size: int64(len(content)),
+	})
+
+	return nil
+}
+
+func (fsm *FileSystemManager) writeBucketFile(ctx context.Context, bucketID, filePath string, content []byte, contentType, hash string) error {
+	if err := fsm.clearOverlayDeletion(ctx, bucketID, filePath); err != nil {
+		return err
+	}
 
 	if len(content) > maxRedisCacheSize {
 		objectKey := fmt.Sprintf("%s/%s", bucketID, filePath)
-		_, err := fsm.objectStorage.PutObject(fsm.bucketName, objectKey, content, &contentType, nil)
-		return err
+		metadata := map[string]string{hashMetadataKey: hash}
+		if _, err := fsm.objectStorage.PutObject(fsm.bucketName, objectKey, content, &contentType, metadata); err != nil {
+			return err
+		}
+
+		return fsm.markFileChanged(ctx, bucketID, filePath)
 	}
 
 	redisKey := fmt.Sprintf("bucket:%s:file:%s", bucketID, filePath)
@@ -153,6 +235,7 @@ func (fsm *FileSystemManager) PutBucketFile(ctx context.Context, bucketID, fileP
 		Content:     content,
 		ContentType: contentType,
 		ModifiedAt:  time.Now(),
+		Hash:        hash,
 	}
 
 	data, err := json.Marshal(fileData)
@@ -168,24 +251,100 @@ func (fsm *FileSystemManager) PutBucketFile(ctx context.Context, bucketID, fileP
 	flushKey := fmt.Sprintf("flush:%s:%s", bucketID, filePath)
 	fsm.redis.Set(ctx, flushKey, time.Now().Unix(), redisFlushDelay*2)
 
-	return nil
+	return fsm.markFileChanged(ctx, bucketID, filePath)
 }
 
 func (fsm *FileSystemManager) DeleteBucketFile(ctx context.Context, bucketID, filePath string) error {
+	return fsm.withLock(ctx, fileLockKey(bucketID, filePath), func() error {
+		if err := fsm.deleteBucketFile(ctx, bucketID, filePath); err != nil {
+			return err
+		}
+
+		fsm.recordChange(ctx, bucketID, Event{Type: EventTypeDelete, Path: filePath})
+
+		return nil
+	})
+}
+
+func (fsm *FileSystemManager) deleteBucketFile(ctx context.Context, bucketID, filePath string) error {
+	ownErr := fsm.deleteOwnBucketFile(ctx, bucketID, filePath)
+
+	overlay, err := fsm.getOverlay(ctx, bucketID)
+	if err != nil {
+		return err
+	}
+	if overlay == nil || overlay.isDeleted(filePath) {
+		return ownErr
+	}
+
+	// The base still provides this path, so it has to be hidden explicitly
+	if _, _, err := fsm.GetBucketFile(ctx, overlay.BaseBucketID, filePath); err != nil {
+		return ownErr
+	}
+
+	return fsm.markOverlayDeletion(ctx, bucketID, filePath)
+}
+
+func (fsm *FileSystemManager) deleteOwnBucketFile(ctx context.Context, bucketID, filePath string) error {
 	redisKey := fmt.Sprintf("bucket:%s:file:%s", bucketID, filePath)
 	exists := fsm.redis.Exists(ctx, redisKey).Val()
 
 	if exists != 0 {
-		fsm.redis.Del(ctx, redisKey)
+		fsm.redis.Del(ctx, redisKey, fmt.Sprintf("flush:%s:%s", bucketID, filePath))
 	}
 
 	objectKey := fmt.Sprintf("%s/%s", bucketID, filePath)
 	err := fsm.objectStorage.DeleteObject(fsm.bucketName, objectKey)
 
-	return err
+	// Files that were never flushed only live in redis
+	if isObjectNotFound(err) {
+		if exists == 0 {
+			return fmt.Errorf("file not found")
+		}
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return fsm.markFileChanged(ctx, bucketID, filePath)
 }
 
 func (fsm *FileSystemManager) GetBucketFiles(ctx context.Context, bucketID, prefix string) ([]FileInfo, error) {
+	files, err := fsm.listOwnBucketFiles(ctx, bucketID, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	overlay, err := fsm.getOverlay(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+	if overlay == nil {
+		return files, nil
+	}
+
+	baseFiles, err := fsm.GetBucketFiles(ctx, overlay.BaseBucketID, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make(map[string]bool, len(files))
+	for _, f := range files {
+		own[f.Path] = true
+	}
+
+	for _, f := range baseFiles {
+		if own[f.Path] || overlay.isDeleted(f.Path) {
+			continue
+		}
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+func (fsm *FileSystemManager) listOwnBucketFiles(ctx context.Context, bucketID, prefix string) ([]FileInfo, error) {
 	files := make([]FileInfo, 0)
 
 	pattern := fmt.Sprintf("bucket:%s:file:*", bucketID)
@@ -211,11 +370,18 @@ func (fsm *FileSystemManager) GetBucketFiles(ctx context.Context, bucketID, pref
 				continue
 			}
 
+			// Entries written before hashes were stored are hashed here
+			hash := fileData.Hash
+			if hash == "" {
+				hash = hashContent(fileData.Content)
+			}
+
 			files = append(files, FileInfo{
 				Path:        filePath,
 				Size:        int64(len(fileData.Content)),
 				ContentType: fileData.ContentType,
 				ModifiedAt:  fileData.ModifiedAt,
+				Hash:        hash,
 			})
 		}
 	}
@@ -264,6 +430,7 @@ func (fsm *FileSystemManager) GetBucketFiles(ctx context.Context, bucketID, pref
 				Size:        int64(obj.Size),
 				ContentType: contentType,
 				ModifiedAt:  modifiedAt,
+				Hash:        obj.Metadata[hashMetadataKey],
 			})
 		}
 	}
@@ -271,66 +438,7 @@ func (fsm *FileSystemManager) GetBucketFiles(ctx context.Context, bucketID, pref
 	return files, nil
 }
 
-func (fsm *FileSystemManager) GetBucketFilesAsZip(ctx context.Context, bucketId, prefix string) (*string, *time.Time, error) {
-	files, err := fsm.GetBucketFiles(ctx, bucketId, prefix)
-	if err != nil {
-		return nil, nil, status.Errorf(codes.Internal, "failed to get files: %v", err)
-	}
-
-	tmpFile, err := os.CreateTemp("", "bucket-zip-*.zip")
-	if err != nil {
-		return nil, nil, status.Errorf(codes.Internal, "failed to create temp file: %v", err)
-	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
-
-	hash := sha256.New()
-	multiWriter := io.MultiWriter(tmpFile, hash)
-
-	zipWriter := zip.NewWriter(multiWriter)
-
-	for _, file := range files {
-		_, data, err := fsm.GetBucketFile(ctx, bucketId, file.Path)
-		if err != nil {
-			continue
-		}
-
-		f, err := zipWriter.Create(file.Path)
-		if err != nil {
-			continue
-		}
-
-		f.Write(data.Content)
-	}
-
-	zipWriter.Close()
-
-	zipKey := fmt.Sprintf("zips/%x.zip", hash.Sum(nil))
-
-	tmpFile.Seek(0, 0)
-
-	zipContent, err := io.ReadAll(tmpFile)
-	if err != nil {
-		return nil, nil, status.Errorf(codes.Internal, "failed to read zip file: %v", err)
-	}
-
-	contentType := "application/zip"
-	_, err = fsm.objectStorage.PutObject(fsm.bucketName, zipKey, zipContent, &contentType, nil)
-	if err != nil {
-		return nil, nil, status.Errorf(codes.Internal, "failed to upload zip: %v", err)
-	}
-
-	url := fmt.Sprintf("/download/%s/%s", fsm.bucketName, zipKey)
-
-	redisKey := fmt.Sprintf("zip:%s", zipKey)
-	fsm.redis.Set(ctx, redisKey, time.Now().Unix(), zipExpiration*2)
-
-	expiresAt := time.Now().Add(zipExpiration)
-
-	return &url, &expiresAt, nil
-}
-
-func (fsm *FileSystemManager) Clone(ctx context.Context, sourceBucketId, newBucketId string) error {
+func (fsm *FileSystemManager) Clone(ctx context.Context, sourceBucketId, newBucketId string, filter *glob.Filter) error {
 	select {
 	case fsm.importSemaphore <- struct{}{}:
 		defer func() { <-fsm.importSemaphore }()
@@ -343,6 +451,8 @@ func (fsm *FileSystemManager) Clone(ctx context.Context, sourceBucketId, newBuck
 		return status.Errorf(codes.NotFound, "source bucket not found: %v", err)
 	}
 
+	files = util.Filter(files, func(f FileInfo) bool { return filter.Match(f.Path) })
+
 	queue := memoryQueue.NewBlockingJobQueue(15)
 
 	for _, file := range files {
@@ -424,6 +534,7 @@ func (fsm *FileSystemManager) SetBucketFiles(ctx context.Context, bucketId strin
 
 func (fsm *FileSystemManager) Close() {
 	fsm.flushPendingFiles()
+	fsm.webhookQueue.Stop()
 
 	if fsm.flushTicker != nil {
 		fsm.flushTicker.Stop()
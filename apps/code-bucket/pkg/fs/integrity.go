@@ -0,0 +1,267 @@
+package fs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/metorial/metorial/services/code-bucket/pkg/merkle"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	objectstorage "github.com/metorial/object-storage/clients/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Object storage metadata key holding the SHA-256 of a file
+const hashMetadataKey = "sha256"
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyContent checks content against its stored hash. Files written before
+// hashes were stored have none, their hash is computed instead.
+func verifyContent(filePath string, content []byte, expected string) (string, error) {
+	actual := hashContent(content)
+	if expected != "" && expected != actual {
+		return "", status.Errorf(codes.DataLoss, "file %s is corrupted: expected sha256 %s, got %s", filePath, expected, actual)
+	}
+
+	return actual, nil
+}
+
+// getObject works like objectStorage.GetObject, but also returns the custom
+// metadata the client drops.
+func (fsm *FileSystemManager) getObject(key string) (*objectstorage.ObjectData, error) {
+	urlPath := fmt.Sprintf("%s/buckets/%s/objects/%s", fsm.objectStorageEndpoint, fsm.bucketName, key)
+	resp, err := fsm.httpClient.Get(urlPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, &objectstorage.Error{
+			StatusCode: resp.StatusCode,
+			Message:    string(bodyBytes),
+		}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &objectstorage.ObjectData{
+		Metadata: objectstorage.ObjectMetadata{
+			Key:          key,
+			Size:         uint64(len(data)),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Metadata:     make(map[string]string),
+		},
+		Data: data,
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		obj.Metadata.ContentType = &contentType
+	}
+
+	for name, values := range resp.Header {
+		lowerName := strings.ToLower(name)
+		if strings.HasPrefix(lowerName, "x-object-meta-") && len(values) > 0 {
+			obj.Metadata.Metadata[strings.TrimPrefix(lowerName, "x-object-meta-")] = values[0]
+		}
+	}
+
+	return obj, nil
+}
+
+// listFilesWithHashes lists files like GetBucketFiles, but fills in the
+// hashes of files stored before hashes were recorded.
+func (fsm *FileSystemManager) listFilesWithHashes(ctx context.Context, bucketID, prefix string) ([]FileInfo, error) {
+	files, err := fsm.GetBucketFiles(ctx, bucketID, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing hashes mean reading the whole file, so a few are read at once
+	semaphore := make(chan struct{}, 10)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+
+	for i, f := range files {
+		if f.Hash != "" {
+			continue
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, filePath string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			info, _, err := fsm.GetBucketFile(ctx, bucketID, filePath)
+			if err != nil {
+				errOnce.Do(func() { firstErr = err })
+				return
+			}
+			files[i].Hash = info.Hash
+		}(i, f.Path)
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+// GetBucketDigest returns a SHA-256 over the sorted paths and content hashes
+// of all files under prefix, so equal buckets always have equal digests.
+func (fsm *FileSystemManager) GetBucketDigest(ctx context.Context, bucketID, prefix string) (string, int, error) {
+	files, err := fsm.listFilesWithHashes(ctx, bucketID, prefix)
+	if err != nil {
+		return "", 0, err
+	}
+
+	digest := sha256.New()
+	for _, f := range files {
+		digest.Write([]byte(f.Path))
+		digest.Write([]byte{0})
+		digest.Write([]byte(f.Hash))
+		digest.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)), len(files), nil
+}
+
+// Built sync trees are cached per bucket version. The version changes with
+// every write and delete, and the version of an overlay includes those of its
+// bases, so a cached tree is only served while the bucket is unchanged.
+
+const maxCachedSyncTrees = 32
+
+type cachedSyncTree struct {
+	version string
+	tree    *merkle.Tree
+}
+
+type syncTreeCache struct {
+	mutex   sync.Mutex
+	entries map[string]cachedSyncTree
+}
+
+func newSyncTreeCache() *syncTreeCache {
+	return &syncTreeCache{entries: make(map[string]cachedSyncTree)}
+}
+
+func syncTreeVersionKey(bucketID string) string {
+	return fmt.Sprintf("sync-tree:%s:version", bucketID)
+}
+
+// markFileChanged records that a file of the bucket was written or deleted
+func (fsm *FileSystemManager) markFileChanged(ctx context.Context, bucketID, filePath string) error {
+	if err := fsm.markSearchIndexDirty(ctx, bucketID, filePath); err != nil {
+		return err
+	}
+	return fsm.bumpSyncTreeVersion(ctx, bucketID)
+}
+
+func (fsm *FileSystemManager) bumpSyncTreeVersion(ctx context.Context, bucketID string) error {
+	return fsm.redis.Set(ctx, syncTreeVersionKey(bucketID), strconv.FormatInt(time.Now().UnixNano(), 10), 0).Err()
+}
+
+// syncTreeVersion returns the version of a bucket and its overlay bases
+func (fsm *FileSystemManager) syncTreeVersion(ctx context.Context, bucketID string) (string, error) {
+	var versions []string
+
+	for bucketID != "" {
+		versionKey := syncTreeVersionKey(bucketID)
+
+		version, err := fsm.redis.Get(ctx, versionKey).Result()
+		if err != nil && err != redis.Nil {
+			return "", err
+		}
+
+		// Buckets unchanged since versions were introduced get one now
+		if version == "" {
+			version = strconv.FormatInt(time.Now().UnixNano(), 10)
+			if !fsm.redis.SetNX(ctx, versionKey, version, 0).Val() {
+				if version, err = fsm.redis.Get(ctx, versionKey).Result(); err != nil {
+					return "", err
+				}
+			}
+		}
+		versions = append(versions, version)
+
+		overlay, err := fsm.getOverlay(ctx, bucketID)
+		if err != nil {
+			return "", err
+		}
+		bucketID = ""
+		if overlay != nil {
+			bucketID = overlay.BaseBucketID
+		}
+	}
+
+	return strings.Join(versions, ":"), nil
+}
+
+// GetSyncTree returns the Merkle tree of a bucket's content hashes.
+func (fsm *FileSystemManager) GetSyncTree(ctx context.Context, bucketID string) (*merkle.Tree, error) {
+	// Read before listing, a write during the listing changes the version and
+	// the tree built here is never served for it
+	version, err := fsm.syncTreeVersion(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := fsm.syncTrees
+	cache.mutex.Lock()
+	cached, ok := cache.entries[bucketID]
+	cache.mutex.Unlock()
+
+	if ok && cached.version == version {
+		return cached.tree, nil
+	}
+
+	files, err := fsm.listFilesWithHashes(ctx, bucketID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tree := merkle.Build(util.Map(files, func(f FileInfo) merkle.File {
+		return merkle.File{Path: f.Path, Hash: f.Hash, Size: f.Size}
+	}))
+
+	cache.mutex.Lock()
+	if len(cache.entries) >= maxCachedSyncTrees {
+		for key := range cache.entries {
+			delete(cache.entries, key)
+			break
+		}
+	}
+	cache.entries[bucketID] = cachedSyncTree{version: version, tree: tree}
+	cache.mutex.Unlock()
+
+	return tree, nil
+}
@@ -0,0 +1,251 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/diff"
+	memoryQueue "github.com/metorial/metorial/services/code-bucket/pkg/memory-queue"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	MergeResolutionOurs     = "ours"
+	MergeResolutionTheirs   = "theirs"
+	MergeResolutionMerged   = "merged"
+	MergeResolutionConflict = "conflict"
+
+	ConflictTypeContent      = "content"
+	ConflictTypeModifyDelete = "modify_delete"
+	ConflictTypeBinary       = "binary"
+)
+
+type MergeOptions struct {
+	BaseBucketID   string
+	OursBucketID   string
+	TheirsBucketID string
+
+	// TargetBucketID receives the merge result and defaults to OursBucketID
+	TargetBucketID string
+
+	// ConflictMarkers writes conflicting files with git-style markers.
+	// Otherwise they are written with our side of every conflict.
+	ConflictMarkers bool
+}
+
+type ConflictHunk struct {
+	BaseStart   int    `json:"base_start"`
+	OursStart   int    `json:"ours_start"`
+	TheirsStart int    `json:"theirs_start"`
+	Base        string `json:"base"`
+	Ours        string `json:"ours"`
+	Theirs      string `json:"theirs"`
+}
+
+type MergeFileResult struct {
+	Path         string         `json:"path"`
+	Resolution   string         `json:"resolution"`
+	Deleted      bool           `json:"deleted"`
+	ConflictType string         `json:"conflict_type,omitempty"`
+	Hunks        []ConflictHunk `json:"hunks,omitempty"`
+}
+
+type MergeResult struct {
+	HasConflicts bool              `json:"has_conflicts"`
+	Files        []MergeFileResult `json:"files"`
+}
+
+type mergeSide struct {
+	exists      bool
+	content     []byte
+	contentType string
+}
+
+func (s *mergeSide) equal(other *mergeSide) bool {
+	return s.exists == other.exists && bytes.Equal(s.content, other.content)
+}
+
+// MergeBuckets combines the changes made in ours and theirs since base, file
+// by file. Only files changed on at least one side are reported. The target
+// bucket ends up containing exactly the merge result.
+func (fsm *FileSystemManager) MergeBuckets(ctx context.Context, opts MergeOptions) (*MergeResult, error) {
+	if opts.TargetBucketID == "" {
+		opts.TargetBucketID = opts.OursBucketID
+	}
+
+	listings := make([]map[string]FileInfo, 4)
+	for i, bucketID := range []string{opts.BaseBucketID, opts.OursBucketID, opts.TheirsBucketID, opts.TargetBucketID} {
+		files, err := fsm.listRelativeFiles(ctx, bucketID, "")
+		if err != nil {
+			return nil, err
+		}
+		listings[i] = files
+	}
+
+	baseFiles, oursFiles, theirsFiles, targetFiles := listings[0], listings[1], listings[2], listings[3]
+
+	pathSet := make(map[string]bool)
+	for _, files := range listings {
+		for p := range files {
+			pathSet[p] = true
+		}
+	}
+
+	paths := make([]string, 0, len(pathSet))
+	for p := range pathSet {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var mutex sync.Mutex
+	results := make(map[string]*MergeFileResult)
+
+	queue := memoryQueue.NewBlockingJobQueue(15)
+
+	for _, filePath := range paths {
+		p := filePath
+		queue.AddAndBlockIfFull(func() error {
+			load := func(bucketID string, files map[string]FileInfo) (*mergeSide, error) {
+				if _, ok := files[p]; !ok {
+					return &mergeSide{}, nil
+				}
+
+				info, data, err := fsm.GetBucketFile(ctx, bucketID, p)
+				if err != nil {
+					return nil, err
+				}
+
+				return &mergeSide{exists: true, content: data.Content, contentType: info.ContentType}, nil
+			}
+
+			base, err := load(opts.BaseBucketID, baseFiles)
+			if err != nil {
+				return err
+			}
+			ours, err := load(opts.OursBucketID, oursFiles)
+			if err != nil {
+				return err
+			}
+			theirs, err := load(opts.TheirsBucketID, theirsFiles)
+			if err != nil {
+				return err
+			}
+			target, err := load(opts.TargetBucketID, targetFiles)
+			if err != nil {
+				return err
+			}
+
+			merged, result := mergeFile(p, base, ours, theirs, opts.ConflictMarkers)
+
+			if !merged.equal(target) {
+				if merged.exists {
+					err = fsm.PutBucketFile(ctx, opts.TargetBucketID, p, merged.content, merged.contentType)
+				} else {
+					err = fsm.DeleteBucketFile(ctx, opts.TargetBucketID, p)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if result != nil {
+				mutex.Lock()
+				results[p] = result
+				mutex.Unlock()
+			}
+
+			return nil
+		})
+	}
+
+	if err := queue.Wait(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to merge buckets: %v", err)
+	}
+
+	mergeResult := &MergeResult{Files: make([]MergeFileResult, 0, len(results))}
+	for _, p := range paths {
+		if result, ok := results[p]; ok {
+			mergeResult.Files = append(mergeResult.Files, *result)
+			mergeResult.HasConflicts = mergeResult.HasConflicts || result.Resolution == MergeResolutionConflict
+		}
+	}
+
+	return mergeResult, nil
+}
+
+// mergeFile decides the merged state of a single file. The returned result is
+// nil if neither side changed the file.
+func mergeFile(filePath string, base, ours, theirs *mergeSide, conflictMarkers bool) (*mergeSide, *MergeFileResult) {
+	switch {
+	case ours.equal(theirs):
+		if ours.equal(base) {
+			return ours, nil
+		}
+		return ours, &MergeFileResult{Path: filePath, Resolution: MergeResolutionOurs, Deleted: !ours.exists}
+	case ours.equal(base):
+		return theirs, &MergeFileResult{Path: filePath, Resolution: MergeResolutionTheirs, Deleted: !theirs.exists}
+	case theirs.equal(base):
+		return ours, &MergeFileResult{Path: filePath, Resolution: MergeResolutionOurs, Deleted: !ours.exists}
+	}
+
+	result := &MergeFileResult{Path: filePath, Resolution: MergeResolutionConflict}
+
+	// One side deleted the file the other one changed, keep the changes
+	if !ours.exists || !theirs.exists {
+		result.ConflictType = ConflictTypeModifyDelete
+		return util.Ternary(ours.exists, ours, theirs), result
+	}
+
+	if util.IsBinary(base.content) || util.IsBinary(ours.content) || util.IsBinary(theirs.content) {
+		result.ConflictType = ConflictTypeBinary
+		return ours, result
+	}
+
+	// Files added on both sides are merged against an empty base
+	chunks := diff.Merge3(
+		diff.SplitLines(string(base.content)),
+		diff.SplitLines(string(ours.content)),
+		diff.SplitLines(string(theirs.content)),
+	)
+
+	merged := &mergeSide{exists: true, contentType: ours.contentType}
+
+	if !diff.HasConflicts(chunks) {
+		merged.content = []byte(diff.FormatMerge(chunks, diff.ConflictLabels{}))
+		result.Resolution = MergeResolutionMerged
+		return merged, result
+	}
+
+	result.ConflictType = ConflictTypeContent
+	for _, chunk := range chunks {
+		if !chunk.Conflict {
+			continue
+		}
+
+		result.Hunks = append(result.Hunks, ConflictHunk{
+			BaseStart:   chunk.BaseStart,
+			OursStart:   chunk.OursStart,
+			TheirsStart: chunk.TheirsStart,
+			Base:        strings.Join(chunk.Base, ""),
+			Ours:        strings.Join(chunk.Ours, ""),
+			Theirs:      strings.Join(chunk.Theirs, ""),
+		})
+	}
+
+	if conflictMarkers {
+		merged.content = []byte(diff.FormatMerge(chunks, diff.ConflictLabels{
+			Ours:   "ours",
+			Base:   "base",
+			Theirs: "theirs",
+		}))
+	} else {
+		merged.content = ours.content
+	}
+
+	return merged, result
+}
@@ -0,0 +1,76 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	objectstorage "github.com/metorial/object-storage/clients/go"
+)
+
+// Bucket metadata lives next to the bucket contents in object storage and is
+// cached in redis. Missing documents are cached as empty values so lookups on
+// every read stay cheap.
+
+func metadataObjectKey(bucketID, name string) string {
+	return fmt.Sprintf("meta/%s/%s.json", bucketID, name)
+}
+
+func metadataRedisKey(bucketID, name string) string {
+	return fmt.Sprintf("meta:%s:%s", bucketID, name)
+}
+
+func (fsm *FileSystemManager) loadMetadata(ctx context.Context, bucketID, name string, v any) (bool, error) {
+	redisKey := metadataRedisKey(bucketID, name)
+
+	result, err := fsm.redis.Get(ctx, redisKey).Result()
+	if err == nil {
+		if result == "" {
+			return false, nil
+		}
+		return true, json.Unmarshal([]byte(result), v)
+	}
+
+	obj, err := fsm.objectStorage.GetObject(fsm.bucketName, metadataObjectKey(bucketID, name))
+	if err != nil {
+		if isObjectNotFound(err) {
+			fsm.redis.Set(ctx, redisKey, "", redisFlushDelay*2)
+			return false, nil
+		}
+		return false, err
+	}
+
+	fsm.redis.Set(ctx, redisKey, obj.Data, redisFlushDelay*2)
+
+	return true, json.Unmarshal(obj.Data, v)
+}
+
+func (fsm *FileSystemManager) storeMetadata(ctx context.Context, bucketID, name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	contentType := "application/json"
+	if _, err := fsm.objectStorage.PutObject(fsm.bucketName, metadataObjectKey(bucketID, name), data, &contentType, nil); err != nil {
+		return err
+	}
+
+	return fsm.redis.Set(ctx, metadataRedisKey(bucketID, name), data, redisFlushDelay*2).Err()
+}
+
+func (fsm *FileSystemManager) deleteMetadata(ctx context.Context, bucketID, name string) error {
+	err := fsm.objectStorage.DeleteObject(fsm.bucketName, metadataObjectKey(bucketID, name))
+	if err != nil && !isObjectNotFound(err) {
+		return err
+	}
+
+	return fsm.redis.Set(ctx, metadataRedisKey(bucketID, name), "", redisFlushDelay*2).Err()
+}
+
+func isObjectNotFound(err error) bool {
+	var storageErr *objectstorage.Error
+	return errors.As(err, &storageErr) && storageErr.StatusCode == http.StatusNotFound
+}
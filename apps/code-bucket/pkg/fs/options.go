@@ -1,10 +1,17 @@
 package fs
 
+// DefaultMaxFileSize matches the default limit on request bodies
+const DefaultMaxFileSize = 64 * 1024 * 1024
+
 type FileSystemManagerOptions struct {
 	RedisURL string
 
 	ObjectStorageEndpoint string
 	ObjectStorageBucket   string
+
+	// MaxFileSize limits the files the server builds itself, from deltas and
+	// imported archives or repositories. Defaults to DefaultMaxFileSize.
+	MaxFileSize int64
 }
 
 type FileSystemManagerOption func(*FileSystemManagerOptions)
@@ -26,3 +33,9 @@ func WithObjectStorageBucket(bucket string) FileSystemManagerOption {
 		opts.ObjectStorageBucket = bucket
 	}
 }
+
+func WithMaxFileSize(maxFileSize int64) FileSystemManagerOption {
+	return func(opts *FileSystemManagerOptions) {
+		opts.MaxFileSize = maxFileSize
+	}
+}
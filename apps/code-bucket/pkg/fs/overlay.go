@@ -0,0 +1,279 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/audit"
+	memoryQueue "github.com/metorial/metorial/services/code-bucket/pkg/memory-queue"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ChangeTypeAdded    = "added"
+	ChangeTypeModified = "modified"
+	ChangeTypeDeleted  = "deleted"
+)
+
+// An overlay bucket stores only the paths written to it. Everything else is
+// read from its base bucket, except for paths deleted in the overlay.
+type overlayState struct {
+	BaseBucketID string   `json:"base_bucket_id"`
+	Deleted      []string `json:"deleted"`
+}
+
+type OverlayChange struct {
+	Path       string    `json:"path"`
+	ChangeType string    `json:"change_type"`
+	FileInfo   *FileInfo `json:"file_info,omitempty"`
+}
+
+func (o *overlayState) isDeleted(filePath string) bool {
+	return slices.Contains(o.Deleted, filePath)
+}
+
+func (fsm *FileSystemManager) getOverlay(ctx context.Context, bucketID string) (*overlayState, error) {
+	var overlay overlayState
+	found, err := fsm.loadMetadata(ctx, bucketID, "overlay", &overlay)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &overlay, nil
+}
+
+func (fsm *FileSystemManager) updateOverlay(ctx context.Context, bucketID string, update func(overlay *overlayState) bool) error {
+	lockKey := fmt.Sprintf("lock:overlay:%s", bucketID)
+
+	return fsm.withLock(ctx, lockKey, func() error {
+		overlay, err := fsm.getOverlay(ctx, bucketID)
+		if err != nil || overlay == nil {
+			return err
+		}
+
+		if !update(overlay) {
+			return nil
+		}
+
+		if err := fsm.storeMetadata(ctx, bucketID, "overlay", overlay); err != nil {
+			return err
+		}
+		return fsm.bumpSyncTreeVersion(ctx, bucketID)
+	})
+}
+
+func (fsm *FileSystemManager) markOverlayDeletion(ctx context.Context, bucketID, filePath string) error {
+	return fsm.updateOverlay(ctx, bucketID, func(overlay *overlayState) bool {
+		if overlay.isDeleted(filePath) {
+			return false
+		}
+
+		overlay.Deleted = append(overlay.Deleted, filePath)
+		return true
+	})
+}
+
+func (fsm *FileSystemManager) clearOverlayDeletion(ctx context.Context, bucketID, filePath string) error {
+	overlay, err := fsm.getOverlay(ctx, bucketID)
+	if err != nil {
+		return err
+	}
+	if overlay == nil || !overlay.isDeleted(filePath) {
+		return nil
+	}
+
+	return fsm.updateOverlay(ctx, bucketID, func(overlay *overlayState) bool {
+		index := slices.Index(overlay.Deleted, filePath)
+		if index < 0 {
+			return false
+		}
+
+		overlay.Deleted = slices.Delete(overlay.Deleted, index, index+1)
+		return true
+	})
+}
+
+func (fsm *FileSystemManager) CreateOverlay(ctx context.Context, baseBucketID, newBucketID string) error {
+	if baseBucketID == newBucketID {
+		return status.Errorf(codes.InvalidArgument, "overlay and base bucket must differ")
+	}
+
+	// Overlays may be stacked, but never on top of themselves
+	for bucketID := baseBucketID; bucketID != ""; {
+		overlay, err := fsm.getOverlay(ctx, bucketID)
+		if err != nil {
+			return err
+		}
+		if overlay == nil {
+			break
+		}
+		if overlay.BaseBucketID == newBucketID {
+			return status.Errorf(codes.InvalidArgument, "overlay would create a cycle")
+		}
+		bucketID = overlay.BaseBucketID
+	}
+
+	existing, err := fsm.getOverlay(ctx, newBucketID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return status.Errorf(codes.AlreadyExists, "bucket is already an overlay")
+	}
+
+	err = fsm.storeMetadata(ctx, newBucketID, "overlay", &overlayState{
+		BaseBucketID: baseBucketID,
+		Deleted:      []string{},
+	})
+	if err != nil {
+		return err
+	}
+
+	return fsm.bumpSyncTreeVersion(ctx, newBucketID)
+}
+
+func (fsm *FileSystemManager) GetOverlayChanges(ctx context.Context, bucketID string) ([]OverlayChange, error) {
+	overlay, err := fsm.getOverlay(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+	if overlay == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "bucket is not an overlay")
+	}
+
+	ownFiles, err := fsm.listOwnBucketFiles(ctx, bucketID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	baseFiles, err := fsm.GetBucketFiles(ctx, overlay.BaseBucketID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	inBase := make(map[string]bool, len(baseFiles))
+	for _, f := range baseFiles {
+		inBase[f.Path] = true
+	}
+
+	changes := make([]OverlayChange, 0, len(ownFiles)+len(overlay.Deleted))
+	for _, f := range ownFiles {
+		info := f
+		changeType := ChangeTypeAdded
+		if inBase[f.Path] {
+			changeType = ChangeTypeModified
+		}
+
+		changes = append(changes, OverlayChange{
+			Path:       f.Path,
+			ChangeType: changeType,
+			FileInfo:   &info,
+		})
+	}
+
+	for _, filePath := range overlay.Deleted {
+		changes = append(changes, OverlayChange{
+			Path:       filePath,
+			ChangeType: ChangeTypeDeleted,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, nil
+}
+
+// DiscardOverlay drops everything written to the overlay, so it presents the
+// base bucket unchanged again.
+func (fsm *FileSystemManager) DiscardOverlay(ctx context.Context, bucketID string) error {
+	overlay, err := fsm.getOverlay(ctx, bucketID)
+	if err != nil {
+		return err
+	}
+	if overlay == nil {
+		return status.Errorf(codes.FailedPrecondition, "bucket is not an overlay")
+	}
+
+	ownFiles, err := fsm.listOwnBucketFiles(ctx, bucketID, "")
+	if err != nil {
+		return err
+	}
+
+	queue := memoryQueue.NewBlockingJobQueue(15)
+
+	for _, file := range ownFiles {
+		f := file
+		queue.AddAndBlockIfFull(func() error {
+			return fsm.deleteOwnBucketFile(ctx, bucketID, f.Path)
+		})
+	}
+
+	if err := queue.Wait(); err != nil {
+		return err
+	}
+
+	err = fsm.updateOverlay(ctx, bucketID, func(overlay *overlayState) bool {
+		overlay.Deleted = []string{}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	fsm.recordAuditEntry(ctx, bucketID, &audit.Entry{Operation: AuditOperationDiscardOverlay, Path: "/"})
+
+	return nil
+}
+
+// CommitOverlay writes the overlay's changes into its base bucket and resets
+// the overlay.
+func (fsm *FileSystemManager) CommitOverlay(ctx context.Context, bucketID string) error {
+	overlay, err := fsm.getOverlay(ctx, bucketID)
+	if err != nil {
+		return err
+	}
+	if overlay == nil {
+		return status.Errorf(codes.FailedPrecondition, "bucket is not an overlay")
+	}
+
+	ownFiles, err := fsm.listOwnBucketFiles(ctx, bucketID, "")
+	if err != nil {
+		return err
+	}
+
+	queue := memoryQueue.NewBlockingJobQueue(15)
+
+	for _, file := range ownFiles {
+		f := file
+		queue.AddAndBlockIfFull(func() error {
+			info, content, err := fsm.getOwnBucketFile(ctx, bucketID, f.Path)
+			if err != nil {
+				return err
+			}
+
+			return fsm.PutBucketFile(ctx, overlay.BaseBucketID, f.Path, content.Content, info.ContentType)
+		})
+	}
+
+	for _, filePath := range overlay.Deleted {
+		p := filePath
+		queue.AddAndBlockIfFull(func() error {
+			err := fsm.DeleteBucketFile(ctx, overlay.BaseBucketID, p)
+			if err != nil && err.Error() != "file not found" {
+				return err
+			}
+			return nil
+		})
+	}
+
+	if err := queue.Wait(); err != nil {
+		return err
+	}
+
+	return fsm.DiscardOverlay(ctx, bucketID)
+}
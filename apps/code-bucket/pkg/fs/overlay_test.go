@@ -0,0 +1,256 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func putFiles(t *testing.T, fsm *FileSystemManager, bucketID string, files map[string]string) {
+	for filePath, content := range files {
+		if err := fsm.PutBucketFile(context.Background(), bucketID, filePath, []byte(content), "text/plain"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// bucketContents reads every file of a bucket by path
+func bucketContents(t *testing.T, fsm *FileSystemManager, bucketID string) map[string]string {
+	ctx := context.Background()
+
+	files, err := fsm.GetBucketFiles(ctx, bucketID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents := make(map[string]string, len(files))
+	for _, f := range files {
+		_, data, err := fsm.GetBucketFile(ctx, bucketID, f.Path)
+		if err != nil {
+			t.Fatalf("%s: %v", f.Path, err)
+		}
+		contents[f.Path] = string(data.Content)
+	}
+	return contents
+}
+
+func assertContents(t *testing.T, name string, got, want map[string]string) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("%s: got %v, want %v", name, got, want)
+	}
+}
+
+// newTestOverlay creates an overlay on a base bucket with two files
+func newTestOverlay(t *testing.T) (*FileSystemManager, *fakeObjectStorage) {
+	fsm, storage := newTestManager(t)
+	putFiles(t, fsm, "base", map[string]string{"/a.txt": "base a", "/b.txt": "base b"})
+
+	if err := fsm.CreateOverlay(context.Background(), "base", "overlay"); err != nil {
+		t.Fatal(err)
+	}
+	return fsm, storage
+}
+
+func TestOverlay_ReadsThroughToBase(t *testing.T) {
+	fsm, _ := newTestOverlay(t)
+
+	assertContents(t, "overlay", bucketContents(t, fsm, "overlay"), map[string]string{"/a.txt": "base a", "/b.txt": "base b"})
+
+	putFiles(t, fsm, "overlay", map[string]string{"/a.txt": "overlay a", "/c.txt": "overlay c"})
+
+	assertContents(t, "overlay", bucketContents(t, fsm, "overlay"), map[string]string{"/a.txt": "overlay a", "/b.txt": "base b", "/c.txt": "overlay c"})
+	assertContents(t, "base", bucketContents(t, fsm, "base"), map[string]string{"/a.txt": "base a", "/b.txt": "base b"})
+}
+
+func TestOverlay_DeletionHidesBaseFile(t *testing.T) {
+	fsm, _ := newTestOverlay(t)
+	ctx := context.Background()
+
+	if err := fsm.DeleteBucketFile(ctx, "overlay", "/b.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := fsm.GetBucketFile(ctx, "overlay", "/b.txt"); err == nil || err.Error() != "file not found" {
+		t.Errorf("deleted file is still served: %v", err)
+	}
+	assertContents(t, "overlay", bucketContents(t, fsm, "overlay"), map[string]string{"/a.txt": "base a"})
+	assertContents(t, "base", bucketContents(t, fsm, "base"), map[string]string{"/a.txt": "base a", "/b.txt": "base b"})
+
+	if err := fsm.DeleteBucketFile(ctx, "overlay", "/b.txt"); err == nil {
+		t.Error("deleting a deleted file succeeded")
+	}
+
+	// Writing the path again lifts the deletion
+	putFiles(t, fsm, "overlay", map[string]string{"/b.txt": "overlay b"})
+	assertContents(t, "overlay", bucketContents(t, fsm, "overlay"), map[string]string{"/a.txt": "base a", "/b.txt": "overlay b"})
+
+	overlay, err := fsm.getOverlay(ctx, "overlay")
+	if err != nil || overlay.isDeleted("/b.txt") {
+		t.Errorf("deletion is still recorded: %+v, %v", overlay, err)
+	}
+}
+
+func TestOverlay_Changes(t *testing.T) {
+	fsm, _ := newTestOverlay(t)
+	ctx := context.Background()
+
+	putFiles(t, fsm, "overlay", map[string]string{"/a.txt": "overlay a", "/c.txt": "overlay c"})
+	if err := fsm.DeleteBucketFile(ctx, "overlay", "/b.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := fsm.GetOverlayChanges(ctx, "overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Path+" "+change.ChangeType)
+	}
+	want := []string{"/a.txt modified", "/b.txt deleted", "/c.txt added"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got changes %v, want %v", got, want)
+	}
+
+	if _, err := fsm.GetOverlayChanges(ctx, "base"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for a bucket that isn't an overlay, got %v", err)
+	}
+}
+
+func TestOverlay_Discard(t *testing.T) {
+	fsm, _ := newTestOverlay(t)
+	ctx := context.Background()
+
+	putFiles(t, fsm, "overlay", map[string]string{"/a.txt": "overlay a", "/c.txt": "overlay c"})
+	if err := fsm.DeleteBucketFile(ctx, "overlay", "/b.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fsm.DiscardOverlay(ctx, "overlay"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertContents(t, "overlay", bucketContents(t, fsm, "overlay"), map[string]string{"/a.txt": "base a", "/b.txt": "base b"})
+	if changes, err := fsm.GetOverlayChanges(ctx, "overlay"); err != nil || len(changes) != 0 {
+		t.Errorf("changes left after discarding: %v, %v", changes, err)
+	}
+}
+
+func TestOverlay_Commit(t *testing.T) {
+	fsm, _ := newTestOverlay(t)
+	ctx := context.Background()
+
+	putFiles(t, fsm, "overlay", map[string]string{"/a.txt": "overlay a", "/c.txt": "overlay c"})
+	if err := fsm.DeleteBucketFile(ctx, "overlay", "/b.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fsm.CommitOverlay(ctx, "overlay"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"/a.txt": "overlay a", "/c.txt": "overlay c"}
+	assertContents(t, "base", bucketContents(t, fsm, "base"), want)
+	assertContents(t, "overlay", bucketContents(t, fsm, "overlay"), want)
+	if changes, err := fsm.GetOverlayChanges(ctx, "overlay"); err != nil || len(changes) != 0 {
+		t.Errorf("changes left after committing: %v, %v", changes, err)
+	}
+}
+
+func TestOverlay_Stacked(t *testing.T) {
+	fsm, _ := newTestOverlay(t)
+	ctx := context.Background()
+
+	if err := fsm.CreateOverlay(ctx, "overlay", "top"); err != nil {
+		t.Fatal(err)
+	}
+	putFiles(t, fsm, "overlay", map[string]string{"/c.txt": "overlay c"})
+	if err := fsm.DeleteBucketFile(ctx, "top", "/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertContents(t, "top", bucketContents(t, fsm, "top"), map[string]string{"/b.txt": "base b", "/c.txt": "overlay c"})
+	assertContents(t, "overlay", bucketContents(t, fsm, "overlay"), map[string]string{"/a.txt": "base a", "/b.txt": "base b", "/c.txt": "overlay c"})
+}
+
+func TestCreateOverlay_Invalid(t *testing.T) {
+	fsm, _ := newTestOverlay(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		base, overlay string
+		code          codes.Code
+	}{
+		{"base", "base", codes.InvalidArgument},
+		{"overlay", "base", codes.InvalidArgument},
+		{"base", "overlay", codes.AlreadyExists},
+	}
+
+	for _, c := range cases {
+		if err := fsm.CreateOverlay(ctx, c.base, c.overlay); status.Code(err) != c.code {
+			t.Errorf("CreateOverlay(%s, %s): expected %v, got %v", c.base, c.overlay, c.code, err)
+		}
+	}
+}
+
+func TestGetBucketFile_OverlayErrorsAreNotReplacedByBase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("corrupt", func(t *testing.T) {
+		fsm, _ := newTestOverlay(t)
+
+		data, _ := json.Marshal(FileData{Content: []byte("overlay a"), Hash: hashContent([]byte("something else"))})
+		if err := fsm.redis.Set(ctx, "bucket:overlay:file:/a.txt", data, 0).Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := fsm.GetBucketFile(ctx, "overlay", "/a.txt"); status.Code(err) != codes.DataLoss {
+			t.Errorf("expected DataLoss, got %v", err)
+		}
+	})
+
+	t.Run("storage unavailable", func(t *testing.T) {
+		fsm, storage := newTestOverlay(t)
+
+		// The base file is cached in redis, the overlay has to ask the storage
+		storage.setFailing(true)
+
+		_, data, err := fsm.GetBucketFile(ctx, "overlay", "/a.txt")
+		if err == nil || err.Error() == "file not found" {
+			t.Errorf("expected the storage error, got %v and %q", err, data)
+		}
+	})
+}
+
+func TestMetadata_RoundTrip(t *testing.T) {
+	fsm, _ := newTestManager(t)
+	ctx := context.Background()
+
+	var v map[string]string
+	if found, err := fsm.loadMetadata(ctx, "bucket", "settings", &v); found || err != nil {
+		t.Fatalf("missing metadata: %v, %v", found, err)
+	}
+
+	if err := fsm.storeMetadata(ctx, "bucket", "settings", map[string]string{"a": "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the cached copy it is read from the object storage
+	fsm.redis.Del(ctx, metadataRedisKey("bucket", "settings"))
+	if found, err := fsm.loadMetadata(ctx, "bucket", "settings", &v); !found || err != nil || v["a"] != "b" {
+		t.Fatalf("got %v, %v, %v", v, found, err)
+	}
+
+	if err := fsm.deleteMetadata(ctx, "bucket", "settings"); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := fsm.loadMetadata(ctx, "bucket", "settings", &v); found || err != nil {
+		t.Errorf("deleted metadata: %v, %v", found, err)
+	}
+}
@@ -0,0 +1,312 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/access"
+	memoryQueue "github.com/metorial/metorial/services/code-bucket/pkg/memory-queue"
+	"github.com/metorial/metorial/services/code-bucket/pkg/patch"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const ChangeTypeRenamed = "renamed"
+
+type PatchOptions struct {
+	DryRun bool
+	Strict bool
+
+	// MaxFuzz defaults to patch.DefaultMaxFuzz, negative values disable fuzz
+	MaxFuzz int
+
+	// PathPrefix is joined with every path named in the patch. Paths are
+	// stored as they were written, so the prefix decides whether the patched
+	// paths have a leading slash: "/" for files written over HTTP, empty for
+	// imported files.
+	PathPrefix string
+
+	// Authorize is called for every path the patch reads, and unless it is a
+	// dry run for every path it writes or deletes, before anything is
+	// written. Its error is returned as PermissionDenied.
+	Authorize func(operation, filePath string) error
+}
+
+func (opts *PatchOptions) authorize(operation, filePath string) error {
+	if opts.Authorize == nil {
+		return nil
+	}
+	if err := opts.Authorize(operation, filePath); err != nil {
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+	return nil
+}
+
+type PatchFileResult struct {
+	Path       string             `json:"path"`
+	OldPath    string             `json:"old_path,omitempty"`
+	ChangeType string             `json:"change_type"`
+	Hunks      []patch.HunkResult `json:"hunks"`
+	Error      string             `json:"error,omitempty"`
+}
+
+type PatchResult struct {
+	Applied bool              `json:"applied"`
+	Files   []PatchFileResult `json:"files"`
+}
+
+type patchedFile struct {
+	content     string
+	contentType string
+	exists      bool
+	changed     bool
+
+	// State as loaded, restored if writing the patch fails part way
+	original       string
+	originalExists bool
+}
+
+// ApplyPatch applies a unified diff or git patch to a bucket. Every file is
+// patched in memory first and nothing is written unless all of them apply. If
+// a write fails the files already written are restored to their old content.
+func (fsm *FileSystemManager) ApplyPatch(ctx context.Context, bucketID, patchText string, opts PatchOptions) (*PatchResult, error) {
+	filePatches, err := patch.Parse(patchText)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse patch: %v", err)
+	}
+	if len(filePatches) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "patch contains no file changes")
+	}
+	if util.HasDotDot(opts.PathPrefix) {
+		return nil, status.Errorf(codes.InvalidArgument, "path prefix %s must not contain .. segments", opts.PathPrefix)
+	}
+	for _, fp := range filePatches {
+		for _, filePath := range []string{fp.OldPath, fp.NewPath} {
+			if util.HasDotDot(filePath) {
+				return nil, status.Errorf(codes.InvalidArgument, "patch path %s must not contain .. segments", filePath)
+			}
+		}
+	}
+
+	if opts.MaxFuzz == 0 {
+		opts.MaxFuzz = patch.DefaultMaxFuzz
+	}
+	opts.MaxFuzz = max(opts.MaxFuzz, 0)
+
+	// Paths in the patch are relative to the prefix, the working set is keyed
+	// by the bucket path they resolve to
+	bucketPath := func(filePath string) string {
+		return path.Join(opts.PathPrefix, filePath)
+	}
+
+	files := make(map[string]*patchedFile)
+	var touched []string
+
+	load := func(filePath string) (*patchedFile, error) {
+		filePath = bucketPath(filePath)
+		if f, ok := files[filePath]; ok {
+			return f, nil
+		}
+
+		if err := opts.authorize(access.OperationRead, filePath); err != nil {
+			return nil, err
+		}
+
+		f := &patchedFile{contentType: "application/octet-stream"}
+		info, data, err := fsm.GetBucketFile(ctx, bucketID, filePath)
+		if err != nil && err.Error() != "file not found" {
+			return nil, err
+		}
+		if err == nil {
+			f.content = string(data.Content)
+			f.contentType = info.ContentType
+			f.exists = true
+		}
+		f.original, f.originalExists = f.content, f.exists
+
+		files[filePath] = f
+		touched = append(touched, filePath)
+
+		return f, nil
+	}
+
+	result := &PatchResult{Applied: true}
+
+	for _, fp := range filePatches {
+		fileResult := PatchFileResult{
+			Path:       util.Ternary(fp.IsDelete, fp.OldPath, fp.NewPath),
+			ChangeType: ChangeTypeModified,
+		}
+
+		switch {
+		case fp.IsNew:
+			fileResult.ChangeType = ChangeTypeAdded
+		case fp.IsDelete:
+			fileResult.ChangeType = ChangeTypeDeleted
+		case fp.IsRename:
+			fileResult.ChangeType = ChangeTypeRenamed
+			fileResult.OldPath = fp.OldPath
+		case fp.IsCopy:
+			fileResult.ChangeType = ChangeTypeAdded
+			fileResult.OldPath = fp.OldPath
+		}
+
+		if errMessage, err := fsm.applyFilePatch(fp, opts, load, &fileResult); err != nil {
+			return nil, err
+		} else if errMessage != "" {
+			fileResult.Error = errMessage
+			result.Applied = false
+		}
+
+		result.Files = append(result.Files, fileResult)
+	}
+
+	if !result.Applied || opts.DryRun {
+		return result, nil
+	}
+
+	var changed []string
+	for _, filePath := range touched {
+		f := files[filePath]
+		if !f.changed {
+			continue
+		}
+
+		operation := util.Ternary(f.exists, access.OperationWrite, access.OperationDelete)
+		if err := opts.authorize(operation, filePath); err != nil {
+			return nil, err
+		}
+		changed = append(changed, filePath)
+	}
+
+	written, err := fsm.writePatchedFiles(ctx, bucketID, changed, files, false)
+	if err == nil {
+		return result, nil
+	}
+
+	// Put back what was written so the bucket isn't left half patched
+	var restore []string
+	for _, filePath := range changed {
+		if written[filePath] {
+			restore = append(restore, filePath)
+		}
+	}
+	restored, restoreErr := fsm.writePatchedFiles(ctx, bucketID, restore, files, true)
+	if restoreErr != nil {
+		var patched []string
+		for _, filePath := range restore {
+			if !restored[filePath] {
+				patched = append(patched, filePath)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to write patched files: %v, and failed to restore %s: %v", err, strings.Join(patched, ", "), restoreErr)
+	}
+
+	return nil, status.Errorf(codes.Internal, "failed to write patched files, no changes were made: %v", err)
+}
+
+// writePatchedFiles writes the new content of the files, or their original
+// content when restoring, and returns the paths that were written.
+func (fsm *FileSystemManager) writePatchedFiles(ctx context.Context, bucketID string, paths []string, files map[string]*patchedFile, restore bool) (map[string]bool, error) {
+	var mu sync.Mutex
+	written := make(map[string]bool, len(paths))
+
+	queue := memoryQueue.NewBlockingJobQueue(15)
+	defer queue.Stop()
+
+	for _, filePath := range paths {
+		p := filePath
+		f := files[p]
+
+		content, exists := f.content, f.exists
+		if restore {
+			content, exists = f.original, f.originalExists
+		}
+
+		queue.AddAndBlockIfFull(func() error {
+			var err error
+			if exists {
+				err = fsm.PutBucketFile(ctx, bucketID, p, []byte(content), f.contentType)
+			} else if err = fsm.DeleteBucketFile(ctx, bucketID, p); err != nil && err.Error() == "file not found" {
+				err = nil
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", p, err)
+			}
+
+			mu.Lock()
+			written[p] = true
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	return written, queue.Wait()
+}
+
+// applyFilePatch patches a single file in the working set. It returns a
+// message describing why the patch does not apply, or an error if the file
+// could not be loaded.
+func (fsm *FileSystemManager) applyFilePatch(fp *patch.FilePatch, opts PatchOptions, load func(string) (*patchedFile, error), fileResult *PatchFileResult) (string, error) {
+	if fp.IsBinary {
+		return "binary patches are not supported", nil
+	}
+
+	sourcePath := util.Ternary(fp.IsNew, fp.NewPath, fp.OldPath)
+	source, err := load(sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	if fp.IsNew && source.exists {
+		return "file already exists", nil
+	}
+	if !fp.IsNew && !source.exists {
+		return "file not found", nil
+	}
+
+	content, hunkResults, err := patch.Apply(source.content, fp, patch.ApplyOptions{
+		MaxFuzz: opts.MaxFuzz,
+		Strict:  opts.Strict,
+	})
+	fileResult.Hunks = hunkResults
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	if fp.IsDelete {
+		if content != "" {
+			return "file is not empty after removing the patched lines", nil
+		}
+
+		source.exists = false
+		source.changed = true
+		return "", nil
+	}
+
+	target := source
+	if fp.NewPath != sourcePath {
+		if target, err = load(fp.NewPath); err != nil {
+			return "", err
+		}
+		if target.exists {
+			return "target file already exists", nil
+		}
+
+		target.contentType = source.contentType
+		if fp.IsRename {
+			source.exists = false
+			source.changed = true
+		}
+	}
+
+	target.content = content
+	target.exists = true
+	target.changed = true
+
+	return "", nil
+}
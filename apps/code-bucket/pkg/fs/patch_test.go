@@ -0,0 +1,68 @@
+package fs
+
+import (
+	"context"
+	"testing"
+)
+
+const testPatch = `--- a/src/main.go
++++ b/src/main.go
+@@ -1 +1 @@
+-old
++new
+--- /dev/null
++++ b/src/added.go
+@@ -0,0 +1 @@
++added
+`
+
+func TestApplyPatch_PathConventions(t *testing.T) {
+	cases := []struct {
+		name, prefix string
+		files, want  map[string]string
+	}{
+		{
+			name:   "relative paths",
+			prefix: "",
+			files:  map[string]string{"src/main.go": "old\n"},
+			want:   map[string]string{"src/main.go": "new\n", "src/added.go": "added\n"},
+		},
+		{
+			name:   "absolute paths",
+			prefix: "/",
+			files:  map[string]string{"/src/main.go": "old\n"},
+			want:   map[string]string{"/src/main.go": "new\n", "/src/added.go": "added\n"},
+		},
+		{
+			name:   "prefix",
+			prefix: "apps/web",
+			files:  map[string]string{"apps/web/src/main.go": "old\n"},
+			want:   map[string]string{"apps/web/src/main.go": "new\n", "apps/web/src/added.go": "added\n"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fsm, _ := newTestManager(t)
+			putFiles(t, fsm, "bucket", c.files)
+
+			result, err := fsm.ApplyPatch(context.Background(), "bucket", testPatch, PatchOptions{PathPrefix: c.prefix})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Applied {
+				t.Fatalf("patch not applied: %+v", result.Files)
+			}
+
+			assertContents(t, c.name, bucketContents(t, fsm, "bucket"), c.want)
+		})
+	}
+}
+
+func TestApplyPatch_PrefixWithDotDot(t *testing.T) {
+	fsm, _ := newTestManager(t)
+
+	if _, err := fsm.ApplyPatch(context.Background(), "bucket", testPatch, PatchOptions{PathPrefix: "../other"}); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,82 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Rate limits are token buckets kept in redis, so every instance draws from
+// the same budget. A bucket refills at rate tokens per second up to burst,
+// every request takes one.
+
+type RateLimit struct {
+	Rate  float64 // Tokens per second, 0 disables the limit
+	Burst int
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+func rateLimitKey(scope, id string) string {
+	return fmt.Sprintf("ratelimit:%s:%s", scope, id)
+}
+
+// Refills the bucket for the time since the last request and takes a token.
+// Uses the clock of redis so instances with skewed clocks agree. Returns
+// whether the request is allowed and, if not, the seconds until it would be.
+var takeRateLimitTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "time")
+local tokens = tonumber(state[1]) or burst
+local last = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - last) * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = (1 - tokens) / rate
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "time", tostring(now))
+redis.call("EXPIRE", KEYS[1], math.ceil(burst / rate) + 1)
+
+return {allowed, tostring(wait)}
+`)
+
+// TakeRateLimitToken takes a token from the bucket of the given scope and id,
+// e.g. "token" and the token id. If the bucket is empty it returns false and
+// how long to wait before retrying.
+func (fsm *FileSystemManager) TakeRateLimitToken(ctx context.Context, scope, id string, limit RateLimit) (bool, time.Duration, error) {
+	if !limit.Enabled() {
+		return true, 0, nil
+	}
+
+	result, err := takeRateLimitTokenScript.Run(ctx, fsm.redis, []string{rateLimitKey(scope, id)}, limit.Rate, limit.Burst).Slice()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if allowed, _ := result[0].(int64); allowed == 1 {
+		return true, 0, nil
+	}
+
+	waitStr, _ := result[1].(string)
+	wait, _ := strconv.ParseFloat(waitStr, 64)
+
+	return false, time.Duration(math.Ceil(wait * float64(time.Second))), nil
+}
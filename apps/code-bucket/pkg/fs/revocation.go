@@ -0,0 +1,80 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Revoked tokens are kept in redis so every instance rejects them right away.
+// Single tokens are revoked by id until they expire, whole buckets by a cutoff
+// on the issue time. The cutoff is kept in seconds with millisecond decimals.
+
+func revokedTokenKey(tokenID string) string {
+	return fmt.Sprintf("revoked-token:%s", tokenID)
+}
+
+func revokedBeforeKey(bucketID string) string {
+	return fmt.Sprintf("revoked-tokens-before:%s", bucketID)
+}
+
+// Only ever moves the cutoff forward
+var raiseRevocationCutoff = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+if tonumber(ARGV[1]) > current then
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// RevokeToken rejects the token with the given id. The revocation is kept
+// until expiresAt, or forever if it is zero.
+func (fsm *FileSystemManager) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	var ttl time.Duration
+	if !expiresAt.IsZero() {
+		ttl = time.Until(expiresAt)
+		if ttl <= 0 {
+			return nil
+		}
+	}
+
+	return fsm.redis.Set(ctx, revokedTokenKey(tokenID), "1", ttl).Err()
+}
+
+// RevokeTokensIssuedBefore rejects every token of a bucket issued before the
+// given time.
+func (fsm *FileSystemManager) RevokeTokensIssuedBefore(ctx context.Context, bucketID string, issuedBefore time.Time) error {
+	cutoff := strconv.FormatFloat(float64(issuedBefore.UnixMilli())/1000, 'f', 3, 64)
+	return raiseRevocationCutoff.Run(ctx, fsm.redis, []string{revokedBeforeKey(bucketID)}, cutoff).Err()
+}
+
+func (fsm *FileSystemManager) IsTokenRevoked(ctx context.Context, bucketID, tokenID string, issuedAt time.Time) (bool, error) {
+	keys := []string{revokedBeforeKey(bucketID)}
+	if tokenID != "" {
+		keys = append(keys, revokedTokenKey(tokenID))
+	}
+
+	values, err := fsm.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if cutoff, ok := values[0].(string); ok && issuedBefore(issuedAt, cutoff) {
+		return true, nil
+	}
+
+	return len(values) > 1 && values[1] != nil, nil
+}
+
+// issuedBefore compares an issue time with a stored cutoff
+func issuedBefore(issuedAt time.Time, cutoff string) bool {
+	before, err := strconv.ParseFloat(cutoff, 64)
+	if err != nil {
+		return false
+	}
+	return issuedAt.UnixMilli() < int64(math.Round(before*1000))
+}
@@ -0,0 +1,26 @@
+package fs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssuedBefore(t *testing.T) {
+	cases := []struct {
+		issuedAt time.Time
+		cutoff   string
+		want     bool
+	}{
+		{time.UnixMilli(1700000000499), "1700000000.500", true},
+		{time.UnixMilli(1700000000500), "1700000000.500", false},
+		{time.UnixMilli(1700000000999), "1700000001", true},
+		{time.UnixMilli(1700000001000), "1700000001", false},
+		{time.UnixMilli(1700000000000), "invalid", false},
+	}
+
+	for _, c := range cases {
+		if got := issuedBefore(c.issuedAt, c.cutoff); got != c.want {
+			t.Errorf("issuedBefore(%d, %s): expected %v, got %v", c.issuedAt.UnixMilli(), c.cutoff, c.want, got)
+		}
+	}
+}
@@ -14,6 +14,8 @@ import (
 func (fsm *FileSystemManager) backgroundFlush() {
 	for range fsm.flushTicker.C {
 		fsm.flushPendingFiles()
+		fsm.updateSearchIndexes()
+		fsm.flushAuditLogs()
 	}
 }
 
@@ -96,6 +98,27 @@ func (fsm *FileSystemManager) releaseLock(ctx context.Context, lockKey string) {
 	fsm.redis.Del(ctx, lockKey)
 }
 
+// fileLockKey is held while a file is written or deleted, and by operations
+// that read a file and write it back
+func fileLockKey(bucketID, filePath string) string {
+	return fmt.Sprintf("lock:file:%s:%s", bucketID, filePath)
+}
+
+// withLock waits for the lock instead of giving up, for read-modify-write
+// updates that must not be skipped.
+func (fsm *FileSystemManager) withLock(ctx context.Context, lockKey string, fn func() error) error {
+	for !fsm.acquireLock(ctx, lockKey) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	defer fsm.releaseLock(ctx, lockKey)
+
+	return fn()
+}
+
 func (fsm *FileSystemManager) flushFileToStorage(ctx context.Context, bucketID, filePath string) error {
 	redisKey := fmt.Sprintf("bucket:%s:file:%s", bucketID, filePath)
 
@@ -109,8 +132,14 @@ func (fsm *FileSystemManager) flushFileToStorage(ctx context.Context, bucketID,
 		return err
 	}
 
+	hash, err := verifyContent(filePath, fileData.Content, fileData.Hash)
+	if err != nil {
+		return err
+	}
+
 	objectKey := fmt.Sprintf("%s/%s", bucketID, filePath)
-	_, err = fsm.objectStorage.PutObject(fsm.bucketName, objectKey, fileData.Content, &fileData.ContentType, nil)
+	metadata := map[string]string{hashMetadataKey: hash}
+	_, err = fsm.objectStorage.PutObject(fsm.bucketName, objectKey, fileData.Content, &fileData.ContentType, metadata)
 
 	return err
 }
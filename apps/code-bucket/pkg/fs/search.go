@@ -0,0 +1,219 @@
+package fs
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/diff"
+	"github.com/metorial/metorial/services/code-bucket/pkg/glob"
+	"github.com/metorial/metorial/services/code-bucket/pkg/trigram"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultSearchMaxResults  = 1000
+	defaultSearchMaxFileSize = 1024 * 1024
+	maxSearchContextLines    = 20
+	searchPrefetch           = 15
+)
+
+type SearchOptions struct {
+	Query           string
+	IsRegex         bool
+	CaseInsensitive bool
+
+	Prefix string
+	Glob   string
+
+	// PathFilter, if set, excludes the files it returns false for
+	PathFilter func(filePath string) bool
+
+	ContextLines int
+	MaxResults   int
+
+	// Larger files are skipped
+	MaxFileSize int64
+}
+
+type SearchMatch struct {
+	Path          string   `json:"path"`
+	LineNumber    int      `json:"line_number"`
+	Column        int      `json:"column"`
+	Line          string   `json:"line"`
+	ContextBefore []string `json:"context_before"`
+	ContextAfter  []string `json:"context_after"`
+}
+
+func compileSearchQuery(opts SearchOptions) (*regexp.Regexp, error) {
+	if opts.Query == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "query is required")
+	}
+
+	expression := opts.Query
+	if !opts.IsRegex {
+		expression = regexp.QuoteMeta(expression)
+	}
+	if opts.CaseInsensitive {
+		expression = "(?i)" + expression
+	}
+
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid regular expression: %v", err)
+	}
+
+	return re, nil
+}
+
+// SearchBucket searches the text files of a bucket line by line and calls
+// emit for every matching line, in path order. It returns whether the search
+// stopped early because MaxResults was reached.
+func (fsm *FileSystemManager) SearchBucket(ctx context.Context, bucketID string, opts SearchOptions, emit func(SearchMatch) error) (bool, error) {
+	re, err := compileSearchQuery(opts)
+	if err != nil {
+		return false, err
+	}
+
+	var pattern *glob.Pattern
+	if opts.Glob != "" {
+		if pattern, err = glob.Compile(opts.Glob); err != nil {
+			return false, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = defaultSearchMaxResults
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultSearchMaxFileSize
+	}
+	opts.ContextLines = min(max(opts.ContextLines, 0), maxSearchContextLines)
+
+	files, err := fsm.GetBucketFiles(ctx, bucketID, opts.Prefix)
+	if err != nil {
+		return false, err
+	}
+
+	// Only files the index can't rule out have to be downloaded
+	query, err := trigram.RegexpQuery(re.String())
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "invalid regular expression: %v", err)
+	}
+
+	candidates, indexed, err := fsm.searchCandidates(ctx, bucketID, query)
+	if err != nil {
+		return false, err
+	}
+
+	files = util.Filter(files, func(f FileInfo) bool {
+		if indexed && !candidates[f.Path] {
+			return false
+		}
+		if opts.PathFilter != nil && !opts.PathFilter(f.Path) {
+			return false
+		}
+		return f.Size <= opts.MaxFileSize && (pattern == nil || pattern.Match(f.Path))
+	})
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Fetch ahead of the file being searched, but emit results in order
+	type fetchResult struct {
+		content []byte
+		err     error
+	}
+
+	pending := make([]chan fetchResult, len(files))
+	for i := range pending {
+		pending[i] = make(chan fetchResult, 1)
+	}
+
+	semaphore := make(chan struct{}, searchPrefetch)
+
+	go func() {
+		for i, f := range files {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(result chan fetchResult, filePath string) {
+				_, data, err := fsm.GetBucketFile(ctx, bucketID, filePath)
+				if err != nil {
+					result <- fetchResult{err: err}
+					return
+				}
+				result <- fetchResult{content: data.Content}
+			}(pending[i], f.Path)
+		}
+	}()
+
+	results := 0
+	for i, f := range files {
+		var fetched fetchResult
+		select {
+		case fetched = <-pending[i]:
+			<-semaphore
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+
+		if fetched.err != nil {
+			// Files deleted while searching are skipped
+			if fetched.err.Error() == "file not found" {
+				continue
+			}
+			return false, fetched.err
+		}
+
+		if util.IsBinary(fetched.content) {
+			continue
+		}
+
+		lines := diff.SplitLines(string(fetched.content))
+		for lineIndex, line := range lines {
+			line = strings.TrimRight(line, "\r\n")
+
+			location := re.FindStringIndex(line)
+			if location == nil {
+				continue
+			}
+
+			match := SearchMatch{
+				Path:          f.Path,
+				LineNumber:    lineIndex + 1,
+				Column:        location[0] + 1,
+				Line:          line,
+				ContextBefore: []string{},
+				ContextAfter:  []string{},
+			}
+
+			for j := max(lineIndex-opts.ContextLines, 0); j < lineIndex; j++ {
+				match.ContextBefore = append(match.ContextBefore, strings.TrimRight(lines[j], "\r\n"))
+			}
+			for j := lineIndex + 1; j < len(lines) && j <= lineIndex+opts.ContextLines; j++ {
+				match.ContextAfter = append(match.ContextAfter, strings.TrimRight(lines[j], "\r\n"))
+			}
+
+			if err := emit(match); err != nil {
+				return false, err
+			}
+
+			results++
+			if results >= opts.MaxResults {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,335 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	memoryQueue "github.com/metorial/metorial/services/code-bucket/pkg/memory-queue"
+	"github.com/metorial/metorial/services/code-bucket/pkg/trigram"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+)
+
+// Every bucket has a trigram index snapshot in object storage. Writes don't
+// touch the snapshot, they only record the path in a redis hash of dirty
+// files. Dirty files are always searched, and the background flush folds them
+// into the snapshot.
+
+const maxCachedSearchIndexes = 32
+
+// Removes a dirty entry only if it wasn't written again since it was read
+var compareAndDeleteField = redis.NewScript(`
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+type cachedSearchIndex struct {
+	version string
+	index   *trigram.Index
+}
+
+// searchIndexCache keeps recently used snapshots in memory. Cached indexes
+// are never modified, updates always start from a fresh copy.
+type searchIndexCache struct {
+	mutex   sync.Mutex
+	entries map[string]cachedSearchIndex
+}
+
+func newSearchIndexCache() *searchIndexCache {
+	return &searchIndexCache{entries: make(map[string]cachedSearchIndex)}
+}
+
+func searchIndexObjectKey(bucketID string) string {
+	return fmt.Sprintf("meta/%s/search-index.bin", bucketID)
+}
+
+func searchIndexDirtyKey(bucketID string) string {
+	return fmt.Sprintf("search-index:%s:dirty", bucketID)
+}
+
+func searchIndexVersionKey(bucketID string) string {
+	return fmt.Sprintf("search-index:%s:version", bucketID)
+}
+
+func (fsm *FileSystemManager) markSearchIndexDirty(ctx context.Context, bucketID, filePath string) error {
+	return fsm.redis.HSet(ctx, searchIndexDirtyKey(bucketID), filePath, strconv.FormatInt(time.Now().UnixNano(), 10)).Err()
+}
+
+func (fsm *FileSystemManager) readSearchIndex(bucketID string) (*trigram.Index, error) {
+	obj, err := fsm.objectStorage.GetObject(fsm.bucketName, searchIndexObjectKey(bucketID))
+	if err != nil {
+		if isObjectNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	index := trigram.NewIndex()
+	if err := index.UnmarshalBinary(obj.Data); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+func (fsm *FileSystemManager) writeSearchIndex(ctx context.Context, bucketID string, index *trigram.Index) error {
+	data, err := index.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	contentType := "application/octet-stream"
+	if _, err := fsm.objectStorage.PutObject(fsm.bucketName, searchIndexObjectKey(bucketID), data, &contentType, nil); err != nil {
+		return err
+	}
+
+	return fsm.redis.Set(ctx, searchIndexVersionKey(bucketID), strconv.FormatInt(time.Now().UnixNano(), 10), 0).Err()
+}
+
+// loadSearchIndex returns the current snapshot of a bucket, or nil if the
+// bucket has never been indexed.
+func (fsm *FileSystemManager) loadSearchIndex(ctx context.Context, bucketID string) (*trigram.Index, error) {
+	versionKey := searchIndexVersionKey(bucketID)
+
+	version, err := fsm.redis.Get(ctx, versionKey).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	if version == "none" {
+		return nil, nil
+	}
+
+	cache := fsm.searchIndexes
+	cache.mutex.Lock()
+	cached, ok := cache.entries[bucketID]
+	cache.mutex.Unlock()
+
+	if ok && version != "" && cached.version == version {
+		return cached.index, nil
+	}
+
+	index, err := fsm.readSearchIndex(bucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if index == nil {
+		fsm.redis.SetNX(ctx, versionKey, "none", redisFlushDelay*2)
+		return nil, nil
+	}
+
+	if version == "" {
+		version = strconv.FormatInt(time.Now().UnixNano(), 10)
+		if !fsm.redis.SetNX(ctx, versionKey, version, 0).Val() {
+			return index, nil
+		}
+	}
+
+	cache.mutex.Lock()
+	if len(cache.entries) >= maxCachedSearchIndexes {
+		for key := range cache.entries {
+			delete(cache.entries, key)
+			break
+		}
+	}
+	cache.entries[bucketID] = cachedSearchIndex{version: version, index: index}
+	cache.mutex.Unlock()
+
+	return index, nil
+}
+
+// searchCandidates returns the paths that may match the query. The boolean
+// result is false if the bucket, or the base of an overlay, isn't indexed.
+func (fsm *FileSystemManager) searchCandidates(ctx context.Context, bucketID string, query *trigram.Query) (map[string]bool, bool, error) {
+	if query.Op == trigram.QueryAll {
+		return nil, false, nil
+	}
+
+	index, err := fsm.loadSearchIndex(ctx, bucketID)
+	if err != nil || index == nil {
+		return nil, false, err
+	}
+
+	dirty, err := fsm.redis.HKeys(ctx, searchIndexDirtyKey(bucketID)).Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	candidates := make(map[string]bool)
+	for _, p := range index.Candidates(query) {
+		candidates[p] = true
+	}
+	for _, p := range dirty {
+		candidates[p] = true
+	}
+
+	overlay, err := fsm.getOverlay(ctx, bucketID)
+	if err != nil {
+		return nil, false, err
+	}
+	if overlay != nil {
+		baseCandidates, ok, err := fsm.searchCandidates(ctx, overlay.BaseBucketID, query)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		for p := range baseCandidates {
+			candidates[p] = true
+		}
+	}
+
+	return candidates, true, nil
+}
+
+// RebuildSearchIndex indexes every file of a bucket from scratch and returns
+// the number of files in the new index.
+func (fsm *FileSystemManager) RebuildSearchIndex(ctx context.Context, bucketID string) (int, error) {
+	lockKey := fmt.Sprintf("lock:search-index:%s", bucketID)
+
+	var count int
+	err := fsm.withLock(ctx, lockKey, func() error {
+		index, err := fsm.buildSearchIndex(ctx, bucketID)
+		if err != nil {
+			return err
+		}
+
+		count = index.Len()
+		return nil
+	})
+
+	return count, err
+}
+
+func (fsm *FileSystemManager) buildSearchIndex(ctx context.Context, bucketID string) (*trigram.Index, error) {
+	dirtyKey := searchIndexDirtyKey(bucketID)
+
+	// Read the dirty versions first, anything written later stays dirty
+	dirty, err := fsm.redis.HGetAll(ctx, dirtyKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := fsm.listOwnBucketFiles(ctx, bucketID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var mutex sync.Mutex
+	index := trigram.NewIndex()
+
+	queue := memoryQueue.NewBlockingJobQueue(15)
+
+	for _, file := range files {
+		f := file
+		queue.AddAndBlockIfFull(func() error {
+			_, data, err := fsm.getOwnBucketFile(ctx, bucketID, f.Path)
+			if err != nil {
+				if err.Error() == "file not found" {
+					return nil
+				}
+				return err
+			}
+
+			mutex.Lock()
+			index.Add(f.Path, data.Content, util.IsBinary(data.Content))
+			mutex.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := queue.Wait(); err != nil {
+		return nil, err
+	}
+
+	if err := fsm.writeSearchIndex(ctx, bucketID, index); err != nil {
+		return nil, err
+	}
+
+	fsm.clearSearchIndexDirty(ctx, bucketID, dirty)
+
+	return index, nil
+}
+
+func (fsm *FileSystemManager) clearSearchIndexDirty(ctx context.Context, bucketID string, dirty map[string]string) {
+	dirtyKey := searchIndexDirtyKey(bucketID)
+	for filePath, version := range dirty {
+		compareAndDeleteField.Run(ctx, fsm.redis, []string{dirtyKey}, filePath, version)
+	}
+}
+
+// updateSearchIndex folds the dirty files of a bucket into its snapshot.
+// Buckets without a snapshot are indexed in full.
+func (fsm *FileSystemManager) updateSearchIndex(ctx context.Context, bucketID string) error {
+	index, err := fsm.readSearchIndex(bucketID)
+	if err != nil {
+		return err
+	}
+	if index == nil {
+		_, err := fsm.buildSearchIndex(ctx, bucketID)
+		return err
+	}
+
+	dirty, err := fsm.redis.HGetAll(ctx, searchIndexDirtyKey(bucketID)).Result()
+	if err != nil || len(dirty) == 0 {
+		return err
+	}
+
+	for filePath := range dirty {
+		_, data, err := fsm.getOwnBucketFile(ctx, bucketID, filePath)
+		if err != nil {
+			if err.Error() != "file not found" {
+				return err
+			}
+
+			index.Remove(filePath)
+			continue
+		}
+
+		index.Add(filePath, data.Content, util.IsBinary(data.Content))
+	}
+
+	if err := fsm.writeSearchIndex(ctx, bucketID, index); err != nil {
+		return err
+	}
+
+	fsm.clearSearchIndexDirty(ctx, bucketID, dirty)
+
+	return nil
+}
+
+func (fsm *FileSystemManager) updateSearchIndexes() {
+	ctx := context.Background()
+
+	var keys []string
+	iter := fsm.redis.Scan(ctx, 0, "search-index:*:dirty", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		log.Printf("Error scanning search index keys: %v", err)
+		return
+	}
+
+	for _, key := range keys {
+		bucketID := strings.TrimSuffix(strings.TrimPrefix(key, "search-index:"), ":dirty")
+
+		lockKey := fmt.Sprintf("lock:search-index:%s", bucketID)
+		if !fsm.acquireLock(ctx, lockKey) {
+			continue
+		}
+
+		if err := fsm.updateSearchIndex(ctx, bucketID); err != nil {
+			log.Printf("Error updating search index of bucket %s: %v", bucketID, err)
+		}
+
+		fsm.releaseLock(ctx, lockKey)
+	}
+}
@@ -0,0 +1,330 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/url"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/metorial/metorial/services/code-bucket/pkg/netguard"
+	"github.com/metorial/metorial/services/code-bucket/pkg/util"
+	"github.com/metorial/metorial/services/code-bucket/pkg/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Webhooks are stored in a redis hash per bucket, plus one for global
+// webhooks that receive the events of every bucket. Deliveries run on an
+// in-memory retry queue, every attempt is recorded in a capped log. Events
+// are dropped rather than slowing down writes when the queue is full.
+
+const (
+	WebhookEventFilePut         = "file.put"
+	WebhookEventFileDelete      = "file.delete"
+	WebhookEventFileMove        = "file.move"
+	WebhookEventImportCompleted = "import.completed"
+	WebhookEventExportCompleted = "export.completed"
+	WebhookEventTest            = "webhook.test"
+
+	webhookMaxTries         = 6
+	webhookTimeout          = 10 * time.Second
+	webhookMaxBackoff       = 30 * time.Minute
+	maxWebhookDeliveryLogs  = 100
+	webhookDeliveryLogTTL   = 30 * 24 * time.Hour
+	webhookQueueConcurrency = 10
+)
+
+type Webhook struct {
+	ID        string    `json:"id"`
+	BucketID  string    `json:"bucket_id"` // Empty for global webhooks
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"` // Empty for all events
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	Success    bool      `json:"success"`
+	StatusCode int       `json:"status_code"`
+	Response   string    `json:"response"`
+	Error      string    `json:"error"`
+	DurationMs int64     `json:"duration_ms"`
+	Time       time.Time `json:"time"`
+}
+
+func webhooksKey(bucketID string) string {
+	if bucketID == "" {
+		return "webhooks:global"
+	}
+	return fmt.Sprintf("webhooks:bucket:%s", bucketID)
+}
+
+// Maps webhook ids to the hash they are stored in
+const webhookIndexKey = "webhooks:index"
+
+func webhookDeliveriesKey(webhookID string) string {
+	return fmt.Sprintf("webhook-deliveries:%s", webhookID)
+}
+
+// webhookBackoff spreads the retries of a delivery over more than half an
+// hour, so a receiver that is down for a deploy still gets it
+func webhookBackoff(retry int) time.Duration {
+	backoff := min(time.Minute<<(retry-1), webhookMaxBackoff)
+	return backoff + rand.N(backoff/2)
+}
+
+// CreateWebhook stores a webhook. A secret is generated if none is given.
+func (fsm *FileSystemManager) CreateWebhook(ctx context.Context, hook Webhook) (*Webhook, error) {
+	parsed, err := url.Parse(hook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "url must be an absolute http or https url")
+	}
+
+	// Deliveries are checked again when connecting, in case the host is
+	// changed to resolve to a private address later
+	if err := netguard.CheckURL(ctx, hook.URL); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook url: %v", err)
+	}
+
+	hook.ID = util.RandomID("whk_")
+	hook.CreatedAt = time.Now().UTC()
+	if hook.Secret == "" {
+		hook.Secret = util.RandomID("whsec_")
+	}
+
+	data, err := json.Marshal(hook)
+	if err != nil {
+		return nil, err
+	}
+
+	key := webhooksKey(hook.BucketID)
+
+	_, err = fsm.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, hook.ID, data)
+		pipe.HSet(ctx, webhookIndexKey, hook.ID, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
+
+// ListWebhooks returns the webhooks of a bucket, or the global ones if
+// bucketID is empty.
+func (fsm *FileSystemManager) ListWebhooks(ctx context.Context, bucketID string) ([]Webhook, error) {
+	entries, err := fsm.redis.HGetAll(ctx, webhooksKey(bucketID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]Webhook, 0, len(entries))
+	for _, entry := range entries {
+		var hook Webhook
+		if err := json.Unmarshal([]byte(entry), &hook); err != nil {
+			continue
+		}
+		hooks = append(hooks, hook)
+	}
+
+	return util.Sort(hooks, func(a, b Webhook) bool { return a.CreatedAt.Before(b.CreatedAt) }), nil
+}
+
+func (fsm *FileSystemManager) getWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	key, err := fsm.redis.HGet(ctx, webhookIndexKey, webhookID).Result()
+	if err == redis.Nil {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := fsm.redis.HGet(ctx, key, webhookID).Result()
+	if err == redis.Nil {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hook Webhook
+	if err := json.Unmarshal([]byte(data), &hook); err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
+
+func (fsm *FileSystemManager) DeleteWebhook(ctx context.Context, webhookID string) error {
+	key, err := fsm.redis.HGet(ctx, webhookIndexKey, webhookID).Result()
+	if err == redis.Nil {
+		return status.Errorf(codes.NotFound, "webhook not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fsm.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, key, webhookID)
+		pipe.HDel(ctx, webhookIndexKey, webhookID)
+		pipe.Del(ctx, webhookDeliveriesKey(webhookID))
+		return nil
+	})
+
+	return err
+}
+
+// GetWebhookDeliveries returns the most recent delivery attempts first
+func (fsm *FileSystemManager) GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]WebhookDelivery, error) {
+	if _, err := fsm.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxWebhookDeliveryLogs {
+		limit = maxWebhookDeliveryLogs
+	}
+
+	entries, err := fsm.redis.LRange(ctx, webhookDeliveriesKey(webhookID), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]WebhookDelivery, 0, len(entries))
+	for _, entry := range entries {
+		var delivery WebhookDelivery
+		if err := json.Unmarshal([]byte(entry), &delivery); err != nil {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// NotifyWebhooks queues a delivery to every webhook of the bucket, and every
+// global webhook, subscribed to the event. The webhooks are looked up and
+// called in the background, failures are retried with backoff.
+func (fsm *FileSystemManager) NotifyWebhooks(ctx context.Context, bucketID, eventType string, data any) {
+	now := time.Now().UTC()
+
+	queued := fsm.webhookQueue.TryAdd(func() error {
+		fsm.queueWebhookDeliveries(bucketID, eventType, data, now)
+		return nil
+	}, 1)
+	if !queued {
+		log.Printf("Webhook queue is full, dropped %s event of bucket %s", eventType, bucketID)
+	}
+}
+
+func (fsm *FileSystemManager) queueWebhookDeliveries(bucketID, eventType string, data any, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	var hooks []Webhook
+	for _, scope := range []string{bucketID, ""} {
+		scoped, err := fsm.ListWebhooks(ctx, scope)
+		if err != nil {
+			log.Printf("Error loading webhooks for bucket %s: %v", bucketID, err)
+			return
+		}
+		hooks = append(hooks, scoped...)
+	}
+
+	for _, hook := range hooks {
+		if !webhook.Matches(hook.Events, eventType) {
+			continue
+		}
+
+		payload := &webhook.Payload{
+			ID:       util.RandomID("dlv_"),
+			Type:     eventType,
+			BucketID: bucketID,
+			Time:     now,
+			Data:     data,
+		}
+
+		h := hook
+		attempt := 0
+		queued := fsm.webhookQueue.TryAdd(func() error {
+			attempt++
+			return fsm.deliverWebhook(&h, payload, attempt).Err
+		}, webhookMaxTries)
+		if !queued {
+			log.Printf("Webhook queue is full, dropped delivery %s to webhook %s", payload.ID, h.ID)
+		}
+	}
+}
+
+// TestWebhook sends a test event right away and returns the outcome. It is
+// not retried.
+func (fsm *FileSystemManager) TestWebhook(ctx context.Context, webhookID string) (*WebhookDelivery, error) {
+	hook, err := fsm.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &webhook.Payload{
+		ID:       util.RandomID("dlv_"),
+		Type:     WebhookEventTest,
+		BucketID: hook.BucketID,
+		Time:     time.Now().UTC(),
+		Data:     map[string]string{"webhook_id": hook.ID},
+	}
+
+	result := fsm.deliverWebhook(hook, payload, 1)
+
+	return newWebhookDelivery(hook, payload, 1, result), nil
+}
+
+func newWebhookDelivery(hook *Webhook, payload *webhook.Payload, attempt int, result *webhook.Result) *WebhookDelivery {
+	delivery := &WebhookDelivery{
+		ID:         payload.ID,
+		WebhookID:  hook.ID,
+		Event:      payload.Type,
+		Attempt:    attempt,
+		Success:    result.Success(),
+		StatusCode: result.StatusCode,
+		Response:   result.Response,
+		DurationMs: result.Duration.Milliseconds(),
+		Time:       time.Now().UTC(),
+	}
+	if result.Err != nil {
+		delivery.Error = result.Err.Error()
+	}
+
+	return delivery
+}
+
+func (fsm *FileSystemManager) deliverWebhook(hook *Webhook, payload *webhook.Payload, attempt int) *webhook.Result {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	result := webhook.Deliver(ctx, fsm.webhookClient, hook.URL, hook.Secret, payload)
+
+	data, err := json.Marshal(newWebhookDelivery(hook, payload, attempt, result))
+	if err != nil {
+		return result
+	}
+
+	key := webhookDeliveriesKey(hook.ID)
+	_, err = fsm.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, maxWebhookDeliveryLogs-1)
+		pipe.Expire(ctx, key, webhookDeliveryLogTTL)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error recording delivery of webhook %s: %v", hook.ID, err)
+	}
+
+	return result
+}
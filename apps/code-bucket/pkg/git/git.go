@@ -0,0 +1,356 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/metorial/metorial/services/code-bucket/pkg/netguard"
+)
+
+// Talks to any git remote over the smart protocol, without a git binary.
+// Repositories are cloned shallowly into memory: only the commit of the ref
+// is fetched, never the history.
+
+// Limits bound what is fetched from a remote. MaxTotalSize applies to every
+// response of the remote as well as to the files of a clone.
+type Limits struct {
+	MaxFileSize  int64
+	MaxTotalSize int64
+}
+
+// DefaultLimits apply to Clone and Push. MaxFileSize matches the default
+// maximum bucket file size.
+var DefaultLimits = Limits{
+	MaxFileSize:  64 * 1024 * 1024,
+	MaxTotalSize: 1024 * 1024 * 1024,
+}
+
+func init() {
+	// Remotes are user supplied urls, they must not reach the private network
+	// of the server or send more than the limits allow
+	guarded := githttp.NewClient(&http.Client{
+		Timeout:   10 * time.Minute,
+		Transport: limitedTransport{netguard.NewTransport()},
+	})
+	client.InstallProtocol("http", guarded)
+	client.InstallProtocol("https", guarded)
+}
+
+// limitedTransport fails reading response bodies larger than
+// DefaultLimits.MaxTotalSize
+type limitedTransport struct {
+	http.RoundTripper
+}
+
+func (t limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = &limitedBody{ReadCloser: resp.Body, limit: DefaultLimits.MaxTotalSize}
+	return resp, nil
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.read += int64(n); b.read > b.limit {
+		return n, fmt.Errorf("remote sent more than %d bytes", b.limit)
+	}
+	return n, err
+}
+
+const (
+	defaultAuthorName  = "Metorial CodeBucket"
+	defaultAuthorEmail = "code-bucket@metorial.com"
+	defaultMessage     = "Update from Metorial CodeBucket"
+)
+
+type Auth struct {
+	Username string
+	Password string // Or an access token
+}
+
+func (a Auth) method() transport.AuthMethod {
+	if a.Username == "" && a.Password == "" {
+		return nil
+	}
+
+	// Most hosts ignore the username of token auth, but require one
+	username := a.Username
+	if username == "" {
+		username = "x-access-token"
+	}
+
+	return &githttp.BasicAuth{Username: username, Password: a.Password}
+}
+
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Clone fetches the files under dir at ref, a branch or tag, or the default
+// branch if ref is empty. Paths are relative to dir. Returns the commit the
+// files are from. Fails if a file or the files together are larger than
+// DefaultLimits allow.
+func Clone(ctx context.Context, url, ref, dir string, auth Auth) ([]File, string, error) {
+	repo, err := cloneRef(ctx, url, ref, auth)
+	if err != nil {
+		return nil, "", err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, "", err
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, "", err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if dir = strings.Trim(dir, "/"); dir != "" {
+		if tree, err = tree.Tree(dir); err != nil {
+			return nil, "", fmt.Errorf("path %s not found at %s: %w", dir, ref, err)
+		}
+	}
+
+	limits := DefaultLimits
+
+	var files []File
+	var total int64
+	err = tree.Files().ForEach(func(f *object.File) error {
+		// Buckets only hold regular files
+		if f.Mode != filemode.Regular && f.Mode != filemode.Executable {
+			return nil
+		}
+
+		if f.Size > limits.MaxFileSize {
+			return fmt.Errorf("%s exceeds the maximum file size of %d bytes", f.Name, limits.MaxFileSize)
+		}
+		if total += f.Size; total > limits.MaxTotalSize {
+			return fmt.Errorf("files exceed the maximum total size of %d bytes", limits.MaxTotalSize)
+		}
+
+		reader, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, File{Path: f.Name, Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return files, head.Hash().String(), nil
+}
+
+// cloneRef clones a branch or tag, trying branches first
+func cloneRef(ctx context.Context, url, ref string, auth Auth) (*gogit.Repository, error) {
+	opts := &gogit.CloneOptions{
+		URL:          url,
+		Auth:         auth.method(),
+		Depth:        1,
+		SingleBranch: true,
+		Tags:         gogit.NoTags,
+	}
+
+	if ref == "" {
+		return gogit.CloneContext(ctx, memory.NewStorage(), nil, opts)
+	}
+
+	var err error
+	for _, name := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)} {
+		opts.ReferenceName = name
+
+		var repo *gogit.Repository
+		if repo, err = gogit.CloneContext(ctx, memory.NewStorage(), nil, opts); err == nil {
+			return repo, nil
+		}
+		if !isRefNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("ref %s not found: %w", ref, err)
+}
+
+func isRefNotFound(err error) bool {
+	var noMatch gogit.NoMatchingRefSpecError
+	return errors.As(err, &noMatch) || errors.Is(err, plumbing.ErrReferenceNotFound)
+}
+
+type PushOptions struct {
+	Branch      string // Created from the default branch if missing
+	Dir         string // Directory the files are written to
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+}
+
+// Push writes the files under opts.Dir and pushes them as one commit on
+// opts.Branch. Other files of the repository are kept. Returns the new commit,
+// or the current one if nothing changed.
+func Push(ctx context.Context, url string, auth Auth, files []File, opts PushOptions) (string, error) {
+	if opts.Branch == "" {
+		return "", fmt.Errorf("branch is required")
+	}
+
+	branch := plumbing.NewBranchReferenceName(opts.Branch)
+
+	repo, err := gogit.CloneContext(ctx, memory.NewStorage(), memfs.New(), &gogit.CloneOptions{
+		URL:           url,
+		Auth:          auth.method(),
+		Depth:         1,
+		SingleBranch:  true,
+		Tags:          gogit.NoTags,
+		ReferenceName: branch,
+	})
+	if isRefNotFound(err) || errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		repo, err = cloneForNewBranch(ctx, url, auth, branch)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range files {
+		filePath := path.Join(strings.Trim(opts.Dir, "/"), strings.TrimPrefix(file.Path, "/"))
+
+		if err := worktree.Filesystem.MkdirAll(path.Dir(filePath), 0o755); err != nil {
+			return "", err
+		}
+
+		f, err := worktree.Filesystem.Create(filePath)
+		if err != nil {
+			return "", err
+		}
+		_, err = f.Write(file.Content)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := worktree.AddWithOptions(&gogit.AddOptions{All: true}); err != nil {
+		return "", err
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return "", err
+	}
+	if status.IsClean() {
+		if head, err := repo.Head(); err == nil {
+			return head.Hash().String(), nil
+		}
+	}
+
+	message := opts.Message
+	if message == "" {
+		message = defaultMessage
+	}
+	author := &object.Signature{Name: opts.AuthorName, Email: opts.AuthorEmail, When: time.Now()}
+	if author.Name == "" {
+		author.Name = defaultAuthorName
+	}
+	if author.Email == "" {
+		author.Email = defaultAuthorEmail
+	}
+
+	commit, err := worktree.Commit(message, &gogit.CommitOptions{Author: author, AllowEmptyCommits: true})
+	if err != nil {
+		return "", err
+	}
+
+	err = repo.PushContext(ctx, &gogit.PushOptions{
+		Auth:     auth.method(),
+		RefSpecs: []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", branch, branch))},
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return "", err
+	}
+
+	return commit.String(), nil
+}
+
+// cloneForNewBranch starts a branch from the default branch, or from scratch
+// if the repository is empty
+func cloneForNewBranch(ctx context.Context, url string, auth Auth, branch plumbing.ReferenceName) (*gogit.Repository, error) {
+	repo, err := gogit.CloneContext(ctx, memory.NewStorage(), memfs.New(), &gogit.CloneOptions{
+		URL:          url,
+		Auth:         auth.method(),
+		Depth:        1,
+		SingleBranch: true,
+		Tags:         gogit.NoTags,
+	})
+
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		repo, err = gogit.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = repo.CreateRemote(&config.RemoteConfig{Name: gogit.DefaultRemoteName, URLs: []string{url}})
+		if err != nil {
+			return nil, err
+		}
+
+		return repo, repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branch))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, worktree.Checkout(&gogit.CheckoutOptions{Hash: head.Hash(), Branch: branch, Create: true})
+}
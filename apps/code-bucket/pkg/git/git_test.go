@@ -0,0 +1,181 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/metorial/metorial/services/code-bucket/pkg/netguard"
+)
+
+func bareRepo(t *testing.T) string {
+	dir := t.TempDir()
+	_, err := gogit.PlainInitWithOptions(dir, &gogit.PlainInitOptions{
+		InitOptions: gogit.InitOptions{DefaultBranch: plumbing.Main},
+		Bare:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func fileMap(files []File) map[string]string {
+	m := make(map[string]string, len(files))
+	for _, f := range files {
+		m[f.Path] = string(f.Content)
+	}
+	return m
+}
+
+func keys(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestPushAndClone(t *testing.T) {
+	ctx := context.Background()
+	url := bareRepo(t)
+
+	first, err := Push(ctx, url, Auth{}, []File{
+		{Path: "/README.md", Content: []byte("# Hello")},
+		{Path: "/src/index.ts", Content: []byte("export {}")},
+	}, PushOptions{Branch: "main", Message: "Initial"})
+	if err != nil {
+		t.Fatalf("push to empty repository: %v", err)
+	}
+
+	files, commit, err := Clone(ctx, url, "main", "", Auth{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != first {
+		t.Errorf("cloned commit %s, pushed %s", commit, first)
+	}
+	if got := fileMap(files); got["README.md"] != "# Hello" || got["src/index.ts"] != "export {}" {
+		t.Errorf("unexpected files %v", keys(got))
+	}
+
+	// Only the directory is cloned, paths are relative to it
+	files, _, err = Clone(ctx, url, "main", "/src", Auth{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := keys(fileMap(files)); len(got) != 1 || got[0] != "index.ts" {
+		t.Errorf("unexpected files %v", got)
+	}
+}
+
+func TestPush_NewBranchFromDefault(t *testing.T) {
+	ctx := context.Background()
+	url := bareRepo(t)
+
+	if _, err := Push(ctx, url, Auth{}, []File{{Path: "/README.md", Content: []byte("main")}}, PushOptions{Branch: "main"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Push(ctx, url, Auth{}, []File{{Path: "/app.js", Content: []byte("feature")}}, PushOptions{Branch: "feature", Dir: "/web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _, err := Clone(ctx, url, "feature", "", Auth{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := keys(fileMap(files)); len(got) != 2 || got[0] != "README.md" || got[1] != "web/app.js" {
+		t.Errorf("unexpected files %v", got)
+	}
+
+	// The default branch is untouched
+	files, _, err = Clone(ctx, url, "", "", Auth{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := keys(fileMap(files)); len(got) != 1 {
+		t.Errorf("unexpected files on main %v", got)
+	}
+}
+
+func TestPush_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	url := bareRepo(t)
+	files := []File{{Path: "/README.md", Content: []byte("same")}}
+
+	first, err := Push(ctx, url, Auth{}, files, PushOptions{Branch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := Push(ctx, url, Auth{}, files, PushOptions{Branch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("unchanged push created commit %s", second)
+	}
+}
+
+func TestClone_UnknownRef(t *testing.T) {
+	ctx := context.Background()
+	url := bareRepo(t)
+
+	if _, err := Push(ctx, url, Auth{}, []File{{Path: "/a", Content: []byte("a")}}, PushOptions{Branch: "main"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Clone(ctx, url, "missing", "", Auth{}); err == nil {
+		t.Error("expected an error for a missing ref")
+	}
+}
+
+func TestClone_Limits(t *testing.T) {
+	ctx := context.Background()
+	url := bareRepo(t)
+
+	files := []File{
+		{Path: "/a", Content: bytes.Repeat([]byte("a"), 100)},
+		{Path: "/b", Content: bytes.Repeat([]byte("b"), 100)},
+	}
+	if _, err := Push(ctx, url, Auth{}, files, PushOptions{Branch: "main"}); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := DefaultLimits
+	t.Cleanup(func() { DefaultLimits = defaults })
+
+	for name, limits := range map[string]Limits{
+		"file size":  {MaxFileSize: 99, MaxTotalSize: 1000},
+		"total size": {MaxFileSize: 100, MaxTotalSize: 150},
+	} {
+		DefaultLimits = limits
+		if _, _, err := Clone(ctx, url, "main", "", Auth{}); err == nil {
+			t.Errorf("%s: repository over the limits was cloned", name)
+		}
+	}
+
+	DefaultLimits = Limits{MaxFileSize: 100, MaxTotalSize: 200}
+	if _, _, err := Clone(ctx, url, "main", "", Auth{}); err != nil {
+		t.Errorf("repository at the limits: %v", err)
+	}
+}
+
+func TestClone_PrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, _, err := Clone(context.Background(), server.URL+"/repo.git", "", "", Auth{})
+	if !errors.Is(err, netguard.ErrPrivateAddress) {
+		t.Errorf("expected the private address to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+	zipImporter "github.com/metorial/metorial/services/code-bucket/pkg/zip-importer"
+)
+
+// Forgejo is a fork of Gitea with the same api, so both are served by this
+// provider with a different default instance.
+
+const (
+	GiteaApiUrl   = "https://gitea.com/api/v1"
+	ForgejoApiUrl = "https://codeberg.org/api/v1"
+
+	// Page size of tree listings, the maximum of the default configuration
+	treePageSize = 1000
+)
+
+type Provider struct {
+	DefaultApiUrl string
+}
+
+var _ scm.Provider = Provider{}
+
+func headers(token string) map[string]string {
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if token != "" {
+		headers["Authorization"] = fmt.Sprintf("token %s", token)
+	}
+
+	return headers
+}
+
+func (p Provider) repoUrl(repo scm.Repo) (string, error) {
+	owner, name, err := repo.SplitName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/repos/%s/%s", repo.ApiUrlOr(p.DefaultApiUrl), url.PathEscape(owner), url.PathEscape(name)), nil
+}
+
+func (p Provider) DownloadArchive(ctx context.Context, repo scm.Repo, ref, repoPath string) (*zipImporter.ZipFileIterator, error) {
+	baseUrl, err := p.repoUrl(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Archives are only served for a named ref
+	if ref == "" {
+		if ref, err = p.defaultBranch(ctx, baseUrl, repo.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	headers := headers(repo.Token)
+	headers["Accept"] = "*/*"
+
+	return zipImporter.DownloadArchive(fmt.Sprintf("%s/archive/%s.zip", baseUrl, ref), repoPath, headers)
+}
+
+func (p Provider) defaultBranch(ctx context.Context, baseUrl, token string) (string, error) {
+	var info struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if _, err := scm.Do(ctx, http.MethodGet, baseUrl, headers(token), nil, &info); err != nil {
+		return "", fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	return info.DefaultBranch, nil
+}
+
+func (p Provider) ResolveRef(ctx context.Context, repo scm.Repo, ref string) (string, error) {
+	baseUrl, err := p.repoUrl(repo)
+	if err != nil {
+		return "", err
+	}
+
+	var commits []struct {
+		SHA string `json:"sha"`
+	}
+	commitsUrl := fmt.Sprintf("%s/commits?sha=%s&limit=1&stat=false&verification=false&files=false", baseUrl, url.QueryEscape(ref))
+	_, err = scm.Do(ctx, http.MethodGet, commitsUrl, headers(repo.Token), nil, &commits)
+	if scm.IsNotFound(err) || (err == nil && len(commits) == 0) {
+		return "", fmt.Errorf("%w: %s", scm.ErrRefNotFound, ref)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return commits[0].SHA, nil
+}
+
+type giteaIdentity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type giteaFileOperation struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	SHA       string `json:"sha,omitempty"`
+}
+
+// Branch is the branch the commit is made on, or the one NewBranch is
+// created from
+type giteaChangeFilesRequest struct {
+	Branch    string               `json:"branch"`
+	NewBranch string               `json:"new_branch,omitempty"`
+	Message   string               `json:"message"`
+	Author    *giteaIdentity       `json:"author,omitempty"`
+	Files     []giteaFileOperation `json:"files"`
+}
+
+type giteaTreeResponse struct {
+	Tree []struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+		SHA  string `json:"sha"`
+	} `json:"tree"`
+	Truncated bool `json:"truncated"`
+}
+
+// UploadFiles writes all files in a single commit with the change files api
+// of Gitea 1.20 and later
+func (p Provider) UploadFiles(ctx context.Context, repo scm.Repo, files []scm.File, opts scm.UploadOptions) (string, error) {
+	if repo.Token == "" {
+		return "", fmt.Errorf("Gitea token is required")
+	}
+
+	baseUrl, err := p.repoUrl(repo)
+	if err != nil {
+		return "", err
+	}
+
+	changeReq := giteaChangeFilesRequest{
+		Branch:  opts.BranchOrDefault(),
+		Message: opts.MessageOr(fmt.Sprintf("Upload %d files", len(files))),
+	}
+	if opts.AuthorName != "" && opts.AuthorEmail != "" {
+		changeReq.Author = &giteaIdentity{Name: opts.AuthorName, Email: opts.AuthorEmail}
+	}
+
+	// Updates and deletes need the blob sha of the existing file
+	existing, err := p.listBlobs(ctx, baseUrl, changeReq.Branch, repo.Token)
+	if scm.IsNotFound(err) {
+		existing, err = p.newBranchBlobs(ctx, baseUrl, repo.Token, &changeReq)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to list repository files: %w", err)
+	}
+
+	operations := make([]giteaFileOperation, 0, len(files))
+	for _, file := range files {
+		fullPath := scm.FilePath(opts.Path, file.Path)
+
+		operation := giteaFileOperation{
+			Operation: "create",
+			Path:      fullPath,
+			Content:   base64.StdEncoding.EncodeToString(file.Content),
+		}
+		if sha, ok := existing[fullPath]; ok {
+			operation.Operation = "update"
+			operation.SHA = sha
+		}
+
+		operations = append(operations, operation)
+	}
+
+	remote := make([]string, 0, len(existing))
+	for remotePath := range existing {
+		remote = append(remote, remotePath)
+	}
+	deletions, err := opts.Deletions(remote, files)
+	if err != nil {
+		return "", err
+	}
+	for _, deletion := range deletions {
+		operations = append(operations, giteaFileOperation{Operation: "delete", Path: deletion, SHA: existing[deletion]})
+	}
+
+	changeReq.Files = operations
+
+	var resp struct {
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if _, err := scm.Do(ctx, http.MethodPost, fmt.Sprintf("%s/contents", baseUrl), headers(repo.Token), changeReq, &resp); err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return resp.Commit.SHA, nil
+}
+
+// newBranchBlobs sets up a change that creates its branch. The branch is
+// created from the default branch, so the blobs of that one are returned.
+// In an empty repository the branch is committed to directly.
+func (p Provider) newBranchBlobs(ctx context.Context, baseUrl, token string, changeReq *giteaChangeFilesRequest) (map[string]string, error) {
+	base, err := p.defaultBranch(ctx, baseUrl, token)
+	if err != nil {
+		return nil, err
+	}
+	if base == "" || base == changeReq.Branch {
+		return map[string]string{}, nil
+	}
+
+	blobs, err := p.listBlobs(ctx, baseUrl, base, token)
+	if scm.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changeReq.Branch, changeReq.NewBranch = base, changeReq.Branch
+	return blobs, nil
+}
+
+// listBlobs returns the blob sha of every file on branch by path
+func (p Provider) listBlobs(ctx context.Context, baseUrl, branch, token string) (map[string]string, error) {
+	blobs := map[string]string{}
+
+	for page := 1; ; page++ {
+		treeUrl := fmt.Sprintf("%s/git/trees/%s?recursive=true&per_page=%d&page=%d", baseUrl, url.PathEscape(branch), treePageSize, page)
+
+		var tree giteaTreeResponse
+		if _, err := scm.Do(ctx, http.MethodGet, treeUrl, headers(token), nil, &tree); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range tree.Tree {
+			if entry.Type == "blob" {
+				blobs[entry.Path] = entry.SHA
+			}
+		}
+
+		if !tree.Truncated || len(tree.Tree) == 0 {
+			return blobs, nil
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+)
+
+// fakeGitea serves the tree of the main branch split over two pages and
+// records the change files request. Other branches don't exist, in an empty
+// repository not even main.
+type fakeGitea struct {
+	pages  [][]map[string]string
+	empty  bool
+	change *giteaChangeFilesRequest
+}
+
+func (f *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "token token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reply := func(v any) { json.NewEncoder(w).Encode(v) }
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo":
+		reply(map[string]string{"default_branch": "main"})
+
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/git/trees/main" && !f.empty:
+		page := 1
+		if r.URL.Query().Get("page") == "2" {
+			page = 2
+		}
+		reply(map[string]any{"tree": f.pages[page-1], "truncated": page < len(f.pages)})
+
+	case r.Method == http.MethodPost && r.URL.Path == "/repos/owner/repo/contents":
+		f.change = &giteaChangeFilesRequest{}
+		json.NewDecoder(r.Body).Decode(f.change)
+		reply(map[string]any{"commit": map[string]string{"sha": "abc123"}})
+
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/commits":
+		if r.URL.Query().Get("sha") != "main" {
+			reply([]any{})
+			return
+		}
+		reply([]map[string]string{{"sha": "abc123"}})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/owner/repo/git/trees/"):
+		http.Error(w, "not found", http.StatusNotFound)
+
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusBadRequest)
+	}
+}
+
+func startFakeGitea(t *testing.T) (*fakeGitea, scm.Repo) {
+	fake := &fakeGitea{pages: [][]map[string]string{
+		{
+			{"path": "web", "type": "tree", "sha": "sha-web"},
+			{"path": "web/index.js", "type": "blob", "sha": "sha-index"},
+		},
+		{
+			{"path": "web/old.js", "type": "blob", "sha": "sha-old"},
+			{"path": "README.md", "type": "blob", "sha": "sha-readme"},
+		},
+	}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, scm.Repo{Name: "owner/repo", Token: "token", ApiUrl: server.URL}
+}
+
+func TestUploadFiles(t *testing.T) {
+	fake, repo := startFakeGitea(t)
+
+	commit, err := Provider{}.UploadFiles(context.Background(), repo, []scm.File{
+		{Path: "/index.js", Content: []byte("module.exports = 1")},
+		{Path: "/lib/utils.js", Content: []byte("exports.a = 2")},
+	}, scm.UploadOptions{Path: "web", Message: "Update", AuthorName: "Ada", AuthorEmail: "ada@example.com", Sync: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != "abc123" {
+		t.Errorf("got commit %q, want abc123", commit)
+	}
+
+	change := fake.change
+	if change.Branch != "main" || change.Message != "Update" || change.Author == nil || change.Author.Email != "ada@example.com" {
+		t.Errorf("unexpected request %+v", change)
+	}
+
+	want := map[string]giteaFileOperation{
+		"web/index.js":     {Operation: "update", Path: "web/index.js", Content: base64.StdEncoding.EncodeToString([]byte("module.exports = 1")), SHA: "sha-index"},
+		"web/lib/utils.js": {Operation: "create", Path: "web/lib/utils.js", Content: base64.StdEncoding.EncodeToString([]byte("exports.a = 2"))},
+		"web/old.js":       {Operation: "delete", Path: "web/old.js", SHA: "sha-old"},
+	}
+	if len(change.Files) != len(want) {
+		t.Errorf("got operations %+v, want %+v", change.Files, want)
+	}
+	for _, operation := range change.Files {
+		if operation != want[operation.Path] {
+			t.Errorf("%s: got %+v, want %+v", operation.Path, operation, want[operation.Path])
+		}
+	}
+}
+
+func TestUploadFiles_NewBranch(t *testing.T) {
+	fake, repo := startFakeGitea(t)
+
+	_, err := Provider{}.UploadFiles(context.Background(), repo, []scm.File{
+		{Path: "/index.js", Content: []byte("module.exports = 1")},
+	}, scm.UploadOptions{Path: "web", Branch: "feature", Sync: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The branch is created from main, so the files of main are updated and
+	// deleted
+	change := fake.change
+	if change.Branch != "main" || change.NewBranch != "feature" {
+		t.Errorf("expected a new branch feature from main, got %+v", change)
+	}
+
+	want := map[string]giteaFileOperation{
+		"web/index.js": {Operation: "update", Path: "web/index.js", Content: base64.StdEncoding.EncodeToString([]byte("module.exports = 1")), SHA: "sha-index"},
+		"web/old.js":   {Operation: "delete", Path: "web/old.js", SHA: "sha-old"},
+	}
+	if len(change.Files) != len(want) {
+		t.Errorf("got operations %+v, want %+v", change.Files, want)
+	}
+	for _, operation := range change.Files {
+		if operation != want[operation.Path] {
+			t.Errorf("%s: got %+v, want %+v", operation.Path, operation, want[operation.Path])
+		}
+	}
+}
+
+func TestUploadFiles_EmptyRepository(t *testing.T) {
+	for _, branch := range []string{"main", "feature"} {
+		fake, repo := startFakeGitea(t)
+		fake.empty = true
+
+		_, err := Provider{}.UploadFiles(context.Background(), repo, []scm.File{
+			{Path: "/index.js", Content: []byte("module.exports = 1")},
+		}, scm.UploadOptions{Branch: branch, Sync: true})
+		if err != nil {
+			t.Fatalf("%s: %v", branch, err)
+		}
+
+		change := fake.change
+		if change.Branch != branch || change.NewBranch != "" {
+			t.Errorf("%s: expected a commit on the branch itself, got %+v", branch, change)
+		}
+		if len(change.Files) != 1 || change.Files[0].Operation != "create" {
+			t.Errorf("%s: unexpected operations %+v", branch, change.Files)
+		}
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	_, repo := startFakeGitea(t)
+
+	commit, err := Provider{}.ResolveRef(context.Background(), repo, "main")
+	if err != nil || commit != "abc123" {
+		t.Errorf("got %q, %v", commit, err)
+	}
+
+	if _, err := (Provider{}).ResolveRef(context.Background(), repo, "missing"); !errors.Is(err, scm.ErrRefNotFound) {
+		t.Errorf("expected ErrRefNotFound, got %v", err)
+	}
+}
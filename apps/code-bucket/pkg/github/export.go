@@ -0,0 +1,415 @@
+package github
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+)
+
+// Exports go through the Git Data API: the files are uploaded as blobs, put
+// in a tree on top of the tree of the branch, committed, and the branch is
+// moved to the commit. The branch only changes in the last step, so an export
+// either lands completely or not at all, and fails instead of overwriting
+// commits pushed to the branch meanwhile. The Git Data API doesn't work in an
+// empty repository, there the first file is committed with the contents API
+// and the rest on top of it.
+
+const (
+	// Blobs uploaded at the same time
+	blobUploads = 8
+
+	fileMode = "100644"
+)
+
+// GitHub answers requests for the refs of a repository without commits with
+// a conflict
+var errEmptyRepository = errors.New("repository is empty")
+
+type ExportOptions struct {
+	scm.UploadOptions
+	BaseBranch        string // Branch a missing branch is created from, defaults to the default branch
+	CreatePullRequest bool   // Opens a pull request from the branch into the base branch
+	PullRequestTitle  string // Defaults to the commit message
+	PullRequestBody   string
+}
+
+type ExportResult struct {
+	CommitSHA      string // The head of the branch, which is unchanged if no file changed
+	PullRequestUrl string
+	DeletedPaths   []string // Files removed by a sync
+}
+
+type api struct {
+	baseUrl string
+	token   string
+}
+
+func (a *api) do(ctx context.Context, method, path string, in, out any) error {
+	_, err := scm.Do(ctx, method, a.baseUrl+path, headers(a.token), in, out)
+	return err
+}
+
+type treeEntry struct {
+	Path string  `json:"path"`
+	Mode string  `json:"mode"`
+	Type string  `json:"type"`
+	SHA  *string `json:"sha"` // Null deletes the path
+}
+
+type commitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Export writes the files under opts.Path in a single commit on opts.Branch.
+// Files already in the branch with the same content are skipped, if nothing
+// changed no commit is made. Syncs delete in the same commit.
+func (Provider) Export(ctx context.Context, repo scm.Repo, files []scm.File, opts ExportOptions) (*ExportResult, error) {
+	if repo.Token == "" {
+		return nil, fmt.Errorf("GitHub token is required")
+	}
+
+	baseUrl, err := repoUrl(repo)
+	if err != nil {
+		return nil, err
+	}
+	a := &api{baseUrl: baseUrl, token: repo.Token}
+
+	branch := opts.BranchOrDefault()
+	baseBranch := opts.BaseBranch
+
+	head, err := a.branchHead(ctx, branch)
+	missing := errors.Is(err, scm.ErrRefNotFound)
+	empty := errors.Is(err, errEmptyRepository)
+	if err != nil && !missing && !empty {
+		return nil, fmt.Errorf("failed to get branch %s: %w", branch, err)
+	}
+
+	if baseBranch == "" && (missing || opts.CreatePullRequest) {
+		if baseBranch, err = a.defaultBranch(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get default branch: %w", err)
+		}
+	}
+	if opts.CreatePullRequest && baseBranch == branch {
+		return nil, fmt.Errorf("a pull request needs a branch other than its base branch %s", baseBranch)
+	}
+
+	if missing {
+		head, err = a.branchHead(ctx, baseBranch)
+
+		// A missing default branch means there are no commits yet
+		empty = errors.Is(err, errEmptyRepository) || (opts.BaseBranch == "" && errors.Is(err, scm.ErrRefNotFound))
+		if err != nil && !empty {
+			return nil, fmt.Errorf("failed to get base branch %s: %w", baseBranch, err)
+		}
+	}
+
+	// An empty repository has no tree to build on
+	var baseTree string
+	existing := map[string]treeEntry{}
+	if !empty {
+		var parent struct {
+			Tree struct {
+				SHA string `json:"sha"`
+			} `json:"tree"`
+		}
+		if err := a.do(ctx, http.MethodGet, fmt.Sprintf("/git/commits/%s", head), nil, &parent); err != nil {
+			return nil, fmt.Errorf("failed to get commit %s: %w", head, err)
+		}
+		baseTree = parent.Tree.SHA
+
+		var truncated bool
+		if existing, truncated, err = a.listTree(ctx, baseTree); err != nil {
+			return nil, fmt.Errorf("failed to list repository files: %w", err)
+		}
+		if opts.Sync && truncated {
+			return nil, fmt.Errorf("the repository has too many files to sync")
+		}
+	}
+
+	// Only changed files go into the new tree, the rest comes from the base tree
+	var changed []scm.File
+	var entries []treeEntry
+	for _, file := range files {
+		fullPath := scm.FilePath(opts.Path, file.Path)
+
+		mode := fileMode
+		if entry, ok := existing[fullPath]; ok {
+			if *entry.SHA == blobSHA(file.Content) {
+				continue
+			}
+			mode = entry.Mode // Keeps the executable bit
+		}
+
+		changed = append(changed, file)
+		entries = append(entries, treeEntry{Path: fullPath, Mode: mode, Type: "blob"})
+	}
+
+	remote := make([]string, 0, len(existing))
+	for remotePath := range existing {
+		remote = append(remote, remotePath)
+	}
+	deletions, err := opts.Deletions(remote, files)
+	if err != nil {
+		return nil, err
+	}
+	for _, deletion := range deletions {
+		entries = append(entries, treeEntry{Path: deletion, Mode: existing[deletion].Mode, Type: "blob"})
+	}
+
+	if empty {
+		if len(changed) == 0 {
+			return nil, fmt.Errorf("the repository is empty and there are no files to export")
+		}
+
+		// Creates the branch
+		if head, baseTree, err = a.createFile(ctx, branch, entries[0].Path, changed[0].Content, opts.UploadOptions, len(files)); err != nil {
+			return nil, err
+		}
+		changed, entries = changed[1:], entries[1:]
+		missing = false
+	}
+
+	commitSHA := head
+	if len(entries) > 0 {
+		if err := a.uploadBlobs(ctx, changed, entries[:len(changed)]); err != nil {
+			return nil, err
+		}
+
+		if commitSHA, err = a.commit(ctx, baseTree, head, entries, opts.UploadOptions, len(files)); err != nil {
+			return nil, err
+		}
+	}
+
+	if missing {
+		err = a.do(ctx, http.MethodPost, "/git/refs", map[string]string{"ref": "refs/heads/" + branch, "sha": commitSHA}, nil)
+	} else if commitSHA != head {
+		// Not forced, so commits pushed since the branch was read aren't lost
+		err = a.do(ctx, http.MethodPatch, fmt.Sprintf("/git/refs/heads/%s", branch), map[string]any{"sha": commitSHA, "force": false}, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update branch %s: %w", branch, err)
+	}
+
+	result := &ExportResult{CommitSHA: commitSHA, DeletedPaths: deletions}
+
+	if opts.CreatePullRequest {
+		title := opts.PullRequestTitle
+		if title == "" {
+			title, _, _ = strings.Cut(opts.MessageOr(fmt.Sprintf("Upload %d files", len(files))), "\n")
+		}
+
+		if result.PullRequestUrl, err = a.pullRequest(ctx, repo, branch, baseBranch, title, opts.PullRequestBody); err != nil {
+			return nil, fmt.Errorf("failed to create pull request: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// blobSHA is the id git gives a file, used to skip unchanged files
+func blobSHA(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (a *api) branchHead(ctx context.Context, branch string) (string, error) {
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	err := a.do(ctx, http.MethodGet, fmt.Sprintf("/git/ref/heads/%s", branch), nil, &ref)
+	if scm.IsNotFound(err) {
+		return "", fmt.Errorf("%w: %s", scm.ErrRefNotFound, branch)
+	}
+	var httpErr *scm.HttpError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict {
+		return "", errEmptyRepository
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return ref.Object.SHA, nil
+}
+
+func (a *api) defaultBranch(ctx context.Context) (string, error) {
+	var info struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := a.do(ctx, http.MethodGet, "", nil, &info); err != nil {
+		return "", err
+	}
+
+	return info.DefaultBranch, nil
+}
+
+// listTree returns the blobs of a tree by path. Very large trees are
+// truncated by GitHub, which is reported with the second return value.
+func (a *api) listTree(ctx context.Context, treeSHA string) (map[string]treeEntry, bool, error) {
+	var tree struct {
+		Tree      []treeEntry `json:"tree"`
+		Truncated bool        `json:"truncated"`
+	}
+	if err := a.do(ctx, http.MethodGet, fmt.Sprintf("/git/trees/%s?recursive=1", treeSHA), nil, &tree); err != nil {
+		return nil, false, err
+	}
+
+	blobs := make(map[string]treeEntry, len(tree.Tree))
+	for _, entry := range tree.Tree {
+		if entry.Type == "blob" && entry.SHA != nil {
+			blobs[entry.Path] = entry
+		}
+	}
+
+	return blobs, tree.Truncated, nil
+}
+
+// uploadBlobs creates a blob for every file and sets the sha of its entry
+func (a *api) uploadBlobs(ctx context.Context, files []scm.File, entries []treeEntry) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	semaphore := make(chan struct{}, blobUploads)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i := range files {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			var blob struct {
+				SHA string `json:"sha"`
+			}
+			err := a.do(ctx, http.MethodPost, "/git/blobs", map[string]string{
+				"content":  base64.StdEncoding.EncodeToString(files[i].Content),
+				"encoding": "base64",
+			}, &blob)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("failed to upload file %s: %w", entries[i].Path, err)
+					cancel()
+				})
+				return
+			}
+
+			entries[i].SHA = &blob.SHA
+		}(i)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// createFile commits a single file with the contents API, which unlike the
+// Git Data API works in an empty repository. It returns the commit and its
+// tree.
+func (a *api) createFile(ctx context.Context, branch, filePath string, content []byte, opts scm.UploadOptions, fileCount int) (string, string, error) {
+	fileReq := map[string]any{
+		"message": opts.MessageOr(fmt.Sprintf("Upload %d files", fileCount)),
+		"content": base64.StdEncoding.EncodeToString(content),
+		"branch":  branch,
+	}
+	if opts.AuthorName != "" && opts.AuthorEmail != "" {
+		fileReq["author"] = commitAuthor{Name: opts.AuthorName, Email: opts.AuthorEmail}
+	}
+
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	var resp struct {
+		Commit struct {
+			SHA  string `json:"sha"`
+			Tree struct {
+				SHA string `json:"sha"`
+			} `json:"tree"`
+		} `json:"commit"`
+	}
+	if err := a.do(ctx, http.MethodPut, "/contents/"+strings.Join(segments, "/"), fileReq, &resp); err != nil {
+		return "", "", fmt.Errorf("failed to create file %s: %w", filePath, err)
+	}
+
+	return resp.Commit.SHA, resp.Commit.Tree.SHA, nil
+}
+
+// commit creates a commit of the entries on top of baseTree and parent
+func (a *api) commit(ctx context.Context, baseTree, parent string, entries []treeEntry, opts scm.UploadOptions, fileCount int) (string, error) {
+	var tree struct {
+		SHA string `json:"sha"`
+	}
+	if err := a.do(ctx, http.MethodPost, "/git/trees", map[string]any{"base_tree": baseTree, "tree": entries}, &tree); err != nil {
+		return "", fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	commitReq := map[string]any{
+		"message": opts.MessageOr(fmt.Sprintf("Upload %d files", fileCount)),
+		"tree":    tree.SHA,
+		"parents": []string{parent},
+	}
+	if opts.AuthorName != "" && opts.AuthorEmail != "" {
+		commitReq["author"] = commitAuthor{Name: opts.AuthorName, Email: opts.AuthorEmail}
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := a.do(ctx, http.MethodPost, "/git/commits", commitReq, &commit); err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return commit.SHA, nil
+}
+
+type pullRequest struct {
+	HtmlUrl string `json:"html_url"`
+}
+
+// pullRequest opens a pull request from branch into base, or returns the one
+// that is already open
+func (a *api) pullRequest(ctx context.Context, repo scm.Repo, branch, base, title, body string) (string, error) {
+	var pr pullRequest
+	err := a.do(ctx, http.MethodPost, "/pulls", map[string]string{
+		"title": title,
+		"head":  branch,
+		"base":  base,
+		"body":  body,
+	}, &pr)
+
+	var httpErr *scm.HttpError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnprocessableEntity && strings.Contains(httpErr.Body, "already exists") {
+		owner, _, _ := repo.SplitName()
+
+		var open []pullRequest
+		query := url.Values{"head": {owner + ":" + branch}, "base": {base}, "state": {"open"}}
+		if err := a.do(ctx, http.MethodGet, "/pulls?"+query.Encode(), nil, &open); err != nil {
+			return "", err
+		}
+		if len(open) > 0 {
+			return open[0].HtmlUrl, nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return pr.HtmlUrl, nil
+}
@@ -0,0 +1,392 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+)
+
+// fakeGitHub implements the parts of the Git Data and contents APIs used by
+// Export. Without files it is an empty repository, in which like on GitHub
+// only the contents API works.
+type fakeGitHub struct {
+	mu      sync.Mutex
+	nextID  int
+	refs    map[string]string               // branch -> commit
+	commits map[string]string               // commit -> tree
+	trees   map[string]map[string]treeEntry // tree -> path -> entry
+	pulls   []map[string]string
+	calls   map[string]int // "METHOD kind" -> count
+}
+
+func newFakeGitHub(files map[string]string) *fakeGitHub {
+	f := &fakeGitHub{
+		refs:    map[string]string{},
+		commits: map[string]string{},
+		trees:   map[string]map[string]treeEntry{},
+		calls:   map[string]int{},
+	}
+	if files == nil {
+		return f
+	}
+
+	tree := map[string]treeEntry{}
+	for path, content := range files {
+		sha := blobSHA([]byte(content))
+		tree[path] = treeEntry{Path: path, Mode: fileMode, Type: "blob", SHA: &sha}
+	}
+	f.trees["tree-0"] = tree
+	f.commits["commit-0"] = "tree-0"
+	f.refs["main"] = "commit-0"
+
+	return f
+}
+
+func (f *fakeGitHub) id(kind string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", kind, f.nextID)
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo")
+	var body map[string]any
+	json.NewDecoder(r.Body).Decode(&body)
+
+	reply := func(v any) { json.NewEncoder(w).Encode(v) }
+	kind := strings.Split(strings.TrimPrefix(path, "/git"), "/")[1:]
+	if len(kind) > 0 {
+		f.calls[r.Method+" "+kind[0]]++
+	}
+
+	if len(f.refs) == 0 && strings.HasPrefix(path, "/git/") {
+		http.Error(w, `{"message":"Git Repository is empty."}`, http.StatusConflict)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		reply(map[string]string{"default_branch": "main"})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/git/ref/heads/"):
+		sha, ok := f.refs[strings.TrimPrefix(path, "/git/ref/heads/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		reply(map[string]any{"object": map[string]string{"sha": sha}})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/git/commits/"):
+		reply(map[string]any{"tree": map[string]string{"sha": f.commits[strings.TrimPrefix(path, "/git/commits/")]}})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/git/trees/"):
+		var entries []treeEntry
+		for _, entry := range f.trees[strings.TrimPrefix(path, "/git/trees/")] {
+			entries = append(entries, entry)
+		}
+		reply(map[string]any{"tree": entries, "truncated": false})
+
+	case r.Method == http.MethodPost && path == "/git/blobs":
+		content, _ := base64.StdEncoding.DecodeString(body["content"].(string))
+		reply(map[string]string{"sha": blobSHA(content)})
+
+	case r.Method == http.MethodPost && path == "/git/trees":
+		base, ok := f.trees[fmt.Sprint(body["base_tree"])]
+		if body["base_tree"] != nil && !ok {
+			http.Error(w, `{"message":"base_tree not found"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		tree := map[string]treeEntry{}
+		for p, entry := range base {
+			tree[p] = entry
+		}
+		for _, raw := range body["tree"].([]any) {
+			entry := raw.(map[string]any)
+			p := entry["path"].(string)
+			if entry["sha"] == nil {
+				delete(tree, p)
+				continue
+			}
+			sha := entry["sha"].(string)
+			tree[p] = treeEntry{Path: p, Mode: entry["mode"].(string), Type: "blob", SHA: &sha}
+		}
+		sha := f.id("tree")
+		f.trees[sha] = tree
+		reply(map[string]string{"sha": sha})
+
+	case r.Method == http.MethodPost && path == "/git/commits":
+		for _, parent := range body["parents"].([]any) {
+			if _, ok := f.commits[parent.(string)]; !ok {
+				http.Error(w, `{"message":"parent not found"}`, http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		sha := f.id("commit")
+		f.commits[sha] = body["tree"].(string)
+		reply(map[string]string{"sha": sha})
+
+	case r.Method == http.MethodPost && path == "/git/refs":
+		f.refs[strings.TrimPrefix(body["ref"].(string), "refs/heads/")] = body["sha"].(string)
+		reply(map[string]any{})
+
+	case r.Method == http.MethodPatch && strings.HasPrefix(path, "/git/refs/heads/"):
+		f.refs[strings.TrimPrefix(path, "/git/refs/heads/")] = body["sha"].(string)
+		reply(map[string]any{})
+
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "/contents/"):
+		branch := body["branch"].(string)
+		tree := map[string]treeEntry{}
+		for p, entry := range f.trees[f.commits[f.refs[branch]]] {
+			tree[p] = entry
+		}
+		p := strings.TrimPrefix(path, "/contents/")
+		content, _ := base64.StdEncoding.DecodeString(body["content"].(string))
+		sha := blobSHA(content)
+		tree[p] = treeEntry{Path: p, Mode: fileMode, Type: "blob", SHA: &sha}
+
+		treeSHA, commitSHA := f.id("tree"), f.id("commit")
+		f.trees[treeSHA] = tree
+		f.commits[commitSHA] = treeSHA
+		f.refs[branch] = commitSHA
+		reply(map[string]any{"commit": map[string]any{"sha": commitSHA, "tree": map[string]string{"sha": treeSHA}}})
+
+	case r.Method == http.MethodPost && path == "/pulls":
+		for _, pr := range f.pulls {
+			if pr["head"] == body["head"] {
+				http.Error(w, `{"message":"A pull request already exists for owner:feature."}`, http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		pr := map[string]string{"head": body["head"].(string), "html_url": fmt.Sprintf("https://github.com/owner/repo/pull/%d", len(f.pulls)+1)}
+		f.pulls = append(f.pulls, pr)
+		reply(pr)
+
+	case r.Method == http.MethodGet && path == "/pulls":
+		reply(f.pulls)
+
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+path, http.StatusBadRequest)
+	}
+}
+
+// files returns the contents of the branch by path
+func (f *fakeGitHub) files(t *testing.T, branch string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	files := map[string]string{}
+	for p, entry := range f.trees[f.commits[f.refs[branch]]] {
+		files[p] = *entry.SHA
+	}
+	return files
+}
+
+func startFakeGitHub(t *testing.T, files map[string]string) (*fakeGitHub, scm.Repo) {
+	fake := newFakeGitHub(files)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, scm.Repo{Name: "owner/repo", Token: "token", ApiUrl: server.URL}
+}
+
+func TestExport_SingleCommit(t *testing.T) {
+	fake, repo := startFakeGitHub(t, map[string]string{"README.md": "old", "web/keep.txt": "keep"})
+
+	result, err := Provider{}.Export(context.Background(), repo, []scm.File{
+		{Path: "/README.md", Content: []byte("new")},
+		{Path: "/src/index.ts", Content: []byte("export {}")},
+	}, ExportOptions{UploadOptions: scm.UploadOptions{Branch: "main"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fake.calls["POST commits"] != 1 {
+		t.Errorf("made %d commits, want 1", fake.calls["POST commits"])
+	}
+	if fake.refs["main"] != result.CommitSHA {
+		t.Errorf("main is at %s, want %s", fake.refs["main"], result.CommitSHA)
+	}
+
+	files := fake.files(t, "main")
+	want := map[string]string{
+		"README.md":    blobSHA([]byte("new")),
+		"src/index.ts": blobSHA([]byte("export {}")),
+		"web/keep.txt": blobSHA([]byte("keep")),
+	}
+	for p, sha := range want {
+		if files[p] != sha {
+			t.Errorf("%s: got %q, want %q", p, files[p], sha)
+		}
+	}
+}
+
+func TestExport_Unchanged(t *testing.T) {
+	fake, repo := startFakeGitHub(t, map[string]string{"README.md": "same"})
+
+	result, err := Provider{}.Export(context.Background(), repo, []scm.File{
+		{Path: "/README.md", Content: []byte("same")},
+	}, ExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.CommitSHA != "commit-0" || fake.calls["POST commits"] != 0 || fake.calls["POST blobs"] != 0 {
+		t.Errorf("unchanged export committed %s", result.CommitSHA)
+	}
+}
+
+func TestExport_NewBranchWithPullRequest(t *testing.T) {
+	fake, repo := startFakeGitHub(t, map[string]string{"README.md": "main"})
+
+	opts := ExportOptions{
+		UploadOptions:     scm.UploadOptions{Branch: "feature", Path: "docs", Message: "Add docs\n\nLonger description"},
+		CreatePullRequest: true,
+	}
+	files := []scm.File{{Path: "/guide.md", Content: []byte("guide")}}
+
+	result, err := Provider{}.Export(context.Background(), repo, files, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fake.refs["main"] != "commit-0" {
+		t.Error("base branch was changed")
+	}
+	if got := fake.files(t, "feature"); len(got) != 2 || got["docs/guide.md"] == "" {
+		t.Errorf("unexpected files on the new branch %v", got)
+	}
+	if result.PullRequestUrl != "https://github.com/owner/repo/pull/1" {
+		t.Errorf("unexpected pull request %q", result.PullRequestUrl)
+	}
+
+	// Exporting again returns the open pull request
+	files[0].Content = []byte("guide v2")
+	result, err = Provider{}.Export(context.Background(), repo, files, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PullRequestUrl != "https://github.com/owner/repo/pull/1" || len(fake.pulls) != 1 {
+		t.Errorf("unexpected pull request %q", result.PullRequestUrl)
+	}
+}
+
+func TestExport_PullRequestIntoItself(t *testing.T) {
+	_, repo := startFakeGitHub(t, nil)
+
+	_, err := Provider{}.Export(context.Background(), repo, nil, ExportOptions{
+		UploadOptions:     scm.UploadOptions{Branch: "main"},
+		CreatePullRequest: true,
+	})
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestExport_Sync(t *testing.T) {
+	fake, repo := startFakeGitHub(t, map[string]string{
+		"README.md":        "root",
+		"web/index.html":   "old",
+		"web/stale.js":     "stale",
+		"web/lib/gone.css": "gone",
+	})
+
+	files := []scm.File{{Path: "/index.html", Content: []byte("new")}}
+
+	// Over the cap nothing is written
+	_, err := Provider{}.Export(context.Background(), repo, files, ExportOptions{
+		UploadOptions: scm.UploadOptions{Path: "web", Sync: true, MaxDeletions: 1},
+	})
+	if !errors.Is(err, scm.ErrTooManyDeletions) || fake.refs["main"] != "commit-0" {
+		t.Fatalf("expected ErrTooManyDeletions without a commit, got %v", err)
+	}
+
+	result, err := Provider{}.Export(context.Background(), repo, files, ExportOptions{
+		UploadOptions: scm.UploadOptions{Path: "web", Sync: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fake.calls["POST commits"] != 1 {
+		t.Errorf("made %d commits, want 1", fake.calls["POST commits"])
+	}
+	if len(result.DeletedPaths) != 2 {
+		t.Errorf("unexpected deletions %v", result.DeletedPaths)
+	}
+
+	got := fake.files(t, "main")
+	if len(got) != 2 || got["README.md"] == "" || got["web/index.html"] != blobSHA([]byte("new")) {
+		t.Errorf("unexpected files after sync %v", got)
+	}
+}
+
+func TestExport_EmptyRepository(t *testing.T) {
+	fake, repo := startFakeGitHub(t, nil)
+
+	// The first file creates the branch, the other one is committed on top
+	result, err := Provider{}.Export(context.Background(), repo, []scm.File{
+		{Path: "/README.md", Content: []byte("first")},
+		{Path: "/guide.md", Content: []byte("guide")},
+	}, ExportOptions{UploadOptions: scm.UploadOptions{Path: "docs", Sync: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fake.refs["main"] != result.CommitSHA {
+		t.Errorf("main is at %s, want %s", fake.refs["main"], result.CommitSHA)
+	}
+	if fake.calls["PUT contents"] != 1 || fake.calls["POST commits"] != 1 {
+		t.Errorf("unexpected calls %v", fake.calls)
+	}
+	files := fake.files(t, "main")
+	if len(files) != 2 || files["docs/README.md"] != blobSHA([]byte("first")) || files["docs/guide.md"] != blobSHA([]byte("guide")) {
+		t.Errorf("got files %v", files)
+	}
+
+	// The next export builds on the first commit
+	if _, err := (Provider{}).Export(context.Background(), repo, []scm.File{
+		{Path: "/CHANGELOG.md", Content: []byte("second")},
+	}, ExportOptions{UploadOptions: scm.UploadOptions{Path: "docs"}}); err != nil {
+		t.Fatal(err)
+	}
+	if files := fake.files(t, "main"); len(files) != 3 {
+		t.Errorf("got files %v", files)
+	}
+}
+
+func TestExport_EmptyRepositoryWithoutFiles(t *testing.T) {
+	_, repo := startFakeGitHub(t, nil)
+
+	if _, err := (Provider{}).Export(context.Background(), repo, nil, ExportOptions{}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestExport_EmptyRepositorySingleFile(t *testing.T) {
+	fake, repo := startFakeGitHub(t, nil)
+
+	result, err := Provider{}.Export(context.Background(), repo, []scm.File{
+		{Path: "/README.md", Content: []byte("first")},
+	}, ExportOptions{UploadOptions: scm.UploadOptions{Branch: "feature"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fake.refs["feature"] != result.CommitSHA || fake.calls["POST commits"] != 0 {
+		t.Errorf("feature is at %s, want %s", fake.refs["feature"], result.CommitSHA)
+	}
+	if files := fake.files(t, "feature"); len(files) != 1 || files["README.md"] != blobSHA([]byte("first")) {
+		t.Errorf("got files %v", files)
+	}
+}
@@ -1,22 +1,29 @@
 package gitlab
 
 import (
-	"bytes"
+	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"path"
-	"strings"
+	"net/url"
+	"strconv"
 
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
 	zipImporter "github.com/metorial/metorial/services/code-bucket/pkg/zip-importer"
 )
 
-func DownloadRepo(projectID int64, repoPath, ref, token, gitlabAPIURL string) (*zipImporter.ZipFileIterator, error) {
-	// GitLab API endpoint for downloading repository archive
-	url := fmt.Sprintf("%s/projects/%d/repository/archive.zip?sha=%s", gitlabAPIURL, projectID, ref)
+const (
+	defaultApiUrl = "https://gitlab.com/api/v4"
+
+	// Page size of tree listings, the maximum of GitLab
+	treePageSize = 100
+)
+
+type Provider struct{}
 
+var _ scm.Provider = Provider{}
+
+func headers(token string) map[string]string {
 	headers := map[string]string{
 		"Accept": "*/*",
 	}
@@ -25,116 +32,217 @@ func DownloadRepo(projectID int64, repoPath, ref, token, gitlabAPIURL string) (*
 		headers["Authorization"] = fmt.Sprintf("Bearer %s", token)
 	}
 
-	return zipImporter.DownloadZip(url, repoPath, headers)
+	return headers
+}
+
+// Projects are addressed by id or by their url-encoded full path
+func projectUrl(repo scm.Repo) string {
+	return fmt.Sprintf("%s/projects/%s", repo.ApiUrlOr(defaultApiUrl), url.PathEscape(repo.Name))
 }
 
-type FileToUpload struct {
-	Path    string
-	Content []byte
+func (Provider) DownloadArchive(ctx context.Context, repo scm.Repo, ref, repoPath string) (*zipImporter.ZipFileIterator, error) {
+	// GitLab API endpoint for downloading repository archive
+	archiveUrl := fmt.Sprintf("%s/repository/archive.zip?sha=%s", projectUrl(repo), url.QueryEscape(ref))
+
+	return zipImporter.DownloadArchive(archiveUrl, repoPath, headers(repo.Token))
+}
+
+func (Provider) ResolveRef(ctx context.Context, repo scm.Repo, ref string) (string, error) {
+	var commit struct {
+		ID string `json:"id"`
+	}
+	_, err := scm.Do(ctx, http.MethodGet, fmt.Sprintf("%s/repository/commits/%s", projectUrl(repo), url.PathEscape(ref)), headers(repo.Token), nil, &commit)
+	if scm.IsNotFound(err) {
+		return "", fmt.Errorf("%w: %s", scm.ErrRefNotFound, ref)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return commit.ID, nil
 }
 
 type gitlabFileAction struct {
 	Action   string `json:"action"`
 	FilePath string `json:"file_path"`
 	Content  string `json:"content"`
+	Encoding string `json:"encoding,omitempty"`
 }
 
+// A missing Branch is created from StartBranch
 type gitlabCommitRequest struct {
 	Branch        string             `json:"branch"`
+	StartBranch   string             `json:"start_branch,omitempty"`
 	CommitMessage string             `json:"commit_message"`
+	AuthorName    string             `json:"author_name,omitempty"`
+	AuthorEmail   string             `json:"author_email,omitempty"`
 	Actions       []gitlabFileAction `json:"actions"`
 }
 
-func UploadToRepo(projectID int64, targetPath, token, gitlabAPIURL string, files []FileToUpload) error {
-	if token == "" {
-		return fmt.Errorf("GitLab token is required")
+type ExportResult struct {
+	CommitSHA    string
+	DeletedPaths []string // Files removed by a sync
+}
+
+func (p Provider) UploadFiles(ctx context.Context, repo scm.Repo, files []scm.File, opts scm.UploadOptions) (string, error) {
+	result, err := p.Export(ctx, repo, files, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return result.CommitSHA, nil
+}
+
+// Export works like UploadFiles, and also reports the files a sync deleted
+func (Provider) Export(ctx context.Context, repo scm.Repo, files []scm.File, opts scm.UploadOptions) (*ExportResult, error) {
+	if repo.Token == "" {
+		return nil, fmt.Errorf("GitLab token is required")
 	}
 
-	client := &http.Client{}
-	branch := "main" // Default to main branch
+	branch := opts.BranchOrDefault()
+
+	startBranch, err := startBranch(ctx, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	// The files under the target path decide between create and update. A
+	// new branch starts with the files of the branch it is created from.
+	ref := branch
+	if startBranch != "" {
+		ref = startBranch
+	}
+	remote, err := listFiles(ctx, repo, ref, scm.FilePath(opts.Path, ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %w", err)
+	}
+
+	existing := make(map[string]bool, len(remote))
+	for _, remotePath := range remote {
+		existing[remotePath] = true
+	}
+
+	deletions, err := opts.Deletions(remote, files)
+	if err != nil {
+		return nil, err
+	}
 
 	// GitLab supports batch commits, so we can upload all files in a single commit
-	actions := make([]gitlabFileAction, 0, len(files))
+	actions := make([]gitlabFileAction, 0, len(files)+len(deletions))
 
 	for _, file := range files {
-		// Normalize the path by joining targetPath with file.Path
-		fullPath := path.Join(targetPath, file.Path)
-		// Clean up any double slashes or leading slashes
-		fullPath = strings.TrimPrefix(fullPath, "/")
-
-		// Encode content to base64
-		encodedContent := base64.StdEncoding.EncodeToString(file.Content)
+		fullPath := scm.FilePath(opts.Path, file.Path)
 
-		// Check if file exists to determine action
 		action := "create"
-		if fileExists, _ := checkFileExists(client, projectID, fullPath, branch, token, gitlabAPIURL); fileExists {
+		if existing[fullPath] {
 			action = "update"
 		}
 
 		actions = append(actions, gitlabFileAction{
 			Action:   action,
 			FilePath: fullPath,
-			Content:  encodedContent,
+			Content:  base64.StdEncoding.EncodeToString(file.Content),
+			Encoding: "base64",
 		})
 	}
 
+	for _, deletion := range deletions {
+		actions = append(actions, gitlabFileAction{Action: "delete", FilePath: deletion})
+	}
+
 	// Create commit with all file actions
 	commitReq := gitlabCommitRequest{
 		Branch:        branch,
-		CommitMessage: fmt.Sprintf("Upload %d files", len(files)),
+		StartBranch:   startBranch,
+		CommitMessage: opts.MessageOr(fmt.Sprintf("Upload %d files", len(files))),
+		AuthorName:    opts.AuthorName,
+		AuthorEmail:   opts.AuthorEmail,
 		Actions:       actions,
 	}
 
-	commitJSON, err := json.Marshal(commitReq)
-	if err != nil {
-		return fmt.Errorf("failed to marshal commit request: %w", err)
+	var commit struct {
+		ID string `json:"id"`
 	}
-
-	// POST to commits API
-	commitURL := fmt.Sprintf("%s/projects/%d/repository/commits", gitlabAPIURL, projectID)
-	req, err := http.NewRequest("POST", commitURL, bytes.NewBuffer(commitJSON))
-	if err != nil {
-		return fmt.Errorf("failed to create commit request: %w", err)
+	if _, err := scm.Do(ctx, http.MethodPost, fmt.Sprintf("%s/repository/commits", projectUrl(repo)), headers(repo.Token), commitReq, &commit); err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("Content-Type", "application/json")
+	return &ExportResult{CommitSHA: commit.ID, DeletedPaths: deletions}, nil
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to create commit: %w", err)
+// startBranch returns the branch a missing branch is created from: the
+// default branch, or none in an empty repository. It is empty if the branch
+// exists.
+func startBranch(ctx context.Context, repo scm.Repo, branch string) (string, error) {
+	if exists, err := branchExists(ctx, repo, branch); err != nil || exists {
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if _, err := scm.Do(ctx, http.MethodGet, projectUrl(repo), headers(repo.Token), nil, &project); err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+	if project.DefaultBranch == "" || project.DefaultBranch == branch {
+		return "", nil
+	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to create commit (status %d): %s", resp.StatusCode, string(body))
+	// Empty repositories name a default branch that doesn't exist yet
+	if exists, err := branchExists(ctx, repo, project.DefaultBranch); err != nil || !exists {
+		return "", err
 	}
 
-	return nil
+	return project.DefaultBranch, nil
 }
 
-// Helper function to check if a file exists in the repository
-func checkFileExists(client *http.Client, projectID int64, filePath, branch, token, gitlabAPIURL string) (bool, error) {
-	fileURL := fmt.Sprintf("%s/projects/%d/repository/files/%s?ref=%s",
-		gitlabAPIURL,
-		projectID,
-		strings.ReplaceAll(filePath, "/", "%2F"), // URL encode the file path
-		branch,
-	)
-
-	req, err := http.NewRequest("GET", fileURL, nil)
+func branchExists(ctx context.Context, repo scm.Repo, branch string) (bool, error) {
+	_, err := scm.Do(ctx, http.MethodGet, fmt.Sprintf("%s/repository/branches/%s", projectUrl(repo), url.PathEscape(branch)), headers(repo.Token), nil, nil)
+	if scm.IsNotFound(err) {
+		return false, nil
+	}
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("failed to get branch %s: %w", branch, err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return false, err
+	return true, nil
+}
+
+// listFiles lists the files under dir on branch. A missing branch or
+// directory has no files.
+func listFiles(ctx context.Context, repo scm.Repo, branch, dir string) ([]string, error) {
+	var files []string
+
+	page := "1"
+	for page != "" {
+		query := url.Values{
+			"ref":       {branch},
+			"path":      {dir},
+			"recursive": {"true"},
+			"per_page":  {strconv.Itoa(treePageSize)},
+			"page":      {page},
+		}
+
+		var entries []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		}
+		resp, err := scm.Do(ctx, http.MethodGet, fmt.Sprintf("%s/repository/tree?%s", projectUrl(repo), query.Encode()), headers(repo.Token), nil, &entries)
+		if scm.IsNotFound(err) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.Type == "blob" {
+				files = append(files, entry.Path)
+			}
+		}
+
+		page = resp.Header.Get("X-Next-Page")
 	}
-	defer resp.Body.Close()
 
-	return resp.StatusCode == http.StatusOK, nil
+	return files, nil
 }
@@ -0,0 +1,215 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/scm"
+)
+
+// fakeGitLab serves the files of its branches as a tree listing of two entries
+// per page and records the commit requests. Without branches it is an empty
+// repository.
+type fakeGitLab struct {
+	mu       sync.Mutex
+	branches map[string][]string // branch -> file paths
+	commits  []gitlabCommitRequest
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.EscapedPath(), "/projects/owner%2Frepo")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	reply := func(v any) { json.NewEncoder(w).Encode(v) }
+
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		reply(map[string]string{"default_branch": "main"})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/repository/branches/"):
+		if _, ok := f.branches[strings.TrimPrefix(path, "/repository/branches/")]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		reply(map[string]any{})
+
+	case r.Method == http.MethodGet && path == "/repository/tree":
+		query := r.URL.Query()
+		files, ok := f.branches[query.Get("ref")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		var matching []string
+		for _, p := range files {
+			if dir := query.Get("path"); dir == "" || strings.HasPrefix(p, dir+"/") {
+				matching = append(matching, p)
+			}
+		}
+		sort.Strings(matching)
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		start, end := min((page-1)*2, len(matching)), min(page*2, len(matching))
+		if end < len(matching) {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+
+		entries := []map[string]string{}
+		for _, p := range matching[start:end] {
+			entries = append(entries, map[string]string{"path": p, "type": "blob"})
+		}
+		reply(entries)
+
+	case r.Method == http.MethodPost && path == "/repository/commits":
+		var commit gitlabCommitRequest
+		json.NewDecoder(r.Body).Decode(&commit)
+		f.commits = append(f.commits, commit)
+		reply(map[string]string{"id": "abc123"})
+
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+path, http.StatusBadRequest)
+	}
+}
+
+// actions returns the actions of the last commit as "action path"
+func (f *fakeGitLab) actions(t *testing.T) []string {
+	t.Helper()
+	if len(f.commits) == 0 {
+		t.Fatal("no commit was made")
+	}
+
+	var actions []string
+	for _, action := range f.commits[len(f.commits)-1].Actions {
+		actions = append(actions, action.Action+" "+action.FilePath)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+func startFakeGitLab(t *testing.T, branches map[string][]string) (*fakeGitLab, scm.Repo) {
+	fake := &fakeGitLab{branches: branches}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, scm.Repo{Name: "owner/repo", Token: "token", ApiUrl: server.URL}
+}
+
+var mainFiles = map[string][]string{
+	"main": {"README.md", "web/index.js", "web/lib/gone.css", "web/old.js", "web/vendor/keep.js"},
+}
+
+func TestExport_Sync(t *testing.T) {
+	fake, repo := startFakeGitLab(t, mainFiles)
+
+	files := []scm.File{
+		{Path: "/index.js", Content: []byte("module.exports = 1")},
+		{Path: "/new.js", Content: []byte("exports.a = 2")},
+	}
+
+	// Over the cap nothing is written
+	_, err := Provider{}.Export(context.Background(), repo, files, scm.UploadOptions{Path: "web", Sync: true, MaxDeletions: 2})
+	if !errors.Is(err, scm.ErrTooManyDeletions) || len(fake.commits) != 0 {
+		t.Fatalf("expected ErrTooManyDeletions without a commit, got %v", err)
+	}
+
+	result, err := Provider{}.Export(context.Background(), repo, files, scm.UploadOptions{Path: "web", Sync: true, Message: "Sync"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.CommitSHA != "abc123" || strings.Join(result.DeletedPaths, ",") != "web/lib/gone.css,web/old.js,web/vendor/keep.js" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	commit := fake.commits[0]
+	if commit.Branch != "main" || commit.StartBranch != "" || commit.CommitMessage != "Sync" {
+		t.Errorf("unexpected commit %+v", commit)
+	}
+	if commit.Actions[0].Content != base64.StdEncoding.EncodeToString([]byte("module.exports = 1")) || commit.Actions[0].Encoding != "base64" {
+		t.Errorf("unexpected action %+v", commit.Actions[0])
+	}
+
+	// The listing of the four files spans two pages
+	got := strings.Join(fake.actions(t), ", ")
+	if want := "create web/new.js, delete web/lib/gone.css, delete web/old.js, delete web/vendor/keep.js, update web/index.js"; got != want {
+		t.Errorf("got actions %s, want %s", got, want)
+	}
+}
+
+func TestExport_SyncFilter(t *testing.T) {
+	fake, repo := startFakeGitLab(t, mainFiles)
+
+	_, err := Provider{}.Export(context.Background(), repo, []scm.File{
+		{Path: "/index.js", Content: []byte("module.exports = 1")},
+	}, scm.UploadOptions{Path: "web", Sync: true, SyncFilter: func(filePath string) bool {
+		return !strings.HasPrefix(filePath, "/vendor/")
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := strings.Join(fake.actions(t), ", ")
+	if want := "delete web/lib/gone.css, delete web/old.js, update web/index.js"; got != want {
+		t.Errorf("got actions %s, want %s", got, want)
+	}
+}
+
+func TestExport_NewBranch(t *testing.T) {
+	fake, repo := startFakeGitLab(t, mainFiles)
+
+	_, err := Provider{}.Export(context.Background(), repo, []scm.File{
+		{Path: "/index.js", Content: []byte("module.exports = 1")},
+	}, scm.UploadOptions{Path: "web", Branch: "feature"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The branch starts with the files of main
+	commit := fake.commits[0]
+	if commit.Branch != "feature" || commit.StartBranch != "main" {
+		t.Errorf("expected feature to start from main, got %+v", commit)
+	}
+	if got := strings.Join(fake.actions(t), ", "); got != "update web/index.js" {
+		t.Errorf("unexpected actions %s", got)
+	}
+}
+
+func TestExport_EmptyRepository(t *testing.T) {
+	for _, branch := range []string{"main", "feature"} {
+		fake, repo := startFakeGitLab(t, map[string][]string{})
+
+		_, err := Provider{}.Export(context.Background(), repo, []scm.File{
+			{Path: "/index.js", Content: []byte("module.exports = 1")},
+		}, scm.UploadOptions{Branch: branch, Sync: true})
+		if err != nil {
+			t.Fatalf("%s: %v", branch, err)
+		}
+
+		commit := fake.commits[0]
+		if commit.Branch != branch || commit.StartBranch != "" {
+			t.Errorf("%s: expected a commit on the branch itself, got %+v", branch, commit)
+		}
+		if got := strings.Join(fake.actions(t), ", "); got != "create index.js" {
+			t.Errorf("%s: unexpected actions %s", branch, got)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package glob
+
+import "strings"
+
+type rule struct {
+	pattern *Pattern
+	negated bool
+}
+
+// Filter selects files by include and exclude patterns. A file is kept if it
+// matches any include pattern, or there are none, and is not excluded.
+// Patterns also match the directories a file is in, so `node_modules`
+// excludes everything below it. Exclude patterns are evaluated in order like
+// a .gitignore file, and a leading `!` re-includes files excluded earlier.
+type Filter struct {
+	include []*Pattern
+	exclude []rule
+}
+
+func NewFilter(include, exclude []string) (*Filter, error) {
+	filter := &Filter{}
+
+	for _, source := range include {
+		p, err := Compile(source)
+		if err != nil {
+			return nil, err
+		}
+		filter.include = append(filter.include, p)
+	}
+
+	for _, source := range exclude {
+		negated := strings.HasPrefix(source, "!")
+
+		p, err := Compile(strings.TrimPrefix(source, "!"))
+		if err != nil {
+			return nil, err
+		}
+		filter.exclude = append(filter.exclude, rule{pattern: p, negated: negated})
+	}
+
+	return filter, nil
+}
+
+// IsEmpty reports whether the filter keeps every file.
+func (f *Filter) IsEmpty() bool {
+	return f == nil || (len(f.include) == 0 && len(f.exclude) == 0)
+}
+
+// Match reports whether the filter keeps a file. A nil filter keeps all files.
+func (f *Filter) Match(filePath string) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	candidates := pathCandidates(filePath)
+
+	if len(f.include) > 0 {
+		included := false
+		for _, p := range f.include {
+			if p.matchAny(candidates) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	excluded := false
+	for _, r := range f.exclude {
+		if r.pattern.matchAny(candidates) {
+			excluded = !r.negated
+		}
+	}
+
+	return !excluded
+}
+
+// pathCandidates returns the directories containing a file followed by the
+// file itself, e.g. "a", "a/b" and "a/b/c.txt".
+func pathCandidates(filePath string) []string {
+	filePath = strings.TrimPrefix(filePath, "/")
+
+	var candidates []string
+	for i := 0; i < len(filePath); i++ {
+		if filePath[i] == '/' {
+			candidates = append(candidates, filePath[:i])
+		}
+	}
+
+	return append(candidates, filePath)
+}
+
+func (p *Pattern) matchAny(candidates []string) bool {
+	for i, candidate := range candidates {
+		isDir := i < len(candidates)-1
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.Match(candidate) {
+			return true
+		}
+	}
+
+	return false
+}
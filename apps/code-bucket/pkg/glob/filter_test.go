@@ -0,0 +1,56 @@
+package glob
+
+import "testing"
+
+func TestFilter(t *testing.T) {
+	filter, err := NewFilter(nil, []string{"node_modules/**", "*.log", "build/", "!important.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]bool{
+		"/src/index.ts":                   true,
+		"/node_modules/lib/index.js":      false,
+		"/packages/a/node_modules/x.js":   true,
+		"/debug.log":                      false,
+		"/logs/deep/server.log":           false,
+		"/important.log":                  true,
+		"/build/out.js":                   false,
+		"/build":                          true,
+		"/src/build/generated/types.d.ts": false,
+	}
+
+	for path, want := range cases {
+		if got := filter.Match(path); got != want {
+			t.Errorf("Match(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestFilter_Include(t *testing.T) {
+	filter, err := NewFilter([]string{"src/**", "/README.md"}, []string{"**/*.test.ts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]bool{
+		"/src/index.ts":      true,
+		"/src/index.test.ts": false,
+		"/README.md":         true,
+		"/docs/README.md":    false,
+		"/package.json":      false,
+	}
+
+	for path, want := range cases {
+		if got := filter.Match(path); got != want {
+			t.Errorf("Match(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestFilter_Nil(t *testing.T) {
+	var filter *Filter
+	if !filter.Match("/anything") {
+		t.Error("nil filter should keep every file")
+	}
+}
@@ -0,0 +1,111 @@
+package glob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pattern is a compiled glob. Besides the usual `*`, `?` and `[...]`, a `**`
+// path segment matches any number of directories. As in .gitignore, patterns
+// without a slash match at any depth, so `*.go` matches all Go files, while a
+// leading slash anchors the pattern to the root. A trailing slash marks a
+// pattern that only applies to directories.
+type Pattern struct {
+	source  string
+	re      *regexp.Regexp
+	dirOnly bool
+}
+
+func Compile(pattern string) (*Pattern, error) {
+	anchored := strings.HasPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+
+	normalized := strings.TrimSuffix(strings.TrimPrefix(pattern, "/"), "/")
+	if normalized == "" {
+		return nil, fmt.Errorf("empty glob pattern")
+	}
+
+	if !anchored && !strings.Contains(normalized, "/") {
+		normalized = "**/" + normalized
+	}
+
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(normalized); i++ {
+		c := normalized[i]
+
+		switch c {
+		case '*':
+			if i+1 < len(normalized) && normalized[i+1] == '*' {
+				atSegmentStart := i == 0 || normalized[i-1] == '/'
+				i++
+
+				switch {
+				case atSegmentStart && i+1 < len(normalized) && normalized[i+1] == '/':
+					// "**/" matches zero or more directories
+					sb.WriteString("(?:.*/)?")
+					i++
+				default:
+					sb.WriteString(".*")
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+
+		case '?':
+			sb.WriteString("[^/]")
+
+		case '[':
+			end := strings.IndexByte(normalized[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class in %q", pattern)
+			}
+
+			class := normalized[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+
+		case '\\':
+			if i+1 < len(normalized) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(normalized[i])))
+			}
+
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+	}
+
+	return &Pattern{source: pattern, re: re, dirOnly: dirOnly}, nil
+}
+
+// Match reports whether a file path matches the pattern. Leading slashes are
+// ignored, so bucket paths can be matched as they are stored.
+func (p *Pattern) Match(filePath string) bool {
+	return p.re.MatchString(strings.TrimPrefix(filePath, "/"))
+}
+
+func (p *Pattern) String() string {
+	return p.source
+}
+
+func Match(pattern, filePath string) (bool, error) {
+	p, err := Compile(pattern)
+	if err != nil {
+		return false, err
+	}
+
+	return p.Match(filePath), nil
+}
@@ -0,0 +1,44 @@
+package glob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "/pkg/fs/fsManager.go", true},
+		{"*.go", "main.ts", false},
+		{"src/*.ts", "src/index.ts", true},
+		{"src/*.ts", "src/lib/index.ts", false},
+		{"src/**/*.ts", "src/index.ts", true},
+		{"src/**/*.ts", "/src/lib/deep/index.ts", true},
+		{"src/**", "src/lib/index.ts", true},
+		{"src/**", "other/index.ts", false},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"[abc].txt", "b.txt", true},
+		{"[!abc].txt", "b.txt", false},
+		{"docs/a.md", "/docs/a.md", true},
+	}
+
+	for _, c := range cases {
+		got, err := Match(c.pattern, c.path)
+		if err != nil {
+			t.Fatalf("Match(%q) returned error: %v", c.pattern, err)
+		}
+		if got != c.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", c.pattern, c.path, got, c.want)
+		}
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	for _, pattern := range []string{"", "[abc"} {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("expected an error for %q", pattern)
+		}
+	}
+}
@@ -6,8 +6,13 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
-func NewGrpcServer(serviceName string) *grpc.Server {
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(RecoveryInterceptor))
+// NewGrpcServer creates a server with panic recovery. Interceptors passed in
+// opts with grpc.ChainUnaryInterceptor run after the recovery interceptors.
+func NewGrpcServer(serviceName string, opts ...grpc.ServerOption) *grpc.Server {
+	grpcServer := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(RecoveryInterceptor),
+		grpc.ChainStreamInterceptor(StreamRecoveryInterceptor),
+	}, opts...)...)
 
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
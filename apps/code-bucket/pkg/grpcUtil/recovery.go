@@ -31,3 +31,24 @@ func RecoveryInterceptor(
 
 	return handler(ctx, req)
 }
+
+func StreamRecoveryInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		r := recover()
+
+		if r != nil {
+			sentry.CurrentHub().Recover(r)
+			sentry.Flush(time.Second * 5)
+
+			log.Printf("recovered from panic: %v", r)
+			err = status.Errorf(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(srv, stream)
+}
@@ -0,0 +1,260 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// A keyring signs tokens with one key and verifies them with any of its keys,
+// picked by the kid header. To rotate, add the new key everywhere first, then
+// switch the signing key, and remove the old key once its tokens expired.
+//
+// The legacy shared secret has no id. It verifies tokens without a kid header
+// and signs tokens if no other signing key is configured.
+
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+
+	signKey   any // nil for keys that only verify
+	verifyKey any
+}
+
+func NewHMACKey(id string, secret []byte) *Key {
+	return &Key{ID: id, Method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+}
+
+// ParsePEM reads an Ed25519 or RSA key. Private keys (PKCS #8, or PKCS #1
+// for RSA) can sign, public keys (PKIX) only verify.
+func ParsePEM(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data found", id)
+	}
+
+	var parsed any
+	var err error
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %v", id, err)
+	}
+
+	key := &Key{ID: id}
+
+	switch k := parsed.(type) {
+	case ed25519.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodEdDSA, k
+	case *rsa.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodRS256, k
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %T", id, parsed)
+	}
+
+	return key, nil
+}
+
+// LoadDir reads every <kid>.pem file in a directory
+func LoadDir(dir string) ([]*Key, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*Key, 0, len(paths))
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParsePEM(strings.TrimSuffix(filepath.Base(p), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (k *Key) CanSign() bool {
+	return k.signKey != nil
+}
+
+type Keyring struct {
+	signing *Key
+	keys    map[string]*Key
+	legacy  *Key
+}
+
+// New creates a keyring from the keys in keysDir and the legacy secret, both
+// optional. signingKeyID picks the signing key and defaults to the only
+// private key in keysDir, or the secret.
+func New(secret, keysDir, signingKeyID string) (*Keyring, error) {
+	var keys []*Key
+	if keysDir != "" {
+		var err error
+		if keys, err = LoadDir(keysDir); err != nil {
+			return nil, err
+		}
+	}
+
+	var legacy *Key
+	if secret != "" {
+		legacy = NewHMACKey("", []byte(secret))
+	}
+
+	return NewFromKeys(keys, legacy, signingKeyID)
+}
+
+func NewFromKeys(keys []*Key, legacy *Key, signingKeyID string) (*Keyring, error) {
+	kr := &Keyring{keys: make(map[string]*Key), legacy: legacy}
+
+	var signers []*Key
+	for _, key := range keys {
+		if _, ok := kr.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %s", key.ID)
+		}
+		kr.keys[key.ID] = key
+
+		if key.CanSign() {
+			signers = append(signers, key)
+		}
+	}
+
+	switch {
+	case signingKeyID != "":
+		key, ok := kr.keys[signingKeyID]
+		if !ok || !key.CanSign() {
+			return nil, fmt.Errorf("signing key %s not found or not a private key", signingKeyID)
+		}
+		kr.signing = key
+	case len(signers) == 1:
+		kr.signing = signers[0]
+	case len(signers) > 1:
+		return nil, fmt.Errorf("multiple private keys found, the signing key id has to be set")
+	case legacy != nil:
+		kr.signing = legacy
+	default:
+		return nil, fmt.Errorf("no signing key configured")
+	}
+
+	return kr, nil
+}
+
+// Sign signs the claims with the signing key and sets its kid header
+func (kr *Keyring) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(kr.signing.Method, claims)
+	if kr.signing.ID != "" {
+		token.Header["kid"] = kr.signing.ID
+	}
+
+	return token.SignedString(kr.signing.signKey)
+}
+
+// Keyfunc resolves the verification key of a token for jwt.Parse
+func (kr *Keyring) Keyfunc(token *jwt.Token) (any, error) {
+	key := kr.legacy
+
+	if kid, ok := token.Header["kid"]; ok {
+		id, _ := kid.(string)
+		if key, ok = kr.keys[id]; !ok {
+			return nil, fmt.Errorf("unknown key id %q", id)
+		}
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("token has no key id")
+	}
+
+	// The algorithm is fixed by the key, never by the token
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}
+
+// ValidMethods lists the algorithms of all keys, for jwt.WithValidMethods
+func (kr *Keyring) ValidMethods() []string {
+	var methods []string
+	add := func(key *Key) {
+		if !slices.Contains(methods, key.Method.Alg()) {
+			methods = append(methods, key.Method.Alg())
+		}
+	}
+
+	for _, key := range kr.keys {
+		add(key)
+	}
+	if kr.legacy != nil {
+		add(kr.legacy)
+	}
+
+	return methods
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys. The shared secret is never published.
+func (kr *Keyring) JWKS() JWKS {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range kr.keys {
+		jwk := JWK{Kid: key.ID, Alg: key.Method.Alg(), Use: "sig"}
+
+		switch k := key.verifyKey.(type) {
+		case ed25519.PublicKey:
+			jwk.Kty, jwk.Crv, jwk.X = "OKP", "Ed25519", encode(k)
+		case *rsa.PublicKey:
+			jwk.Kty, jwk.N, jwk.E = "RSA", encode(k.N.Bytes()), encode(big.NewInt(int64(k.E)).Bytes())
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	slices.SortFunc(jwks.Keys, func(a, b JWK) int { return strings.Compare(a.Kid, b.Kid) })
+
+	return jwks
+}
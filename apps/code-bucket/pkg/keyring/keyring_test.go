@@ -0,0 +1,189 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func ed25519Key(t *testing.T, id string) *Key {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ParsePEM(id, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func rsaPublicKey(t *testing.T, id string) (*Key, *rsa.PrivateKey) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ParsePEM(id, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, private
+}
+
+func claims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+}
+
+func parse(kr *Keyring, token string) error {
+	_, err := jwt.Parse(token, kr.Keyfunc, jwt.WithValidMethods(kr.ValidMethods()))
+	return err
+}
+
+func TestKeyring_SignAndVerify(t *testing.T) {
+	kr, err := NewFromKeys([]*Key{ed25519Key(t, "a")}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := kr.Sign(claims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Header["kid"] != "a" || parsed.Header["alg"] != "EdDSA" {
+		t.Errorf("unexpected header %v", parsed.Header)
+	}
+
+	if err := parse(kr, token); err != nil {
+		t.Errorf("token did not verify: %v", err)
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	oldKey, newKey := ed25519Key(t, "old"), ed25519Key(t, "new")
+
+	before, err := NewFromKeys([]*Key{oldKey}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldToken, _ := before.Sign(claims())
+
+	// Both keys are active, the new one signs
+	during, err := NewFromKeys([]*Key{oldKey, newKey}, nil, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newToken, _ := during.Sign(claims())
+
+	if err := parse(during, oldToken); err != nil {
+		t.Errorf("old token rejected during rotation: %v", err)
+	}
+	if err := parse(during, newToken); err != nil {
+		t.Errorf("new token rejected during rotation: %v", err)
+	}
+
+	after, err := NewFromKeys([]*Key{newKey}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parse(after, oldToken); err == nil {
+		t.Error("token of a removed key verified")
+	}
+}
+
+func TestKeyring_LegacySecret(t *testing.T) {
+	kr, err := New("secret", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := kr.Sign(claims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parse(kr, token); err != nil {
+		t.Errorf("legacy token did not verify: %v", err)
+	}
+
+	if len(kr.JWKS().Keys) != 0 {
+		t.Error("the shared secret must not be published")
+	}
+}
+
+func TestKeyring_RejectsAlgorithmMismatch(t *testing.T) {
+	key, _ := rsaPublicKey(t, "rsa")
+
+	kr, err := NewFromKeys([]*Key{key}, NewHMACKey("", []byte("secret")), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An HS256 token claiming the id of the RSA key
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+	token.Header["kid"] = "rsa"
+	forged, _ := token.SignedString([]byte("secret"))
+
+	if err := parse(kr, forged); err == nil {
+		t.Error("token with a mismatched algorithm verified")
+	}
+}
+
+func TestKeyring_VerificationOnlyKey(t *testing.T) {
+	key, private := rsaPublicKey(t, "external")
+
+	if key.CanSign() {
+		t.Fatal("public keys can't sign")
+	}
+
+	kr, err := NewFromKeys([]*Key{key, ed25519Key(t, "local")}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims())
+	token.Header["kid"] = "external"
+	signed, err := token.SignedString(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := parse(kr, signed); err != nil {
+		t.Errorf("token of a verification key rejected: %v", err)
+	}
+
+	jwks := kr.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Kid != "external" || jwks.Keys[0].Kty != "RSA" || jwks.Keys[0].E != "AQAB" {
+		t.Errorf("unexpected JWKS %+v", jwks)
+	}
+	if jwks.Keys[1].Kty != "OKP" || jwks.Keys[1].Crv != "Ed25519" || jwks.Keys[1].X == "" {
+		t.Errorf("unexpected JWKS %+v", jwks)
+	}
+}
+
+func TestNewFromKeys_MultipleSigners(t *testing.T) {
+	if _, err := NewFromKeys([]*Key{ed25519Key(t, "a"), ed25519Key(t, "b")}, nil, ""); err == nil {
+		t.Error("expected an error without a signing key id")
+	}
+}
@@ -18,6 +18,7 @@ type jobRecord struct {
 type JobQueue struct {
 	queue     chan jobRecord
 	wg        sync.WaitGroup
+	adding    sync.RWMutex
 	ctx       context.Context
 	cancel    context.CancelFunc
 	semaphore chan struct{}
@@ -25,6 +26,12 @@ type JobQueue struct {
 }
 
 func NewJobQueue(concurrency int) *JobQueue {
+	return NewJobQueueWithBackoff(concurrency, defaultBackoff)
+}
+
+// NewJobQueueWithBackoff creates a queue that waits backoff(retry) before
+// retrying a failed job. Jobs waiting for a retry don't take up a worker.
+func NewJobQueueWithBackoff(concurrency int, backoff func(retry int) time.Duration) *JobQueue {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	q := &JobQueue{
@@ -32,7 +39,7 @@ func NewJobQueue(concurrency int) *JobQueue {
 		ctx:       ctx,
 		cancel:    cancel,
 		semaphore: make(chan struct{}, concurrency),
-		backoff:   defaultBackoff,
+		backoff:   backoff,
 	}
 
 	go q.dispatcher()
@@ -40,13 +47,41 @@ func NewJobQueue(concurrency int) *JobQueue {
 	return q
 }
 
+// Add queues a job, blocking while the queue is full. Jobs added after Stop
+// are dropped.
 func (q *JobQueue) Add(job JobFunc, maxTries int) {
-	if maxTries < 1 {
-		maxTries = 1
+	q.adding.RLock()
+	defer q.adding.RUnlock()
+
+	if q.ctx.Err() != nil {
+		return
 	}
+
+	q.wg.Add(1)
 	select {
-	case q.queue <- jobRecord{job: job, attempts: 0, maxTries: maxTries}:
+	case q.queue <- jobRecord{job: job, attempts: 0, maxTries: max(maxTries, 1)}:
 	case <-q.ctx.Done():
+		q.wg.Done()
+	}
+}
+
+// TryAdd queues a job unless the queue is full or stopped, and reports
+// whether it did
+func (q *JobQueue) TryAdd(job JobFunc, maxTries int) bool {
+	q.adding.RLock()
+	defer q.adding.RUnlock()
+
+	if q.ctx.Err() != nil {
+		return false
+	}
+
+	q.wg.Add(1)
+	select {
+	case q.queue <- jobRecord{job: job, attempts: 0, maxTries: max(maxTries, 1)}:
+		return true
+	default:
+		q.wg.Done()
+		return false
 	}
 }
 
@@ -57,45 +92,59 @@ func (q *JobQueue) dispatcher() {
 			return
 		case job := <-q.queue:
 			q.semaphore <- struct{}{}
-			q.wg.Add(1)
 			go q.runJob(job)
 		}
 	}
 }
 
+// runJob runs one attempt of a job on a worker taken by the caller. Retries
+// are scheduled without holding on to the worker.
 func (q *JobQueue) runJob(j jobRecord) {
-	defer func() {
-		<-q.semaphore
-		q.wg.Done()
-	}()
+	defer func() { <-q.semaphore }()
 
-	for {
-		err := runJobWithRecovery(j.job)
-		j.attempts++
+	err := runJobWithRecovery(j.job)
+	j.attempts++
 
-		if err == nil {
-			return
-		}
-
-		if j.attempts >= j.maxTries {
-			fmt.Printf("Job failed after %d attempts: %v\n", j.attempts, err)
-			return
-		}
+	if err == nil {
+		q.wg.Done()
+		return
+	}
 
-		backoff := q.backoff(j.attempts)
+	if j.attempts >= j.maxTries {
+		fmt.Printf("Job failed after %d attempts: %v\n", j.attempts, err)
+		q.wg.Done()
+		return
+	}
 
+	time.AfterFunc(q.backoff(j.attempts), func() {
 		select {
-		case <-time.After(backoff):
+		case q.semaphore <- struct{}{}:
+			q.runJob(j)
 		case <-q.ctx.Done():
-			return
+			q.wg.Done()
 		}
-	}
+	})
 }
 
 func (q *JobQueue) Wait() {
 	q.wg.Wait()
 }
 
+// Stop drops the jobs that haven't started yet, so Wait only waits for the
+// running ones
 func (q *JobQueue) Stop() {
 	q.cancel()
+
+	// Once no Add is in progress nothing can be queued anymore
+	q.adding.Lock()
+	q.adding.Unlock()
+
+	for {
+		select {
+		case <-q.queue:
+			q.wg.Done()
+		default:
+			return
+		}
+	}
 }
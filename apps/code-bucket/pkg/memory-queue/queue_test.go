@@ -0,0 +1,133 @@
+package memoryQueue
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitWithTimeout fails the test if the queue doesn't drain in time
+func waitWithTimeout(t *testing.T, q *JobQueue) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		q.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait didn't return")
+	}
+}
+
+func TestJobQueue_Retry(t *testing.T) {
+	var mu sync.Mutex
+	var retries []int
+	q := NewJobQueueWithBackoff(2, func(retry int) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		retries = append(retries, retry)
+		return time.Millisecond
+	})
+	defer q.Stop()
+
+	var succeeding, failing atomic.Int32
+	q.Add(func() error {
+		if succeeding.Add(1) < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}, 5)
+	q.Add(func() error {
+		failing.Add(1)
+		panic("always")
+	}, 2)
+	waitWithTimeout(t, q)
+
+	if succeeding.Load() != 3 {
+		t.Errorf("expected the job to stop after succeeding on the 3rd attempt, got %d attempts", succeeding.Load())
+	}
+	if failing.Load() != 2 {
+		t.Errorf("expected the failing job to be tried 2 times, got %d", failing.Load())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := fmt.Sprint(retries); got != "[1 1 2]" && got != "[1 2 1]" {
+		t.Errorf("unexpected backoff calls %s", got)
+	}
+}
+
+func TestJobQueue_TryAddWhenFull(t *testing.T) {
+	q := NewJobQueue(1)
+	defer q.Stop()
+
+	release := make(chan struct{})
+	var ran atomic.Int32
+	job := func() error {
+		<-release
+		ran.Add(1)
+		return nil
+	}
+
+	added := 0
+	for ; added < 2*cap(q.queue); added++ {
+		if !q.TryAdd(job, 1) {
+			break
+		}
+	}
+	if added == 2*cap(q.queue) {
+		t.Fatal("TryAdd never reported a full queue")
+	}
+
+	close(release)
+	waitWithTimeout(t, q)
+
+	if int(ran.Load()) != added {
+		t.Errorf("expected %d jobs to run, got %d", added, ran.Load())
+	}
+}
+
+func TestJobQueue_StopDropsQueuedJobs(t *testing.T) {
+	q := NewJobQueue(1)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var ran atomic.Int32
+	q.Add(func() error {
+		close(started)
+		<-release
+		ran.Add(1)
+		return nil
+	}, 1)
+	<-started
+
+	for range 10 {
+		q.Add(func() error {
+			ran.Add(1)
+			return nil
+		}, 1)
+	}
+
+	q.Stop()
+	close(release)
+	waitWithTimeout(t, q)
+
+	// The running job finishes, of the queued ones at most the one the
+	// dispatcher already took may run
+	if n := ran.Load(); n < 1 || n > 2 {
+		t.Errorf("expected the running job to finish and the queued ones to be dropped, %d ran", n)
+	}
+
+	if q.TryAdd(func() error { return nil }, 1) {
+		t.Error("TryAdd succeeded after Stop")
+	}
+	q.Add(func() error { return nil }, 1)
+	waitWithTimeout(t, q)
+}
@@ -0,0 +1,118 @@
+// Package merkle builds a tree of content hashes mirroring the directories
+// of a bucket, so two copies can be compared one directory at a time.
+//
+// A file's hash is the hex SHA-256 of its content. A directory's hash is the
+// hex SHA-256 of one line per child, sorted by name, in the form
+// "<blob|tree> <hash> <name>\n".
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+type File struct {
+	Path string
+	Hash string
+	Size int64
+}
+
+type Entry struct {
+	Name  string
+	Path  string
+	IsDir bool
+	Hash  string
+	Size  int64
+}
+
+type Node struct {
+	Path    string
+	Hash    string
+	Entries []Entry
+}
+
+type Tree struct {
+	nodes map[string]*Node
+}
+
+type dir struct {
+	files map[string]File
+	dirs  map[string]*dir
+}
+
+func newDir() *dir {
+	return &dir{files: make(map[string]File), dirs: make(map[string]*dir)}
+}
+
+// Build creates the tree for a set of files. Leading slashes are ignored, so
+// "/src/a.go" and "src/a.go" end up in the same place.
+func Build(files []File) *Tree {
+	root := newDir()
+
+	for _, f := range files {
+		segments := strings.Split(strings.Trim(f.Path, "/"), "/")
+
+		current := root
+		for _, segment := range segments[:len(segments)-1] {
+			next, ok := current.dirs[segment]
+			if !ok {
+				next = newDir()
+				current.dirs[segment] = next
+			}
+			current = next
+		}
+
+		current.files[segments[len(segments)-1]] = f
+	}
+
+	tree := &Tree{nodes: make(map[string]*Node)}
+	tree.hashDir("", root)
+
+	return tree
+}
+
+func (t *Tree) hashDir(dirPath string, d *dir) string {
+	node := &Node{Path: dirPath}
+
+	for name, f := range d.files {
+		node.Entries = append(node.Entries, Entry{Name: name, Path: f.Path, Hash: f.Hash, Size: f.Size})
+	}
+	for name, sub := range d.dirs {
+		subPath := name
+		if dirPath != "" {
+			subPath = dirPath + "/" + name
+		}
+
+		node.Entries = append(node.Entries, Entry{Name: name, Path: subPath, IsDir: true, Hash: t.hashDir(subPath, sub)})
+	}
+
+	sort.Slice(node.Entries, func(i, j int) bool {
+		return node.Entries[i].Name < node.Entries[j].Name
+	})
+
+	hash := sha256.New()
+	for _, e := range node.Entries {
+		kind := "blob"
+		if e.IsDir {
+			kind = "tree"
+		}
+		hash.Write([]byte(kind + " " + e.Hash + " " + e.Name + "\n"))
+	}
+
+	node.Hash = hex.EncodeToString(hash.Sum(nil))
+	t.nodes[dirPath] = node
+
+	return node.Hash
+}
+
+func (t *Tree) Root() *Node {
+	return t.nodes[""]
+}
+
+// Node returns the directory at dirPath, "" or "/" being the root.
+func (t *Tree) Node(dirPath string) (*Node, bool) {
+	node, ok := t.nodes[strings.Trim(dirPath, "/")]
+	return node, ok
+}
@@ -0,0 +1,61 @@
+package merkle
+
+import "testing"
+
+func TestBuild_Structure(t *testing.T) {
+	tree := Build([]File{
+		{Path: "/README.md", Hash: "r"},
+		{Path: "/src/main.go", Hash: "m"},
+		{Path: "/src/lib/util.go", Hash: "u"},
+	})
+
+	root := tree.Root()
+	if len(root.Entries) != 2 || root.Entries[0].Name != "README.md" || !root.Entries[1].IsDir {
+		t.Fatalf("unexpected root entries: %+v", root.Entries)
+	}
+
+	lib, ok := tree.Node("/src/lib")
+	if !ok || len(lib.Entries) != 1 || lib.Entries[0].Path != "/src/lib/util.go" {
+		t.Fatalf("unexpected lib node: %+v", lib)
+	}
+
+	if _, ok := tree.Node("missing"); ok {
+		t.Error("expected missing directory to be absent")
+	}
+}
+
+func TestBuild_HashesOnlyChangeAlongPath(t *testing.T) {
+	before := Build([]File{
+		{Path: "a/x.txt", Hash: "1"},
+		{Path: "b/y.txt", Hash: "2"},
+	})
+	after := Build([]File{
+		{Path: "a/x.txt", Hash: "1"},
+		{Path: "b/y.txt", Hash: "3"},
+	})
+
+	if before.Root().Hash == after.Root().Hash {
+		t.Error("root hash should change")
+	}
+
+	a1, _ := before.Node("a")
+	a2, _ := after.Node("a")
+	if a1.Hash != a2.Hash {
+		t.Error("unchanged directory hash should be stable")
+	}
+
+	b1, _ := before.Node("b")
+	b2, _ := after.Node("b")
+	if b1.Hash == b2.Hash {
+		t.Error("changed directory hash should differ")
+	}
+}
+
+func TestBuild_Deterministic(t *testing.T) {
+	files := []File{{Path: "b", Hash: "2"}, {Path: "a", Hash: "1"}, {Path: "c/d", Hash: "3"}}
+	reversed := []File{files[2], files[1], files[0]}
+
+	if Build(files).Root().Hash != Build(reversed).Root().Hash {
+		t.Error("hash must not depend on input order")
+	}
+}
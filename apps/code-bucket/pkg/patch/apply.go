@@ -0,0 +1,156 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/diff"
+)
+
+const DefaultMaxFuzz = 2
+
+type ApplyOptions struct {
+	// MaxFuzz is the number of leading and trailing context lines that may be
+	// ignored when a hunk does not match exactly.
+	MaxFuzz int
+
+	// Strict disables searching for a hunk away from its stated position.
+	Strict bool
+}
+
+type HunkResult struct {
+	Applied bool   `json:"applied"`
+	Offset  int    `json:"offset"`
+	Fuzz    int    `json:"fuzz"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Apply applies the hunks of a file patch to content. All hunks are attempted
+// even if one fails, so callers can report on each of them; the returned error
+// only states that at least one hunk failed.
+func Apply(content string, p *FilePatch, opts ApplyOptions) (string, []HunkResult, error) {
+	lines := diff.SplitLines(content)
+	results := make([]HunkResult, len(p.Hunks))
+
+	delta := 0
+	minPosition := 0
+	failed := 0
+
+	for i, hunk := range p.Hunks {
+		oldLines, newLines := hunk.sides()
+
+		applied := false
+		for fuzz := 0; fuzz <= opts.MaxFuzz && !applied; fuzz++ {
+			leading, trailing := hunk.trimmableContext(fuzz)
+			if fuzz > 0 && leading+trailing == 0 {
+				break
+			}
+
+			search := oldLines[leading : len(oldLines)-trailing]
+			replace := newLines[leading : len(newLines)-trailing]
+
+			expected := hunk.OldStart - 1 + leading + delta
+			if len(oldLines) == 0 {
+				// Pure insertions name the line they follow
+				expected = hunk.OldStart + delta
+			}
+
+			position, ok := findLines(lines, search, expected, minPosition, !opts.Strict)
+			if !ok {
+				continue
+			}
+
+			lines = append(lines[:position], append(append([]string{}, replace...), lines[position+len(search):]...)...)
+
+			results[i] = HunkResult{Applied: true, Offset: position - expected, Fuzz: fuzz}
+			delta += position - expected + len(replace) - len(search)
+			minPosition = position + len(replace)
+			applied = true
+		}
+
+		if !applied {
+			results[i] = HunkResult{Error: fmt.Sprintf("hunk #%d does not apply at line %d", i+1, hunk.OldStart)}
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return "", results, fmt.Errorf("%d of %d hunks failed", failed, len(p.Hunks))
+	}
+
+	return strings.Join(lines, ""), results, nil
+}
+
+func (h *Hunk) sides() ([]string, []string) {
+	var oldLines, newLines []string
+	for _, line := range h.Lines {
+		if line.Kind != '+' {
+			oldLines = append(oldLines, line.Text)
+		}
+		if line.Kind != '-' {
+			newLines = append(newLines, line.Text)
+		}
+	}
+
+	return oldLines, newLines
+}
+
+// trimmableContext returns how many context lines may be dropped from the
+// start and end of the hunk for the given fuzz factor.
+func (h *Hunk) trimmableContext(fuzz int) (int, int) {
+	leading := 0
+	for leading < len(h.Lines) && leading < fuzz && h.Lines[leading].Kind == ' ' {
+		leading++
+	}
+
+	trailing := 0
+	for trailing < len(h.Lines)-leading && trailing < fuzz && h.Lines[len(h.Lines)-1-trailing].Kind == ' ' {
+		trailing++
+	}
+
+	return leading, trailing
+}
+
+// findLines looks for search in lines, starting at expected and moving
+// outwards in both directions when offsets are allowed.
+func findLines(lines, search []string, expected, minPosition int, allowOffset bool) (int, bool) {
+	maxPosition := len(lines) - len(search)
+	if maxPosition < minPosition {
+		return 0, false
+	}
+
+	// Without offsets the hunk has to apply exactly where it says
+	if !allowOffset && (expected < minPosition || expected > maxPosition) {
+		return 0, false
+	}
+
+	expected = min(max(expected, minPosition), maxPosition)
+	if matchesAt(lines, search, expected) {
+		return expected, true
+	}
+
+	if !allowOffset {
+		return 0, false
+	}
+
+	for distance := 1; expected-distance >= minPosition || expected+distance <= maxPosition; distance++ {
+		if position := expected - distance; position >= minPosition && matchesAt(lines, search, position) {
+			return position, true
+		}
+		if position := expected + distance; position <= maxPosition && matchesAt(lines, search, position) {
+			return position, true
+		}
+	}
+
+	return 0, false
+}
+
+func matchesAt(lines, search []string, position int) bool {
+	for i, line := range search {
+		if lines[position+i] != line {
+			return false
+		}
+	}
+
+	return true
+}
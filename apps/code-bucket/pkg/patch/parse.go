@@ -0,0 +1,288 @@
+package patch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/metorial/metorial/services/code-bucket/pkg/diff"
+)
+
+// FilePatch describes the changes to a single file. OldPath is empty for new
+// files and NewPath is empty for deleted files.
+type FilePatch struct {
+	OldPath  string
+	NewPath  string
+	IsNew    bool
+	IsDelete bool
+	IsRename bool
+	IsCopy   bool
+	IsBinary bool
+	Hunks    []*Hunk
+
+	headersSeen bool
+}
+
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []Line
+}
+
+// Line is a single hunk line. Kind is ' ', '-' or '+' and Text keeps its
+// trailing newline unless the patch marks it as missing.
+type Line struct {
+	Kind byte
+	Text string
+}
+
+// Parse reads a unified diff, a git diff or the output of `git format-patch`.
+// Anything outside of file headers and hunks, like mail headers, commit
+// messages and diffstats, is ignored.
+func Parse(text string) ([]*FilePatch, error) {
+	lines := diff.SplitLines(text)
+
+	var patches []*FilePatch
+	var current *FilePatch
+
+	for i := 0; i < len(lines); {
+		line := strings.TrimRight(lines[i], "\r\n")
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			current = parseGitHeader(line)
+			patches = append(patches, current)
+			i = parseExtendedHeaders(lines, i+1, current)
+
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			// Plain unified diffs start a new file here, git diffs already did
+			if current == nil || len(current.Hunks) > 0 || current.hasFileHeaders() {
+				current = &FilePatch{}
+				patches = append(patches, current)
+			}
+
+			oldPath := parseFilePath(line[4:])
+			newPath := parseFilePath(strings.TrimRight(lines[i+1], "\r\n")[4:])
+			oldPath, newPath = stripPrefixes(oldPath, newPath)
+
+			current.OldPath = oldPath
+			current.NewPath = newPath
+			current.IsNew = current.IsNew || oldPath == ""
+			current.IsDelete = current.IsDelete || newPath == ""
+			current.headersSeen = true
+			i += 2
+
+		case strings.HasPrefix(line, "@@ "):
+			if current == nil {
+				return nil, fmt.Errorf("hunk without file header at line %d", i+1)
+			}
+
+			hunk, next, err := parseHunk(lines, i)
+			if err != nil {
+				return nil, err
+			}
+
+			current.Hunks = append(current.Hunks, hunk)
+			i = next
+
+		default:
+			i++
+		}
+	}
+
+	for _, p := range patches {
+		if p.IsNew {
+			p.OldPath = ""
+		}
+		if p.IsDelete {
+			p.NewPath = ""
+		}
+	}
+
+	return patches, nil
+}
+
+func (p *FilePatch) hasFileHeaders() bool {
+	return p.headersSeen
+}
+
+func parseGitHeader(line string) *FilePatch {
+	rest := strings.TrimPrefix(line, "diff --git ")
+
+	p := &FilePatch{}
+	if index := strings.Index(rest, " b/"); index >= 0 {
+		p.OldPath, p.NewPath = stripPrefixes(rest[:index], rest[index+1:])
+	}
+
+	return p
+}
+
+func parseExtendedHeaders(lines []string, i int, p *FilePatch) int {
+	for ; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r\n")
+
+		switch {
+		case strings.HasPrefix(line, "new file mode "):
+			p.IsNew = true
+		case strings.HasPrefix(line, "deleted file mode "):
+			p.IsDelete = true
+		case strings.HasPrefix(line, "rename from "):
+			p.IsRename = true
+			p.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			p.IsRename = true
+			p.NewPath = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "copy from "):
+			p.IsCopy = true
+			p.OldPath = strings.TrimPrefix(line, "copy from ")
+		case strings.HasPrefix(line, "copy to "):
+			p.IsCopy = true
+			p.NewPath = strings.TrimPrefix(line, "copy to ")
+		case strings.HasPrefix(line, "Binary files "), strings.HasPrefix(line, "GIT binary patch"):
+			p.IsBinary = true
+		case strings.HasPrefix(line, "old mode "), strings.HasPrefix(line, "new mode "),
+			strings.HasPrefix(line, "index "), strings.HasPrefix(line, "similarity index "),
+			strings.HasPrefix(line, "dissimilarity index "):
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+func parseFilePath(value string) string {
+	// Some tools append a timestamp separated by a tab
+	if index := strings.Index(value, "\t"); index >= 0 {
+		value = value[:index]
+	}
+	value = strings.TrimSpace(value)
+
+	if value == "/dev/null" {
+		return ""
+	}
+
+	return value
+}
+
+// stripPrefixes removes the a/ and b/ prefixes git and most tools add.
+func stripPrefixes(oldPath, newPath string) (string, string) {
+	oldOk := oldPath == "" || strings.HasPrefix(oldPath, "a/")
+	newOk := newPath == "" || strings.HasPrefix(newPath, "b/")
+
+	if oldOk && newOk {
+		return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(newPath, "b/")
+	}
+
+	return oldPath, newPath
+}
+
+func parseHunk(lines []string, i int) (*Hunk, int, error) {
+	header := strings.TrimRight(lines[i], "\r\n")
+
+	hunk := &Hunk{}
+	if err := parseHunkHeader(header, hunk); err != nil {
+		return nil, 0, fmt.Errorf("invalid hunk header at line %d: %w", i+1, err)
+	}
+
+	oldRemaining, newRemaining := hunk.OldLines, hunk.NewLines
+	i++
+
+	for i < len(lines) && (oldRemaining > 0 || newRemaining > 0) {
+		line := lines[i]
+
+		kind := byte(' ')
+		text := line
+		if len(line) > 0 {
+			kind = line[0]
+			text = line[1:]
+		}
+
+		// Editors often strip the single space of empty context lines
+		if line == "\n" || line == "\r\n" {
+			kind = ' '
+			text = line
+		}
+
+		switch kind {
+		case ' ':
+			oldRemaining--
+			newRemaining--
+		case '-':
+			oldRemaining--
+		case '+':
+			newRemaining--
+		case '\\':
+			// "\ No newline at end of file" applies to the previous line
+			if len(hunk.Lines) > 0 {
+				previous := &hunk.Lines[len(hunk.Lines)-1]
+				previous.Text = strings.TrimSuffix(previous.Text, "\n")
+			}
+			i++
+			continue
+		default:
+			return nil, 0, fmt.Errorf("unexpected line in hunk at line %d", i+1)
+		}
+
+		if oldRemaining < 0 || newRemaining < 0 {
+			return nil, 0, fmt.Errorf("hunk at line %d is longer than its header says", i+1)
+		}
+
+		hunk.Lines = append(hunk.Lines, Line{Kind: kind, Text: text})
+		i++
+	}
+
+	if oldRemaining > 0 || newRemaining > 0 {
+		return nil, 0, fmt.Errorf("hunk ends early at line %d", i)
+	}
+
+	if i < len(lines) && strings.HasPrefix(lines[i], "\\") {
+		last := &hunk.Lines[len(hunk.Lines)-1]
+		last.Text = strings.TrimSuffix(last.Text, "\n")
+		i++
+	}
+
+	return hunk, i, nil
+}
+
+func parseHunkHeader(header string, hunk *Hunk) error {
+	end := strings.Index(header[3:], " @@")
+	if end < 0 {
+		return fmt.Errorf("missing closing @@")
+	}
+
+	ranges := strings.Fields(header[3 : 3+end])
+	if len(ranges) != 2 || !strings.HasPrefix(ranges[0], "-") || !strings.HasPrefix(ranges[1], "+") {
+		return fmt.Errorf("malformed ranges")
+	}
+
+	var err error
+	if hunk.OldStart, hunk.OldLines, err = parseRange(ranges[0][1:]); err != nil {
+		return err
+	}
+	if hunk.NewStart, hunk.NewLines, err = parseRange(ranges[1][1:]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func parseRange(value string) (int, int, error) {
+	startText, countText, hasCount := strings.Cut(value, ",")
+
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	count := 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return start, count, nil
+}
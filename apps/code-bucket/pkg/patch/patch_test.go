@@ -0,0 +1,189 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+)
+
+const formatPatch = `From 1234567890abcdef1234567890abcdef12345678 Mon Sep 17 00:00:00 2001
+From: Someone <someone@example.com>
+Date: Mon, 1 Jan 2024 00:00:00 +0000
+Subject: [PATCH] Update files
+
+---
+ main.go     | 2 +-
+ new.txt     | 1 +
+ old.txt     | 1 -
+ 3 files changed
+
+diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-var x = 1
++var x = 2
+ func main() {}
+diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++hello
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+diff --git a/a.txt b/b.txt
+similarity index 100%
+rename from a.txt
+rename to b.txt
+-- 
+2.40.0
+`
+
+func TestParse_FormatPatch(t *testing.T) {
+	patches, err := Parse(formatPatch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(patches) != 4 {
+		t.Fatalf("expected 4 file patches, got %d", len(patches))
+	}
+
+	if patches[0].OldPath != "main.go" || patches[0].NewPath != "main.go" || len(patches[0].Hunks) != 1 {
+		t.Errorf("unexpected modification: %+v", patches[0])
+	}
+	if !patches[1].IsNew || patches[1].NewPath != "new.txt" || patches[1].OldPath != "" {
+		t.Errorf("unexpected new file: %+v", patches[1])
+	}
+	if !patches[2].IsDelete || patches[2].OldPath != "old.txt" || patches[2].NewPath != "" {
+		t.Errorf("unexpected deletion: %+v", patches[2])
+	}
+	if !patches[3].IsRename || patches[3].OldPath != "a.txt" || patches[3].NewPath != "b.txt" || len(patches[3].Hunks) != 0 {
+		t.Errorf("unexpected rename: %+v", patches[3])
+	}
+}
+
+func TestParse_PlainUnifiedDiff(t *testing.T) {
+	text := "--- src/a.txt\t2024-01-01 00:00:00\n+++ src/a.txt\t2024-01-02 00:00:00\n@@ -1 +1 @@\n-a\n+b\n"
+
+	patches, err := Parse(text)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(patches) != 1 || patches[0].OldPath != "src/a.txt" || patches[0].NewPath != "src/a.txt" {
+		t.Fatalf("unexpected patches: %+v", patches)
+	}
+}
+
+func TestParse_MalformedHunk(t *testing.T) {
+	if _, err := Parse("--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n a\n"); err == nil {
+		t.Fatal("expected error for truncated hunk, got nil")
+	}
+}
+
+func applyText(t *testing.T, content, patchText string, opts ApplyOptions) (string, []HunkResult, error) {
+	t.Helper()
+
+	patches, err := Parse(patchText)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	return Apply(content, patches[0], opts)
+}
+
+func TestApply_Exact(t *testing.T) {
+	got, results, err := applyText(t, "package main\nvar x = 1\nfunc main() {}\n", formatPatch, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "package main\nvar x = 2\nfunc main() {}\n" {
+		t.Errorf("unexpected result %q", got)
+	}
+	if !results[0].Applied || results[0].Offset != 0 || results[0].Fuzz != 0 {
+		t.Errorf("unexpected hunk result %+v", results[0])
+	}
+}
+
+func TestApply_Offset(t *testing.T) {
+	content := "// header\n// more\npackage main\nvar x = 1\nfunc main() {}\n"
+
+	got, results, err := applyText(t, content, formatPatch, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, "var x = 2") {
+		t.Errorf("unexpected result %q", got)
+	}
+	if results[0].Offset != 2 {
+		t.Errorf("expected offset 2, got %d", results[0].Offset)
+	}
+
+	if _, _, err := applyText(t, content, formatPatch, ApplyOptions{Strict: true}); err == nil {
+		t.Error("expected strict apply to fail on offset hunk")
+	}
+}
+
+func TestApply_StrictOutOfRange(t *testing.T) {
+	patchText := "--- a/f\n+++ b/f\n@@ -5,2 +5,2 @@\n-a\n+x\n b\n"
+
+	if _, _, err := applyText(t, "a\nb\n", patchText, ApplyOptions{Strict: true}); err == nil {
+		t.Error("expected strict apply to fail on a hunk past the end of the file")
+	}
+
+	got, results, err := applyText(t, "a\nb\n", patchText, ApplyOptions{})
+	if err != nil || got != "x\nb\n" || results[0].Offset != -4 {
+		t.Errorf("unexpected result %q, %+v (err %v)", got, results, err)
+	}
+}
+
+func TestApply_Fuzz(t *testing.T) {
+	content := "package other\nvar x = 1\nfunc main() {}\n"
+
+	if _, _, err := applyText(t, content, formatPatch, ApplyOptions{}); err == nil {
+		t.Fatal("expected apply without fuzz to fail")
+	}
+
+	got, results, err := applyText(t, content, formatPatch, ApplyOptions{MaxFuzz: DefaultMaxFuzz})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "package other\nvar x = 2\nfunc main() {}\n" {
+		t.Errorf("unexpected result %q", got)
+	}
+	if results[0].Fuzz != 1 {
+		t.Errorf("expected fuzz 1, got %d", results[0].Fuzz)
+	}
+}
+
+func TestApply_MultipleHunksAndNoNewline(t *testing.T) {
+	content := "1\n2\n3\n4\n5\n6\n7\n8\n9\nlast"
+	patchText := "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n-1\n+one\n 2\n@@ -9,2 +9,2 @@\n 9\n-last\n\\ No newline at end of file\n+end\n"
+
+	got, _, err := applyText(t, content, patchText, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "one\n2\n3\n4\n5\n6\n7\n8\n9\nend\n" {
+		t.Errorf("unexpected result %q", got)
+	}
+}
+
+func TestApply_NewFile(t *testing.T) {
+	patches, err := Parse(formatPatch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, _, err := Apply("", patches[1], ApplyOptions{})
+	if err != nil || got != "hello\n" {
+		t.Errorf("expected %q, got %q (err %v)", "hello\n", got, err)
+	}
+}
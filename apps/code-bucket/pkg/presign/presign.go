@@ -0,0 +1,123 @@
+package presign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A presigned URL grants one method on one path of a bucket until it
+// expires, without a token. The grant is carried in query parameters and
+// signed with HMAC-SHA256, so it can't be changed without invalidating the
+// signature.
+
+const (
+	ParamBucket      = "metorial-code-bucket-bucket"
+	ParamExpires     = "metorial-code-bucket-expires"
+	ParamIssued      = "metorial-code-bucket-issued" // Unix milliseconds
+	ParamContentType = "metorial-code-bucket-content-type"
+	ParamMaxSize     = "metorial-code-bucket-max-size"
+	ParamSignature   = "metorial-code-bucket-signature"
+)
+
+type Grant struct {
+	BucketID    string
+	Path        string
+	Method      string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	ContentType string // Required content type of uploads, empty for any
+	MaxSize     int64  // Maximum size of uploads in bytes, 0 for any
+}
+
+// IsPresigned reports whether a query carries a presigned grant
+func IsPresigned(query url.Values) bool {
+	return query.Has(ParamSignature)
+}
+
+func (g *Grant) canonical() string {
+	return strings.Join([]string{
+		g.Method,
+		g.BucketID,
+		g.Path,
+		strconv.FormatInt(g.IssuedAt.UnixMilli(), 10),
+		strconv.FormatInt(g.ExpiresAt.Unix(), 10),
+		g.ContentType,
+		strconv.FormatInt(g.MaxSize, 10),
+	}, "\n")
+}
+
+func (g *Grant) signature(secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(g.canonical()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Query returns the signed query parameters of the grant. The method and path
+// are part of the signature but not of the query, they are taken from the
+// request.
+func (g *Grant) Query(secret []byte) url.Values {
+	query := url.Values{}
+	query.Set(ParamBucket, g.BucketID)
+	query.Set(ParamIssued, strconv.FormatInt(g.IssuedAt.UnixMilli(), 10))
+	query.Set(ParamExpires, strconv.FormatInt(g.ExpiresAt.Unix(), 10))
+	if g.ContentType != "" {
+		query.Set(ParamContentType, g.ContentType)
+	}
+	if g.MaxSize > 0 {
+		query.Set(ParamMaxSize, strconv.FormatInt(g.MaxSize, 10))
+	}
+	query.Set(ParamSignature, g.signature(secret))
+
+	return query
+}
+
+// Verify reads the grant of a request and checks its signature and expiry
+func Verify(secret []byte, method, path string, query url.Values, now time.Time) (*Grant, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("presigned urls are not enabled")
+	}
+
+	grant := &Grant{
+		BucketID:    query.Get(ParamBucket),
+		Path:        path,
+		Method:      method,
+		ContentType: query.Get(ParamContentType),
+	}
+	if grant.BucketID == "" {
+		return nil, fmt.Errorf("presigned url has no bucket")
+	}
+
+	issued, err := strconv.ParseInt(query.Get(ParamIssued), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("presigned url has an invalid issue time")
+	}
+	grant.IssuedAt = time.UnixMilli(issued)
+
+	expires, err := strconv.ParseInt(query.Get(ParamExpires), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("presigned url has an invalid expiry")
+	}
+	grant.ExpiresAt = time.Unix(expires, 0)
+
+	if value := query.Get(ParamMaxSize); value != "" {
+		if grant.MaxSize, err = strconv.ParseInt(value, 10, 64); err != nil || grant.MaxSize <= 0 {
+			return nil, fmt.Errorf("presigned url has an invalid max size")
+		}
+	}
+
+	if !hmac.Equal([]byte(grant.signature(secret)), []byte(query.Get(ParamSignature))) {
+		return nil, fmt.Errorf("presigned url has an invalid signature")
+	}
+
+	if !now.Before(grant.ExpiresAt) {
+		return nil, fmt.Errorf("presigned url has expired")
+	}
+
+	return grant, nil
+}
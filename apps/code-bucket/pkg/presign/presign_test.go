@@ -0,0 +1,94 @@
+package presign
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+var secret = []byte("secret")
+
+func grant() *Grant {
+	now := time.Now()
+	return &Grant{
+		BucketID:    "bucket",
+		Path:        "/src/index.ts",
+		Method:      http.MethodPut,
+		IssuedAt:    now,
+		ExpiresAt:   now.Add(time.Minute),
+		ContentType: "text/plain",
+		MaxSize:     1024,
+	}
+}
+
+func TestVerify_Valid(t *testing.T) {
+	g := grant()
+
+	verified, err := Verify(secret, g.Method, g.Path, g.Query(secret), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified.BucketID != g.BucketID || verified.ContentType != g.ContentType || verified.MaxSize != g.MaxSize {
+		t.Errorf("unexpected grant %+v", verified)
+	}
+}
+
+func TestVerify_OtherMethodOrPath(t *testing.T) {
+	g := grant()
+	query := g.Query(secret)
+
+	if _, err := Verify(secret, http.MethodGet, g.Path, query, time.Now()); err == nil {
+		t.Error("grant verified for another method")
+	}
+	if _, err := Verify(secret, g.Method, "/src/other.ts", query, time.Now()); err == nil {
+		t.Error("grant verified for another path")
+	}
+}
+
+func TestVerify_ModifiedConstraint(t *testing.T) {
+	query := grant().Query(secret)
+	query.Set(ParamMaxSize, "1048576")
+
+	if _, err := Verify(secret, http.MethodPut, "/src/index.ts", query, time.Now()); err == nil {
+		t.Error("grant with a raised max size verified")
+	}
+
+	query = grant().Query(secret)
+	query.Del(ParamContentType)
+
+	if _, err := Verify(secret, http.MethodPut, "/src/index.ts", query, time.Now()); err == nil {
+		t.Error("grant without its content type verified")
+	}
+}
+
+func TestVerify_Expired(t *testing.T) {
+	g := grant()
+
+	if _, err := Verify(secret, g.Method, g.Path, g.Query(secret), g.ExpiresAt); err == nil {
+		t.Error("expired grant verified")
+	}
+}
+
+func TestVerify_OtherSecret(t *testing.T) {
+	g := grant()
+
+	if _, err := Verify([]byte("other"), g.Method, g.Path, g.Query(secret), time.Now()); err == nil {
+		t.Error("grant verified with another secret")
+	}
+	if _, err := Verify(nil, g.Method, g.Path, g.Query(nil), time.Now()); err == nil {
+		t.Error("grant verified without a secret")
+	}
+}
+
+func TestVerify_IssuedAtMilliseconds(t *testing.T) {
+	g := grant()
+	g.IssuedAt = time.UnixMilli(1700000000123)
+
+	verified, err := Verify(secret, g.Method, g.Path, g.Query(secret), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verified.IssuedAt.Equal(g.IssuedAt) {
+		t.Errorf("expected issue time %v, got %v", g.IssuedAt, verified.IssuedAt)
+	}
+}
@@ -0,0 +1,226 @@
+package scm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	zipImporter "github.com/metorial/metorial/services/code-bucket/pkg/zip-importer"
+)
+
+// Common interface of the hosted git providers (GitHub, GitLab, Gitea,
+// Bitbucket). Each provider lives in its own package and is selected by name
+// in the service.
+
+const (
+	// DefaultBranch is the branch uploads go to if none is given
+	DefaultBranch = "main"
+
+	// DefaultMaxDeletions caps the files a sync deletes if no cap is given
+	DefaultMaxDeletions = 100
+)
+
+var (
+	ErrRefNotFound      = errors.New("ref not found")
+	ErrTooManyDeletions = errors.New("too many deletions")
+	ErrSyncNotSupported = errors.New("sync is not supported by this provider")
+)
+
+type Repo struct {
+	Name   string // owner/name, or the id or full path of a GitLab project
+	Token  string
+	ApiUrl string // For self-hosted instances, defaults to the public instance of the provider
+}
+
+type File struct {
+	Path    string
+	Content []byte
+}
+
+type UploadOptions struct {
+	Path        string // Directory of the repository the files are written to
+	Branch      string // Defaults to DefaultBranch
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+
+	// Sync deletes the files under Path that aren't uploaded in the same
+	// commit, failing with ErrTooManyDeletions instead of deleting more than
+	// MaxDeletions files
+	Sync         bool
+	MaxDeletions int // Defaults to DefaultMaxDeletions
+
+	// SyncFilter limits a sync to the remote files it matches by their bucket
+	// path, their path under Path with a leading slash. Files the export
+	// filtered out stay in the repository. Nil matches every file.
+	SyncFilter func(filePath string) bool
+}
+
+type Provider interface {
+	// DownloadArchive downloads the repository at ref, a branch, tag or
+	// commit, and iterates the files under path. An empty ref is the default
+	// branch.
+	DownloadArchive(ctx context.Context, repo Repo, ref, path string) (*zipImporter.ZipFileIterator, error)
+
+	// UploadFiles writes the files under opts.Path and returns the commit
+	// they were written in.
+	UploadFiles(ctx context.Context, repo Repo, files []File, opts UploadOptions) (string, error)
+
+	// ResolveRef resolves a branch, tag or commit to a commit sha. Fails with
+	// ErrRefNotFound if there is no such ref.
+	ResolveRef(ctx context.Context, repo Repo, ref string) (string, error)
+}
+
+// FilePath joins the upload directory and the path of a bucket file into a
+// path relative to the repository root
+func FilePath(dir, filePath string) string {
+	return strings.TrimPrefix(path.Join("/", dir, filePath), "/")
+}
+
+func (o UploadOptions) BranchOrDefault() string {
+	if o.Branch == "" {
+		return DefaultBranch
+	}
+	return o.Branch
+}
+
+func (o UploadOptions) MessageOr(fallback string) string {
+	if o.Message == "" {
+		return fallback
+	}
+	return o.Message
+}
+
+// Deletions returns the remote files under Path which aren't among the
+// uploaded files and match SyncFilter if syncing, so the sync deletes them
+func (o UploadOptions) Deletions(remote []string, files []File) ([]string, error) {
+	if !o.Sync {
+		return nil, nil
+	}
+
+	uploaded := make(map[string]bool, len(files))
+	for _, file := range files {
+		uploaded[FilePath(o.Path, file.Path)] = true
+	}
+
+	dir := FilePath(o.Path, "")
+
+	var deletions []string
+	for _, remotePath := range remote {
+		if dir != "" && !strings.HasPrefix(remotePath, dir+"/") {
+			continue
+		}
+		if uploaded[remotePath] {
+			continue
+		}
+		if o.SyncFilter != nil && !o.SyncFilter("/"+strings.TrimPrefix(remotePath, dir+"/")) {
+			continue
+		}
+		deletions = append(deletions, remotePath)
+	}
+	sort.Strings(deletions)
+
+	maxDeletions := o.MaxDeletions
+	if maxDeletions <= 0 {
+		maxDeletions = DefaultMaxDeletions
+	}
+	if len(deletions) > maxDeletions {
+		return nil, fmt.Errorf("%w: the sync would delete %d files, at most %d are allowed", ErrTooManyDeletions, len(deletions), maxDeletions)
+	}
+
+	return deletions, nil
+}
+
+// ApiUrlOr returns the api url of the repository without a trailing slash, or
+// fallback if it has none
+func (r Repo) ApiUrlOr(fallback string) string {
+	if r.ApiUrl == "" {
+		return fallback
+	}
+	return strings.TrimSuffix(r.ApiUrl, "/")
+}
+
+// SplitName splits an owner/name repository name
+func (r Repo) SplitName() (string, string, error) {
+	owner, name, ok := strings.Cut(r.Name, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("repository must be in the form owner/name, got %q", r.Name)
+	}
+	return owner, name, nil
+}
+
+var httpClient = &http.Client{Timeout: 5 * time.Minute}
+
+type HttpError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HttpError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var httpErr *HttpError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// Do sends a request to a provider api. A non-nil in is sent as JSON, or as is
+// if it's an io.Reader. A non-nil out is decoded from the JSON response.
+// Responses other than 2xx fail with an *HttpError.
+func Do(ctx context.Context, method, url string, headers map[string]string, in, out any) (*http.Response, error) {
+	var body io.Reader
+	switch in := in.(type) {
+	case nil:
+	case io.Reader:
+		body = in
+	default:
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := in.(io.Reader); !ok && in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, &HttpError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,105 @@
+package scm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFilePath(t *testing.T) {
+	cases := []struct{ dir, file, want string }{
+		{"", "/README.md", "README.md"},
+		{"/", "/README.md", "README.md"},
+		{"apps/web", "/src/index.ts", "apps/web/src/index.ts"},
+		{"/apps/web/", "src/index.ts", "apps/web/src/index.ts"},
+		{"apps", "/../../etc/passwd", "etc/passwd"},
+	}
+
+	for _, c := range cases {
+		if got := FilePath(c.dir, c.file); got != c.want {
+			t.Errorf("FilePath(%q, %q) = %q, want %q", c.dir, c.file, got, c.want)
+		}
+	}
+}
+
+func TestRepo_SplitName(t *testing.T) {
+	owner, name, err := Repo{Name: "metorial/origin"}.SplitName()
+	if err != nil || owner != "metorial" || name != "origin" {
+		t.Errorf("got %q %q %v", owner, name, err)
+	}
+
+	for _, invalid := range []string{"", "origin", "/origin", "metorial/", "a/b/c"} {
+		if _, _, err := (Repo{Name: invalid}).SplitName(); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "token secret" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"sha":"abc"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	headers := map[string]string{"Authorization": "token secret"}
+
+	var out struct {
+		SHA string `json:"sha"`
+	}
+	if _, err := Do(ctx, http.MethodPost, server.URL+"/commits", headers, map[string]string{"a": "b"}, &out); err != nil || out.SHA != "abc" {
+		t.Errorf("got %q, %v", out.SHA, err)
+	}
+
+	_, err := Do(ctx, http.MethodGet, server.URL+"/missing", headers, nil, nil)
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestUploadOptions_Deletions(t *testing.T) {
+	remote := []string{"README.md", "web/index.html", "web/old.js", "web/lib/stale.js", "website/keep.txt"}
+	files := []File{{Path: "/index.html"}}
+
+	deletions, err := UploadOptions{Path: "/web/"}.Deletions(remote, files)
+	if err != nil || deletions != nil {
+		t.Errorf("deleted %v without syncing, %v", deletions, err)
+	}
+
+	deletions, err = UploadOptions{Path: "/web/", Sync: true}.Deletions(remote, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(deletions, ",") != "web/lib/stale.js,web/old.js" {
+		t.Errorf("unexpected deletions %v", deletions)
+	}
+
+	// Syncing the repository root considers every file
+	deletions, _ = UploadOptions{Sync: true}.Deletions(remote, []File{{Path: "/README.md"}})
+	if len(deletions) != 4 {
+		t.Errorf("unexpected deletions %v", deletions)
+	}
+
+	// Files the export filtered out are kept
+	jsOnly := func(filePath string) bool { return strings.HasSuffix(filePath, ".js") && filePath != "/lib/stale.js" }
+	deletions, _ = UploadOptions{Path: "web", Sync: true, SyncFilter: jsOnly}.Deletions(remote, nil)
+	if strings.Join(deletions, ",") != "web/old.js" {
+		t.Errorf("unexpected deletions %v", deletions)
+	}
+
+	_, err = UploadOptions{Path: "web", Sync: true, MaxDeletions: 1}.Deletions(remote, files)
+	if !errors.Is(err, ErrTooManyDeletions) {
+		t.Errorf("expected ErrTooManyDeletions, got %v", err)
+	}
+}
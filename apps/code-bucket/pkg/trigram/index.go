@@ -0,0 +1,346 @@
+package trigram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Files larger than this are not broken into trigrams. They are kept in the
+// index as candidates for every query instead.
+const MaxIndexedFileSize = 4 * 1024 * 1024
+
+var indexMagic = []byte("TRI1")
+
+type document struct {
+	path      string
+	unindexed bool
+	removed   bool
+}
+
+// Index is an inverted index from trigrams to the files containing them.
+// Replaced and removed files leave tombstones behind, which are dropped when
+// they make up most of the index.
+type Index struct {
+	docs     []document
+	ids      map[string]uint32
+	postings map[Trigram][]uint32
+	removed  int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		ids:      make(map[string]uint32),
+		postings: make(map[Trigram][]uint32),
+	}
+}
+
+// Len returns the number of files in the index.
+func (ix *Index) Len() int {
+	return len(ix.ids)
+}
+
+// Add indexes the content of a file, replacing any previous version. Binary
+// files are removed from the index since searches never match them.
+func (ix *Index) Add(path string, content []byte, isBinary bool) {
+	ix.Remove(path)
+	if isBinary {
+		return
+	}
+
+	id := uint32(len(ix.docs))
+	ix.docs = append(ix.docs, document{path: path})
+	ix.ids[path] = id
+
+	if len(content) > MaxIndexedFileSize {
+		ix.docs[id].unindexed = true
+		return
+	}
+
+	for _, t := range Extract(content) {
+		ix.postings[t] = append(ix.postings[t], id)
+	}
+}
+
+func (ix *Index) Remove(path string) {
+	id, ok := ix.ids[path]
+	if !ok {
+		return
+	}
+
+	ix.docs[id].removed = true
+	delete(ix.ids, path)
+	ix.removed++
+
+	if ix.removed > len(ix.ids) && ix.removed > 1024 {
+		ix.compact()
+	}
+}
+
+func (ix *Index) compact() {
+	remap := make([]uint32, len(ix.docs))
+	docs := make([]document, 0, len(ix.ids))
+
+	for id, doc := range ix.docs {
+		if doc.removed {
+			continue
+		}
+		remap[id] = uint32(len(docs))
+		ix.ids[doc.path] = uint32(len(docs))
+		docs = append(docs, doc)
+	}
+
+	for t, ids := range ix.postings {
+		live := ids[:0]
+		for _, id := range ids {
+			if !ix.docs[id].removed {
+				live = append(live, remap[id])
+			}
+		}
+
+		if len(live) == 0 {
+			delete(ix.postings, t)
+		} else {
+			ix.postings[t] = live
+		}
+	}
+
+	ix.docs = docs
+	ix.removed = 0
+}
+
+// Candidates returns the paths of all files that may match the query.
+func (ix *Index) Candidates(q *Query) []string {
+	ids, all := ix.evaluate(q)
+
+	var paths []string
+	for _, doc := range ix.docs {
+		if doc.removed {
+			continue
+		}
+		if all || doc.unindexed {
+			paths = append(paths, doc.path)
+		}
+	}
+	if all {
+		return paths
+	}
+
+	for _, id := range ids {
+		if doc := ix.docs[id]; !doc.removed && !doc.unindexed {
+			paths = append(paths, doc.path)
+		}
+	}
+
+	return paths
+}
+
+// evaluate returns the sorted ids matching q, or all if q can't narrow the
+// result down.
+func (ix *Index) evaluate(q *Query) ([]uint32, bool) {
+	switch q.Op {
+	case QueryAnd:
+		var result []uint32
+		all := true
+
+		for _, t := range q.Trigrams {
+			result, all = intersect(result, all, ix.postings[t]), false
+		}
+		for _, sub := range q.Sub {
+			ids, subAll := ix.evaluate(sub)
+			if !subAll {
+				result, all = intersect(result, all, ids), false
+			}
+		}
+
+		return result, all
+
+	case QueryOr:
+		var result []uint32
+		for _, sub := range q.Sub {
+			ids, subAll := ix.evaluate(sub)
+			if subAll {
+				return nil, true
+			}
+			result = union(result, ids)
+		}
+
+		return result, false
+	}
+
+	return nil, true
+}
+
+func intersect(a []uint32, aAll bool, b []uint32) []uint32 {
+	if aAll {
+		return b
+	}
+
+	var result []uint32
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+func union(a, b []uint32) []uint32 {
+	result := make([]uint32, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
+
+// MarshalBinary encodes the index without tombstones. Posting lists are delta
+// encoded varints.
+func (ix *Index) MarshalBinary() ([]byte, error) {
+	if ix.removed > 0 {
+		ix.compact()
+	}
+
+	var buf bytes.Buffer
+	buf.Write(indexMagic)
+
+	writeUvarint := func(v uint64) {
+		buf.Write(binary.AppendUvarint(nil, v))
+	}
+
+	writeUvarint(uint64(len(ix.docs)))
+	for _, doc := range ix.docs {
+		writeUvarint(uint64(len(doc.path)))
+		buf.WriteString(doc.path)
+		if doc.unindexed {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	}
+
+	trigrams := make([]Trigram, 0, len(ix.postings))
+	for t := range ix.postings {
+		trigrams = append(trigrams, t)
+	}
+	sort.Slice(trigrams, func(i, j int) bool { return trigrams[i] < trigrams[j] })
+
+	writeUvarint(uint64(len(trigrams)))
+	for _, t := range trigrams {
+		ids := ix.postings[t]
+		writeUvarint(uint64(t))
+		writeUvarint(uint64(len(ids)))
+
+		previous := uint32(0)
+		for _, id := range ids {
+			writeUvarint(uint64(id - previous))
+			previous = id
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decoder reads varints and keeps the first error, so decoding code can
+// check once at the end.
+type decoder struct {
+	reader *bytes.Reader
+	err    error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadUvarint(d.reader)
+	d.err = err
+	return v
+}
+
+func (d *decoder) bytes(n uint64) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n > uint64(d.reader.Len()) {
+		d.err = io.ErrUnexpectedEOF
+		return nil
+	}
+
+	b := make([]byte, n)
+	_, d.err = io.ReadFull(d.reader, b)
+	return b
+}
+
+func (ix *Index) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, indexMagic) {
+		return errors.New("not a trigram index")
+	}
+
+	d := &decoder{reader: bytes.NewReader(data[len(indexMagic):])}
+	decoded := NewIndex()
+
+	docCount := d.uvarint()
+	for i := uint64(0); i < docCount && d.err == nil; i++ {
+		path := string(d.bytes(d.uvarint()))
+		flag := d.bytes(1)
+		if d.err != nil {
+			break
+		}
+
+		decoded.ids[path] = uint32(len(decoded.docs))
+		decoded.docs = append(decoded.docs, document{path: path, unindexed: flag[0] == 1})
+	}
+
+	trigramCount := d.uvarint()
+	for i := uint64(0); i < trigramCount && d.err == nil; i++ {
+		t := Trigram(d.uvarint())
+		count := d.uvarint()
+		if count > uint64(len(decoded.docs)) {
+			return errors.New("corrupt trigram index: posting list too long")
+		}
+
+		ids := make([]uint32, count)
+		previous := uint64(0)
+		for j := range ids {
+			previous += d.uvarint()
+			if previous >= uint64(len(decoded.docs)) {
+				return errors.New("corrupt trigram index: posting out of range")
+			}
+			ids[j] = uint32(previous)
+		}
+
+		decoded.postings[t] = ids
+	}
+
+	if d.err != nil {
+		return fmt.Errorf("corrupt trigram index: %w", d.err)
+	}
+
+	*ix = *decoded
+	return nil
+}
@@ -0,0 +1,147 @@
+package trigram
+
+import (
+	"regexp/syntax"
+	"unicode"
+	"unicode/utf8"
+)
+
+type QueryOp int
+
+const (
+	// QueryAll matches every file, the query cannot narrow anything down
+	QueryAll QueryOp = iota
+	QueryAnd
+	QueryOr
+)
+
+// Query describes the trigrams a file must contain to possibly match a
+// search. For QueryAnd all trigrams and sub-queries must match, for QueryOr
+// at least one sub-query must.
+type Query struct {
+	Op       QueryOp
+	Trigrams []Trigram
+	Sub      []*Query
+}
+
+var allQuery = &Query{Op: QueryAll}
+
+// LiteralQuery requires every trigram of s.
+func LiteralQuery(s string) *Query {
+	trigrams := Extract([]byte(s))
+	if len(trigrams) == 0 {
+		return allQuery
+	}
+
+	return &Query{Op: QueryAnd, Trigrams: trigrams}
+}
+
+// RegexpQuery derives a query from an RE2 expression. The result is
+// conservative: every file the expression can match satisfies the query.
+func RegexpQuery(expression string) (*Query, error) {
+	re, err := syntax.Parse(expression, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	return regexpQuery(re.Simplify()), nil
+}
+
+func regexpQuery(re *syntax.Regexp) *Query {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return foldedLiteralQuery(re.Rune)
+		}
+
+		return LiteralQuery(string(re.Rune))
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return regexpQuery(re.Sub[0])
+
+	case syntax.OpRepeat:
+		if re.Min >= 1 {
+			return regexpQuery(re.Sub[0])
+		}
+		return allQuery
+
+	case syntax.OpConcat:
+		subs := make([]*Query, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			subs = append(subs, regexpQuery(sub))
+		}
+		return and(subs)
+
+	case syntax.OpAlternate:
+		subs := make([]*Query, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			q := regexpQuery(sub)
+			if q.Op == QueryAll {
+				return allQuery
+			}
+			subs = append(subs, q)
+		}
+		return &Query{Op: QueryOr, Sub: subs}
+	}
+
+	return allQuery
+}
+
+// foldedLiteralQuery requires the trigrams of a case-insensitive literal. The
+// index only folds ASCII letters, so the literal is split around runes that
+// fold to other bytes, like k to the Kelvin sign or é to É, and only trigrams
+// of the runs between them are required.
+func foldedLiteralQuery(runes []rune) *Query {
+	var subs []*Query
+
+	start := 0
+	for i, r := range runes {
+		if foldsToSameBytes(r) {
+			continue
+		}
+		subs = append(subs, LiteralQuery(string(runes[start:i])))
+		start = i + 1
+	}
+	subs = append(subs, LiteralQuery(string(runes[start:])))
+
+	return and(subs)
+}
+
+// foldsToSameBytes reports whether every case variant of r is encoded the same
+// once ASCII letters are lowercased
+func foldsToSameBytes(r rune) bool {
+	want := lowerRune(r)
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if lowerRune(f) != want {
+			return false
+		}
+	}
+	return true
+}
+
+func lowerRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		return rune(lower(byte(r)))
+	}
+	return r
+}
+
+func and(subs []*Query) *Query {
+	result := &Query{Op: QueryAnd}
+	for _, sub := range subs {
+		switch sub.Op {
+		case QueryAll:
+		case QueryAnd:
+			result.Trigrams = append(result.Trigrams, sub.Trigrams...)
+			result.Sub = append(result.Sub, sub.Sub...)
+		default:
+			result.Sub = append(result.Sub, sub)
+		}
+	}
+
+	if len(result.Trigrams) == 0 && len(result.Sub) == 0 {
+		return allQuery
+	}
+
+	return result
+}
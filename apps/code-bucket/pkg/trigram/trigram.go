@@ -0,0 +1,38 @@
+package trigram
+
+import "sort"
+
+// Trigram is three consecutive bytes of a file. ASCII letters are lowercased
+// so a single index serves case-sensitive and case-insensitive searches.
+type Trigram uint32
+
+func lower(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+func newTrigram(a, b, c byte) Trigram {
+	return Trigram(uint32(lower(a))<<16 | uint32(lower(b))<<8 | uint32(lower(c)))
+}
+
+// Extract returns the sorted, distinct trigrams of content.
+func Extract(content []byte) []Trigram {
+	if len(content) < 3 {
+		return nil
+	}
+
+	seen := make(map[Trigram]struct{})
+	for i := 0; i+2 < len(content); i++ {
+		seen[newTrigram(content[i], content[i+1], content[i+2])] = struct{}{}
+	}
+
+	trigrams := make([]Trigram, 0, len(seen))
+	for t := range seen {
+		trigrams = append(trigrams, t)
+	}
+	sort.Slice(trigrams, func(i, j int) bool { return trigrams[i] < trigrams[j] })
+
+	return trigrams
+}
@@ -0,0 +1,157 @@
+package trigram
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func buildIndex(files map[string]string) *Index {
+	ix := NewIndex()
+	for path, content := range files {
+		ix.Add(path, []byte(content), false)
+	}
+	return ix
+}
+
+func candidates(t *testing.T, ix *Index, expression string) []string {
+	t.Helper()
+
+	q, err := RegexpQuery(expression)
+	if err != nil {
+		t.Fatalf("RegexpQuery(%q): %v", expression, err)
+	}
+
+	paths := ix.Candidates(q)
+	sort.Strings(paths)
+	return paths
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var testFiles = map[string]string{
+	"a.go":  "package main\nfunc Hello() {}\n",
+	"b.go":  "package util\nfunc goodbye() {}\n",
+	"c.txt": "nothing to see here\n",
+}
+
+func TestIndex_NarrowsCandidates(t *testing.T) {
+	ix := buildIndex(testFiles)
+
+	cases := []struct {
+		expression string
+		want       []string
+	}{
+		{regexp.QuoteMeta("Hello()"), []string{"a.go"}},
+		{"(?i)HELLO", []string{"a.go"}},
+		{"package (main|util)", []string{"a.go", "b.go"}},
+		{"hello|see", []string{"a.go", "c.txt"}},
+		{"xyz", nil},
+		{"a.c", []string{"a.go", "b.go", "c.txt"}},
+	}
+
+	for _, c := range cases {
+		if got := candidates(t, ix, c.expression); !equal(got, c.want) {
+			t.Errorf("candidates for %q = %v, want %v", c.expression, got, c.want)
+		}
+	}
+}
+
+func TestIndex_CandidatesAreSuperset(t *testing.T) {
+	ix := buildIndex(testFiles)
+
+	for _, expression := range []string{"func \\w+\\(", "(?i)package\\s+MAIN", "good(bye)+", "[a-z]+lo"} {
+		re := regexp.MustCompile(expression)
+		got := candidates(t, ix, expression)
+
+		for path, content := range testFiles {
+			if re.MatchString(content) && !contains(got, path) {
+				t.Errorf("%q matches %s but it is not a candidate", expression, path)
+			}
+		}
+	}
+}
+
+func TestIndex_CaseFoldingOutsideASCII(t *testing.T) {
+	files := map[string]string{
+		"kelvin.txt": "0 \u212Aelvin\n",
+		"long-s.txt": "Mi\u017F\u017Fi\u017F\u017Fippi\n",
+		"accent.txt": "CAFÉ\n",
+		"plain.txt":  "kelvin mississippi café\n",
+	}
+	ix := buildIndex(files)
+
+	for _, expression := range []string{"(?i)kelvin", "(?i)mississippi", "(?i)café", "(?i)ÉLVIN|CAFÉ"} {
+		re := regexp.MustCompile(expression)
+		got := candidates(t, ix, expression)
+
+		for path, content := range files {
+			if re.MatchString(content) && !contains(got, path) {
+				t.Errorf("%q matches %s but it is not a candidate", expression, path)
+			}
+		}
+	}
+
+	// Runs between the folded runes still narrow the candidates
+	if got := candidates(t, ix, "(?i)kelvin"); contains(got, "long-s.txt") {
+		t.Errorf("unexpected candidates %v", got)
+	}
+}
+
+func contains(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIndex_ReplaceAndRemove(t *testing.T) {
+	ix := buildIndex(testFiles)
+
+	ix.Add("a.go", []byte("package main\n"), false)
+	ix.Remove("c.txt")
+	ix.Add("d.bin", []byte("Hello\x00"), true)
+
+	if got := candidates(t, ix, "Hello"); len(got) != 0 {
+		t.Errorf("expected no candidates, got %v", got)
+	}
+	if ix.Len() != 2 {
+		t.Errorf("expected 2 files, got %d", ix.Len())
+	}
+}
+
+func TestIndex_RoundTrip(t *testing.T) {
+	ix := buildIndex(testFiles)
+	ix.Remove("b.go")
+	ix.Add("big.txt", make([]byte, MaxIndexedFileSize+1), false)
+
+	data, err := ix.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := NewIndex()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := candidates(t, decoded, "Hello"); !equal(got, []string{"a.go", "big.txt"}) {
+		t.Errorf("unexpected candidates after round trip: %v", got)
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-3]); err == nil {
+		t.Error("expected an error for truncated data")
+	}
+}
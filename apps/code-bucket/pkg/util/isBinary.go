@@ -0,0 +1,13 @@
+package util
+
+import "bytes"
+
+// IsBinary reports whether content looks like binary data, using the same
+// heuristic as git: a NUL byte within the first 8000 bytes.
+func IsBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+
+	return bytes.IndexByte(content, 0) >= 0
+}
@@ -40,6 +40,17 @@ func NormalizePath(filePath string) string {
 	return result
 }
 
+// HasDotDot reports whether a path has a ".." segment. NormalizePath resolves
+// those, paths that are checked before they are normalized must reject them.
+func HasDotDot(filePath string) bool {
+	for _, segment := range strings.FieldsFunc(filePath, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
 // NormalizePathClean is an alternative using path.Clean for comparison
 func NormalizePathClean(filePath string) string {
 	if filePath == "" {
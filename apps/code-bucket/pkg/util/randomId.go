@@ -0,0 +1,13 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RandomID returns the prefix followed by 32 random hex characters
+func RandomID(prefix string) string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return prefix + hex.EncodeToString(buf)
+}
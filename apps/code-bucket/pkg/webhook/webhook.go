@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Receivers verify a delivery by computing the HMAC-SHA256 of
+// "<timestamp>.<body>" with the webhook secret and comparing it to the
+// signature header. The timestamp is signed too so old deliveries can't be
+// replayed.
+
+const (
+	HeaderEvent     = "X-Code-Bucket-Event"
+	HeaderDelivery  = "X-Code-Bucket-Delivery"
+	HeaderTimestamp = "X-Code-Bucket-Timestamp"
+	HeaderSignature = "X-Code-Bucket-Signature"
+
+	signaturePrefix = "sha256="
+
+	// Only this much of the response body is kept for the delivery log
+	maxResponseBody = 1024
+)
+
+type Payload struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	BucketID string    `json:"bucket_id"`
+	Time     time.Time `json:"time"`
+	Data     any       `json:"data"`
+}
+
+type Result struct {
+	StatusCode int
+	Response   string
+	Duration   time.Duration
+	Err        error
+}
+
+func (r *Result) Success() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Sign returns the signature header value for a body sent at timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header in constant time
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Matches reports whether a subscription to events includes eventType. No
+// events means all of them, "file.*" matches every type starting with "file.".
+func Matches(events []string, eventType string) bool {
+	if len(events) == 0 {
+		return true
+	}
+
+	for _, event := range events {
+		if event == "*" || event == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(event, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deliver makes a single delivery attempt
+func Deliver(ctx context.Context, client *http.Client, url, secret string, payload *Payload) *Result {
+	start := time.Now()
+	result := &Result{}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "metorial-code-bucket")
+	req.Header.Set(HeaderEvent, payload.Type)
+	req.Header.Set(HeaderDelivery, payload.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	response, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result.StatusCode = resp.StatusCode
+	result.Response = string(response)
+
+	if !result.Success() {
+		result.Err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"file.put"}`)
+	signature := Sign("secret", 1700000000, body)
+
+	if !Verify("secret", 1700000000, body, signature) {
+		t.Fatal("expected the signature to verify")
+	}
+	if Verify("other", 1700000000, body, signature) {
+		t.Error("signature verified with the wrong secret")
+	}
+	if Verify("secret", 1700000001, body, signature) {
+		t.Error("signature verified with a different timestamp")
+	}
+	if Verify("secret", 1700000000, []byte(`{}`), signature) {
+		t.Error("signature verified with a different body")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	cases := []struct {
+		events    []string
+		eventType string
+		expected  bool
+	}{
+		{nil, "file.put", true},
+		{[]string{"file.put"}, "file.put", true},
+		{[]string{"file.put"}, "file.delete", false},
+		{[]string{"file.*"}, "file.move", true},
+		{[]string{"file.*"}, "export.completed", false},
+		{[]string{"*"}, "import.completed", true},
+	}
+
+	for _, c := range cases {
+		if got := Matches(c.events, c.eventType); got != c.expected {
+			t.Errorf("Matches(%v, %q) = %v, expected %v", c.events, c.eventType, got, c.expected)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	var received Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+
+		if !Verify("secret", timestamp, body, r.Header.Get(HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.Unmarshal(body, &received)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	payload := &Payload{ID: "dlv_1", Type: "file.put", BucketID: "bucket", Time: time.Now()}
+
+	result := Deliver(context.Background(), server.Client(), server.URL, "secret", payload)
+	if !result.Success() {
+		t.Fatalf("delivery failed: %d %v", result.StatusCode, result.Err)
+	}
+	if result.Response != "ok" || received.ID != "dlv_1" {
+		t.Errorf("unexpected delivery %q %+v", result.Response, received)
+	}
+
+	result = Deliver(context.Background(), server.Client(), server.URL, "wrong", payload)
+	if result.Success() || result.StatusCode != http.StatusUnauthorized || result.Err == nil {
+		t.Errorf("expected the delivery to be rejected, got %d %v", result.StatusCode, result.Err)
+	}
+}
@@ -1,7 +1,11 @@
 package zipImporter
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +13,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 var httpClient = &http.Client{
@@ -20,20 +26,77 @@ var httpClient = &http.Client{
 	},
 }
 
-func DownloadZip(url, path string, headers map[string]string) (*ZipFileIterator, error) {
+// Limits bound what is extracted from an archive, so a small compressed
+// archive can't fill the disk.
+type Limits struct {
+	MaxFileSize  int64
+	MaxTotalSize int64
+	MaxFiles     int
+}
+
+// DefaultLimits apply to DownloadArchive. MaxFileSize matches the default
+// maximum bucket file size.
+var DefaultLimits = Limits{
+	MaxFileSize:  64 * 1024 * 1024,
+	MaxTotalSize: 1024 * 1024 * 1024,
+	MaxFiles:     100_000,
+}
+
+type ArchiveFormat string
+
+const (
+	FormatZip     ArchiveFormat = "zip"
+	FormatTar     ArchiveFormat = "tar"
+	FormatTarGzip ArchiveFormat = "tar.gz"
+	FormatTarZstd ArchiveFormat = "tar.zst"
+)
+
+var (
+	zipMagic      = []byte("PK\x03\x04")
+	emptyZipMagic = []byte("PK\x05\x06")
+	gzipMagic     = []byte{0x1f, 0x8b}
+	zstdMagic     = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	tarMagic      = []byte("ustar")
+)
+
+// Offset of the magic in the header of a tar entry
+const tarMagicOffset = 257
+
+// DetectFormat tells the archive format from the first bytes of an archive.
+// Compressed archives are assumed to contain a tar.
+func DetectFormat(header []byte) (ArchiveFormat, error) {
+	switch {
+	case bytes.HasPrefix(header, zipMagic), bytes.HasPrefix(header, emptyZipMagic):
+		return FormatZip, nil
+	case bytes.HasPrefix(header, gzipMagic):
+		return FormatTarGzip, nil
+	case bytes.HasPrefix(header, zstdMagic):
+		return FormatTarZstd, nil
+	case len(header) >= tarMagicOffset+len(tarMagic) && bytes.Equal(header[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic):
+		return FormatTar, nil
+	}
+
+	return "", fmt.Errorf("unsupported archive format")
+}
+
+// DownloadArchive downloads a zip, tar, tar.gz or tar.zst archive, detected
+// by its content, and iterates the files under path. Archives are expected
+// to have a single top-level directory, like the ones of GitHub, GitLab or
+// npm, which is stripped.
+func DownloadArchive(url, path string, headers map[string]string) (*ZipFileIterator, error) {
 	tmpDir, err := os.MkdirTemp("", "gh-zip-*")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp dir: %w", err)
 	}
 
-	zipPath := filepath.Join(tmpDir, "repo.zip")
-	if err := downloadFile(url, zipPath, headers); err != nil {
-		return nil, fmt.Errorf("failed to download zip: %w", err)
+	archivePath := filepath.Join(tmpDir, "archive")
+	if err := downloadFile(url, archivePath, headers); err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
 	}
 
 	extractDir := filepath.Join(tmpDir, "unzipped")
-	if err := unzip(zipPath, extractDir); err != nil {
-		return nil, fmt.Errorf("failed to unzip archive: %w", err)
+	if err := extract(archivePath, extractDir, DefaultLimits); err != nil {
+		return nil, fmt.Errorf("failed to extract archive: %w", err)
 	}
 
 	topDirs, err := os.ReadDir(extractDir)
@@ -64,6 +127,48 @@ func DownloadZip(url, path string, headers map[string]string) (*ZipFileIterator,
 	}, nil
 }
 
+func extract(src, dest string, limits Limits) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReaderSize(f, tarMagicOffset+len(tarMagic))
+	header, _ := reader.Peek(tarMagicOffset + len(tarMagic))
+
+	format, err := DetectFormat(header)
+	if err != nil {
+		return err
+	}
+
+	e := &extractor{dest: dest, limits: limits}
+
+	switch format {
+	case FormatZip:
+		return e.unzip(src)
+
+	case FormatTarGzip:
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		return e.untar(gz)
+
+	case FormatTarZstd:
+		zr, err := zstd.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		return e.untar(zr)
+
+	default:
+		return e.untar(reader)
+	}
+}
+
 func downloadFile(url, dest string, headers map[string]string) error {
 	req, _ := http.NewRequest("GET", url, nil)
 	req.Header.Set("User-Agent", "Metorial CodeBucket (https://metorial.com)")
@@ -97,7 +202,27 @@ func downloadFile(url, dest string, headers map[string]string) error {
 	return err
 }
 
-func unzip(src, dest string) error {
+// safeJoin joins an archive entry name to dest, refusing names that would
+// escape it
+func safeJoin(dest, name string) (string, error) {
+	fpath := filepath.Join(dest, name)
+	if !strings.HasPrefix(fpath, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal file path: %s", fpath)
+	}
+	return fpath, nil
+}
+
+// extractor writes archive entries under dest and counts them against its
+// limits.
+type extractor struct {
+	dest   string
+	limits Limits
+
+	files int
+	total int64
+}
+
+func (e *extractor) unzip(src string) error {
 	r, err := zip.OpenReader(src)
 	if err != nil {
 		return err
@@ -105,9 +230,9 @@ func unzip(src, dest string) error {
 	defer r.Close()
 
 	for _, f := range r.File {
-		fpath := filepath.Join(dest, f.Name)
-		if !strings.HasPrefix(fpath, filepath.Clean(dest)+string(os.PathSeparator)) {
-			return fmt.Errorf("illegal file path: %s", fpath)
+		fpath, err := safeJoin(e.dest, f.Name)
+		if err != nil {
+			return err
 		}
 
 		if f.FileInfo().IsDir() {
@@ -126,18 +251,81 @@ func unzip(src, dest string) error {
 		if err != nil {
 			return err
 		}
-		defer inFile.Close()
-
-		outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+		err = e.writeFile(f.Name, fpath, inFile, f.Mode())
+		inFile.Close()
 		if err != nil {
 			return err
 		}
-		defer outFile.Close()
+	}
+	return nil
+}
 
-		_, err = io.Copy(outFile, inFile)
+// untar extracts regular files and directories. Links and special files are
+// skipped, buckets only hold regular files.
+func (e *extractor) untar(r io.Reader) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			fpath, err := safeJoin(e.dest, header.Name)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(fpath, os.ModePerm); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			fpath, err := safeJoin(e.dest, header.Name)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+				return err
+			}
+			if err := e.writeFile(header.Name, fpath, tr, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeFile copies an entry to fpath, failing once the entry, or the archive
+// as a whole, is larger than the limits allow. Entry sizes in the archive
+// headers aren't trusted, the copy is cut off instead.
+func (e *extractor) writeFile(name, fpath string, r io.Reader, mode os.FileMode) error {
+	e.files++
+	if e.files > e.limits.MaxFiles {
+		return fmt.Errorf("archive contains more than %d files", e.limits.MaxFiles)
+	}
+
+	out, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	limit := min(e.limits.MaxFileSize, e.limits.MaxTotalSize-e.total)
+	n, err := io.Copy(out, io.LimitReader(r, limit+1))
+	e.total += n
+	if err != nil {
+		return err
+	}
+
+	if n > e.limits.MaxFileSize {
+		return fmt.Errorf("%s exceeds the maximum file size of %d bytes", name, e.limits.MaxFileSize)
+	}
+	if e.total > e.limits.MaxTotalSize {
+		return fmt.Errorf("archive exceeds the maximum extracted size of %d bytes", e.limits.MaxTotalSize)
 	}
 	return nil
 }
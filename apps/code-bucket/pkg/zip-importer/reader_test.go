@@ -0,0 +1,158 @@
+package zipImporter
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var testFiles = map[string]string{
+	"package/index.js":     "module.exports = 1",
+	"package/lib/utils.js": "exports.a = 2",
+}
+
+func tarArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	return buf.Bytes()
+}
+
+func compress(t *testing.T, data []byte, format ArchiveFormat) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch format {
+	case FormatTarGzip:
+		w = gzip.NewWriter(&buf)
+	case FormatTarZstd:
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w = zw
+	default:
+		return data
+	}
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	zw.Close()
+	return buf.Bytes()
+}
+
+func extractBytes(t *testing.T, data []byte) (string, error) {
+	return extractBytesWithLimits(t, data, DefaultLimits)
+}
+
+func extractBytesWithLimits(t *testing.T, data []byte, limits Limits) (string, error) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "archive")
+	if err := os.WriteFile(src, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(dir, "out")
+	return dest, extract(src, dest, limits)
+}
+
+func TestExtract_Formats(t *testing.T) {
+	archives := map[ArchiveFormat][]byte{
+		FormatZip:     zipArchive(t, testFiles),
+		FormatTar:     tarArchive(t, testFiles),
+		FormatTarGzip: compress(t, tarArchive(t, testFiles), FormatTarGzip),
+		FormatTarZstd: compress(t, tarArchive(t, testFiles), FormatTarZstd),
+	}
+
+	for format, data := range archives {
+		t.Run(string(format), func(t *testing.T) {
+			detected, err := DetectFormat(data)
+			if err != nil || detected != format {
+				t.Fatalf("detected %q, %v", detected, err)
+			}
+
+			dest, err := extractBytes(t, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for name, content := range testFiles {
+				got, err := os.ReadFile(filepath.Join(dest, name))
+				if err != nil || string(got) != content {
+					t.Errorf("%s: got %q, %v", name, got, err)
+				}
+			}
+		})
+	}
+}
+
+func TestExtract_PathTraversal(t *testing.T) {
+	data := compress(t, tarArchive(t, map[string]string{"../evil.txt": "x"}), FormatTarGzip)
+
+	if _, err := extractBytes(t, data); err == nil {
+		t.Error("entry outside the destination was extracted")
+	}
+}
+
+func TestExtract_Limits(t *testing.T) {
+	files := map[string]string{
+		"package/a.txt": strings.Repeat("a", 100),
+		"package/b.txt": strings.Repeat("b", 100),
+		"package/c.txt": strings.Repeat("c", 100),
+	}
+
+	limits := map[string]Limits{
+		"file size":  {MaxFileSize: 99, MaxTotalSize: 1000, MaxFiles: 10},
+		"total size": {MaxFileSize: 100, MaxTotalSize: 250, MaxFiles: 10},
+		"file count": {MaxFileSize: 100, MaxTotalSize: 1000, MaxFiles: 2},
+	}
+
+	for name, l := range limits {
+		for format, data := range map[ArchiveFormat][]byte{
+			FormatZip:     zipArchive(t, files),
+			FormatTarGzip: compress(t, tarArchive(t, files), FormatTarGzip),
+		} {
+			t.Run(name+"/"+string(format), func(t *testing.T) {
+				if _, err := extractBytesWithLimits(t, data, l); err == nil {
+					t.Error("archive over the limits was extracted")
+				}
+			})
+		}
+	}
+
+	exact := Limits{MaxFileSize: 100, MaxTotalSize: 300, MaxFiles: 3}
+	if _, err := extractBytesWithLimits(t, zipArchive(t, files), exact); err != nil {
+		t.Errorf("archive at the limits: %v", err)
+	}
+}
+
+func TestDetectFormat_Unknown(t *testing.T) {
+	if _, err := DetectFormat([]byte("plain text")); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -11,7 +11,9 @@ import {
   type ChannelCredentials,
   Client,
   type ClientOptions,
+  type ClientReadableStream,
   type ClientUnaryCall,
+  type handleServerStreamingCall,
   type handleUnaryCall,
   makeGenericClientConstructor,
   type Metadata,
@@ -27,6 +29,8 @@ export interface FileInfo {
   size: Long;
   contentType: string;
   modifiedAt: Long;
+  /** Hex encoded SHA-256 of the content */
+  hash: string;
 }
 
 export interface FileContent {
@@ -37,10 +41,15 @@ export interface FileContent {
 export interface CloneBucketRequest {
   sourceBucketId: string;
   newBucketId: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
 }
 
 export interface CreateBucketFromZipRequest {
   newBucketId: string;
+  /** A zip, tar, tar.gz or tar.zst archive, detected by its content */
   zipUrl: string;
   path: string;
   headers: { [key: string]: string };
@@ -77,10 +86,17 @@ export interface GetBucketTokenRequest {
   bucketId: string;
   expiresInSeconds: Long;
   isReadOnly: boolean;
+  /** Recorded on changes made with the token */
+  actor: string;
+  /** Unrestricted if empty */
+  scopes: TokenScope[];
 }
 
 export interface GetBucketTokenResponse {
   token: string;
+  /** jti of the token, used to revoke it */
+  tokenId: string;
+  expiresAt: Long;
 }
 
 export interface GetBucketFileRequest {
@@ -96,6 +112,10 @@ export interface GetBucketFilesRequest {
   bucketId: string;
   /** Optional filter */
   prefix: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
 }
 
 export interface GetBucketFilesResponse {
@@ -110,9 +130,14 @@ export interface GetBucketFilesAsZipRequest {
   bucketId: string;
   /** Optional filter */
   prefix: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
 }
 
 export interface GetBucketFilesAsZipResponse {
+  /** Relative to the HTTP API. Presigned if presigned urls are enabled, otherwise it takes a token */
   downloadUrl: string;
   expiresAt: Long;
 }
@@ -148,9 +173,35 @@ export interface ExportBucketToGithubRequest {
   repo: string;
   path: string;
   token: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
+  /** Defaults to main */
+  branch: string;
+  /** Branch a missing branch is created from, defaults to the default branch */
+  baseBranch: string;
+  /** Optional commit message */
+  message: string;
+  authorName: string;
+  authorEmail: string;
+  /** Opens a pull request from the branch into the base branch, or returns the open one */
+  createPullRequest: boolean;
+  /** Defaults to the first line of the commit message */
+  pullRequestTitle: string;
+  pullRequestBody: string;
+  /** Deletes files under path that aren't in the bucket, in the same commit */
+  sync: boolean;
+  /** Sync fails instead of deleting more files, defaults to 100 */
+  maxDeletions: number;
 }
 
 export interface ExportBucketToGithubResponse {
+  /** Unchanged head of the branch if no file changed */
+  commitSha: string;
+  pullRequestUrl: string;
+  /** Files deleted by the sync */
+  deletedPaths: string[];
 }
 
 export interface CreateBucketFromGitlabRequest {
@@ -168,13 +219,565 @@ export interface ExportBucketToGitlabRequest {
   path: string;
   token: string;
   gitlabApiUrl: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
+  /** Deletes files under path that aren't in the bucket, in the same commit */
+  sync: boolean;
+  /** Sync fails instead of deleting more files, defaults to 100 */
+  maxDeletions: number;
 }
 
 export interface ExportBucketToGitlabResponse {
+  /** Files deleted by the sync */
+  deletedPaths: string[];
+}
+
+export interface CreateBucketOverlayRequest {
+  baseBucketId: string;
+  newBucketId: string;
+}
+
+export interface OverlayChange {
+  path: string;
+  /** "added", "modified" or "deleted" */
+  changeType: string;
+  /** Unset for deletions */
+  fileInfo: FileInfo | undefined;
+}
+
+export interface GetBucketOverlayChangesRequest {
+  bucketId: string;
+}
+
+export interface GetBucketOverlayChangesResponse {
+  changes: OverlayChange[];
+}
+
+export interface DiscardBucketOverlayRequest {
+  bucketId: string;
+}
+
+export interface DiscardBucketOverlayResponse {
+}
+
+export interface CommitBucketOverlayRequest {
+  bucketId: string;
+}
+
+export interface CommitBucketOverlayResponse {
+}
+
+export interface DiffBucketsRequest {
+  baseBucketId: string;
+  /** Optional filter */
+  basePrefix: string;
+  /** Defaults to base_bucket_id */
+  targetBucketId: string;
+  /** Optional filter */
+  targetPrefix: string;
+  includeTextDiffs: boolean;
+  /** Defaults to 3 */
+  contextLines: number;
+  /** Larger files are compared by size only, defaults to 10 MiB */
+  maxFileSize: Long;
+}
+
+export interface FileDiff {
+  path: string;
+  /** "added", "modified" or "deleted" */
+  changeType: string;
+  isBinary: boolean;
+  oldSize: Long;
+  newSize: Long;
+  unifiedDiff: string;
+}
+
+export interface DiffBucketsResponse {
+  files: FileDiff[];
+}
+
+export interface ApplyPatchRequest {
+  bucketId: string;
+  /** Unified diff or git format-patch output */
+  patch: string;
+  dryRun: boolean;
+  /** Require hunks to apply at their stated line */
+  strict: boolean;
+  /** Context lines that may be ignored, defaults to 2, negative disables fuzz */
+  maxFuzz: number;
+  /** Prepended to every path in the patch */
+  pathPrefix: string;
+}
+
+export interface PatchHunkResult {
+  applied: boolean;
+  offset: number;
+  fuzz: number;
+  error: string;
+}
+
+export interface PatchFileResult {
+  path: string;
+  /** Set for renames and copies */
+  oldPath: string;
+  /** "added", "modified", "deleted" or "renamed" */
+  changeType: string;
+  hunks: PatchHunkResult[];
+  error: string;
+}
+
+export interface ApplyPatchResponse {
+  /** False if any file failed, in which case nothing was written */
+  applied: boolean;
+  files: PatchFileResult[];
+}
+
+export interface MergeBucketsRequest {
+  /** Common ancestor of ours and theirs */
+  baseBucketId: string;
+  oursBucketId: string;
+  theirsBucketId: string;
+  /** Receives the result, defaults to ours_bucket_id */
+  targetBucketId: string;
+  /** Write conflicts with markers instead of our side */
+  conflictMarkers: boolean;
+}
+
+export interface MergeConflictHunk {
+  baseStart: number;
+  oursStart: number;
+  theirsStart: number;
+  base: string;
+  ours: string;
+  theirs: string;
+}
+
+export interface MergeFileResult {
+  path: string;
+  /** "ours", "theirs", "merged" or "conflict" */
+  resolution: string;
+  deleted: boolean;
+  /** "content", "modify_delete" or "binary" */
+  conflictType: string;
+  hunks: MergeConflictHunk[];
+}
+
+export interface MergeBucketsResponse {
+  hasConflicts: boolean;
+  /** Files changed on at least one side */
+  files: MergeFileResult[];
+}
+
+export interface SearchBucketRequest {
+  bucketId: string;
+  query: string;
+  /** RE2 syntax, otherwise the query is matched literally */
+  isRegex: boolean;
+  caseInsensitive: boolean;
+  /** Optional filter */
+  prefix: string;
+  /** Optional filter, e.g. "src/**/*.ts" */
+  glob: string;
+  /** Up to 20 */
+  contextLines: number;
+  /** Defaults to 1000 */
+  maxResults: number;
+  /** Larger files are skipped, defaults to 1 MiB */
+  maxFileSize: Long;
+}
+
+export interface SearchMatch {
+  path: string;
+  lineNumber: number;
+  /** 1-based byte offset of the first match in the line */
+  column: number;
+  line: string;
+  contextBefore: string[];
+  contextAfter: string[];
+}
+
+export interface SearchBucketResponse {
+  /** Unset on the final message if the limit was reached */
+  match: SearchMatch | undefined;
+  limitReached: boolean;
+}
+
+export interface RebuildBucketSearchIndexRequest {
+  bucketId: string;
+}
+
+export interface RebuildBucketSearchIndexResponse {
+  indexedFiles: number;
+}
+
+export interface GetBucketDigestRequest {
+  bucketId: string;
+  /** Optional filter */
+  prefix: string;
+}
+
+export interface GetBucketDigestResponse {
+  /** Hex encoded SHA-256 over the sorted paths and file hashes */
+  digest: string;
+  fileCount: number;
+}
+
+/**
+ * A directory's hash is the SHA-256 of one "<blob|tree> <hash> <name>\n" line
+ * per child, sorted by name. File hashes are the SHA-256 of their content.
+ */
+export interface GetBucketSyncTreeRequest {
+  bucketId: string;
+  /** Directory to return, defaults to the root */
+  path: string;
+  /** The client's hash of the directory, if it matches no entries are returned */
+  knownHash: string;
+}
+
+export interface SyncTreeEntry {
+  name: string;
+  /** Full path, usable with GetBucketFile for files */
+  path: string;
+  isDir: boolean;
+  hash: string;
+  /** Unset for directories */
+  size: Long;
+}
+
+export interface GetBucketSyncTreeResponse {
+  path: string;
+  hash: string;
+  /** True if hash equals known_hash */
+  unchanged: boolean;
+  entries: SyncTreeEntry[];
+}
+
+export interface ApplyBucketFileDeltaRequest {
+  bucketId: string;
+  path: string;
+  /** Hash of the content the delta was computed against */
+  baseHash: string;
+  /** See pkg/delta for the format */
+  delta: Uint8Array;
+}
+
+export interface ApplyBucketFileDeltaResponse {
+  fileInfo: FileInfo | undefined;
+}
+
+export interface MoveBucketFileRequest {
+  bucketId: string;
+  fromPath: string;
+  /** Overwritten if it exists */
+  toPath: string;
+}
+
+export interface MoveBucketFileResponse {
+  fileInfo: FileInfo | undefined;
+}
+
+export interface WatchBucketRequest {
+  bucketId: string;
+  /** Only changes to paths with this prefix are sent */
+  prefix: string;
+  /** Last cursor received, replays the changes since. Empty watches new changes only */
+  cursor: string;
+}
+
+export interface BucketEvent {
+  /** Resume point for WatchBucket */
+  cursor: string;
+  /** put, delete, move or reset */
+  type: string;
+  path: string;
+  /** Source path of a move */
+  oldPath: string;
+  /** Unset for deletes */
+  hash: string;
+  size: Long;
+  actor: string;
+  /** Unix milliseconds */
+  time: Long;
+}
+
+export interface Webhook {
+  id: string;
+  /** Empty for global webhooks */
+  bucketId: string;
+  url: string;
+  events: string[];
+  createdAt: Long;
+}
+
+export interface CreateWebhookRequest {
+  /** Empty to receive the events of every bucket */
+  bucketId: string;
+  url: string;
+  /** Used to sign deliveries, generated if empty */
+  secret: string;
+  /** e.g. file.put or file.*, empty for all events */
+  events: string[];
+}
+
+export interface CreateWebhookResponse {
+  webhook: Webhook | undefined;
+  secret: string;
+}
+
+export interface ListWebhooksRequest {
+  /** Empty to list global webhooks */
+  bucketId: string;
+}
+
+export interface ListWebhooksResponse {
+  webhooks: Webhook[];
+}
+
+export interface DeleteWebhookRequest {
+  webhookId: string;
+}
+
+export interface DeleteWebhookResponse {
+}
+
+export interface WebhookDelivery {
+  /** Same for all attempts of a delivery */
+  id: string;
+  webhookId: string;
+  event: string;
+  attempt: number;
+  success: boolean;
+  /** Unset if no response was received */
+  statusCode: number;
+  /** Start of the response body */
+  response: string;
+  error: string;
+  durationMs: Long;
+  time: Long;
+}
+
+export interface GetWebhookDeliveriesRequest {
+  webhookId: string;
+  /** Defaults to and is capped at 100 */
+  limit: number;
+}
+
+export interface GetWebhookDeliveriesResponse {
+  /** Most recent first */
+  deliveries: WebhookDelivery[];
+}
+
+export interface TestWebhookRequest {
+  webhookId: string;
+}
+
+export interface TestWebhookResponse {
+  delivery: WebhookDelivery | undefined;
+}
+
+export interface AuditEntry {
+  /** Starts at 1 and has no gaps */
+  seq: Long;
+  /** Unix milliseconds */
+  time: Long;
+  /** put, delete, move or discard_overlay */
+  operation: string;
+  path: string;
+  /** Source path of a move */
+  oldPath: string;
+  contentHash: string;
+  /** Token subject, or the caller given in x-code-bucket-actor */
+  actor: string;
+  /** jti of the token used */
+  tokenId: string;
+  sourceIp: string;
+  /** gRPC method or HTTP route */
+  method: string;
+  prevHash: string;
+  /** sha256 over the entry and prev_hash */
+  hash: string;
+}
+
+export interface GetAuditLogRequest {
+  bucketId: string;
+  /** next_page_token of the previous page */
+  pageToken: string;
+  /** Defaults to 100, at most 1000 */
+  limit: number;
+}
+
+export interface GetAuditLogResponse {
+  /** Oldest first */
+  entries: AuditEntry[];
+  /** Empty on the last page */
+  nextPageToken: string;
+}
+
+export interface VerifyAuditLogRequest {
+  bucketId: string;
+}
+
+export interface VerifyAuditLogResponse {
+  valid: boolean;
+  /** Entries verified before the first invalid one */
+  entryCount: Long;
+  headHash: string;
+  /** First entry that doesn't verify */
+  invalidSeq: Long;
+  error: string;
+}
+
+export interface RevokeBucketTokenRequest {
+  tokenId: string;
+  /** Expiry of the token, the revocation is kept until then. Kept forever if unset */
+  expiresAt: Long;
+}
+
+export interface RevokeBucketTokenResponse {
+}
+
+export interface RevokeAllBucketTokensRequest {
+  bucketId: string;
+  /** Unix seconds, defaults to now */
+  issuedBefore: Long;
+}
+
+export interface RevokeAllBucketTokensResponse {
+}
+
+export interface TokenScope {
+  /** Include patterns like /src/ or *.md, empty for all paths */
+  paths: string[];
+  /** read, write, delete or list, empty for all operations */
+  operations: string[];
+}
+
+export interface GetPresignedFileUrlRequest {
+  bucketId: string;
+  path: string;
+  /** GET or PUT */
+  method: string;
+  expiresInSeconds: Long;
+  /** Optional, required content type of the upload */
+  contentType: string;
+  /** Optional, maximum size of the upload in bytes */
+  maxSize: Long;
+}
+
+export interface GetPresignedFileUrlResponse {
+  /** Relative to the HTTP API */
+  url: string;
+  expiresAt: Long;
+}
+
+export interface GetBucketFilesAsArchiveRequest {
+  bucketId: string;
+  /** Optional filter */
+  prefix: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
+  /** zip or tar.gz, defaults to zip */
+  format: string;
+}
+
+export interface GetBucketFilesAsArchiveResponse {
+  /** Relative to the HTTP API. Presigned if presigned urls are enabled, otherwise it takes a token */
+  downloadUrl: string;
+  expiresAt: Long;
+}
+
+export interface CreateBucketFromGitRequest {
+  newBucketId: string;
+  /** http(s) url of the repository */
+  url: string;
+  /** Branch or tag, defaults to the default branch */
+  ref: string;
+  /** Optional directory of the repository to import */
+  path: string;
+  /** Optional, defaults to x-access-token if a password is set */
+  username: string;
+  /** Password or access token */
+  password: string;
+}
+
+export interface ExportBucketToGitRequest {
+  bucketId: string;
+  /** http(s) url of the repository */
+  url: string;
+  /** Created from the default branch if missing */
+  branch: string;
+  /** Directory of the repository the files are written to */
+  path: string;
+  /** Optional, defaults to x-access-token if a password is set */
+  username: string;
+  /** Password or access token */
+  password: string;
+  /** Optional commit message */
+  message: string;
+  authorName: string;
+  authorEmail: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
+}
+
+export interface ExportBucketToGitResponse {
+  commitSha: string;
+}
+
+export interface CreateBucketFromScmRequest {
+  newBucketId: string;
+  /** github, gitlab, gitea, forgejo or bitbucket */
+  provider: string;
+  /** owner/name, or the id or full path of a GitLab project */
+  repo: string;
+  /** Branch, tag or commit, defaults to the default branch */
+  ref: string;
+  /** Optional directory of the repository to import */
+  path: string;
+  token: string;
+  /** Optional, for self-hosted instances */
+  apiUrl: string;
+}
+
+export interface ExportBucketToScmRequest {
+  bucketId: string;
+  /** github, gitlab, gitea, forgejo or bitbucket */
+  provider: string;
+  /** owner/name, or the id or full path of a GitLab project */
+  repo: string;
+  /** Directory of the repository the files are written to */
+  path: string;
+  /** Defaults to main */
+  branch: string;
+  token: string;
+  /** Optional, for self-hosted instances */
+  apiUrl: string;
+  /** Optional commit message */
+  message: string;
+  authorName: string;
+  authorEmail: string;
+  /** Gitignore-style globs, e.g. "src/**" */
+  include: string[];
+  /** Gitignore-style globs, e.g. "node_modules/**" */
+  exclude: string[];
+  /** Deletes files under path that aren't in the bucket, in the same commit. Not supported by bitbucket */
+  sync: boolean;
+  /** Sync fails instead of deleting more files, defaults to 100 */
+  maxDeletions: number;
+}
+
+export interface ExportBucketToScmResponse {
+  commitSha: string;
 }
 
 function createBaseFileInfo(): FileInfo {
-  return { path: "", size: Long.ZERO, contentType: "", modifiedAt: Long.ZERO };
+  return { path: "", size: Long.ZERO, contentType: "", modifiedAt: Long.ZERO, hash: "" };
 }
 
 export const FileInfo: MessageFns<FileInfo> = {
@@ -191,6 +794,9 @@ export const FileInfo: MessageFns<FileInfo> = {
     if (!message.modifiedAt.equals(Long.ZERO)) {
       writer.uint32(32).int64(message.modifiedAt.toString());
     }
+    if (message.hash !== "") {
+      writer.uint32(42).string(message.hash);
+    }
     return writer;
   },
 
@@ -233,6 +839,14 @@ export const FileInfo: MessageFns<FileInfo> = {
           message.modifiedAt = Long.fromString(reader.int64().toString());
           continue;
         }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.hash = reader.string();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -256,6 +870,7 @@ export const FileInfo: MessageFns<FileInfo> = {
         : isSet(object.modified_at)
         ? Long.fromValue(object.modified_at)
         : Long.ZERO,
+      hash: isSet(object.hash) ? globalThis.String(object.hash) : "",
     };
   },
 
@@ -273,6 +888,9 @@ export const FileInfo: MessageFns<FileInfo> = {
     if (!message.modifiedAt.equals(Long.ZERO)) {
       obj.modifiedAt = (message.modifiedAt || Long.ZERO).toString();
     }
+    if (message.hash !== "") {
+      obj.hash = message.hash;
+    }
     return obj;
   },
 
@@ -287,6 +905,7 @@ export const FileInfo: MessageFns<FileInfo> = {
     message.modifiedAt = (object.modifiedAt !== undefined && object.modifiedAt !== null)
       ? Long.fromValue(object.modifiedAt)
       : Long.ZERO;
+    message.hash = object.hash ?? "";
     return message;
   },
 };
@@ -374,7 +993,7 @@ export const FileContent: MessageFns<FileContent> = {
 };
 
 function createBaseCloneBucketRequest(): CloneBucketRequest {
-  return { sourceBucketId: "", newBucketId: "" };
+  return { sourceBucketId: "", newBucketId: "", include: [], exclude: [] };
 }
 
 export const CloneBucketRequest: MessageFns<CloneBucketRequest> = {
@@ -385,6 +1004,12 @@ export const CloneBucketRequest: MessageFns<CloneBucketRequest> = {
     if (message.newBucketId !== "") {
       writer.uint32(18).string(message.newBucketId);
     }
+    for (const v of message.include) {
+      writer.uint32(26).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(34).string(v!);
+    }
     return writer;
   },
 
@@ -411,6 +1036,22 @@ export const CloneBucketRequest: MessageFns<CloneBucketRequest> = {
           message.newBucketId = reader.string();
           continue;
         }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -432,6 +1073,8 @@ export const CloneBucketRequest: MessageFns<CloneBucketRequest> = {
         : isSet(object.new_bucket_id)
         ? globalThis.String(object.new_bucket_id)
         : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
     };
   },
 
@@ -443,6 +1086,12 @@ export const CloneBucketRequest: MessageFns<CloneBucketRequest> = {
     if (message.newBucketId !== "") {
       obj.newBucketId = message.newBucketId;
     }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
     return obj;
   },
 
@@ -453,6 +1102,8 @@ export const CloneBucketRequest: MessageFns<CloneBucketRequest> = {
     const message = createBaseCloneBucketRequest();
     message.sourceBucketId = object.sourceBucketId ?? "";
     message.newBucketId = object.newBucketId ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
     return message;
   },
 };
@@ -1020,7 +1671,7 @@ export const CreateBucketResponse: MessageFns<CreateBucketResponse> = {
 };
 
 function createBaseGetBucketTokenRequest(): GetBucketTokenRequest {
-  return { bucketId: "", expiresInSeconds: Long.ZERO, isReadOnly: false };
+  return { bucketId: "", expiresInSeconds: Long.ZERO, isReadOnly: false, actor: "", scopes: [] };
 }
 
 export const GetBucketTokenRequest: MessageFns<GetBucketTokenRequest> = {
@@ -1034,6 +1685,12 @@ export const GetBucketTokenRequest: MessageFns<GetBucketTokenRequest> = {
     if (message.isReadOnly !== false) {
       writer.uint32(24).bool(message.isReadOnly);
     }
+    if (message.actor !== "") {
+      writer.uint32(34).string(message.actor);
+    }
+    for (const v of message.scopes) {
+      TokenScope.encode(v!, writer.uint32(42).fork()).join();
+    }
     return writer;
   },
 
@@ -1068,6 +1725,22 @@ export const GetBucketTokenRequest: MessageFns<GetBucketTokenRequest> = {
           message.isReadOnly = reader.bool();
           continue;
         }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.actor = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.scopes.push(TokenScope.decode(reader, reader.uint32()));
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1094,6 +1767,8 @@ export const GetBucketTokenRequest: MessageFns<GetBucketTokenRequest> = {
         : isSet(object.is_read_only)
         ? globalThis.Boolean(object.is_read_only)
         : false,
+      actor: isSet(object.actor) ? globalThis.String(object.actor) : "",
+      scopes: globalThis.Array.isArray(object?.scopes) ? object.scopes.map((e: any) => TokenScope.fromJSON(e)) : [],
     };
   },
 
@@ -1108,6 +1783,12 @@ export const GetBucketTokenRequest: MessageFns<GetBucketTokenRequest> = {
     if (message.isReadOnly !== false) {
       obj.isReadOnly = message.isReadOnly;
     }
+    if (message.actor !== "") {
+      obj.actor = message.actor;
+    }
+    if (message.scopes?.length) {
+      obj.scopes = message.scopes.map((e) => TokenScope.toJSON(e));
+    }
     return obj;
   },
 
@@ -1121,12 +1802,14 @@ export const GetBucketTokenRequest: MessageFns<GetBucketTokenRequest> = {
       ? Long.fromValue(object.expiresInSeconds)
       : Long.ZERO;
     message.isReadOnly = object.isReadOnly ?? false;
+    message.actor = object.actor ?? "";
+    message.scopes = object.scopes?.map((e) => TokenScope.fromPartial(e)) || [];
     return message;
   },
 };
 
 function createBaseGetBucketTokenResponse(): GetBucketTokenResponse {
-  return { token: "" };
+  return { token: "", tokenId: "", expiresAt: Long.ZERO };
 }
 
 export const GetBucketTokenResponse: MessageFns<GetBucketTokenResponse> = {
@@ -1134,6 +1817,12 @@ export const GetBucketTokenResponse: MessageFns<GetBucketTokenResponse> = {
     if (message.token !== "") {
       writer.uint32(10).string(message.token);
     }
+    if (message.tokenId !== "") {
+      writer.uint32(18).string(message.tokenId);
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      writer.uint32(24).int64(message.expiresAt.toString());
+    }
     return writer;
   },
 
@@ -1152,17 +1841,45 @@ export const GetBucketTokenResponse: MessageFns<GetBucketTokenResponse> = {
           message.token = reader.string();
           continue;
         }
-      }
-      if ((tag & 7) === 4 || tag === 0) {
-        break;
-      }
-      reader.skip(tag & 7);
-    }
-    return message;
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.tokenId = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.expiresAt = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
   },
 
   fromJSON(object: any): GetBucketTokenResponse {
-    return { token: isSet(object.token) ? globalThis.String(object.token) : "" };
+    return {
+      token: isSet(object.token) ? globalThis.String(object.token) : "",
+      tokenId: isSet(object.tokenId)
+        ? globalThis.String(object.tokenId)
+        : isSet(object.token_id)
+        ? globalThis.String(object.token_id)
+        : "",
+      expiresAt: isSet(object.expiresAt)
+        ? Long.fromValue(object.expiresAt)
+        : isSet(object.expires_at)
+        ? Long.fromValue(object.expires_at)
+        : Long.ZERO,
+    };
   },
 
   toJSON(message: GetBucketTokenResponse): unknown {
@@ -1170,6 +1887,12 @@ export const GetBucketTokenResponse: MessageFns<GetBucketTokenResponse> = {
     if (message.token !== "") {
       obj.token = message.token;
     }
+    if (message.tokenId !== "") {
+      obj.tokenId = message.tokenId;
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      obj.expiresAt = (message.expiresAt || Long.ZERO).toString();
+    }
     return obj;
   },
 
@@ -1179,6 +1902,10 @@ export const GetBucketTokenResponse: MessageFns<GetBucketTokenResponse> = {
   fromPartial(object: DeepPartial<GetBucketTokenResponse>): GetBucketTokenResponse {
     const message = createBaseGetBucketTokenResponse();
     message.token = object.token ?? "";
+    message.tokenId = object.tokenId ?? "";
+    message.expiresAt = (object.expiresAt !== undefined && object.expiresAt !== null)
+      ? Long.fromValue(object.expiresAt)
+      : Long.ZERO;
     return message;
   },
 };
@@ -1324,7 +2051,7 @@ export const GetBucketFileResponse: MessageFns<GetBucketFileResponse> = {
 };
 
 function createBaseGetBucketFilesRequest(): GetBucketFilesRequest {
-  return { bucketId: "", prefix: "" };
+  return { bucketId: "", prefix: "", include: [], exclude: [] };
 }
 
 export const GetBucketFilesRequest: MessageFns<GetBucketFilesRequest> = {
@@ -1335,6 +2062,12 @@ export const GetBucketFilesRequest: MessageFns<GetBucketFilesRequest> = {
     if (message.prefix !== "") {
       writer.uint32(18).string(message.prefix);
     }
+    for (const v of message.include) {
+      writer.uint32(26).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(34).string(v!);
+    }
     return writer;
   },
 
@@ -1361,6 +2094,22 @@ export const GetBucketFilesRequest: MessageFns<GetBucketFilesRequest> = {
           message.prefix = reader.string();
           continue;
         }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1378,6 +2127,8 @@ export const GetBucketFilesRequest: MessageFns<GetBucketFilesRequest> = {
         ? globalThis.String(object.bucket_id)
         : "",
       prefix: isSet(object.prefix) ? globalThis.String(object.prefix) : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
     };
   },
 
@@ -1389,6 +2140,12 @@ export const GetBucketFilesRequest: MessageFns<GetBucketFilesRequest> = {
     if (message.prefix !== "") {
       obj.prefix = message.prefix;
     }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
     return obj;
   },
 
@@ -1399,6 +2156,8 @@ export const GetBucketFilesRequest: MessageFns<GetBucketFilesRequest> = {
     const message = createBaseGetBucketFilesRequest();
     message.bucketId = object.bucketId ?? "";
     message.prefix = object.prefix ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
     return message;
   },
 };
@@ -1522,7 +2281,7 @@ export const GetBucketFilesWithContentResponse: MessageFns<GetBucketFilesWithCon
 };
 
 function createBaseGetBucketFilesAsZipRequest(): GetBucketFilesAsZipRequest {
-  return { bucketId: "", prefix: "" };
+  return { bucketId: "", prefix: "", include: [], exclude: [] };
 }
 
 export const GetBucketFilesAsZipRequest: MessageFns<GetBucketFilesAsZipRequest> = {
@@ -1533,6 +2292,12 @@ export const GetBucketFilesAsZipRequest: MessageFns<GetBucketFilesAsZipRequest>
     if (message.prefix !== "") {
       writer.uint32(18).string(message.prefix);
     }
+    for (const v of message.include) {
+      writer.uint32(26).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(34).string(v!);
+    }
     return writer;
   },
 
@@ -1559,6 +2324,22 @@ export const GetBucketFilesAsZipRequest: MessageFns<GetBucketFilesAsZipRequest>
           message.prefix = reader.string();
           continue;
         }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1576,6 +2357,8 @@ export const GetBucketFilesAsZipRequest: MessageFns<GetBucketFilesAsZipRequest>
         ? globalThis.String(object.bucket_id)
         : "",
       prefix: isSet(object.prefix) ? globalThis.String(object.prefix) : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
     };
   },
 
@@ -1587,6 +2370,12 @@ export const GetBucketFilesAsZipRequest: MessageFns<GetBucketFilesAsZipRequest>
     if (message.prefix !== "") {
       obj.prefix = message.prefix;
     }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
     return obj;
   },
 
@@ -1597,6 +2386,8 @@ export const GetBucketFilesAsZipRequest: MessageFns<GetBucketFilesAsZipRequest>
     const message = createBaseGetBucketFilesAsZipRequest();
     message.bucketId = object.bucketId ?? "";
     message.prefix = object.prefix ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
     return message;
   },
 };
@@ -2073,7 +2864,25 @@ export const DeleteBucketFileResponse: MessageFns<DeleteBucketFileResponse> = {
 };
 
 function createBaseExportBucketToGithubRequest(): ExportBucketToGithubRequest {
-  return { bucketId: "", owner: "", repo: "", path: "", token: "" };
+  return {
+    bucketId: "",
+    owner: "",
+    repo: "",
+    path: "",
+    token: "",
+    include: [],
+    exclude: [],
+    branch: "",
+    baseBranch: "",
+    message: "",
+    authorName: "",
+    authorEmail: "",
+    createPullRequest: false,
+    pullRequestTitle: "",
+    pullRequestBody: "",
+    sync: false,
+    maxDeletions: 0,
+  };
 }
 
 export const ExportBucketToGithubRequest: MessageFns<ExportBucketToGithubRequest> = {
@@ -2093,6 +2902,42 @@ export const ExportBucketToGithubRequest: MessageFns<ExportBucketToGithubRequest
     if (message.token !== "") {
       writer.uint32(42).string(message.token);
     }
+    for (const v of message.include) {
+      writer.uint32(50).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(58).string(v!);
+    }
+    if (message.branch !== "") {
+      writer.uint32(66).string(message.branch);
+    }
+    if (message.baseBranch !== "") {
+      writer.uint32(74).string(message.baseBranch);
+    }
+    if (message.message !== "") {
+      writer.uint32(82).string(message.message);
+    }
+    if (message.authorName !== "") {
+      writer.uint32(90).string(message.authorName);
+    }
+    if (message.authorEmail !== "") {
+      writer.uint32(98).string(message.authorEmail);
+    }
+    if (message.createPullRequest !== false) {
+      writer.uint32(104).bool(message.createPullRequest);
+    }
+    if (message.pullRequestTitle !== "") {
+      writer.uint32(114).string(message.pullRequestTitle);
+    }
+    if (message.pullRequestBody !== "") {
+      writer.uint32(122).string(message.pullRequestBody);
+    }
+    if (message.sync !== false) {
+      writer.uint32(128).bool(message.sync);
+    }
+    if (message.maxDeletions !== 0) {
+      writer.uint32(136).int32(message.maxDeletions);
+    }
     return writer;
   },
 
@@ -2143,6 +2988,102 @@ export const ExportBucketToGithubRequest: MessageFns<ExportBucketToGithubRequest
           message.token = reader.string();
           continue;
         }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.branch = reader.string();
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.baseBranch = reader.string();
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.message = reader.string();
+          continue;
+        }
+        case 11: {
+          if (tag !== 90) {
+            break;
+          }
+
+          message.authorName = reader.string();
+          continue;
+        }
+        case 12: {
+          if (tag !== 98) {
+            break;
+          }
+
+          message.authorEmail = reader.string();
+          continue;
+        }
+        case 13: {
+          if (tag !== 104) {
+            break;
+          }
+
+          message.createPullRequest = reader.bool();
+          continue;
+        }
+        case 14: {
+          if (tag !== 114) {
+            break;
+          }
+
+          message.pullRequestTitle = reader.string();
+          continue;
+        }
+        case 15: {
+          if (tag !== 122) {
+            break;
+          }
+
+          message.pullRequestBody = reader.string();
+          continue;
+        }
+        case 16: {
+          if (tag !== 128) {
+            break;
+          }
+
+          message.sync = reader.bool();
+          continue;
+        }
+        case 17: {
+          if (tag !== 136) {
+            break;
+          }
+
+          message.maxDeletions = reader.int32();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -2163,6 +3104,46 @@ export const ExportBucketToGithubRequest: MessageFns<ExportBucketToGithubRequest
       repo: isSet(object.repo) ? globalThis.String(object.repo) : "",
       path: isSet(object.path) ? globalThis.String(object.path) : "",
       token: isSet(object.token) ? globalThis.String(object.token) : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
+      branch: isSet(object.branch) ? globalThis.String(object.branch) : "",
+      baseBranch: isSet(object.baseBranch)
+        ? globalThis.String(object.baseBranch)
+        : isSet(object.base_branch)
+        ? globalThis.String(object.base_branch)
+        : "",
+      message: isSet(object.message) ? globalThis.String(object.message) : "",
+      authorName: isSet(object.authorName)
+        ? globalThis.String(object.authorName)
+        : isSet(object.author_name)
+        ? globalThis.String(object.author_name)
+        : "",
+      authorEmail: isSet(object.authorEmail)
+        ? globalThis.String(object.authorEmail)
+        : isSet(object.author_email)
+        ? globalThis.String(object.author_email)
+        : "",
+      createPullRequest: isSet(object.createPullRequest)
+        ? globalThis.Boolean(object.createPullRequest)
+        : isSet(object.create_pull_request)
+        ? globalThis.Boolean(object.create_pull_request)
+        : false,
+      pullRequestTitle: isSet(object.pullRequestTitle)
+        ? globalThis.String(object.pullRequestTitle)
+        : isSet(object.pull_request_title)
+        ? globalThis.String(object.pull_request_title)
+        : "",
+      pullRequestBody: isSet(object.pullRequestBody)
+        ? globalThis.String(object.pullRequestBody)
+        : isSet(object.pull_request_body)
+        ? globalThis.String(object.pull_request_body)
+        : "",
+      sync: isSet(object.sync) ? globalThis.Boolean(object.sync) : false,
+      maxDeletions: isSet(object.maxDeletions)
+        ? globalThis.Number(object.maxDeletions)
+        : isSet(object.max_deletions)
+        ? globalThis.Number(object.max_deletions)
+        : 0,
     };
   },
 
@@ -2183,6 +3164,42 @@ export const ExportBucketToGithubRequest: MessageFns<ExportBucketToGithubRequest
     if (message.token !== "") {
       obj.token = message.token;
     }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
+    if (message.branch !== "") {
+      obj.branch = message.branch;
+    }
+    if (message.baseBranch !== "") {
+      obj.baseBranch = message.baseBranch;
+    }
+    if (message.message !== "") {
+      obj.message = message.message;
+    }
+    if (message.authorName !== "") {
+      obj.authorName = message.authorName;
+    }
+    if (message.authorEmail !== "") {
+      obj.authorEmail = message.authorEmail;
+    }
+    if (message.createPullRequest !== false) {
+      obj.createPullRequest = message.createPullRequest;
+    }
+    if (message.pullRequestTitle !== "") {
+      obj.pullRequestTitle = message.pullRequestTitle;
+    }
+    if (message.pullRequestBody !== "") {
+      obj.pullRequestBody = message.pullRequestBody;
+    }
+    if (message.sync !== false) {
+      obj.sync = message.sync;
+    }
+    if (message.maxDeletions !== 0) {
+      obj.maxDeletions = Math.round(message.maxDeletions);
+    }
     return obj;
   },
 
@@ -2196,16 +3213,37 @@ export const ExportBucketToGithubRequest: MessageFns<ExportBucketToGithubRequest
     message.repo = object.repo ?? "";
     message.path = object.path ?? "";
     message.token = object.token ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
+    message.branch = object.branch ?? "";
+    message.baseBranch = object.baseBranch ?? "";
+    message.message = object.message ?? "";
+    message.authorName = object.authorName ?? "";
+    message.authorEmail = object.authorEmail ?? "";
+    message.createPullRequest = object.createPullRequest ?? false;
+    message.pullRequestTitle = object.pullRequestTitle ?? "";
+    message.pullRequestBody = object.pullRequestBody ?? "";
+    message.sync = object.sync ?? false;
+    message.maxDeletions = object.maxDeletions ?? 0;
     return message;
   },
 };
 
 function createBaseExportBucketToGithubResponse(): ExportBucketToGithubResponse {
-  return {};
+  return { commitSha: "", pullRequestUrl: "", deletedPaths: [] };
 }
 
 export const ExportBucketToGithubResponse: MessageFns<ExportBucketToGithubResponse> = {
-  encode(_: ExportBucketToGithubResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+  encode(message: ExportBucketToGithubResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.commitSha !== "") {
+      writer.uint32(10).string(message.commitSha);
+    }
+    if (message.pullRequestUrl !== "") {
+      writer.uint32(18).string(message.pullRequestUrl);
+    }
+    for (const v of message.deletedPaths) {
+      writer.uint32(26).string(v!);
+    }
     return writer;
   },
 
@@ -2216,6 +3254,30 @@ export const ExportBucketToGithubResponse: MessageFns<ExportBucketToGithubRespon
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.commitSha = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.pullRequestUrl = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.deletedPaths.push(reader.string());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -2225,20 +3287,46 @@ export const ExportBucketToGithubResponse: MessageFns<ExportBucketToGithubRespon
     return message;
   },
 
-  fromJSON(_: any): ExportBucketToGithubResponse {
-    return {};
+  fromJSON(object: any): ExportBucketToGithubResponse {
+    return {
+      commitSha: isSet(object.commitSha)
+        ? globalThis.String(object.commitSha)
+        : isSet(object.commit_sha)
+        ? globalThis.String(object.commit_sha)
+        : "",
+      pullRequestUrl: isSet(object.pullRequestUrl)
+        ? globalThis.String(object.pullRequestUrl)
+        : isSet(object.pull_request_url)
+        ? globalThis.String(object.pull_request_url)
+        : "",
+      deletedPaths: globalThis.Array.isArray(object?.deletedPaths)
+        ? object.deletedPaths.map((e: any) => globalThis.String(e))
+        : [],
+    };
   },
 
-  toJSON(_: ExportBucketToGithubResponse): unknown {
+  toJSON(message: ExportBucketToGithubResponse): unknown {
     const obj: any = {};
+    if (message.commitSha !== "") {
+      obj.commitSha = message.commitSha;
+    }
+    if (message.pullRequestUrl !== "") {
+      obj.pullRequestUrl = message.pullRequestUrl;
+    }
+    if (message.deletedPaths?.length) {
+      obj.deletedPaths = message.deletedPaths;
+    }
     return obj;
   },
 
   create(base?: DeepPartial<ExportBucketToGithubResponse>): ExportBucketToGithubResponse {
     return ExportBucketToGithubResponse.fromPartial(base ?? {});
   },
-  fromPartial(_: DeepPartial<ExportBucketToGithubResponse>): ExportBucketToGithubResponse {
+  fromPartial(object: DeepPartial<ExportBucketToGithubResponse>): ExportBucketToGithubResponse {
     const message = createBaseExportBucketToGithubResponse();
+    message.commitSha = object.commitSha ?? "";
+    message.pullRequestUrl = object.pullRequestUrl ?? "";
+    message.deletedPaths = object.deletedPaths?.map((e) => e) || [];
     return message;
   },
 };
@@ -2398,7 +3486,17 @@ export const CreateBucketFromGitlabRequest: MessageFns<CreateBucketFromGitlabReq
 };
 
 function createBaseExportBucketToGitlabRequest(): ExportBucketToGitlabRequest {
-  return { bucketId: "", projectId: Long.ZERO, path: "", token: "", gitlabApiUrl: "" };
+  return {
+    bucketId: "",
+    projectId: Long.ZERO,
+    path: "",
+    token: "",
+    gitlabApiUrl: "",
+    include: [],
+    exclude: [],
+    sync: false,
+    maxDeletions: 0,
+  };
 }
 
 export const ExportBucketToGitlabRequest: MessageFns<ExportBucketToGitlabRequest> = {
@@ -2418,6 +3516,18 @@ export const ExportBucketToGitlabRequest: MessageFns<ExportBucketToGitlabRequest
     if (message.gitlabApiUrl !== "") {
       writer.uint32(42).string(message.gitlabApiUrl);
     }
+    for (const v of message.include) {
+      writer.uint32(50).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(58).string(v!);
+    }
+    if (message.sync !== false) {
+      writer.uint32(64).bool(message.sync);
+    }
+    if (message.maxDeletions !== 0) {
+      writer.uint32(72).int32(message.maxDeletions);
+    }
     return writer;
   },
 
@@ -2468,6 +3578,38 @@ export const ExportBucketToGitlabRequest: MessageFns<ExportBucketToGitlabRequest
           message.gitlabApiUrl = reader.string();
           continue;
         }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
+        case 8: {
+          if (tag !== 64) {
+            break;
+          }
+
+          message.sync = reader.bool();
+          continue;
+        }
+        case 9: {
+          if (tag !== 72) {
+            break;
+          }
+
+          message.maxDeletions = reader.int32();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -2496,6 +3638,14 @@ export const ExportBucketToGitlabRequest: MessageFns<ExportBucketToGitlabRequest
         : isSet(object.gitlab_api_url)
         ? globalThis.String(object.gitlab_api_url)
         : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
+      sync: isSet(object.sync) ? globalThis.Boolean(object.sync) : false,
+      maxDeletions: isSet(object.maxDeletions)
+        ? globalThis.Number(object.maxDeletions)
+        : isSet(object.max_deletions)
+        ? globalThis.Number(object.max_deletions)
+        : 0,
     };
   },
 
@@ -2516,6 +3666,18 @@ export const ExportBucketToGitlabRequest: MessageFns<ExportBucketToGitlabRequest
     if (message.gitlabApiUrl !== "") {
       obj.gitlabApiUrl = message.gitlabApiUrl;
     }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
+    if (message.sync !== false) {
+      obj.sync = message.sync;
+    }
+    if (message.maxDeletions !== 0) {
+      obj.maxDeletions = Math.round(message.maxDeletions);
+    }
     return obj;
   },
 
@@ -2531,16 +3693,23 @@ export const ExportBucketToGitlabRequest: MessageFns<ExportBucketToGitlabRequest
     message.path = object.path ?? "";
     message.token = object.token ?? "";
     message.gitlabApiUrl = object.gitlabApiUrl ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
+    message.sync = object.sync ?? false;
+    message.maxDeletions = object.maxDeletions ?? 0;
     return message;
   },
 };
 
 function createBaseExportBucketToGitlabResponse(): ExportBucketToGitlabResponse {
-  return {};
+  return { deletedPaths: [] };
 }
 
 export const ExportBucketToGitlabResponse: MessageFns<ExportBucketToGitlabResponse> = {
-  encode(_: ExportBucketToGitlabResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+  encode(message: ExportBucketToGitlabResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.deletedPaths) {
+      writer.uint32(10).string(v!);
+    }
     return writer;
   },
 
@@ -2551,6 +3720,14 @@ export const ExportBucketToGitlabResponse: MessageFns<ExportBucketToGitlabRespon
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.deletedPaths.push(reader.string());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -2560,34 +3737,7142 @@ export const ExportBucketToGitlabResponse: MessageFns<ExportBucketToGitlabRespon
     return message;
   },
 
-  fromJSON(_: any): ExportBucketToGitlabResponse {
-    return {};
+  fromJSON(object: any): ExportBucketToGitlabResponse {
+    return {
+      deletedPaths: globalThis.Array.isArray(object?.deletedPaths)
+        ? object.deletedPaths.map((e: any) => globalThis.String(e))
+        : [],
+    };
   },
 
-  toJSON(_: ExportBucketToGitlabResponse): unknown {
+  toJSON(message: ExportBucketToGitlabResponse): unknown {
     const obj: any = {};
+    if (message.deletedPaths?.length) {
+      obj.deletedPaths = message.deletedPaths;
+    }
     return obj;
   },
 
   create(base?: DeepPartial<ExportBucketToGitlabResponse>): ExportBucketToGitlabResponse {
     return ExportBucketToGitlabResponse.fromPartial(base ?? {});
   },
-  fromPartial(_: DeepPartial<ExportBucketToGitlabResponse>): ExportBucketToGitlabResponse {
+  fromPartial(object: DeepPartial<ExportBucketToGitlabResponse>): ExportBucketToGitlabResponse {
     const message = createBaseExportBucketToGitlabResponse();
+    message.deletedPaths = object.deletedPaths?.map((e) => e) || [];
     return message;
   },
 };
 
-export type CodeBucketService = typeof CodeBucketService;
-export const CodeBucketService = {
-  cloneBucket: {
-    path: "/rpc.rpc.CodeBucket/CloneBucket",
-    requestStream: false,
-    responseStream: false,
-    requestSerialize: (value: CloneBucketRequest): Buffer => Buffer.from(CloneBucketRequest.encode(value).finish()),
-    requestDeserialize: (value: Buffer): CloneBucketRequest => CloneBucketRequest.decode(value),
-    responseSerialize: (value: CreateBucketResponse): Buffer =>
-      Buffer.from(CreateBucketResponse.encode(value).finish()),
+function createBaseCreateBucketOverlayRequest(): CreateBucketOverlayRequest {
+  return { baseBucketId: "", newBucketId: "" };
+}
+
+export const CreateBucketOverlayRequest: MessageFns<CreateBucketOverlayRequest> = {
+  encode(message: CreateBucketOverlayRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.baseBucketId !== "") {
+      writer.uint32(10).string(message.baseBucketId);
+    }
+    if (message.newBucketId !== "") {
+      writer.uint32(18).string(message.newBucketId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateBucketOverlayRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateBucketOverlayRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.baseBucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.newBucketId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateBucketOverlayRequest {
+    return {
+      baseBucketId: isSet(object.baseBucketId)
+        ? globalThis.String(object.baseBucketId)
+        : isSet(object.base_bucket_id)
+        ? globalThis.String(object.base_bucket_id)
+        : "",
+      newBucketId: isSet(object.newBucketId)
+        ? globalThis.String(object.newBucketId)
+        : isSet(object.new_bucket_id)
+        ? globalThis.String(object.new_bucket_id)
+        : "",
+    };
+  },
+
+  toJSON(message: CreateBucketOverlayRequest): unknown {
+    const obj: any = {};
+    if (message.baseBucketId !== "") {
+      obj.baseBucketId = message.baseBucketId;
+    }
+    if (message.newBucketId !== "") {
+      obj.newBucketId = message.newBucketId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateBucketOverlayRequest>): CreateBucketOverlayRequest {
+    return CreateBucketOverlayRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CreateBucketOverlayRequest>): CreateBucketOverlayRequest {
+    const message = createBaseCreateBucketOverlayRequest();
+    message.baseBucketId = object.baseBucketId ?? "";
+    message.newBucketId = object.newBucketId ?? "";
+    return message;
+  },
+};
+
+function createBaseOverlayChange(): OverlayChange {
+  return { path: "", changeType: "", fileInfo: undefined };
+}
+
+export const OverlayChange: MessageFns<OverlayChange> = {
+  encode(message: OverlayChange, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.path !== "") {
+      writer.uint32(10).string(message.path);
+    }
+    if (message.changeType !== "") {
+      writer.uint32(18).string(message.changeType);
+    }
+    if (message.fileInfo !== undefined) {
+      FileInfo.encode(message.fileInfo, writer.uint32(26).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): OverlayChange {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOverlayChange();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.changeType = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.fileInfo = FileInfo.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): OverlayChange {
+    return {
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      changeType: isSet(object.changeType)
+        ? globalThis.String(object.changeType)
+        : isSet(object.change_type)
+        ? globalThis.String(object.change_type)
+        : "",
+      fileInfo: isSet(object.fileInfo)
+        ? FileInfo.fromJSON(object.fileInfo)
+        : isSet(object.file_info)
+        ? FileInfo.fromJSON(object.file_info)
+        : undefined,
+    };
+  },
+
+  toJSON(message: OverlayChange): unknown {
+    const obj: any = {};
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.changeType !== "") {
+      obj.changeType = message.changeType;
+    }
+    if (message.fileInfo !== undefined) {
+      obj.fileInfo = FileInfo.toJSON(message.fileInfo);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<OverlayChange>): OverlayChange {
+    return OverlayChange.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<OverlayChange>): OverlayChange {
+    const message = createBaseOverlayChange();
+    message.path = object.path ?? "";
+    message.changeType = object.changeType ?? "";
+    message.fileInfo = (object.fileInfo !== undefined && object.fileInfo !== null)
+      ? FileInfo.fromPartial(object.fileInfo)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseGetBucketOverlayChangesRequest(): GetBucketOverlayChangesRequest {
+  return { bucketId: "" };
+}
+
+export const GetBucketOverlayChangesRequest: MessageFns<GetBucketOverlayChangesRequest> = {
+  encode(message: GetBucketOverlayChangesRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketOverlayChangesRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketOverlayChangesRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketOverlayChangesRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+    };
+  },
+
+  toJSON(message: GetBucketOverlayChangesRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketOverlayChangesRequest>): GetBucketOverlayChangesRequest {
+    return GetBucketOverlayChangesRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketOverlayChangesRequest>): GetBucketOverlayChangesRequest {
+    const message = createBaseGetBucketOverlayChangesRequest();
+    message.bucketId = object.bucketId ?? "";
+    return message;
+  },
+};
+
+function createBaseGetBucketOverlayChangesResponse(): GetBucketOverlayChangesResponse {
+  return { changes: [] };
+}
+
+export const GetBucketOverlayChangesResponse: MessageFns<GetBucketOverlayChangesResponse> = {
+  encode(message: GetBucketOverlayChangesResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.changes) {
+      OverlayChange.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketOverlayChangesResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketOverlayChangesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.changes.push(OverlayChange.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketOverlayChangesResponse {
+    return {
+      changes: globalThis.Array.isArray(object?.changes)
+        ? object.changes.map((e: any) => OverlayChange.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetBucketOverlayChangesResponse): unknown {
+    const obj: any = {};
+    if (message.changes?.length) {
+      obj.changes = message.changes.map((e) => OverlayChange.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketOverlayChangesResponse>): GetBucketOverlayChangesResponse {
+    return GetBucketOverlayChangesResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketOverlayChangesResponse>): GetBucketOverlayChangesResponse {
+    const message = createBaseGetBucketOverlayChangesResponse();
+    message.changes = object.changes?.map((e) => OverlayChange.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseDiscardBucketOverlayRequest(): DiscardBucketOverlayRequest {
+  return { bucketId: "" };
+}
+
+export const DiscardBucketOverlayRequest: MessageFns<DiscardBucketOverlayRequest> = {
+  encode(message: DiscardBucketOverlayRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DiscardBucketOverlayRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDiscardBucketOverlayRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DiscardBucketOverlayRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+    };
+  },
+
+  toJSON(message: DiscardBucketOverlayRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<DiscardBucketOverlayRequest>): DiscardBucketOverlayRequest {
+    return DiscardBucketOverlayRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<DiscardBucketOverlayRequest>): DiscardBucketOverlayRequest {
+    const message = createBaseDiscardBucketOverlayRequest();
+    message.bucketId = object.bucketId ?? "";
+    return message;
+  },
+};
+
+function createBaseDiscardBucketOverlayResponse(): DiscardBucketOverlayResponse {
+  return {};
+}
+
+export const DiscardBucketOverlayResponse: MessageFns<DiscardBucketOverlayResponse> = {
+  encode(_: DiscardBucketOverlayResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DiscardBucketOverlayResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDiscardBucketOverlayResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): DiscardBucketOverlayResponse {
+    return {};
+  },
+
+  toJSON(_: DiscardBucketOverlayResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create(base?: DeepPartial<DiscardBucketOverlayResponse>): DiscardBucketOverlayResponse {
+    return DiscardBucketOverlayResponse.fromPartial(base ?? {});
+  },
+  fromPartial(_: DeepPartial<DiscardBucketOverlayResponse>): DiscardBucketOverlayResponse {
+    const message = createBaseDiscardBucketOverlayResponse();
+    return message;
+  },
+};
+
+function createBaseCommitBucketOverlayRequest(): CommitBucketOverlayRequest {
+  return { bucketId: "" };
+}
+
+export const CommitBucketOverlayRequest: MessageFns<CommitBucketOverlayRequest> = {
+  encode(message: CommitBucketOverlayRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CommitBucketOverlayRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCommitBucketOverlayRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CommitBucketOverlayRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+    };
+  },
+
+  toJSON(message: CommitBucketOverlayRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CommitBucketOverlayRequest>): CommitBucketOverlayRequest {
+    return CommitBucketOverlayRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CommitBucketOverlayRequest>): CommitBucketOverlayRequest {
+    const message = createBaseCommitBucketOverlayRequest();
+    message.bucketId = object.bucketId ?? "";
+    return message;
+  },
+};
+
+function createBaseCommitBucketOverlayResponse(): CommitBucketOverlayResponse {
+  return {};
+}
+
+export const CommitBucketOverlayResponse: MessageFns<CommitBucketOverlayResponse> = {
+  encode(_: CommitBucketOverlayResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CommitBucketOverlayResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCommitBucketOverlayResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): CommitBucketOverlayResponse {
+    return {};
+  },
+
+  toJSON(_: CommitBucketOverlayResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create(base?: DeepPartial<CommitBucketOverlayResponse>): CommitBucketOverlayResponse {
+    return CommitBucketOverlayResponse.fromPartial(base ?? {});
+  },
+  fromPartial(_: DeepPartial<CommitBucketOverlayResponse>): CommitBucketOverlayResponse {
+    const message = createBaseCommitBucketOverlayResponse();
+    return message;
+  },
+};
+
+function createBaseDiffBucketsRequest(): DiffBucketsRequest {
+  return {
+    baseBucketId: "",
+    basePrefix: "",
+    targetBucketId: "",
+    targetPrefix: "",
+    includeTextDiffs: false,
+    contextLines: 0,
+    maxFileSize: Long.ZERO,
+  };
+}
+
+export const DiffBucketsRequest: MessageFns<DiffBucketsRequest> = {
+  encode(message: DiffBucketsRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.baseBucketId !== "") {
+      writer.uint32(10).string(message.baseBucketId);
+    }
+    if (message.basePrefix !== "") {
+      writer.uint32(18).string(message.basePrefix);
+    }
+    if (message.targetBucketId !== "") {
+      writer.uint32(26).string(message.targetBucketId);
+    }
+    if (message.targetPrefix !== "") {
+      writer.uint32(34).string(message.targetPrefix);
+    }
+    if (message.includeTextDiffs !== false) {
+      writer.uint32(40).bool(message.includeTextDiffs);
+    }
+    if (message.contextLines !== 0) {
+      writer.uint32(48).int32(message.contextLines);
+    }
+    if (!message.maxFileSize.equals(Long.ZERO)) {
+      writer.uint32(56).int64(message.maxFileSize.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DiffBucketsRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDiffBucketsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.baseBucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.basePrefix = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.targetBucketId = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.targetPrefix = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.includeTextDiffs = reader.bool();
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.contextLines = reader.int32();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.maxFileSize = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DiffBucketsRequest {
+    return {
+      baseBucketId: isSet(object.baseBucketId)
+        ? globalThis.String(object.baseBucketId)
+        : isSet(object.base_bucket_id)
+        ? globalThis.String(object.base_bucket_id)
+        : "",
+      basePrefix: isSet(object.basePrefix)
+        ? globalThis.String(object.basePrefix)
+        : isSet(object.base_prefix)
+        ? globalThis.String(object.base_prefix)
+        : "",
+      targetBucketId: isSet(object.targetBucketId)
+        ? globalThis.String(object.targetBucketId)
+        : isSet(object.target_bucket_id)
+        ? globalThis.String(object.target_bucket_id)
+        : "",
+      targetPrefix: isSet(object.targetPrefix)
+        ? globalThis.String(object.targetPrefix)
+        : isSet(object.target_prefix)
+        ? globalThis.String(object.target_prefix)
+        : "",
+      includeTextDiffs: isSet(object.includeTextDiffs)
+        ? globalThis.Boolean(object.includeTextDiffs)
+        : isSet(object.include_text_diffs)
+        ? globalThis.Boolean(object.include_text_diffs)
+        : false,
+      contextLines: isSet(object.contextLines)
+        ? globalThis.Number(object.contextLines)
+        : isSet(object.context_lines)
+        ? globalThis.Number(object.context_lines)
+        : 0,
+      maxFileSize: isSet(object.maxFileSize)
+        ? Long.fromValue(object.maxFileSize)
+        : isSet(object.max_file_size)
+        ? Long.fromValue(object.max_file_size)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: DiffBucketsRequest): unknown {
+    const obj: any = {};
+    if (message.baseBucketId !== "") {
+      obj.baseBucketId = message.baseBucketId;
+    }
+    if (message.basePrefix !== "") {
+      obj.basePrefix = message.basePrefix;
+    }
+    if (message.targetBucketId !== "") {
+      obj.targetBucketId = message.targetBucketId;
+    }
+    if (message.targetPrefix !== "") {
+      obj.targetPrefix = message.targetPrefix;
+    }
+    if (message.includeTextDiffs !== false) {
+      obj.includeTextDiffs = message.includeTextDiffs;
+    }
+    if (message.contextLines !== 0) {
+      obj.contextLines = Math.round(message.contextLines);
+    }
+    if (!message.maxFileSize.equals(Long.ZERO)) {
+      obj.maxFileSize = (message.maxFileSize || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<DiffBucketsRequest>): DiffBucketsRequest {
+    return DiffBucketsRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<DiffBucketsRequest>): DiffBucketsRequest {
+    const message = createBaseDiffBucketsRequest();
+    message.baseBucketId = object.baseBucketId ?? "";
+    message.basePrefix = object.basePrefix ?? "";
+    message.targetBucketId = object.targetBucketId ?? "";
+    message.targetPrefix = object.targetPrefix ?? "";
+    message.includeTextDiffs = object.includeTextDiffs ?? false;
+    message.contextLines = object.contextLines ?? 0;
+    message.maxFileSize = (object.maxFileSize !== undefined && object.maxFileSize !== null)
+      ? Long.fromValue(object.maxFileSize)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseFileDiff(): FileDiff {
+  return { path: "", changeType: "", isBinary: false, oldSize: Long.ZERO, newSize: Long.ZERO, unifiedDiff: "" };
+}
+
+export const FileDiff: MessageFns<FileDiff> = {
+  encode(message: FileDiff, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.path !== "") {
+      writer.uint32(10).string(message.path);
+    }
+    if (message.changeType !== "") {
+      writer.uint32(18).string(message.changeType);
+    }
+    if (message.isBinary !== false) {
+      writer.uint32(24).bool(message.isBinary);
+    }
+    if (!message.oldSize.equals(Long.ZERO)) {
+      writer.uint32(32).int64(message.oldSize.toString());
+    }
+    if (!message.newSize.equals(Long.ZERO)) {
+      writer.uint32(40).int64(message.newSize.toString());
+    }
+    if (message.unifiedDiff !== "") {
+      writer.uint32(50).string(message.unifiedDiff);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): FileDiff {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseFileDiff();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.changeType = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.isBinary = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.oldSize = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.newSize = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.unifiedDiff = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): FileDiff {
+    return {
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      changeType: isSet(object.changeType)
+        ? globalThis.String(object.changeType)
+        : isSet(object.change_type)
+        ? globalThis.String(object.change_type)
+        : "",
+      isBinary: isSet(object.isBinary)
+        ? globalThis.Boolean(object.isBinary)
+        : isSet(object.is_binary)
+        ? globalThis.Boolean(object.is_binary)
+        : false,
+      oldSize: isSet(object.oldSize)
+        ? Long.fromValue(object.oldSize)
+        : isSet(object.old_size)
+        ? Long.fromValue(object.old_size)
+        : Long.ZERO,
+      newSize: isSet(object.newSize)
+        ? Long.fromValue(object.newSize)
+        : isSet(object.new_size)
+        ? Long.fromValue(object.new_size)
+        : Long.ZERO,
+      unifiedDiff: isSet(object.unifiedDiff)
+        ? globalThis.String(object.unifiedDiff)
+        : isSet(object.unified_diff)
+        ? globalThis.String(object.unified_diff)
+        : "",
+    };
+  },
+
+  toJSON(message: FileDiff): unknown {
+    const obj: any = {};
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.changeType !== "") {
+      obj.changeType = message.changeType;
+    }
+    if (message.isBinary !== false) {
+      obj.isBinary = message.isBinary;
+    }
+    if (!message.oldSize.equals(Long.ZERO)) {
+      obj.oldSize = (message.oldSize || Long.ZERO).toString();
+    }
+    if (!message.newSize.equals(Long.ZERO)) {
+      obj.newSize = (message.newSize || Long.ZERO).toString();
+    }
+    if (message.unifiedDiff !== "") {
+      obj.unifiedDiff = message.unifiedDiff;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<FileDiff>): FileDiff {
+    return FileDiff.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<FileDiff>): FileDiff {
+    const message = createBaseFileDiff();
+    message.path = object.path ?? "";
+    message.changeType = object.changeType ?? "";
+    message.isBinary = object.isBinary ?? false;
+    message.oldSize = (object.oldSize !== undefined && object.oldSize !== null)
+      ? Long.fromValue(object.oldSize)
+      : Long.ZERO;
+    message.newSize = (object.newSize !== undefined && object.newSize !== null)
+      ? Long.fromValue(object.newSize)
+      : Long.ZERO;
+    message.unifiedDiff = object.unifiedDiff ?? "";
+    return message;
+  },
+};
+
+function createBaseDiffBucketsResponse(): DiffBucketsResponse {
+  return { files: [] };
+}
+
+export const DiffBucketsResponse: MessageFns<DiffBucketsResponse> = {
+  encode(message: DiffBucketsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.files) {
+      FileDiff.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DiffBucketsResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDiffBucketsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.files.push(FileDiff.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DiffBucketsResponse {
+    return { files: globalThis.Array.isArray(object?.files) ? object.files.map((e: any) => FileDiff.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: DiffBucketsResponse): unknown {
+    const obj: any = {};
+    if (message.files?.length) {
+      obj.files = message.files.map((e) => FileDiff.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<DiffBucketsResponse>): DiffBucketsResponse {
+    return DiffBucketsResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<DiffBucketsResponse>): DiffBucketsResponse {
+    const message = createBaseDiffBucketsResponse();
+    message.files = object.files?.map((e) => FileDiff.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseApplyPatchRequest(): ApplyPatchRequest {
+  return { bucketId: "", patch: "", dryRun: false, strict: false, maxFuzz: 0, pathPrefix: "" };
+}
+
+export const ApplyPatchRequest: MessageFns<ApplyPatchRequest> = {
+  encode(message: ApplyPatchRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.patch !== "") {
+      writer.uint32(18).string(message.patch);
+    }
+    if (message.dryRun !== false) {
+      writer.uint32(24).bool(message.dryRun);
+    }
+    if (message.strict !== false) {
+      writer.uint32(32).bool(message.strict);
+    }
+    if (message.maxFuzz !== 0) {
+      writer.uint32(40).int32(message.maxFuzz);
+    }
+    if (message.pathPrefix !== "") {
+      writer.uint32(50).string(message.pathPrefix);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ApplyPatchRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseApplyPatchRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.patch = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.dryRun = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.strict = reader.bool();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.maxFuzz = reader.int32();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.pathPrefix = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ApplyPatchRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      patch: isSet(object.patch) ? globalThis.String(object.patch) : "",
+      dryRun: isSet(object.dryRun)
+        ? globalThis.Boolean(object.dryRun)
+        : isSet(object.dry_run)
+        ? globalThis.Boolean(object.dry_run)
+        : false,
+      strict: isSet(object.strict) ? globalThis.Boolean(object.strict) : false,
+      maxFuzz: isSet(object.maxFuzz)
+        ? globalThis.Number(object.maxFuzz)
+        : isSet(object.max_fuzz)
+        ? globalThis.Number(object.max_fuzz)
+        : 0,
+      pathPrefix: isSet(object.pathPrefix)
+        ? globalThis.String(object.pathPrefix)
+        : isSet(object.path_prefix)
+        ? globalThis.String(object.path_prefix)
+        : "",
+    };
+  },
+
+  toJSON(message: ApplyPatchRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.patch !== "") {
+      obj.patch = message.patch;
+    }
+    if (message.dryRun !== false) {
+      obj.dryRun = message.dryRun;
+    }
+    if (message.strict !== false) {
+      obj.strict = message.strict;
+    }
+    if (message.maxFuzz !== 0) {
+      obj.maxFuzz = Math.round(message.maxFuzz);
+    }
+    if (message.pathPrefix !== "") {
+      obj.pathPrefix = message.pathPrefix;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ApplyPatchRequest>): ApplyPatchRequest {
+    return ApplyPatchRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ApplyPatchRequest>): ApplyPatchRequest {
+    const message = createBaseApplyPatchRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.patch = object.patch ?? "";
+    message.dryRun = object.dryRun ?? false;
+    message.strict = object.strict ?? false;
+    message.maxFuzz = object.maxFuzz ?? 0;
+    message.pathPrefix = object.pathPrefix ?? "";
+    return message;
+  },
+};
+
+function createBasePatchHunkResult(): PatchHunkResult {
+  return { applied: false, offset: 0, fuzz: 0, error: "" };
+}
+
+export const PatchHunkResult: MessageFns<PatchHunkResult> = {
+  encode(message: PatchHunkResult, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.applied !== false) {
+      writer.uint32(8).bool(message.applied);
+    }
+    if (message.offset !== 0) {
+      writer.uint32(16).int32(message.offset);
+    }
+    if (message.fuzz !== 0) {
+      writer.uint32(24).int32(message.fuzz);
+    }
+    if (message.error !== "") {
+      writer.uint32(34).string(message.error);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PatchHunkResult {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePatchHunkResult();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.applied = reader.bool();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.offset = reader.int32();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.fuzz = reader.int32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PatchHunkResult {
+    return {
+      applied: isSet(object.applied) ? globalThis.Boolean(object.applied) : false,
+      offset: isSet(object.offset) ? globalThis.Number(object.offset) : 0,
+      fuzz: isSet(object.fuzz) ? globalThis.Number(object.fuzz) : 0,
+      error: isSet(object.error) ? globalThis.String(object.error) : "",
+    };
+  },
+
+  toJSON(message: PatchHunkResult): unknown {
+    const obj: any = {};
+    if (message.applied !== false) {
+      obj.applied = message.applied;
+    }
+    if (message.offset !== 0) {
+      obj.offset = Math.round(message.offset);
+    }
+    if (message.fuzz !== 0) {
+      obj.fuzz = Math.round(message.fuzz);
+    }
+    if (message.error !== "") {
+      obj.error = message.error;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<PatchHunkResult>): PatchHunkResult {
+    return PatchHunkResult.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<PatchHunkResult>): PatchHunkResult {
+    const message = createBasePatchHunkResult();
+    message.applied = object.applied ?? false;
+    message.offset = object.offset ?? 0;
+    message.fuzz = object.fuzz ?? 0;
+    message.error = object.error ?? "";
+    return message;
+  },
+};
+
+function createBasePatchFileResult(): PatchFileResult {
+  return { path: "", oldPath: "", changeType: "", hunks: [], error: "" };
+}
+
+export const PatchFileResult: MessageFns<PatchFileResult> = {
+  encode(message: PatchFileResult, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.path !== "") {
+      writer.uint32(10).string(message.path);
+    }
+    if (message.oldPath !== "") {
+      writer.uint32(18).string(message.oldPath);
+    }
+    if (message.changeType !== "") {
+      writer.uint32(26).string(message.changeType);
+    }
+    for (const v of message.hunks) {
+      PatchHunkResult.encode(v!, writer.uint32(34).fork()).join();
+    }
+    if (message.error !== "") {
+      writer.uint32(42).string(message.error);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PatchFileResult {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePatchFileResult();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.oldPath = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.changeType = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.hunks.push(PatchHunkResult.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PatchFileResult {
+    return {
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      oldPath: isSet(object.oldPath)
+        ? globalThis.String(object.oldPath)
+        : isSet(object.old_path)
+        ? globalThis.String(object.old_path)
+        : "",
+      changeType: isSet(object.changeType)
+        ? globalThis.String(object.changeType)
+        : isSet(object.change_type)
+        ? globalThis.String(object.change_type)
+        : "",
+      hunks: globalThis.Array.isArray(object?.hunks) ? object.hunks.map((e: any) => PatchHunkResult.fromJSON(e)) : [],
+      error: isSet(object.error) ? globalThis.String(object.error) : "",
+    };
+  },
+
+  toJSON(message: PatchFileResult): unknown {
+    const obj: any = {};
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.oldPath !== "") {
+      obj.oldPath = message.oldPath;
+    }
+    if (message.changeType !== "") {
+      obj.changeType = message.changeType;
+    }
+    if (message.hunks?.length) {
+      obj.hunks = message.hunks.map((e) => PatchHunkResult.toJSON(e));
+    }
+    if (message.error !== "") {
+      obj.error = message.error;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<PatchFileResult>): PatchFileResult {
+    return PatchFileResult.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<PatchFileResult>): PatchFileResult {
+    const message = createBasePatchFileResult();
+    message.path = object.path ?? "";
+    message.oldPath = object.oldPath ?? "";
+    message.changeType = object.changeType ?? "";
+    message.hunks = object.hunks?.map((e) => PatchHunkResult.fromPartial(e)) || [];
+    message.error = object.error ?? "";
+    return message;
+  },
+};
+
+function createBaseApplyPatchResponse(): ApplyPatchResponse {
+  return { applied: false, files: [] };
+}
+
+export const ApplyPatchResponse: MessageFns<ApplyPatchResponse> = {
+  encode(message: ApplyPatchResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.applied !== false) {
+      writer.uint32(8).bool(message.applied);
+    }
+    for (const v of message.files) {
+      PatchFileResult.encode(v!, writer.uint32(18).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ApplyPatchResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseApplyPatchResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.applied = reader.bool();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.files.push(PatchFileResult.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ApplyPatchResponse {
+    return {
+      applied: isSet(object.applied) ? globalThis.Boolean(object.applied) : false,
+      files: globalThis.Array.isArray(object?.files) ? object.files.map((e: any) => PatchFileResult.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: ApplyPatchResponse): unknown {
+    const obj: any = {};
+    if (message.applied !== false) {
+      obj.applied = message.applied;
+    }
+    if (message.files?.length) {
+      obj.files = message.files.map((e) => PatchFileResult.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ApplyPatchResponse>): ApplyPatchResponse {
+    return ApplyPatchResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ApplyPatchResponse>): ApplyPatchResponse {
+    const message = createBaseApplyPatchResponse();
+    message.applied = object.applied ?? false;
+    message.files = object.files?.map((e) => PatchFileResult.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseMergeBucketsRequest(): MergeBucketsRequest {
+  return { baseBucketId: "", oursBucketId: "", theirsBucketId: "", targetBucketId: "", conflictMarkers: false };
+}
+
+export const MergeBucketsRequest: MessageFns<MergeBucketsRequest> = {
+  encode(message: MergeBucketsRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.baseBucketId !== "") {
+      writer.uint32(10).string(message.baseBucketId);
+    }
+    if (message.oursBucketId !== "") {
+      writer.uint32(18).string(message.oursBucketId);
+    }
+    if (message.theirsBucketId !== "") {
+      writer.uint32(26).string(message.theirsBucketId);
+    }
+    if (message.targetBucketId !== "") {
+      writer.uint32(34).string(message.targetBucketId);
+    }
+    if (message.conflictMarkers !== false) {
+      writer.uint32(40).bool(message.conflictMarkers);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): MergeBucketsRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMergeBucketsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.baseBucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.oursBucketId = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.theirsBucketId = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.targetBucketId = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.conflictMarkers = reader.bool();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MergeBucketsRequest {
+    return {
+      baseBucketId: isSet(object.baseBucketId)
+        ? globalThis.String(object.baseBucketId)
+        : isSet(object.base_bucket_id)
+        ? globalThis.String(object.base_bucket_id)
+        : "",
+      oursBucketId: isSet(object.oursBucketId)
+        ? globalThis.String(object.oursBucketId)
+        : isSet(object.ours_bucket_id)
+        ? globalThis.String(object.ours_bucket_id)
+        : "",
+      theirsBucketId: isSet(object.theirsBucketId)
+        ? globalThis.String(object.theirsBucketId)
+        : isSet(object.theirs_bucket_id)
+        ? globalThis.String(object.theirs_bucket_id)
+        : "",
+      targetBucketId: isSet(object.targetBucketId)
+        ? globalThis.String(object.targetBucketId)
+        : isSet(object.target_bucket_id)
+        ? globalThis.String(object.target_bucket_id)
+        : "",
+      conflictMarkers: isSet(object.conflictMarkers)
+        ? globalThis.Boolean(object.conflictMarkers)
+        : isSet(object.conflict_markers)
+        ? globalThis.Boolean(object.conflict_markers)
+        : false,
+    };
+  },
+
+  toJSON(message: MergeBucketsRequest): unknown {
+    const obj: any = {};
+    if (message.baseBucketId !== "") {
+      obj.baseBucketId = message.baseBucketId;
+    }
+    if (message.oursBucketId !== "") {
+      obj.oursBucketId = message.oursBucketId;
+    }
+    if (message.theirsBucketId !== "") {
+      obj.theirsBucketId = message.theirsBucketId;
+    }
+    if (message.targetBucketId !== "") {
+      obj.targetBucketId = message.targetBucketId;
+    }
+    if (message.conflictMarkers !== false) {
+      obj.conflictMarkers = message.conflictMarkers;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MergeBucketsRequest>): MergeBucketsRequest {
+    return MergeBucketsRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<MergeBucketsRequest>): MergeBucketsRequest {
+    const message = createBaseMergeBucketsRequest();
+    message.baseBucketId = object.baseBucketId ?? "";
+    message.oursBucketId = object.oursBucketId ?? "";
+    message.theirsBucketId = object.theirsBucketId ?? "";
+    message.targetBucketId = object.targetBucketId ?? "";
+    message.conflictMarkers = object.conflictMarkers ?? false;
+    return message;
+  },
+};
+
+function createBaseMergeConflictHunk(): MergeConflictHunk {
+  return { baseStart: 0, oursStart: 0, theirsStart: 0, base: "", ours: "", theirs: "" };
+}
+
+export const MergeConflictHunk: MessageFns<MergeConflictHunk> = {
+  encode(message: MergeConflictHunk, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.baseStart !== 0) {
+      writer.uint32(8).int32(message.baseStart);
+    }
+    if (message.oursStart !== 0) {
+      writer.uint32(16).int32(message.oursStart);
+    }
+    if (message.theirsStart !== 0) {
+      writer.uint32(24).int32(message.theirsStart);
+    }
+    if (message.base !== "") {
+      writer.uint32(34).string(message.base);
+    }
+    if (message.ours !== "") {
+      writer.uint32(42).string(message.ours);
+    }
+    if (message.theirs !== "") {
+      writer.uint32(50).string(message.theirs);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): MergeConflictHunk {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMergeConflictHunk();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.baseStart = reader.int32();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.oursStart = reader.int32();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.theirsStart = reader.int32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.base = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.ours = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.theirs = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MergeConflictHunk {
+    return {
+      baseStart: isSet(object.baseStart)
+        ? globalThis.Number(object.baseStart)
+        : isSet(object.base_start)
+        ? globalThis.Number(object.base_start)
+        : 0,
+      oursStart: isSet(object.oursStart)
+        ? globalThis.Number(object.oursStart)
+        : isSet(object.ours_start)
+        ? globalThis.Number(object.ours_start)
+        : 0,
+      theirsStart: isSet(object.theirsStart)
+        ? globalThis.Number(object.theirsStart)
+        : isSet(object.theirs_start)
+        ? globalThis.Number(object.theirs_start)
+        : 0,
+      base: isSet(object.base) ? globalThis.String(object.base) : "",
+      ours: isSet(object.ours) ? globalThis.String(object.ours) : "",
+      theirs: isSet(object.theirs) ? globalThis.String(object.theirs) : "",
+    };
+  },
+
+  toJSON(message: MergeConflictHunk): unknown {
+    const obj: any = {};
+    if (message.baseStart !== 0) {
+      obj.baseStart = Math.round(message.baseStart);
+    }
+    if (message.oursStart !== 0) {
+      obj.oursStart = Math.round(message.oursStart);
+    }
+    if (message.theirsStart !== 0) {
+      obj.theirsStart = Math.round(message.theirsStart);
+    }
+    if (message.base !== "") {
+      obj.base = message.base;
+    }
+    if (message.ours !== "") {
+      obj.ours = message.ours;
+    }
+    if (message.theirs !== "") {
+      obj.theirs = message.theirs;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MergeConflictHunk>): MergeConflictHunk {
+    return MergeConflictHunk.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<MergeConflictHunk>): MergeConflictHunk {
+    const message = createBaseMergeConflictHunk();
+    message.baseStart = object.baseStart ?? 0;
+    message.oursStart = object.oursStart ?? 0;
+    message.theirsStart = object.theirsStart ?? 0;
+    message.base = object.base ?? "";
+    message.ours = object.ours ?? "";
+    message.theirs = object.theirs ?? "";
+    return message;
+  },
+};
+
+function createBaseMergeFileResult(): MergeFileResult {
+  return { path: "", resolution: "", deleted: false, conflictType: "", hunks: [] };
+}
+
+export const MergeFileResult: MessageFns<MergeFileResult> = {
+  encode(message: MergeFileResult, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.path !== "") {
+      writer.uint32(10).string(message.path);
+    }
+    if (message.resolution !== "") {
+      writer.uint32(18).string(message.resolution);
+    }
+    if (message.deleted !== false) {
+      writer.uint32(24).bool(message.deleted);
+    }
+    if (message.conflictType !== "") {
+      writer.uint32(34).string(message.conflictType);
+    }
+    for (const v of message.hunks) {
+      MergeConflictHunk.encode(v!, writer.uint32(42).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): MergeFileResult {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMergeFileResult();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.resolution = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.deleted = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.conflictType = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.hunks.push(MergeConflictHunk.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MergeFileResult {
+    return {
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      resolution: isSet(object.resolution) ? globalThis.String(object.resolution) : "",
+      deleted: isSet(object.deleted) ? globalThis.Boolean(object.deleted) : false,
+      conflictType: isSet(object.conflictType)
+        ? globalThis.String(object.conflictType)
+        : isSet(object.conflict_type)
+        ? globalThis.String(object.conflict_type)
+        : "",
+      hunks: globalThis.Array.isArray(object?.hunks) ? object.hunks.map((e: any) => MergeConflictHunk.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: MergeFileResult): unknown {
+    const obj: any = {};
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.resolution !== "") {
+      obj.resolution = message.resolution;
+    }
+    if (message.deleted !== false) {
+      obj.deleted = message.deleted;
+    }
+    if (message.conflictType !== "") {
+      obj.conflictType = message.conflictType;
+    }
+    if (message.hunks?.length) {
+      obj.hunks = message.hunks.map((e) => MergeConflictHunk.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MergeFileResult>): MergeFileResult {
+    return MergeFileResult.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<MergeFileResult>): MergeFileResult {
+    const message = createBaseMergeFileResult();
+    message.path = object.path ?? "";
+    message.resolution = object.resolution ?? "";
+    message.deleted = object.deleted ?? false;
+    message.conflictType = object.conflictType ?? "";
+    message.hunks = object.hunks?.map((e) => MergeConflictHunk.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseMergeBucketsResponse(): MergeBucketsResponse {
+  return { hasConflicts: false, files: [] };
+}
+
+export const MergeBucketsResponse: MessageFns<MergeBucketsResponse> = {
+  encode(message: MergeBucketsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.hasConflicts !== false) {
+      writer.uint32(8).bool(message.hasConflicts);
+    }
+    for (const v of message.files) {
+      MergeFileResult.encode(v!, writer.uint32(18).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): MergeBucketsResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMergeBucketsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.hasConflicts = reader.bool();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.files.push(MergeFileResult.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MergeBucketsResponse {
+    return {
+      hasConflicts: isSet(object.hasConflicts)
+        ? globalThis.Boolean(object.hasConflicts)
+        : isSet(object.has_conflicts)
+        ? globalThis.Boolean(object.has_conflicts)
+        : false,
+      files: globalThis.Array.isArray(object?.files) ? object.files.map((e: any) => MergeFileResult.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: MergeBucketsResponse): unknown {
+    const obj: any = {};
+    if (message.hasConflicts !== false) {
+      obj.hasConflicts = message.hasConflicts;
+    }
+    if (message.files?.length) {
+      obj.files = message.files.map((e) => MergeFileResult.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MergeBucketsResponse>): MergeBucketsResponse {
+    return MergeBucketsResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<MergeBucketsResponse>): MergeBucketsResponse {
+    const message = createBaseMergeBucketsResponse();
+    message.hasConflicts = object.hasConflicts ?? false;
+    message.files = object.files?.map((e) => MergeFileResult.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseSearchBucketRequest(): SearchBucketRequest {
+  return {
+    bucketId: "",
+    query: "",
+    isRegex: false,
+    caseInsensitive: false,
+    prefix: "",
+    glob: "",
+    contextLines: 0,
+    maxResults: 0,
+    maxFileSize: Long.ZERO,
+  };
+}
+
+export const SearchBucketRequest: MessageFns<SearchBucketRequest> = {
+  encode(message: SearchBucketRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.query !== "") {
+      writer.uint32(18).string(message.query);
+    }
+    if (message.isRegex !== false) {
+      writer.uint32(24).bool(message.isRegex);
+    }
+    if (message.caseInsensitive !== false) {
+      writer.uint32(32).bool(message.caseInsensitive);
+    }
+    if (message.prefix !== "") {
+      writer.uint32(42).string(message.prefix);
+    }
+    if (message.glob !== "") {
+      writer.uint32(50).string(message.glob);
+    }
+    if (message.contextLines !== 0) {
+      writer.uint32(56).int32(message.contextLines);
+    }
+    if (message.maxResults !== 0) {
+      writer.uint32(64).int32(message.maxResults);
+    }
+    if (!message.maxFileSize.equals(Long.ZERO)) {
+      writer.uint32(72).int64(message.maxFileSize.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SearchBucketRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSearchBucketRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.query = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.isRegex = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.caseInsensitive = reader.bool();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.prefix = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.glob = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.contextLines = reader.int32();
+          continue;
+        }
+        case 8: {
+          if (tag !== 64) {
+            break;
+          }
+
+          message.maxResults = reader.int32();
+          continue;
+        }
+        case 9: {
+          if (tag !== 72) {
+            break;
+          }
+
+          message.maxFileSize = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SearchBucketRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      query: isSet(object.query) ? globalThis.String(object.query) : "",
+      isRegex: isSet(object.isRegex)
+        ? globalThis.Boolean(object.isRegex)
+        : isSet(object.is_regex)
+        ? globalThis.Boolean(object.is_regex)
+        : false,
+      caseInsensitive: isSet(object.caseInsensitive)
+        ? globalThis.Boolean(object.caseInsensitive)
+        : isSet(object.case_insensitive)
+        ? globalThis.Boolean(object.case_insensitive)
+        : false,
+      prefix: isSet(object.prefix) ? globalThis.String(object.prefix) : "",
+      glob: isSet(object.glob) ? globalThis.String(object.glob) : "",
+      contextLines: isSet(object.contextLines)
+        ? globalThis.Number(object.contextLines)
+        : isSet(object.context_lines)
+        ? globalThis.Number(object.context_lines)
+        : 0,
+      maxResults: isSet(object.maxResults)
+        ? globalThis.Number(object.maxResults)
+        : isSet(object.max_results)
+        ? globalThis.Number(object.max_results)
+        : 0,
+      maxFileSize: isSet(object.maxFileSize)
+        ? Long.fromValue(object.maxFileSize)
+        : isSet(object.max_file_size)
+        ? Long.fromValue(object.max_file_size)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: SearchBucketRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.query !== "") {
+      obj.query = message.query;
+    }
+    if (message.isRegex !== false) {
+      obj.isRegex = message.isRegex;
+    }
+    if (message.caseInsensitive !== false) {
+      obj.caseInsensitive = message.caseInsensitive;
+    }
+    if (message.prefix !== "") {
+      obj.prefix = message.prefix;
+    }
+    if (message.glob !== "") {
+      obj.glob = message.glob;
+    }
+    if (message.contextLines !== 0) {
+      obj.contextLines = Math.round(message.contextLines);
+    }
+    if (message.maxResults !== 0) {
+      obj.maxResults = Math.round(message.maxResults);
+    }
+    if (!message.maxFileSize.equals(Long.ZERO)) {
+      obj.maxFileSize = (message.maxFileSize || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<SearchBucketRequest>): SearchBucketRequest {
+    return SearchBucketRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<SearchBucketRequest>): SearchBucketRequest {
+    const message = createBaseSearchBucketRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.query = object.query ?? "";
+    message.isRegex = object.isRegex ?? false;
+    message.caseInsensitive = object.caseInsensitive ?? false;
+    message.prefix = object.prefix ?? "";
+    message.glob = object.glob ?? "";
+    message.contextLines = object.contextLines ?? 0;
+    message.maxResults = object.maxResults ?? 0;
+    message.maxFileSize = (object.maxFileSize !== undefined && object.maxFileSize !== null)
+      ? Long.fromValue(object.maxFileSize)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseSearchMatch(): SearchMatch {
+  return { path: "", lineNumber: 0, column: 0, line: "", contextBefore: [], contextAfter: [] };
+}
+
+export const SearchMatch: MessageFns<SearchMatch> = {
+  encode(message: SearchMatch, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.path !== "") {
+      writer.uint32(10).string(message.path);
+    }
+    if (message.lineNumber !== 0) {
+      writer.uint32(16).int32(message.lineNumber);
+    }
+    if (message.column !== 0) {
+      writer.uint32(24).int32(message.column);
+    }
+    if (message.line !== "") {
+      writer.uint32(34).string(message.line);
+    }
+    for (const v of message.contextBefore) {
+      writer.uint32(42).string(v!);
+    }
+    for (const v of message.contextAfter) {
+      writer.uint32(50).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SearchMatch {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSearchMatch();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.lineNumber = reader.int32();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.column = reader.int32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.line = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.contextBefore.push(reader.string());
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.contextAfter.push(reader.string());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SearchMatch {
+    return {
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      lineNumber: isSet(object.lineNumber)
+        ? globalThis.Number(object.lineNumber)
+        : isSet(object.line_number)
+        ? globalThis.Number(object.line_number)
+        : 0,
+      column: isSet(object.column) ? globalThis.Number(object.column) : 0,
+      line: isSet(object.line) ? globalThis.String(object.line) : "",
+      contextBefore: globalThis.Array.isArray(object?.contextBefore)
+        ? object.contextBefore.map((e: any) => globalThis.String(e))
+        : [],
+      contextAfter: globalThis.Array.isArray(object?.contextAfter)
+        ? object.contextAfter.map((e: any) => globalThis.String(e))
+        : [],
+    };
+  },
+
+  toJSON(message: SearchMatch): unknown {
+    const obj: any = {};
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.lineNumber !== 0) {
+      obj.lineNumber = Math.round(message.lineNumber);
+    }
+    if (message.column !== 0) {
+      obj.column = Math.round(message.column);
+    }
+    if (message.line !== "") {
+      obj.line = message.line;
+    }
+    if (message.contextBefore?.length) {
+      obj.contextBefore = message.contextBefore;
+    }
+    if (message.contextAfter?.length) {
+      obj.contextAfter = message.contextAfter;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<SearchMatch>): SearchMatch {
+    return SearchMatch.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<SearchMatch>): SearchMatch {
+    const message = createBaseSearchMatch();
+    message.path = object.path ?? "";
+    message.lineNumber = object.lineNumber ?? 0;
+    message.column = object.column ?? 0;
+    message.line = object.line ?? "";
+    message.contextBefore = object.contextBefore?.map((e) => e) || [];
+    message.contextAfter = object.contextAfter?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseSearchBucketResponse(): SearchBucketResponse {
+  return { match: undefined, limitReached: false };
+}
+
+export const SearchBucketResponse: MessageFns<SearchBucketResponse> = {
+  encode(message: SearchBucketResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.match !== undefined) {
+      SearchMatch.encode(message.match, writer.uint32(10).fork()).join();
+    }
+    if (message.limitReached !== false) {
+      writer.uint32(16).bool(message.limitReached);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SearchBucketResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSearchBucketResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.match = SearchMatch.decode(reader, reader.uint32());
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.limitReached = reader.bool();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SearchBucketResponse {
+    return {
+      match: isSet(object.match) ? SearchMatch.fromJSON(object.match) : undefined,
+      limitReached: isSet(object.limitReached)
+        ? globalThis.Boolean(object.limitReached)
+        : isSet(object.limit_reached)
+        ? globalThis.Boolean(object.limit_reached)
+        : false,
+    };
+  },
+
+  toJSON(message: SearchBucketResponse): unknown {
+    const obj: any = {};
+    if (message.match !== undefined) {
+      obj.match = SearchMatch.toJSON(message.match);
+    }
+    if (message.limitReached !== false) {
+      obj.limitReached = message.limitReached;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<SearchBucketResponse>): SearchBucketResponse {
+    return SearchBucketResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<SearchBucketResponse>): SearchBucketResponse {
+    const message = createBaseSearchBucketResponse();
+    message.match = (object.match !== undefined && object.match !== null)
+      ? SearchMatch.fromPartial(object.match)
+      : undefined;
+    message.limitReached = object.limitReached ?? false;
+    return message;
+  },
+};
+
+function createBaseRebuildBucketSearchIndexRequest(): RebuildBucketSearchIndexRequest {
+  return { bucketId: "" };
+}
+
+export const RebuildBucketSearchIndexRequest: MessageFns<RebuildBucketSearchIndexRequest> = {
+  encode(message: RebuildBucketSearchIndexRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RebuildBucketSearchIndexRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRebuildBucketSearchIndexRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RebuildBucketSearchIndexRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+    };
+  },
+
+  toJSON(message: RebuildBucketSearchIndexRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RebuildBucketSearchIndexRequest>): RebuildBucketSearchIndexRequest {
+    return RebuildBucketSearchIndexRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<RebuildBucketSearchIndexRequest>): RebuildBucketSearchIndexRequest {
+    const message = createBaseRebuildBucketSearchIndexRequest();
+    message.bucketId = object.bucketId ?? "";
+    return message;
+  },
+};
+
+function createBaseRebuildBucketSearchIndexResponse(): RebuildBucketSearchIndexResponse {
+  return { indexedFiles: 0 };
+}
+
+export const RebuildBucketSearchIndexResponse: MessageFns<RebuildBucketSearchIndexResponse> = {
+  encode(message: RebuildBucketSearchIndexResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.indexedFiles !== 0) {
+      writer.uint32(8).int32(message.indexedFiles);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RebuildBucketSearchIndexResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRebuildBucketSearchIndexResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.indexedFiles = reader.int32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RebuildBucketSearchIndexResponse {
+    return {
+      indexedFiles: isSet(object.indexedFiles)
+        ? globalThis.Number(object.indexedFiles)
+        : isSet(object.indexed_files)
+        ? globalThis.Number(object.indexed_files)
+        : 0,
+    };
+  },
+
+  toJSON(message: RebuildBucketSearchIndexResponse): unknown {
+    const obj: any = {};
+    if (message.indexedFiles !== 0) {
+      obj.indexedFiles = Math.round(message.indexedFiles);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RebuildBucketSearchIndexResponse>): RebuildBucketSearchIndexResponse {
+    return RebuildBucketSearchIndexResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<RebuildBucketSearchIndexResponse>): RebuildBucketSearchIndexResponse {
+    const message = createBaseRebuildBucketSearchIndexResponse();
+    message.indexedFiles = object.indexedFiles ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetBucketDigestRequest(): GetBucketDigestRequest {
+  return { bucketId: "", prefix: "" };
+}
+
+export const GetBucketDigestRequest: MessageFns<GetBucketDigestRequest> = {
+  encode(message: GetBucketDigestRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.prefix !== "") {
+      writer.uint32(18).string(message.prefix);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketDigestRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketDigestRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.prefix = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketDigestRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      prefix: isSet(object.prefix) ? globalThis.String(object.prefix) : "",
+    };
+  },
+
+  toJSON(message: GetBucketDigestRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.prefix !== "") {
+      obj.prefix = message.prefix;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketDigestRequest>): GetBucketDigestRequest {
+    return GetBucketDigestRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketDigestRequest>): GetBucketDigestRequest {
+    const message = createBaseGetBucketDigestRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.prefix = object.prefix ?? "";
+    return message;
+  },
+};
+
+function createBaseGetBucketDigestResponse(): GetBucketDigestResponse {
+  return { digest: "", fileCount: 0 };
+}
+
+export const GetBucketDigestResponse: MessageFns<GetBucketDigestResponse> = {
+  encode(message: GetBucketDigestResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.digest !== "") {
+      writer.uint32(10).string(message.digest);
+    }
+    if (message.fileCount !== 0) {
+      writer.uint32(16).int32(message.fileCount);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketDigestResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketDigestResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.digest = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.fileCount = reader.int32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketDigestResponse {
+    return {
+      digest: isSet(object.digest) ? globalThis.String(object.digest) : "",
+      fileCount: isSet(object.fileCount)
+        ? globalThis.Number(object.fileCount)
+        : isSet(object.file_count)
+        ? globalThis.Number(object.file_count)
+        : 0,
+    };
+  },
+
+  toJSON(message: GetBucketDigestResponse): unknown {
+    const obj: any = {};
+    if (message.digest !== "") {
+      obj.digest = message.digest;
+    }
+    if (message.fileCount !== 0) {
+      obj.fileCount = Math.round(message.fileCount);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketDigestResponse>): GetBucketDigestResponse {
+    return GetBucketDigestResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketDigestResponse>): GetBucketDigestResponse {
+    const message = createBaseGetBucketDigestResponse();
+    message.digest = object.digest ?? "";
+    message.fileCount = object.fileCount ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetBucketSyncTreeRequest(): GetBucketSyncTreeRequest {
+  return { bucketId: "", path: "", knownHash: "" };
+}
+
+export const GetBucketSyncTreeRequest: MessageFns<GetBucketSyncTreeRequest> = {
+  encode(message: GetBucketSyncTreeRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.path !== "") {
+      writer.uint32(18).string(message.path);
+    }
+    if (message.knownHash !== "") {
+      writer.uint32(26).string(message.knownHash);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketSyncTreeRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketSyncTreeRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.knownHash = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketSyncTreeRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      knownHash: isSet(object.knownHash)
+        ? globalThis.String(object.knownHash)
+        : isSet(object.known_hash)
+        ? globalThis.String(object.known_hash)
+        : "",
+    };
+  },
+
+  toJSON(message: GetBucketSyncTreeRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.knownHash !== "") {
+      obj.knownHash = message.knownHash;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketSyncTreeRequest>): GetBucketSyncTreeRequest {
+    return GetBucketSyncTreeRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketSyncTreeRequest>): GetBucketSyncTreeRequest {
+    const message = createBaseGetBucketSyncTreeRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.path = object.path ?? "";
+    message.knownHash = object.knownHash ?? "";
+    return message;
+  },
+};
+
+function createBaseSyncTreeEntry(): SyncTreeEntry {
+  return { name: "", path: "", isDir: false, hash: "", size: Long.ZERO };
+}
+
+export const SyncTreeEntry: MessageFns<SyncTreeEntry> = {
+  encode(message: SyncTreeEntry, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.path !== "") {
+      writer.uint32(18).string(message.path);
+    }
+    if (message.isDir !== false) {
+      writer.uint32(24).bool(message.isDir);
+    }
+    if (message.hash !== "") {
+      writer.uint32(34).string(message.hash);
+    }
+    if (!message.size.equals(Long.ZERO)) {
+      writer.uint32(40).int64(message.size.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SyncTreeEntry {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSyncTreeEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.isDir = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.hash = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.size = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SyncTreeEntry {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      isDir: isSet(object.isDir)
+        ? globalThis.Boolean(object.isDir)
+        : isSet(object.is_dir)
+        ? globalThis.Boolean(object.is_dir)
+        : false,
+      hash: isSet(object.hash) ? globalThis.String(object.hash) : "",
+      size: isSet(object.size) ? Long.fromValue(object.size) : Long.ZERO,
+    };
+  },
+
+  toJSON(message: SyncTreeEntry): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.isDir !== false) {
+      obj.isDir = message.isDir;
+    }
+    if (message.hash !== "") {
+      obj.hash = message.hash;
+    }
+    if (!message.size.equals(Long.ZERO)) {
+      obj.size = (message.size || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<SyncTreeEntry>): SyncTreeEntry {
+    return SyncTreeEntry.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<SyncTreeEntry>): SyncTreeEntry {
+    const message = createBaseSyncTreeEntry();
+    message.name = object.name ?? "";
+    message.path = object.path ?? "";
+    message.isDir = object.isDir ?? false;
+    message.hash = object.hash ?? "";
+    message.size = (object.size !== undefined && object.size !== null) ? Long.fromValue(object.size) : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseGetBucketSyncTreeResponse(): GetBucketSyncTreeResponse {
+  return { path: "", hash: "", unchanged: false, entries: [] };
+}
+
+export const GetBucketSyncTreeResponse: MessageFns<GetBucketSyncTreeResponse> = {
+  encode(message: GetBucketSyncTreeResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.path !== "") {
+      writer.uint32(10).string(message.path);
+    }
+    if (message.hash !== "") {
+      writer.uint32(18).string(message.hash);
+    }
+    if (message.unchanged !== false) {
+      writer.uint32(24).bool(message.unchanged);
+    }
+    for (const v of message.entries) {
+      SyncTreeEntry.encode(v!, writer.uint32(34).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketSyncTreeResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketSyncTreeResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.hash = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.unchanged = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.entries.push(SyncTreeEntry.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketSyncTreeResponse {
+    return {
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      hash: isSet(object.hash) ? globalThis.String(object.hash) : "",
+      unchanged: isSet(object.unchanged) ? globalThis.Boolean(object.unchanged) : false,
+      entries: globalThis.Array.isArray(object?.entries)
+        ? object.entries.map((e: any) => SyncTreeEntry.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetBucketSyncTreeResponse): unknown {
+    const obj: any = {};
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.hash !== "") {
+      obj.hash = message.hash;
+    }
+    if (message.unchanged !== false) {
+      obj.unchanged = message.unchanged;
+    }
+    if (message.entries?.length) {
+      obj.entries = message.entries.map((e) => SyncTreeEntry.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketSyncTreeResponse>): GetBucketSyncTreeResponse {
+    return GetBucketSyncTreeResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketSyncTreeResponse>): GetBucketSyncTreeResponse {
+    const message = createBaseGetBucketSyncTreeResponse();
+    message.path = object.path ?? "";
+    message.hash = object.hash ?? "";
+    message.unchanged = object.unchanged ?? false;
+    message.entries = object.entries?.map((e) => SyncTreeEntry.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseApplyBucketFileDeltaRequest(): ApplyBucketFileDeltaRequest {
+  return { bucketId: "", path: "", baseHash: "", delta: new Uint8Array(0) };
+}
+
+export const ApplyBucketFileDeltaRequest: MessageFns<ApplyBucketFileDeltaRequest> = {
+  encode(message: ApplyBucketFileDeltaRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.path !== "") {
+      writer.uint32(18).string(message.path);
+    }
+    if (message.baseHash !== "") {
+      writer.uint32(26).string(message.baseHash);
+    }
+    if (message.delta.length !== 0) {
+      writer.uint32(34).bytes(message.delta);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ApplyBucketFileDeltaRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseApplyBucketFileDeltaRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.baseHash = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.delta = reader.bytes();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ApplyBucketFileDeltaRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      baseHash: isSet(object.baseHash)
+        ? globalThis.String(object.baseHash)
+        : isSet(object.base_hash)
+        ? globalThis.String(object.base_hash)
+        : "",
+      delta: isSet(object.delta) ? bytesFromBase64(object.delta) : new Uint8Array(0),
+    };
+  },
+
+  toJSON(message: ApplyBucketFileDeltaRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.baseHash !== "") {
+      obj.baseHash = message.baseHash;
+    }
+    if (message.delta.length !== 0) {
+      obj.delta = base64FromBytes(message.delta);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ApplyBucketFileDeltaRequest>): ApplyBucketFileDeltaRequest {
+    return ApplyBucketFileDeltaRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ApplyBucketFileDeltaRequest>): ApplyBucketFileDeltaRequest {
+    const message = createBaseApplyBucketFileDeltaRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.path = object.path ?? "";
+    message.baseHash = object.baseHash ?? "";
+    message.delta = object.delta ?? new Uint8Array(0);
+    return message;
+  },
+};
+
+function createBaseApplyBucketFileDeltaResponse(): ApplyBucketFileDeltaResponse {
+  return { fileInfo: undefined };
+}
+
+export const ApplyBucketFileDeltaResponse: MessageFns<ApplyBucketFileDeltaResponse> = {
+  encode(message: ApplyBucketFileDeltaResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.fileInfo !== undefined) {
+      FileInfo.encode(message.fileInfo, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ApplyBucketFileDeltaResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseApplyBucketFileDeltaResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.fileInfo = FileInfo.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ApplyBucketFileDeltaResponse {
+    return {
+      fileInfo: isSet(object.fileInfo)
+        ? FileInfo.fromJSON(object.fileInfo)
+        : isSet(object.file_info)
+        ? FileInfo.fromJSON(object.file_info)
+        : undefined,
+    };
+  },
+
+  toJSON(message: ApplyBucketFileDeltaResponse): unknown {
+    const obj: any = {};
+    if (message.fileInfo !== undefined) {
+      obj.fileInfo = FileInfo.toJSON(message.fileInfo);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ApplyBucketFileDeltaResponse>): ApplyBucketFileDeltaResponse {
+    return ApplyBucketFileDeltaResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ApplyBucketFileDeltaResponse>): ApplyBucketFileDeltaResponse {
+    const message = createBaseApplyBucketFileDeltaResponse();
+    message.fileInfo = (object.fileInfo !== undefined && object.fileInfo !== null)
+      ? FileInfo.fromPartial(object.fileInfo)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseMoveBucketFileRequest(): MoveBucketFileRequest {
+  return { bucketId: "", fromPath: "", toPath: "" };
+}
+
+export const MoveBucketFileRequest: MessageFns<MoveBucketFileRequest> = {
+  encode(message: MoveBucketFileRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.fromPath !== "") {
+      writer.uint32(18).string(message.fromPath);
+    }
+    if (message.toPath !== "") {
+      writer.uint32(26).string(message.toPath);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): MoveBucketFileRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMoveBucketFileRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.fromPath = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.toPath = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MoveBucketFileRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      fromPath: isSet(object.fromPath)
+        ? globalThis.String(object.fromPath)
+        : isSet(object.from_path)
+        ? globalThis.String(object.from_path)
+        : "",
+      toPath: isSet(object.toPath)
+        ? globalThis.String(object.toPath)
+        : isSet(object.to_path)
+        ? globalThis.String(object.to_path)
+        : "",
+    };
+  },
+
+  toJSON(message: MoveBucketFileRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.fromPath !== "") {
+      obj.fromPath = message.fromPath;
+    }
+    if (message.toPath !== "") {
+      obj.toPath = message.toPath;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MoveBucketFileRequest>): MoveBucketFileRequest {
+    return MoveBucketFileRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<MoveBucketFileRequest>): MoveBucketFileRequest {
+    const message = createBaseMoveBucketFileRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.fromPath = object.fromPath ?? "";
+    message.toPath = object.toPath ?? "";
+    return message;
+  },
+};
+
+function createBaseMoveBucketFileResponse(): MoveBucketFileResponse {
+  return { fileInfo: undefined };
+}
+
+export const MoveBucketFileResponse: MessageFns<MoveBucketFileResponse> = {
+  encode(message: MoveBucketFileResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.fileInfo !== undefined) {
+      FileInfo.encode(message.fileInfo, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): MoveBucketFileResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMoveBucketFileResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.fileInfo = FileInfo.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MoveBucketFileResponse {
+    return {
+      fileInfo: isSet(object.fileInfo)
+        ? FileInfo.fromJSON(object.fileInfo)
+        : isSet(object.file_info)
+        ? FileInfo.fromJSON(object.file_info)
+        : undefined,
+    };
+  },
+
+  toJSON(message: MoveBucketFileResponse): unknown {
+    const obj: any = {};
+    if (message.fileInfo !== undefined) {
+      obj.fileInfo = FileInfo.toJSON(message.fileInfo);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MoveBucketFileResponse>): MoveBucketFileResponse {
+    return MoveBucketFileResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<MoveBucketFileResponse>): MoveBucketFileResponse {
+    const message = createBaseMoveBucketFileResponse();
+    message.fileInfo = (object.fileInfo !== undefined && object.fileInfo !== null)
+      ? FileInfo.fromPartial(object.fileInfo)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseWatchBucketRequest(): WatchBucketRequest {
+  return { bucketId: "", prefix: "", cursor: "" };
+}
+
+export const WatchBucketRequest: MessageFns<WatchBucketRequest> = {
+  encode(message: WatchBucketRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.prefix !== "") {
+      writer.uint32(18).string(message.prefix);
+    }
+    if (message.cursor !== "") {
+      writer.uint32(26).string(message.cursor);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): WatchBucketRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWatchBucketRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.prefix = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.cursor = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WatchBucketRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      prefix: isSet(object.prefix) ? globalThis.String(object.prefix) : "",
+      cursor: isSet(object.cursor) ? globalThis.String(object.cursor) : "",
+    };
+  },
+
+  toJSON(message: WatchBucketRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.prefix !== "") {
+      obj.prefix = message.prefix;
+    }
+    if (message.cursor !== "") {
+      obj.cursor = message.cursor;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<WatchBucketRequest>): WatchBucketRequest {
+    return WatchBucketRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<WatchBucketRequest>): WatchBucketRequest {
+    const message = createBaseWatchBucketRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.prefix = object.prefix ?? "";
+    message.cursor = object.cursor ?? "";
+    return message;
+  },
+};
+
+function createBaseBucketEvent(): BucketEvent {
+  return { cursor: "", type: "", path: "", oldPath: "", hash: "", size: Long.ZERO, actor: "", time: Long.ZERO };
+}
+
+export const BucketEvent: MessageFns<BucketEvent> = {
+  encode(message: BucketEvent, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.cursor !== "") {
+      writer.uint32(10).string(message.cursor);
+    }
+    if (message.type !== "") {
+      writer.uint32(18).string(message.type);
+    }
+    if (message.path !== "") {
+      writer.uint32(26).string(message.path);
+    }
+    if (message.oldPath !== "") {
+      writer.uint32(34).string(message.oldPath);
+    }
+    if (message.hash !== "") {
+      writer.uint32(42).string(message.hash);
+    }
+    if (!message.size.equals(Long.ZERO)) {
+      writer.uint32(48).int64(message.size.toString());
+    }
+    if (message.actor !== "") {
+      writer.uint32(58).string(message.actor);
+    }
+    if (!message.time.equals(Long.ZERO)) {
+      writer.uint32(64).int64(message.time.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): BucketEvent {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseBucketEvent();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.cursor = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.type = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.oldPath = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.hash = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.size = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.actor = reader.string();
+          continue;
+        }
+        case 8: {
+          if (tag !== 64) {
+            break;
+          }
+
+          message.time = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): BucketEvent {
+    return {
+      cursor: isSet(object.cursor) ? globalThis.String(object.cursor) : "",
+      type: isSet(object.type) ? globalThis.String(object.type) : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      oldPath: isSet(object.oldPath)
+        ? globalThis.String(object.oldPath)
+        : isSet(object.old_path)
+        ? globalThis.String(object.old_path)
+        : "",
+      hash: isSet(object.hash) ? globalThis.String(object.hash) : "",
+      size: isSet(object.size) ? Long.fromValue(object.size) : Long.ZERO,
+      actor: isSet(object.actor) ? globalThis.String(object.actor) : "",
+      time: isSet(object.time) ? Long.fromValue(object.time) : Long.ZERO,
+    };
+  },
+
+  toJSON(message: BucketEvent): unknown {
+    const obj: any = {};
+    if (message.cursor !== "") {
+      obj.cursor = message.cursor;
+    }
+    if (message.type !== "") {
+      obj.type = message.type;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.oldPath !== "") {
+      obj.oldPath = message.oldPath;
+    }
+    if (message.hash !== "") {
+      obj.hash = message.hash;
+    }
+    if (!message.size.equals(Long.ZERO)) {
+      obj.size = (message.size || Long.ZERO).toString();
+    }
+    if (message.actor !== "") {
+      obj.actor = message.actor;
+    }
+    if (!message.time.equals(Long.ZERO)) {
+      obj.time = (message.time || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<BucketEvent>): BucketEvent {
+    return BucketEvent.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<BucketEvent>): BucketEvent {
+    const message = createBaseBucketEvent();
+    message.cursor = object.cursor ?? "";
+    message.type = object.type ?? "";
+    message.path = object.path ?? "";
+    message.oldPath = object.oldPath ?? "";
+    message.hash = object.hash ?? "";
+    message.size = (object.size !== undefined && object.size !== null) ? Long.fromValue(object.size) : Long.ZERO;
+    message.actor = object.actor ?? "";
+    message.time = (object.time !== undefined && object.time !== null) ? Long.fromValue(object.time) : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseWebhook(): Webhook {
+  return { id: "", bucketId: "", url: "", events: [], createdAt: Long.ZERO };
+}
+
+export const Webhook: MessageFns<Webhook> = {
+  encode(message: Webhook, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.bucketId !== "") {
+      writer.uint32(18).string(message.bucketId);
+    }
+    if (message.url !== "") {
+      writer.uint32(26).string(message.url);
+    }
+    for (const v of message.events) {
+      writer.uint32(34).string(v!);
+    }
+    if (!message.createdAt.equals(Long.ZERO)) {
+      writer.uint32(40).int64(message.createdAt.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Webhook {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWebhook();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.url = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.events.push(reader.string());
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.createdAt = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Webhook {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      url: isSet(object.url) ? globalThis.String(object.url) : "",
+      events: globalThis.Array.isArray(object?.events) ? object.events.map((e: any) => globalThis.String(e)) : [],
+      createdAt: isSet(object.createdAt)
+        ? Long.fromValue(object.createdAt)
+        : isSet(object.created_at)
+        ? Long.fromValue(object.created_at)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: Webhook): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.url !== "") {
+      obj.url = message.url;
+    }
+    if (message.events?.length) {
+      obj.events = message.events;
+    }
+    if (!message.createdAt.equals(Long.ZERO)) {
+      obj.createdAt = (message.createdAt || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<Webhook>): Webhook {
+    return Webhook.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<Webhook>): Webhook {
+    const message = createBaseWebhook();
+    message.id = object.id ?? "";
+    message.bucketId = object.bucketId ?? "";
+    message.url = object.url ?? "";
+    message.events = object.events?.map((e) => e) || [];
+    message.createdAt = (object.createdAt !== undefined && object.createdAt !== null)
+      ? Long.fromValue(object.createdAt)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseCreateWebhookRequest(): CreateWebhookRequest {
+  return { bucketId: "", url: "", secret: "", events: [] };
+}
+
+export const CreateWebhookRequest: MessageFns<CreateWebhookRequest> = {
+  encode(message: CreateWebhookRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.url !== "") {
+      writer.uint32(18).string(message.url);
+    }
+    if (message.secret !== "") {
+      writer.uint32(26).string(message.secret);
+    }
+    for (const v of message.events) {
+      writer.uint32(34).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateWebhookRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateWebhookRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.url = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.secret = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.events.push(reader.string());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateWebhookRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      url: isSet(object.url) ? globalThis.String(object.url) : "",
+      secret: isSet(object.secret) ? globalThis.String(object.secret) : "",
+      events: globalThis.Array.isArray(object?.events) ? object.events.map((e: any) => globalThis.String(e)) : [],
+    };
+  },
+
+  toJSON(message: CreateWebhookRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.url !== "") {
+      obj.url = message.url;
+    }
+    if (message.secret !== "") {
+      obj.secret = message.secret;
+    }
+    if (message.events?.length) {
+      obj.events = message.events;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateWebhookRequest>): CreateWebhookRequest {
+    return CreateWebhookRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CreateWebhookRequest>): CreateWebhookRequest {
+    const message = createBaseCreateWebhookRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.url = object.url ?? "";
+    message.secret = object.secret ?? "";
+    message.events = object.events?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseCreateWebhookResponse(): CreateWebhookResponse {
+  return { webhook: undefined, secret: "" };
+}
+
+export const CreateWebhookResponse: MessageFns<CreateWebhookResponse> = {
+  encode(message: CreateWebhookResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.webhook !== undefined) {
+      Webhook.encode(message.webhook, writer.uint32(10).fork()).join();
+    }
+    if (message.secret !== "") {
+      writer.uint32(18).string(message.secret);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateWebhookResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateWebhookResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.webhook = Webhook.decode(reader, reader.uint32());
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.secret = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateWebhookResponse {
+    return {
+      webhook: isSet(object.webhook) ? Webhook.fromJSON(object.webhook) : undefined,
+      secret: isSet(object.secret) ? globalThis.String(object.secret) : "",
+    };
+  },
+
+  toJSON(message: CreateWebhookResponse): unknown {
+    const obj: any = {};
+    if (message.webhook !== undefined) {
+      obj.webhook = Webhook.toJSON(message.webhook);
+    }
+    if (message.secret !== "") {
+      obj.secret = message.secret;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateWebhookResponse>): CreateWebhookResponse {
+    return CreateWebhookResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CreateWebhookResponse>): CreateWebhookResponse {
+    const message = createBaseCreateWebhookResponse();
+    message.webhook = (object.webhook !== undefined && object.webhook !== null)
+      ? Webhook.fromPartial(object.webhook)
+      : undefined;
+    message.secret = object.secret ?? "";
+    return message;
+  },
+};
+
+function createBaseListWebhooksRequest(): ListWebhooksRequest {
+  return { bucketId: "" };
+}
+
+export const ListWebhooksRequest: MessageFns<ListWebhooksRequest> = {
+  encode(message: ListWebhooksRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ListWebhooksRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhooksRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhooksRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+    };
+  },
+
+  toJSON(message: ListWebhooksRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhooksRequest>): ListWebhooksRequest {
+    return ListWebhooksRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ListWebhooksRequest>): ListWebhooksRequest {
+    const message = createBaseListWebhooksRequest();
+    message.bucketId = object.bucketId ?? "";
+    return message;
+  },
+};
+
+function createBaseListWebhooksResponse(): ListWebhooksResponse {
+  return { webhooks: [] };
+}
+
+export const ListWebhooksResponse: MessageFns<ListWebhooksResponse> = {
+  encode(message: ListWebhooksResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.webhooks) {
+      Webhook.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ListWebhooksResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhooksResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.webhooks.push(Webhook.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhooksResponse {
+    return {
+      webhooks: globalThis.Array.isArray(object?.webhooks) ? object.webhooks.map((e: any) => Webhook.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: ListWebhooksResponse): unknown {
+    const obj: any = {};
+    if (message.webhooks?.length) {
+      obj.webhooks = message.webhooks.map((e) => Webhook.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhooksResponse>): ListWebhooksResponse {
+    return ListWebhooksResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ListWebhooksResponse>): ListWebhooksResponse {
+    const message = createBaseListWebhooksResponse();
+    message.webhooks = object.webhooks?.map((e) => Webhook.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseDeleteWebhookRequest(): DeleteWebhookRequest {
+  return { webhookId: "" };
+}
+
+export const DeleteWebhookRequest: MessageFns<DeleteWebhookRequest> = {
+  encode(message: DeleteWebhookRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.webhookId !== "") {
+      writer.uint32(10).string(message.webhookId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DeleteWebhookRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteWebhookRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.webhookId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DeleteWebhookRequest {
+    return {
+      webhookId: isSet(object.webhookId)
+        ? globalThis.String(object.webhookId)
+        : isSet(object.webhook_id)
+        ? globalThis.String(object.webhook_id)
+        : "",
+    };
+  },
+
+  toJSON(message: DeleteWebhookRequest): unknown {
+    const obj: any = {};
+    if (message.webhookId !== "") {
+      obj.webhookId = message.webhookId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<DeleteWebhookRequest>): DeleteWebhookRequest {
+    return DeleteWebhookRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<DeleteWebhookRequest>): DeleteWebhookRequest {
+    const message = createBaseDeleteWebhookRequest();
+    message.webhookId = object.webhookId ?? "";
+    return message;
+  },
+};
+
+function createBaseDeleteWebhookResponse(): DeleteWebhookResponse {
+  return {};
+}
+
+export const DeleteWebhookResponse: MessageFns<DeleteWebhookResponse> = {
+  encode(_: DeleteWebhookResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DeleteWebhookResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteWebhookResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): DeleteWebhookResponse {
+    return {};
+  },
+
+  toJSON(_: DeleteWebhookResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create(base?: DeepPartial<DeleteWebhookResponse>): DeleteWebhookResponse {
+    return DeleteWebhookResponse.fromPartial(base ?? {});
+  },
+  fromPartial(_: DeepPartial<DeleteWebhookResponse>): DeleteWebhookResponse {
+    const message = createBaseDeleteWebhookResponse();
+    return message;
+  },
+};
+
+function createBaseWebhookDelivery(): WebhookDelivery {
+  return {
+    id: "",
+    webhookId: "",
+    event: "",
+    attempt: 0,
+    success: false,
+    statusCode: 0,
+    response: "",
+    error: "",
+    durationMs: Long.ZERO,
+    time: Long.ZERO,
+  };
+}
+
+export const WebhookDelivery: MessageFns<WebhookDelivery> = {
+  encode(message: WebhookDelivery, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.webhookId !== "") {
+      writer.uint32(18).string(message.webhookId);
+    }
+    if (message.event !== "") {
+      writer.uint32(26).string(message.event);
+    }
+    if (message.attempt !== 0) {
+      writer.uint32(32).int32(message.attempt);
+    }
+    if (message.success !== false) {
+      writer.uint32(40).bool(message.success);
+    }
+    if (message.statusCode !== 0) {
+      writer.uint32(48).int32(message.statusCode);
+    }
+    if (message.response !== "") {
+      writer.uint32(58).string(message.response);
+    }
+    if (message.error !== "") {
+      writer.uint32(66).string(message.error);
+    }
+    if (!message.durationMs.equals(Long.ZERO)) {
+      writer.uint32(72).int64(message.durationMs.toString());
+    }
+    if (!message.time.equals(Long.ZERO)) {
+      writer.uint32(80).int64(message.time.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): WebhookDelivery {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWebhookDelivery();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.webhookId = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.event = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.attempt = reader.int32();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.success = reader.bool();
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.statusCode = reader.int32();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.response = reader.string();
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+        }
+        case 9: {
+          if (tag !== 72) {
+            break;
+          }
+
+          message.durationMs = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 10: {
+          if (tag !== 80) {
+            break;
+          }
+
+          message.time = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WebhookDelivery {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      webhookId: isSet(object.webhookId)
+        ? globalThis.String(object.webhookId)
+        : isSet(object.webhook_id)
+        ? globalThis.String(object.webhook_id)
+        : "",
+      event: isSet(object.event) ? globalThis.String(object.event) : "",
+      attempt: isSet(object.attempt) ? globalThis.Number(object.attempt) : 0,
+      success: isSet(object.success) ? globalThis.Boolean(object.success) : false,
+      statusCode: isSet(object.statusCode)
+        ? globalThis.Number(object.statusCode)
+        : isSet(object.status_code)
+        ? globalThis.Number(object.status_code)
+        : 0,
+      response: isSet(object.response) ? globalThis.String(object.response) : "",
+      error: isSet(object.error) ? globalThis.String(object.error) : "",
+      durationMs: isSet(object.durationMs)
+        ? Long.fromValue(object.durationMs)
+        : isSet(object.duration_ms)
+        ? Long.fromValue(object.duration_ms)
+        : Long.ZERO,
+      time: isSet(object.time) ? Long.fromValue(object.time) : Long.ZERO,
+    };
+  },
+
+  toJSON(message: WebhookDelivery): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.webhookId !== "") {
+      obj.webhookId = message.webhookId;
+    }
+    if (message.event !== "") {
+      obj.event = message.event;
+    }
+    if (message.attempt !== 0) {
+      obj.attempt = Math.round(message.attempt);
+    }
+    if (message.success !== false) {
+      obj.success = message.success;
+    }
+    if (message.statusCode !== 0) {
+      obj.statusCode = Math.round(message.statusCode);
+    }
+    if (message.response !== "") {
+      obj.response = message.response;
+    }
+    if (message.error !== "") {
+      obj.error = message.error;
+    }
+    if (!message.durationMs.equals(Long.ZERO)) {
+      obj.durationMs = (message.durationMs || Long.ZERO).toString();
+    }
+    if (!message.time.equals(Long.ZERO)) {
+      obj.time = (message.time || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    return WebhookDelivery.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    const message = createBaseWebhookDelivery();
+    message.id = object.id ?? "";
+    message.webhookId = object.webhookId ?? "";
+    message.event = object.event ?? "";
+    message.attempt = object.attempt ?? 0;
+    message.success = object.success ?? false;
+    message.statusCode = object.statusCode ?? 0;
+    message.response = object.response ?? "";
+    message.error = object.error ?? "";
+    message.durationMs = (object.durationMs !== undefined && object.durationMs !== null)
+      ? Long.fromValue(object.durationMs)
+      : Long.ZERO;
+    message.time = (object.time !== undefined && object.time !== null) ? Long.fromValue(object.time) : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseGetWebhookDeliveriesRequest(): GetWebhookDeliveriesRequest {
+  return { webhookId: "", limit: 0 };
+}
+
+export const GetWebhookDeliveriesRequest: MessageFns<GetWebhookDeliveriesRequest> = {
+  encode(message: GetWebhookDeliveriesRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.webhookId !== "") {
+      writer.uint32(10).string(message.webhookId);
+    }
+    if (message.limit !== 0) {
+      writer.uint32(16).int32(message.limit);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetWebhookDeliveriesRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetWebhookDeliveriesRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.webhookId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.limit = reader.int32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetWebhookDeliveriesRequest {
+    return {
+      webhookId: isSet(object.webhookId)
+        ? globalThis.String(object.webhookId)
+        : isSet(object.webhook_id)
+        ? globalThis.String(object.webhook_id)
+        : "",
+      limit: isSet(object.limit) ? globalThis.Number(object.limit) : 0,
+    };
+  },
+
+  toJSON(message: GetWebhookDeliveriesRequest): unknown {
+    const obj: any = {};
+    if (message.webhookId !== "") {
+      obj.webhookId = message.webhookId;
+    }
+    if (message.limit !== 0) {
+      obj.limit = Math.round(message.limit);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetWebhookDeliveriesRequest>): GetWebhookDeliveriesRequest {
+    return GetWebhookDeliveriesRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetWebhookDeliveriesRequest>): GetWebhookDeliveriesRequest {
+    const message = createBaseGetWebhookDeliveriesRequest();
+    message.webhookId = object.webhookId ?? "";
+    message.limit = object.limit ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetWebhookDeliveriesResponse(): GetWebhookDeliveriesResponse {
+  return { deliveries: [] };
+}
+
+export const GetWebhookDeliveriesResponse: MessageFns<GetWebhookDeliveriesResponse> = {
+  encode(message: GetWebhookDeliveriesResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.deliveries) {
+      WebhookDelivery.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetWebhookDeliveriesResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetWebhookDeliveriesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.deliveries.push(WebhookDelivery.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetWebhookDeliveriesResponse {
+    return {
+      deliveries: globalThis.Array.isArray(object?.deliveries)
+        ? object.deliveries.map((e: any) => WebhookDelivery.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetWebhookDeliveriesResponse): unknown {
+    const obj: any = {};
+    if (message.deliveries?.length) {
+      obj.deliveries = message.deliveries.map((e) => WebhookDelivery.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetWebhookDeliveriesResponse>): GetWebhookDeliveriesResponse {
+    return GetWebhookDeliveriesResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetWebhookDeliveriesResponse>): GetWebhookDeliveriesResponse {
+    const message = createBaseGetWebhookDeliveriesResponse();
+    message.deliveries = object.deliveries?.map((e) => WebhookDelivery.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseTestWebhookRequest(): TestWebhookRequest {
+  return { webhookId: "" };
+}
+
+export const TestWebhookRequest: MessageFns<TestWebhookRequest> = {
+  encode(message: TestWebhookRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.webhookId !== "") {
+      writer.uint32(10).string(message.webhookId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): TestWebhookRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTestWebhookRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.webhookId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TestWebhookRequest {
+    return {
+      webhookId: isSet(object.webhookId)
+        ? globalThis.String(object.webhookId)
+        : isSet(object.webhook_id)
+        ? globalThis.String(object.webhook_id)
+        : "",
+    };
+  },
+
+  toJSON(message: TestWebhookRequest): unknown {
+    const obj: any = {};
+    if (message.webhookId !== "") {
+      obj.webhookId = message.webhookId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<TestWebhookRequest>): TestWebhookRequest {
+    return TestWebhookRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<TestWebhookRequest>): TestWebhookRequest {
+    const message = createBaseTestWebhookRequest();
+    message.webhookId = object.webhookId ?? "";
+    return message;
+  },
+};
+
+function createBaseTestWebhookResponse(): TestWebhookResponse {
+  return { delivery: undefined };
+}
+
+export const TestWebhookResponse: MessageFns<TestWebhookResponse> = {
+  encode(message: TestWebhookResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.delivery !== undefined) {
+      WebhookDelivery.encode(message.delivery, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): TestWebhookResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTestWebhookResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.delivery = WebhookDelivery.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TestWebhookResponse {
+    return { delivery: isSet(object.delivery) ? WebhookDelivery.fromJSON(object.delivery) : undefined };
+  },
+
+  toJSON(message: TestWebhookResponse): unknown {
+    const obj: any = {};
+    if (message.delivery !== undefined) {
+      obj.delivery = WebhookDelivery.toJSON(message.delivery);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
+    return TestWebhookResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
+    const message = createBaseTestWebhookResponse();
+    message.delivery = (object.delivery !== undefined && object.delivery !== null)
+      ? WebhookDelivery.fromPartial(object.delivery)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseAuditEntry(): AuditEntry {
+  return {
+    seq: Long.ZERO,
+    time: Long.ZERO,
+    operation: "",
+    path: "",
+    oldPath: "",
+    contentHash: "",
+    actor: "",
+    tokenId: "",
+    sourceIp: "",
+    method: "",
+    prevHash: "",
+    hash: "",
+  };
+}
+
+export const AuditEntry: MessageFns<AuditEntry> = {
+  encode(message: AuditEntry, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (!message.seq.equals(Long.ZERO)) {
+      writer.uint32(8).int64(message.seq.toString());
+    }
+    if (!message.time.equals(Long.ZERO)) {
+      writer.uint32(16).int64(message.time.toString());
+    }
+    if (message.operation !== "") {
+      writer.uint32(26).string(message.operation);
+    }
+    if (message.path !== "") {
+      writer.uint32(34).string(message.path);
+    }
+    if (message.oldPath !== "") {
+      writer.uint32(42).string(message.oldPath);
+    }
+    if (message.contentHash !== "") {
+      writer.uint32(50).string(message.contentHash);
+    }
+    if (message.actor !== "") {
+      writer.uint32(58).string(message.actor);
+    }
+    if (message.tokenId !== "") {
+      writer.uint32(66).string(message.tokenId);
+    }
+    if (message.sourceIp !== "") {
+      writer.uint32(74).string(message.sourceIp);
+    }
+    if (message.method !== "") {
+      writer.uint32(82).string(message.method);
+    }
+    if (message.prevHash !== "") {
+      writer.uint32(90).string(message.prevHash);
+    }
+    if (message.hash !== "") {
+      writer.uint32(98).string(message.hash);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): AuditEntry {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAuditEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.seq = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.time = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.operation = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.oldPath = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.contentHash = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.actor = reader.string();
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.tokenId = reader.string();
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.sourceIp = reader.string();
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.method = reader.string();
+          continue;
+        }
+        case 11: {
+          if (tag !== 90) {
+            break;
+          }
+
+          message.prevHash = reader.string();
+          continue;
+        }
+        case 12: {
+          if (tag !== 98) {
+            break;
+          }
+
+          message.hash = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AuditEntry {
+    return {
+      seq: isSet(object.seq) ? Long.fromValue(object.seq) : Long.ZERO,
+      time: isSet(object.time) ? Long.fromValue(object.time) : Long.ZERO,
+      operation: isSet(object.operation) ? globalThis.String(object.operation) : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      oldPath: isSet(object.oldPath)
+        ? globalThis.String(object.oldPath)
+        : isSet(object.old_path)
+        ? globalThis.String(object.old_path)
+        : "",
+      contentHash: isSet(object.contentHash)
+        ? globalThis.String(object.contentHash)
+        : isSet(object.content_hash)
+        ? globalThis.String(object.content_hash)
+        : "",
+      actor: isSet(object.actor) ? globalThis.String(object.actor) : "",
+      tokenId: isSet(object.tokenId)
+        ? globalThis.String(object.tokenId)
+        : isSet(object.token_id)
+        ? globalThis.String(object.token_id)
+        : "",
+      sourceIp: isSet(object.sourceIp)
+        ? globalThis.String(object.sourceIp)
+        : isSet(object.source_ip)
+        ? globalThis.String(object.source_ip)
+        : "",
+      method: isSet(object.method) ? globalThis.String(object.method) : "",
+      prevHash: isSet(object.prevHash)
+        ? globalThis.String(object.prevHash)
+        : isSet(object.prev_hash)
+        ? globalThis.String(object.prev_hash)
+        : "",
+      hash: isSet(object.hash) ? globalThis.String(object.hash) : "",
+    };
+  },
+
+  toJSON(message: AuditEntry): unknown {
+    const obj: any = {};
+    if (!message.seq.equals(Long.ZERO)) {
+      obj.seq = (message.seq || Long.ZERO).toString();
+    }
+    if (!message.time.equals(Long.ZERO)) {
+      obj.time = (message.time || Long.ZERO).toString();
+    }
+    if (message.operation !== "") {
+      obj.operation = message.operation;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.oldPath !== "") {
+      obj.oldPath = message.oldPath;
+    }
+    if (message.contentHash !== "") {
+      obj.contentHash = message.contentHash;
+    }
+    if (message.actor !== "") {
+      obj.actor = message.actor;
+    }
+    if (message.tokenId !== "") {
+      obj.tokenId = message.tokenId;
+    }
+    if (message.sourceIp !== "") {
+      obj.sourceIp = message.sourceIp;
+    }
+    if (message.method !== "") {
+      obj.method = message.method;
+    }
+    if (message.prevHash !== "") {
+      obj.prevHash = message.prevHash;
+    }
+    if (message.hash !== "") {
+      obj.hash = message.hash;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<AuditEntry>): AuditEntry {
+    return AuditEntry.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<AuditEntry>): AuditEntry {
+    const message = createBaseAuditEntry();
+    message.seq = (object.seq !== undefined && object.seq !== null) ? Long.fromValue(object.seq) : Long.ZERO;
+    message.time = (object.time !== undefined && object.time !== null) ? Long.fromValue(object.time) : Long.ZERO;
+    message.operation = object.operation ?? "";
+    message.path = object.path ?? "";
+    message.oldPath = object.oldPath ?? "";
+    message.contentHash = object.contentHash ?? "";
+    message.actor = object.actor ?? "";
+    message.tokenId = object.tokenId ?? "";
+    message.sourceIp = object.sourceIp ?? "";
+    message.method = object.method ?? "";
+    message.prevHash = object.prevHash ?? "";
+    message.hash = object.hash ?? "";
+    return message;
+  },
+};
+
+function createBaseGetAuditLogRequest(): GetAuditLogRequest {
+  return { bucketId: "", pageToken: "", limit: 0 };
+}
+
+export const GetAuditLogRequest: MessageFns<GetAuditLogRequest> = {
+  encode(message: GetAuditLogRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.pageToken !== "") {
+      writer.uint32(18).string(message.pageToken);
+    }
+    if (message.limit !== 0) {
+      writer.uint32(24).int32(message.limit);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetAuditLogRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetAuditLogRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.pageToken = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.limit = reader.int32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetAuditLogRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      pageToken: isSet(object.pageToken)
+        ? globalThis.String(object.pageToken)
+        : isSet(object.page_token)
+        ? globalThis.String(object.page_token)
+        : "",
+      limit: isSet(object.limit) ? globalThis.Number(object.limit) : 0,
+    };
+  },
+
+  toJSON(message: GetAuditLogRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.pageToken !== "") {
+      obj.pageToken = message.pageToken;
+    }
+    if (message.limit !== 0) {
+      obj.limit = Math.round(message.limit);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetAuditLogRequest>): GetAuditLogRequest {
+    return GetAuditLogRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetAuditLogRequest>): GetAuditLogRequest {
+    const message = createBaseGetAuditLogRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.pageToken = object.pageToken ?? "";
+    message.limit = object.limit ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetAuditLogResponse(): GetAuditLogResponse {
+  return { entries: [], nextPageToken: "" };
+}
+
+export const GetAuditLogResponse: MessageFns<GetAuditLogResponse> = {
+  encode(message: GetAuditLogResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.entries) {
+      AuditEntry.encode(v!, writer.uint32(10).fork()).join();
+    }
+    if (message.nextPageToken !== "") {
+      writer.uint32(18).string(message.nextPageToken);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetAuditLogResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetAuditLogResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.entries.push(AuditEntry.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.nextPageToken = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetAuditLogResponse {
+    return {
+      entries: globalThis.Array.isArray(object?.entries) ? object.entries.map((e: any) => AuditEntry.fromJSON(e)) : [],
+      nextPageToken: isSet(object.nextPageToken)
+        ? globalThis.String(object.nextPageToken)
+        : isSet(object.next_page_token)
+        ? globalThis.String(object.next_page_token)
+        : "",
+    };
+  },
+
+  toJSON(message: GetAuditLogResponse): unknown {
+    const obj: any = {};
+    if (message.entries?.length) {
+      obj.entries = message.entries.map((e) => AuditEntry.toJSON(e));
+    }
+    if (message.nextPageToken !== "") {
+      obj.nextPageToken = message.nextPageToken;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetAuditLogResponse>): GetAuditLogResponse {
+    return GetAuditLogResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetAuditLogResponse>): GetAuditLogResponse {
+    const message = createBaseGetAuditLogResponse();
+    message.entries = object.entries?.map((e) => AuditEntry.fromPartial(e)) || [];
+    message.nextPageToken = object.nextPageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseVerifyAuditLogRequest(): VerifyAuditLogRequest {
+  return { bucketId: "" };
+}
+
+export const VerifyAuditLogRequest: MessageFns<VerifyAuditLogRequest> = {
+  encode(message: VerifyAuditLogRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): VerifyAuditLogRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseVerifyAuditLogRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): VerifyAuditLogRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+    };
+  },
+
+  toJSON(message: VerifyAuditLogRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<VerifyAuditLogRequest>): VerifyAuditLogRequest {
+    return VerifyAuditLogRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<VerifyAuditLogRequest>): VerifyAuditLogRequest {
+    const message = createBaseVerifyAuditLogRequest();
+    message.bucketId = object.bucketId ?? "";
+    return message;
+  },
+};
+
+function createBaseVerifyAuditLogResponse(): VerifyAuditLogResponse {
+  return { valid: false, entryCount: Long.ZERO, headHash: "", invalidSeq: Long.ZERO, error: "" };
+}
+
+export const VerifyAuditLogResponse: MessageFns<VerifyAuditLogResponse> = {
+  encode(message: VerifyAuditLogResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.valid !== false) {
+      writer.uint32(8).bool(message.valid);
+    }
+    if (!message.entryCount.equals(Long.ZERO)) {
+      writer.uint32(16).int64(message.entryCount.toString());
+    }
+    if (message.headHash !== "") {
+      writer.uint32(26).string(message.headHash);
+    }
+    if (!message.invalidSeq.equals(Long.ZERO)) {
+      writer.uint32(32).int64(message.invalidSeq.toString());
+    }
+    if (message.error !== "") {
+      writer.uint32(42).string(message.error);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): VerifyAuditLogResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseVerifyAuditLogResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.valid = reader.bool();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.entryCount = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.headHash = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.invalidSeq = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): VerifyAuditLogResponse {
+    return {
+      valid: isSet(object.valid) ? globalThis.Boolean(object.valid) : false,
+      entryCount: isSet(object.entryCount)
+        ? Long.fromValue(object.entryCount)
+        : isSet(object.entry_count)
+        ? Long.fromValue(object.entry_count)
+        : Long.ZERO,
+      headHash: isSet(object.headHash)
+        ? globalThis.String(object.headHash)
+        : isSet(object.head_hash)
+        ? globalThis.String(object.head_hash)
+        : "",
+      invalidSeq: isSet(object.invalidSeq)
+        ? Long.fromValue(object.invalidSeq)
+        : isSet(object.invalid_seq)
+        ? Long.fromValue(object.invalid_seq)
+        : Long.ZERO,
+      error: isSet(object.error) ? globalThis.String(object.error) : "",
+    };
+  },
+
+  toJSON(message: VerifyAuditLogResponse): unknown {
+    const obj: any = {};
+    if (message.valid !== false) {
+      obj.valid = message.valid;
+    }
+    if (!message.entryCount.equals(Long.ZERO)) {
+      obj.entryCount = (message.entryCount || Long.ZERO).toString();
+    }
+    if (message.headHash !== "") {
+      obj.headHash = message.headHash;
+    }
+    if (!message.invalidSeq.equals(Long.ZERO)) {
+      obj.invalidSeq = (message.invalidSeq || Long.ZERO).toString();
+    }
+    if (message.error !== "") {
+      obj.error = message.error;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<VerifyAuditLogResponse>): VerifyAuditLogResponse {
+    return VerifyAuditLogResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<VerifyAuditLogResponse>): VerifyAuditLogResponse {
+    const message = createBaseVerifyAuditLogResponse();
+    message.valid = object.valid ?? false;
+    message.entryCount = (object.entryCount !== undefined && object.entryCount !== null)
+      ? Long.fromValue(object.entryCount)
+      : Long.ZERO;
+    message.headHash = object.headHash ?? "";
+    message.invalidSeq = (object.invalidSeq !== undefined && object.invalidSeq !== null)
+      ? Long.fromValue(object.invalidSeq)
+      : Long.ZERO;
+    message.error = object.error ?? "";
+    return message;
+  },
+};
+
+function createBaseRevokeBucketTokenRequest(): RevokeBucketTokenRequest {
+  return { tokenId: "", expiresAt: Long.ZERO };
+}
+
+export const RevokeBucketTokenRequest: MessageFns<RevokeBucketTokenRequest> = {
+  encode(message: RevokeBucketTokenRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.tokenId !== "") {
+      writer.uint32(10).string(message.tokenId);
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      writer.uint32(16).int64(message.expiresAt.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RevokeBucketTokenRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRevokeBucketTokenRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.tokenId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.expiresAt = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RevokeBucketTokenRequest {
+    return {
+      tokenId: isSet(object.tokenId)
+        ? globalThis.String(object.tokenId)
+        : isSet(object.token_id)
+        ? globalThis.String(object.token_id)
+        : "",
+      expiresAt: isSet(object.expiresAt)
+        ? Long.fromValue(object.expiresAt)
+        : isSet(object.expires_at)
+        ? Long.fromValue(object.expires_at)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: RevokeBucketTokenRequest): unknown {
+    const obj: any = {};
+    if (message.tokenId !== "") {
+      obj.tokenId = message.tokenId;
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      obj.expiresAt = (message.expiresAt || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RevokeBucketTokenRequest>): RevokeBucketTokenRequest {
+    return RevokeBucketTokenRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<RevokeBucketTokenRequest>): RevokeBucketTokenRequest {
+    const message = createBaseRevokeBucketTokenRequest();
+    message.tokenId = object.tokenId ?? "";
+    message.expiresAt = (object.expiresAt !== undefined && object.expiresAt !== null)
+      ? Long.fromValue(object.expiresAt)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseRevokeBucketTokenResponse(): RevokeBucketTokenResponse {
+  return {};
+}
+
+export const RevokeBucketTokenResponse: MessageFns<RevokeBucketTokenResponse> = {
+  encode(_: RevokeBucketTokenResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RevokeBucketTokenResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRevokeBucketTokenResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): RevokeBucketTokenResponse {
+    return {};
+  },
+
+  toJSON(_: RevokeBucketTokenResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create(base?: DeepPartial<RevokeBucketTokenResponse>): RevokeBucketTokenResponse {
+    return RevokeBucketTokenResponse.fromPartial(base ?? {});
+  },
+  fromPartial(_: DeepPartial<RevokeBucketTokenResponse>): RevokeBucketTokenResponse {
+    const message = createBaseRevokeBucketTokenResponse();
+    return message;
+  },
+};
+
+function createBaseRevokeAllBucketTokensRequest(): RevokeAllBucketTokensRequest {
+  return { bucketId: "", issuedBefore: Long.ZERO };
+}
+
+export const RevokeAllBucketTokensRequest: MessageFns<RevokeAllBucketTokensRequest> = {
+  encode(message: RevokeAllBucketTokensRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (!message.issuedBefore.equals(Long.ZERO)) {
+      writer.uint32(16).int64(message.issuedBefore.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RevokeAllBucketTokensRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRevokeAllBucketTokensRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.issuedBefore = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RevokeAllBucketTokensRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      issuedBefore: isSet(object.issuedBefore)
+        ? Long.fromValue(object.issuedBefore)
+        : isSet(object.issued_before)
+        ? Long.fromValue(object.issued_before)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: RevokeAllBucketTokensRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (!message.issuedBefore.equals(Long.ZERO)) {
+      obj.issuedBefore = (message.issuedBefore || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RevokeAllBucketTokensRequest>): RevokeAllBucketTokensRequest {
+    return RevokeAllBucketTokensRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<RevokeAllBucketTokensRequest>): RevokeAllBucketTokensRequest {
+    const message = createBaseRevokeAllBucketTokensRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.issuedBefore = (object.issuedBefore !== undefined && object.issuedBefore !== null)
+      ? Long.fromValue(object.issuedBefore)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseRevokeAllBucketTokensResponse(): RevokeAllBucketTokensResponse {
+  return {};
+}
+
+export const RevokeAllBucketTokensResponse: MessageFns<RevokeAllBucketTokensResponse> = {
+  encode(_: RevokeAllBucketTokensResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RevokeAllBucketTokensResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRevokeAllBucketTokensResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): RevokeAllBucketTokensResponse {
+    return {};
+  },
+
+  toJSON(_: RevokeAllBucketTokensResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create(base?: DeepPartial<RevokeAllBucketTokensResponse>): RevokeAllBucketTokensResponse {
+    return RevokeAllBucketTokensResponse.fromPartial(base ?? {});
+  },
+  fromPartial(_: DeepPartial<RevokeAllBucketTokensResponse>): RevokeAllBucketTokensResponse {
+    const message = createBaseRevokeAllBucketTokensResponse();
+    return message;
+  },
+};
+
+function createBaseTokenScope(): TokenScope {
+  return { paths: [], operations: [] };
+}
+
+export const TokenScope: MessageFns<TokenScope> = {
+  encode(message: TokenScope, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.paths) {
+      writer.uint32(10).string(v!);
+    }
+    for (const v of message.operations) {
+      writer.uint32(18).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): TokenScope {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTokenScope();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.paths.push(reader.string());
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.operations.push(reader.string());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TokenScope {
+    return {
+      paths: globalThis.Array.isArray(object?.paths) ? object.paths.map((e: any) => globalThis.String(e)) : [],
+      operations: globalThis.Array.isArray(object?.operations)
+        ? object.operations.map((e: any) => globalThis.String(e))
+        : [],
+    };
+  },
+
+  toJSON(message: TokenScope): unknown {
+    const obj: any = {};
+    if (message.paths?.length) {
+      obj.paths = message.paths;
+    }
+    if (message.operations?.length) {
+      obj.operations = message.operations;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<TokenScope>): TokenScope {
+    return TokenScope.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<TokenScope>): TokenScope {
+    const message = createBaseTokenScope();
+    message.paths = object.paths?.map((e) => e) || [];
+    message.operations = object.operations?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseGetPresignedFileUrlRequest(): GetPresignedFileUrlRequest {
+  return { bucketId: "", path: "", method: "", expiresInSeconds: Long.ZERO, contentType: "", maxSize: Long.ZERO };
+}
+
+export const GetPresignedFileUrlRequest: MessageFns<GetPresignedFileUrlRequest> = {
+  encode(message: GetPresignedFileUrlRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.path !== "") {
+      writer.uint32(18).string(message.path);
+    }
+    if (message.method !== "") {
+      writer.uint32(26).string(message.method);
+    }
+    if (!message.expiresInSeconds.equals(Long.ZERO)) {
+      writer.uint32(32).int64(message.expiresInSeconds.toString());
+    }
+    if (message.contentType !== "") {
+      writer.uint32(42).string(message.contentType);
+    }
+    if (!message.maxSize.equals(Long.ZERO)) {
+      writer.uint32(48).int64(message.maxSize.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetPresignedFileUrlRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetPresignedFileUrlRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.method = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.expiresInSeconds = Long.fromString(reader.int64().toString());
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.contentType = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.maxSize = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetPresignedFileUrlRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      method: isSet(object.method) ? globalThis.String(object.method) : "",
+      expiresInSeconds: isSet(object.expiresInSeconds)
+        ? Long.fromValue(object.expiresInSeconds)
+        : isSet(object.expires_in_seconds)
+        ? Long.fromValue(object.expires_in_seconds)
+        : Long.ZERO,
+      contentType: isSet(object.contentType)
+        ? globalThis.String(object.contentType)
+        : isSet(object.content_type)
+        ? globalThis.String(object.content_type)
+        : "",
+      maxSize: isSet(object.maxSize)
+        ? Long.fromValue(object.maxSize)
+        : isSet(object.max_size)
+        ? Long.fromValue(object.max_size)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: GetPresignedFileUrlRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.method !== "") {
+      obj.method = message.method;
+    }
+    if (!message.expiresInSeconds.equals(Long.ZERO)) {
+      obj.expiresInSeconds = (message.expiresInSeconds || Long.ZERO).toString();
+    }
+    if (message.contentType !== "") {
+      obj.contentType = message.contentType;
+    }
+    if (!message.maxSize.equals(Long.ZERO)) {
+      obj.maxSize = (message.maxSize || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetPresignedFileUrlRequest>): GetPresignedFileUrlRequest {
+    return GetPresignedFileUrlRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetPresignedFileUrlRequest>): GetPresignedFileUrlRequest {
+    const message = createBaseGetPresignedFileUrlRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.path = object.path ?? "";
+    message.method = object.method ?? "";
+    message.expiresInSeconds = (object.expiresInSeconds !== undefined && object.expiresInSeconds !== null)
+      ? Long.fromValue(object.expiresInSeconds)
+      : Long.ZERO;
+    message.contentType = object.contentType ?? "";
+    message.maxSize = (object.maxSize !== undefined && object.maxSize !== null)
+      ? Long.fromValue(object.maxSize)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseGetPresignedFileUrlResponse(): GetPresignedFileUrlResponse {
+  return { url: "", expiresAt: Long.ZERO };
+}
+
+export const GetPresignedFileUrlResponse: MessageFns<GetPresignedFileUrlResponse> = {
+  encode(message: GetPresignedFileUrlResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.url !== "") {
+      writer.uint32(10).string(message.url);
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      writer.uint32(16).int64(message.expiresAt.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetPresignedFileUrlResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetPresignedFileUrlResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.url = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.expiresAt = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetPresignedFileUrlResponse {
+    return {
+      url: isSet(object.url) ? globalThis.String(object.url) : "",
+      expiresAt: isSet(object.expiresAt)
+        ? Long.fromValue(object.expiresAt)
+        : isSet(object.expires_at)
+        ? Long.fromValue(object.expires_at)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: GetPresignedFileUrlResponse): unknown {
+    const obj: any = {};
+    if (message.url !== "") {
+      obj.url = message.url;
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      obj.expiresAt = (message.expiresAt || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetPresignedFileUrlResponse>): GetPresignedFileUrlResponse {
+    return GetPresignedFileUrlResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetPresignedFileUrlResponse>): GetPresignedFileUrlResponse {
+    const message = createBaseGetPresignedFileUrlResponse();
+    message.url = object.url ?? "";
+    message.expiresAt = (object.expiresAt !== undefined && object.expiresAt !== null)
+      ? Long.fromValue(object.expiresAt)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseGetBucketFilesAsArchiveRequest(): GetBucketFilesAsArchiveRequest {
+  return { bucketId: "", prefix: "", include: [], exclude: [], format: "" };
+}
+
+export const GetBucketFilesAsArchiveRequest: MessageFns<GetBucketFilesAsArchiveRequest> = {
+  encode(message: GetBucketFilesAsArchiveRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.prefix !== "") {
+      writer.uint32(18).string(message.prefix);
+    }
+    for (const v of message.include) {
+      writer.uint32(26).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(34).string(v!);
+    }
+    if (message.format !== "") {
+      writer.uint32(42).string(message.format);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketFilesAsArchiveRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketFilesAsArchiveRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.prefix = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.format = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketFilesAsArchiveRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      prefix: isSet(object.prefix) ? globalThis.String(object.prefix) : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
+      format: isSet(object.format) ? globalThis.String(object.format) : "",
+    };
+  },
+
+  toJSON(message: GetBucketFilesAsArchiveRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.prefix !== "") {
+      obj.prefix = message.prefix;
+    }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
+    if (message.format !== "") {
+      obj.format = message.format;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketFilesAsArchiveRequest>): GetBucketFilesAsArchiveRequest {
+    return GetBucketFilesAsArchiveRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketFilesAsArchiveRequest>): GetBucketFilesAsArchiveRequest {
+    const message = createBaseGetBucketFilesAsArchiveRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.prefix = object.prefix ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
+    message.format = object.format ?? "";
+    return message;
+  },
+};
+
+function createBaseGetBucketFilesAsArchiveResponse(): GetBucketFilesAsArchiveResponse {
+  return { downloadUrl: "", expiresAt: Long.ZERO };
+}
+
+export const GetBucketFilesAsArchiveResponse: MessageFns<GetBucketFilesAsArchiveResponse> = {
+  encode(message: GetBucketFilesAsArchiveResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.downloadUrl !== "") {
+      writer.uint32(10).string(message.downloadUrl);
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      writer.uint32(16).int64(message.expiresAt.toString());
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetBucketFilesAsArchiveResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetBucketFilesAsArchiveResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.downloadUrl = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.expiresAt = Long.fromString(reader.int64().toString());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetBucketFilesAsArchiveResponse {
+    return {
+      downloadUrl: isSet(object.downloadUrl)
+        ? globalThis.String(object.downloadUrl)
+        : isSet(object.download_url)
+        ? globalThis.String(object.download_url)
+        : "",
+      expiresAt: isSet(object.expiresAt)
+        ? Long.fromValue(object.expiresAt)
+        : isSet(object.expires_at)
+        ? Long.fromValue(object.expires_at)
+        : Long.ZERO,
+    };
+  },
+
+  toJSON(message: GetBucketFilesAsArchiveResponse): unknown {
+    const obj: any = {};
+    if (message.downloadUrl !== "") {
+      obj.downloadUrl = message.downloadUrl;
+    }
+    if (!message.expiresAt.equals(Long.ZERO)) {
+      obj.expiresAt = (message.expiresAt || Long.ZERO).toString();
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetBucketFilesAsArchiveResponse>): GetBucketFilesAsArchiveResponse {
+    return GetBucketFilesAsArchiveResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<GetBucketFilesAsArchiveResponse>): GetBucketFilesAsArchiveResponse {
+    const message = createBaseGetBucketFilesAsArchiveResponse();
+    message.downloadUrl = object.downloadUrl ?? "";
+    message.expiresAt = (object.expiresAt !== undefined && object.expiresAt !== null)
+      ? Long.fromValue(object.expiresAt)
+      : Long.ZERO;
+    return message;
+  },
+};
+
+function createBaseCreateBucketFromGitRequest(): CreateBucketFromGitRequest {
+  return { newBucketId: "", url: "", ref: "", path: "", username: "", password: "" };
+}
+
+export const CreateBucketFromGitRequest: MessageFns<CreateBucketFromGitRequest> = {
+  encode(message: CreateBucketFromGitRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.newBucketId !== "") {
+      writer.uint32(10).string(message.newBucketId);
+    }
+    if (message.url !== "") {
+      writer.uint32(18).string(message.url);
+    }
+    if (message.ref !== "") {
+      writer.uint32(26).string(message.ref);
+    }
+    if (message.path !== "") {
+      writer.uint32(34).string(message.path);
+    }
+    if (message.username !== "") {
+      writer.uint32(42).string(message.username);
+    }
+    if (message.password !== "") {
+      writer.uint32(50).string(message.password);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateBucketFromGitRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateBucketFromGitRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.newBucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.url = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.ref = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.username = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.password = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateBucketFromGitRequest {
+    return {
+      newBucketId: isSet(object.newBucketId)
+        ? globalThis.String(object.newBucketId)
+        : isSet(object.new_bucket_id)
+        ? globalThis.String(object.new_bucket_id)
+        : "",
+      url: isSet(object.url) ? globalThis.String(object.url) : "",
+      ref: isSet(object.ref) ? globalThis.String(object.ref) : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      username: isSet(object.username) ? globalThis.String(object.username) : "",
+      password: isSet(object.password) ? globalThis.String(object.password) : "",
+    };
+  },
+
+  toJSON(message: CreateBucketFromGitRequest): unknown {
+    const obj: any = {};
+    if (message.newBucketId !== "") {
+      obj.newBucketId = message.newBucketId;
+    }
+    if (message.url !== "") {
+      obj.url = message.url;
+    }
+    if (message.ref !== "") {
+      obj.ref = message.ref;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.username !== "") {
+      obj.username = message.username;
+    }
+    if (message.password !== "") {
+      obj.password = message.password;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateBucketFromGitRequest>): CreateBucketFromGitRequest {
+    return CreateBucketFromGitRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CreateBucketFromGitRequest>): CreateBucketFromGitRequest {
+    const message = createBaseCreateBucketFromGitRequest();
+    message.newBucketId = object.newBucketId ?? "";
+    message.url = object.url ?? "";
+    message.ref = object.ref ?? "";
+    message.path = object.path ?? "";
+    message.username = object.username ?? "";
+    message.password = object.password ?? "";
+    return message;
+  },
+};
+
+function createBaseExportBucketToGitRequest(): ExportBucketToGitRequest {
+  return {
+    bucketId: "",
+    url: "",
+    branch: "",
+    path: "",
+    username: "",
+    password: "",
+    message: "",
+    authorName: "",
+    authorEmail: "",
+    include: [],
+    exclude: [],
+  };
+}
+
+export const ExportBucketToGitRequest: MessageFns<ExportBucketToGitRequest> = {
+  encode(message: ExportBucketToGitRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.url !== "") {
+      writer.uint32(18).string(message.url);
+    }
+    if (message.branch !== "") {
+      writer.uint32(26).string(message.branch);
+    }
+    if (message.path !== "") {
+      writer.uint32(34).string(message.path);
+    }
+    if (message.username !== "") {
+      writer.uint32(42).string(message.username);
+    }
+    if (message.password !== "") {
+      writer.uint32(50).string(message.password);
+    }
+    if (message.message !== "") {
+      writer.uint32(58).string(message.message);
+    }
+    if (message.authorName !== "") {
+      writer.uint32(66).string(message.authorName);
+    }
+    if (message.authorEmail !== "") {
+      writer.uint32(74).string(message.authorEmail);
+    }
+    for (const v of message.include) {
+      writer.uint32(82).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(90).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ExportBucketToGitRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportBucketToGitRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.url = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.branch = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.username = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.password = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.message = reader.string();
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.authorName = reader.string();
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.authorEmail = reader.string();
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 11: {
+          if (tag !== 90) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportBucketToGitRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      url: isSet(object.url) ? globalThis.String(object.url) : "",
+      branch: isSet(object.branch) ? globalThis.String(object.branch) : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      username: isSet(object.username) ? globalThis.String(object.username) : "",
+      password: isSet(object.password) ? globalThis.String(object.password) : "",
+      message: isSet(object.message) ? globalThis.String(object.message) : "",
+      authorName: isSet(object.authorName)
+        ? globalThis.String(object.authorName)
+        : isSet(object.author_name)
+        ? globalThis.String(object.author_name)
+        : "",
+      authorEmail: isSet(object.authorEmail)
+        ? globalThis.String(object.authorEmail)
+        : isSet(object.author_email)
+        ? globalThis.String(object.author_email)
+        : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
+    };
+  },
+
+  toJSON(message: ExportBucketToGitRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.url !== "") {
+      obj.url = message.url;
+    }
+    if (message.branch !== "") {
+      obj.branch = message.branch;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.username !== "") {
+      obj.username = message.username;
+    }
+    if (message.password !== "") {
+      obj.password = message.password;
+    }
+    if (message.message !== "") {
+      obj.message = message.message;
+    }
+    if (message.authorName !== "") {
+      obj.authorName = message.authorName;
+    }
+    if (message.authorEmail !== "") {
+      obj.authorEmail = message.authorEmail;
+    }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ExportBucketToGitRequest>): ExportBucketToGitRequest {
+    return ExportBucketToGitRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ExportBucketToGitRequest>): ExportBucketToGitRequest {
+    const message = createBaseExportBucketToGitRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.url = object.url ?? "";
+    message.branch = object.branch ?? "";
+    message.path = object.path ?? "";
+    message.username = object.username ?? "";
+    message.password = object.password ?? "";
+    message.message = object.message ?? "";
+    message.authorName = object.authorName ?? "";
+    message.authorEmail = object.authorEmail ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseExportBucketToGitResponse(): ExportBucketToGitResponse {
+  return { commitSha: "" };
+}
+
+export const ExportBucketToGitResponse: MessageFns<ExportBucketToGitResponse> = {
+  encode(message: ExportBucketToGitResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.commitSha !== "") {
+      writer.uint32(10).string(message.commitSha);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ExportBucketToGitResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportBucketToGitResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.commitSha = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportBucketToGitResponse {
+    return {
+      commitSha: isSet(object.commitSha)
+        ? globalThis.String(object.commitSha)
+        : isSet(object.commit_sha)
+        ? globalThis.String(object.commit_sha)
+        : "",
+    };
+  },
+
+  toJSON(message: ExportBucketToGitResponse): unknown {
+    const obj: any = {};
+    if (message.commitSha !== "") {
+      obj.commitSha = message.commitSha;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ExportBucketToGitResponse>): ExportBucketToGitResponse {
+    return ExportBucketToGitResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ExportBucketToGitResponse>): ExportBucketToGitResponse {
+    const message = createBaseExportBucketToGitResponse();
+    message.commitSha = object.commitSha ?? "";
+    return message;
+  },
+};
+
+function createBaseCreateBucketFromScmRequest(): CreateBucketFromScmRequest {
+  return { newBucketId: "", provider: "", repo: "", ref: "", path: "", token: "", apiUrl: "" };
+}
+
+export const CreateBucketFromScmRequest: MessageFns<CreateBucketFromScmRequest> = {
+  encode(message: CreateBucketFromScmRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.newBucketId !== "") {
+      writer.uint32(10).string(message.newBucketId);
+    }
+    if (message.provider !== "") {
+      writer.uint32(18).string(message.provider);
+    }
+    if (message.repo !== "") {
+      writer.uint32(26).string(message.repo);
+    }
+    if (message.ref !== "") {
+      writer.uint32(34).string(message.ref);
+    }
+    if (message.path !== "") {
+      writer.uint32(42).string(message.path);
+    }
+    if (message.token !== "") {
+      writer.uint32(50).string(message.token);
+    }
+    if (message.apiUrl !== "") {
+      writer.uint32(58).string(message.apiUrl);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateBucketFromScmRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateBucketFromScmRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.newBucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.provider = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.repo = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.ref = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.token = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.apiUrl = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateBucketFromScmRequest {
+    return {
+      newBucketId: isSet(object.newBucketId)
+        ? globalThis.String(object.newBucketId)
+        : isSet(object.new_bucket_id)
+        ? globalThis.String(object.new_bucket_id)
+        : "",
+      provider: isSet(object.provider) ? globalThis.String(object.provider) : "",
+      repo: isSet(object.repo) ? globalThis.String(object.repo) : "",
+      ref: isSet(object.ref) ? globalThis.String(object.ref) : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      token: isSet(object.token) ? globalThis.String(object.token) : "",
+      apiUrl: isSet(object.apiUrl)
+        ? globalThis.String(object.apiUrl)
+        : isSet(object.api_url)
+        ? globalThis.String(object.api_url)
+        : "",
+    };
+  },
+
+  toJSON(message: CreateBucketFromScmRequest): unknown {
+    const obj: any = {};
+    if (message.newBucketId !== "") {
+      obj.newBucketId = message.newBucketId;
+    }
+    if (message.provider !== "") {
+      obj.provider = message.provider;
+    }
+    if (message.repo !== "") {
+      obj.repo = message.repo;
+    }
+    if (message.ref !== "") {
+      obj.ref = message.ref;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.token !== "") {
+      obj.token = message.token;
+    }
+    if (message.apiUrl !== "") {
+      obj.apiUrl = message.apiUrl;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateBucketFromScmRequest>): CreateBucketFromScmRequest {
+    return CreateBucketFromScmRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CreateBucketFromScmRequest>): CreateBucketFromScmRequest {
+    const message = createBaseCreateBucketFromScmRequest();
+    message.newBucketId = object.newBucketId ?? "";
+    message.provider = object.provider ?? "";
+    message.repo = object.repo ?? "";
+    message.ref = object.ref ?? "";
+    message.path = object.path ?? "";
+    message.token = object.token ?? "";
+    message.apiUrl = object.apiUrl ?? "";
+    return message;
+  },
+};
+
+function createBaseExportBucketToScmRequest(): ExportBucketToScmRequest {
+  return {
+    bucketId: "",
+    provider: "",
+    repo: "",
+    path: "",
+    branch: "",
+    token: "",
+    apiUrl: "",
+    message: "",
+    authorName: "",
+    authorEmail: "",
+    include: [],
+    exclude: [],
+    sync: false,
+    maxDeletions: 0,
+  };
+}
+
+export const ExportBucketToScmRequest: MessageFns<ExportBucketToScmRequest> = {
+  encode(message: ExportBucketToScmRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.bucketId !== "") {
+      writer.uint32(10).string(message.bucketId);
+    }
+    if (message.provider !== "") {
+      writer.uint32(18).string(message.provider);
+    }
+    if (message.repo !== "") {
+      writer.uint32(26).string(message.repo);
+    }
+    if (message.path !== "") {
+      writer.uint32(34).string(message.path);
+    }
+    if (message.branch !== "") {
+      writer.uint32(42).string(message.branch);
+    }
+    if (message.token !== "") {
+      writer.uint32(50).string(message.token);
+    }
+    if (message.apiUrl !== "") {
+      writer.uint32(58).string(message.apiUrl);
+    }
+    if (message.message !== "") {
+      writer.uint32(66).string(message.message);
+    }
+    if (message.authorName !== "") {
+      writer.uint32(74).string(message.authorName);
+    }
+    if (message.authorEmail !== "") {
+      writer.uint32(82).string(message.authorEmail);
+    }
+    for (const v of message.include) {
+      writer.uint32(90).string(v!);
+    }
+    for (const v of message.exclude) {
+      writer.uint32(98).string(v!);
+    }
+    if (message.sync !== false) {
+      writer.uint32(104).bool(message.sync);
+    }
+    if (message.maxDeletions !== 0) {
+      writer.uint32(112).int32(message.maxDeletions);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ExportBucketToScmRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportBucketToScmRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.bucketId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.provider = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.repo = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.path = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.branch = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.token = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.apiUrl = reader.string();
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.message = reader.string();
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.authorName = reader.string();
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.authorEmail = reader.string();
+          continue;
+        }
+        case 11: {
+          if (tag !== 90) {
+            break;
+          }
+
+          message.include.push(reader.string());
+          continue;
+        }
+        case 12: {
+          if (tag !== 98) {
+            break;
+          }
+
+          message.exclude.push(reader.string());
+          continue;
+        }
+        case 13: {
+          if (tag !== 104) {
+            break;
+          }
+
+          message.sync = reader.bool();
+          continue;
+        }
+        case 14: {
+          if (tag !== 112) {
+            break;
+          }
+
+          message.maxDeletions = reader.int32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportBucketToScmRequest {
+    return {
+      bucketId: isSet(object.bucketId)
+        ? globalThis.String(object.bucketId)
+        : isSet(object.bucket_id)
+        ? globalThis.String(object.bucket_id)
+        : "",
+      provider: isSet(object.provider) ? globalThis.String(object.provider) : "",
+      repo: isSet(object.repo) ? globalThis.String(object.repo) : "",
+      path: isSet(object.path) ? globalThis.String(object.path) : "",
+      branch: isSet(object.branch) ? globalThis.String(object.branch) : "",
+      token: isSet(object.token) ? globalThis.String(object.token) : "",
+      apiUrl: isSet(object.apiUrl)
+        ? globalThis.String(object.apiUrl)
+        : isSet(object.api_url)
+        ? globalThis.String(object.api_url)
+        : "",
+      message: isSet(object.message) ? globalThis.String(object.message) : "",
+      authorName: isSet(object.authorName)
+        ? globalThis.String(object.authorName)
+        : isSet(object.author_name)
+        ? globalThis.String(object.author_name)
+        : "",
+      authorEmail: isSet(object.authorEmail)
+        ? globalThis.String(object.authorEmail)
+        : isSet(object.author_email)
+        ? globalThis.String(object.author_email)
+        : "",
+      include: globalThis.Array.isArray(object?.include) ? object.include.map((e: any) => globalThis.String(e)) : [],
+      exclude: globalThis.Array.isArray(object?.exclude) ? object.exclude.map((e: any) => globalThis.String(e)) : [],
+      sync: isSet(object.sync) ? globalThis.Boolean(object.sync) : false,
+      maxDeletions: isSet(object.maxDeletions)
+        ? globalThis.Number(object.maxDeletions)
+        : isSet(object.max_deletions)
+        ? globalThis.Number(object.max_deletions)
+        : 0,
+    };
+  },
+
+  toJSON(message: ExportBucketToScmRequest): unknown {
+    const obj: any = {};
+    if (message.bucketId !== "") {
+      obj.bucketId = message.bucketId;
+    }
+    if (message.provider !== "") {
+      obj.provider = message.provider;
+    }
+    if (message.repo !== "") {
+      obj.repo = message.repo;
+    }
+    if (message.path !== "") {
+      obj.path = message.path;
+    }
+    if (message.branch !== "") {
+      obj.branch = message.branch;
+    }
+    if (message.token !== "") {
+      obj.token = message.token;
+    }
+    if (message.apiUrl !== "") {
+      obj.apiUrl = message.apiUrl;
+    }
+    if (message.message !== "") {
+      obj.message = message.message;
+    }
+    if (message.authorName !== "") {
+      obj.authorName = message.authorName;
+    }
+    if (message.authorEmail !== "") {
+      obj.authorEmail = message.authorEmail;
+    }
+    if (message.include?.length) {
+      obj.include = message.include;
+    }
+    if (message.exclude?.length) {
+      obj.exclude = message.exclude;
+    }
+    if (message.sync !== false) {
+      obj.sync = message.sync;
+    }
+    if (message.maxDeletions !== 0) {
+      obj.maxDeletions = Math.round(message.maxDeletions);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ExportBucketToScmRequest>): ExportBucketToScmRequest {
+    return ExportBucketToScmRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ExportBucketToScmRequest>): ExportBucketToScmRequest {
+    const message = createBaseExportBucketToScmRequest();
+    message.bucketId = object.bucketId ?? "";
+    message.provider = object.provider ?? "";
+    message.repo = object.repo ?? "";
+    message.path = object.path ?? "";
+    message.branch = object.branch ?? "";
+    message.token = object.token ?? "";
+    message.apiUrl = object.apiUrl ?? "";
+    message.message = object.message ?? "";
+    message.authorName = object.authorName ?? "";
+    message.authorEmail = object.authorEmail ?? "";
+    message.include = object.include?.map((e) => e) || [];
+    message.exclude = object.exclude?.map((e) => e) || [];
+    message.sync = object.sync ?? false;
+    message.maxDeletions = object.maxDeletions ?? 0;
+    return message;
+  },
+};
+
+function createBaseExportBucketToScmResponse(): ExportBucketToScmResponse {
+  return { commitSha: "" };
+}
+
+export const ExportBucketToScmResponse: MessageFns<ExportBucketToScmResponse> = {
+  encode(message: ExportBucketToScmResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.commitSha !== "") {
+      writer.uint32(10).string(message.commitSha);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ExportBucketToScmResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportBucketToScmResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.commitSha = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportBucketToScmResponse {
+    return {
+      commitSha: isSet(object.commitSha)
+        ? globalThis.String(object.commitSha)
+        : isSet(object.commit_sha)
+        ? globalThis.String(object.commit_sha)
+        : "",
+    };
+  },
+
+  toJSON(message: ExportBucketToScmResponse): unknown {
+    const obj: any = {};
+    if (message.commitSha !== "") {
+      obj.commitSha = message.commitSha;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ExportBucketToScmResponse>): ExportBucketToScmResponse {
+    return ExportBucketToScmResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ExportBucketToScmResponse>): ExportBucketToScmResponse {
+    const message = createBaseExportBucketToScmResponse();
+    message.commitSha = object.commitSha ?? "";
+    return message;
+  },
+};
+
+export type CodeBucketService = typeof CodeBucketService;
+export const CodeBucketService = {
+  cloneBucket: {
+    path: "/rpc.rpc.CodeBucket/CloneBucket",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CloneBucketRequest): Buffer => Buffer.from(CloneBucketRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): CloneBucketRequest => CloneBucketRequest.decode(value),
+    responseSerialize: (value: CreateBucketResponse): Buffer =>
+      Buffer.from(CreateBucketResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer): CreateBucketResponse => CreateBucketResponse.decode(value),
   },
   createBucketFromContents: {
@@ -2635,6 +10920,39 @@ export const CodeBucketService = {
       Buffer.from(CreateBucketResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer): CreateBucketResponse => CreateBucketResponse.decode(value),
   },
+  createBucketFromGit: {
+    path: "/rpc.rpc.CodeBucket/CreateBucketFromGit",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CreateBucketFromGitRequest): Buffer =>
+      Buffer.from(CreateBucketFromGitRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): CreateBucketFromGitRequest => CreateBucketFromGitRequest.decode(value),
+    responseSerialize: (value: CreateBucketResponse): Buffer =>
+      Buffer.from(CreateBucketResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): CreateBucketResponse => CreateBucketResponse.decode(value),
+  },
+  createBucketFromScm: {
+    path: "/rpc.rpc.CodeBucket/CreateBucketFromScm",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CreateBucketFromScmRequest): Buffer =>
+      Buffer.from(CreateBucketFromScmRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): CreateBucketFromScmRequest => CreateBucketFromScmRequest.decode(value),
+    responseSerialize: (value: CreateBucketResponse): Buffer =>
+      Buffer.from(CreateBucketResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): CreateBucketResponse => CreateBucketResponse.decode(value),
+  },
+  createBucketOverlay: {
+    path: "/rpc.rpc.CodeBucket/CreateBucketOverlay",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CreateBucketOverlayRequest): Buffer =>
+      Buffer.from(CreateBucketOverlayRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): CreateBucketOverlayRequest => CreateBucketOverlayRequest.decode(value),
+    responseSerialize: (value: CreateBucketResponse): Buffer =>
+      Buffer.from(CreateBucketResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): CreateBucketResponse => CreateBucketResponse.decode(value),
+  },
   getBucketToken: {
     path: "/rpc.rpc.CodeBucket/GetBucketToken",
     requestStream: false,
@@ -2646,6 +10964,39 @@ export const CodeBucketService = {
       Buffer.from(GetBucketTokenResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer): GetBucketTokenResponse => GetBucketTokenResponse.decode(value),
   },
+  revokeBucketToken: {
+    path: "/rpc.rpc.CodeBucket/RevokeBucketToken",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: RevokeBucketTokenRequest): Buffer =>
+      Buffer.from(RevokeBucketTokenRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): RevokeBucketTokenRequest => RevokeBucketTokenRequest.decode(value),
+    responseSerialize: (value: RevokeBucketTokenResponse): Buffer =>
+      Buffer.from(RevokeBucketTokenResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): RevokeBucketTokenResponse => RevokeBucketTokenResponse.decode(value),
+  },
+  revokeAllBucketTokens: {
+    path: "/rpc.rpc.CodeBucket/RevokeAllBucketTokens",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: RevokeAllBucketTokensRequest): Buffer =>
+      Buffer.from(RevokeAllBucketTokensRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): RevokeAllBucketTokensRequest => RevokeAllBucketTokensRequest.decode(value),
+    responseSerialize: (value: RevokeAllBucketTokensResponse): Buffer =>
+      Buffer.from(RevokeAllBucketTokensResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): RevokeAllBucketTokensResponse => RevokeAllBucketTokensResponse.decode(value),
+  },
+  getPresignedFileUrl: {
+    path: "/rpc.rpc.CodeBucket/GetPresignedFileUrl",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetPresignedFileUrlRequest): Buffer =>
+      Buffer.from(GetPresignedFileUrlRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): GetPresignedFileUrlRequest => GetPresignedFileUrlRequest.decode(value),
+    responseSerialize: (value: GetPresignedFileUrlResponse): Buffer =>
+      Buffer.from(GetPresignedFileUrlResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): GetPresignedFileUrlResponse => GetPresignedFileUrlResponse.decode(value),
+  },
   getBucketFile: {
     path: "/rpc.rpc.CodeBucket/GetBucketFile",
     requestStream: false,
@@ -2690,6 +11041,91 @@ export const CodeBucketService = {
       Buffer.from(GetBucketFilesAsZipResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer): GetBucketFilesAsZipResponse => GetBucketFilesAsZipResponse.decode(value),
   },
+  getBucketFilesAsArchive: {
+    path: "/rpc.rpc.CodeBucket/GetBucketFilesAsArchive",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetBucketFilesAsArchiveRequest): Buffer =>
+      Buffer.from(GetBucketFilesAsArchiveRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): GetBucketFilesAsArchiveRequest => GetBucketFilesAsArchiveRequest.decode(value),
+    responseSerialize: (value: GetBucketFilesAsArchiveResponse): Buffer =>
+      Buffer.from(GetBucketFilesAsArchiveResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): GetBucketFilesAsArchiveResponse =>
+      GetBucketFilesAsArchiveResponse.decode(value),
+  },
+  getBucketDigest: {
+    path: "/rpc.rpc.CodeBucket/GetBucketDigest",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetBucketDigestRequest): Buffer =>
+      Buffer.from(GetBucketDigestRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): GetBucketDigestRequest => GetBucketDigestRequest.decode(value),
+    responseSerialize: (value: GetBucketDigestResponse): Buffer =>
+      Buffer.from(GetBucketDigestResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): GetBucketDigestResponse => GetBucketDigestResponse.decode(value),
+  },
+  getBucketSyncTree: {
+    path: "/rpc.rpc.CodeBucket/GetBucketSyncTree",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetBucketSyncTreeRequest): Buffer =>
+      Buffer.from(GetBucketSyncTreeRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): GetBucketSyncTreeRequest => GetBucketSyncTreeRequest.decode(value),
+    responseSerialize: (value: GetBucketSyncTreeResponse): Buffer =>
+      Buffer.from(GetBucketSyncTreeResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): GetBucketSyncTreeResponse => GetBucketSyncTreeResponse.decode(value),
+  },
+  diffBuckets: {
+    path: "/rpc.rpc.CodeBucket/DiffBuckets",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: DiffBucketsRequest): Buffer => Buffer.from(DiffBucketsRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): DiffBucketsRequest => DiffBucketsRequest.decode(value),
+    responseSerialize: (value: DiffBucketsResponse): Buffer => Buffer.from(DiffBucketsResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): DiffBucketsResponse => DiffBucketsResponse.decode(value),
+  },
+  mergeBuckets: {
+    path: "/rpc.rpc.CodeBucket/MergeBuckets",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: MergeBucketsRequest): Buffer => Buffer.from(MergeBucketsRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): MergeBucketsRequest => MergeBucketsRequest.decode(value),
+    responseSerialize: (value: MergeBucketsResponse): Buffer =>
+      Buffer.from(MergeBucketsResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): MergeBucketsResponse => MergeBucketsResponse.decode(value),
+  },
+  searchBucket: {
+    path: "/rpc.rpc.CodeBucket/SearchBucket",
+    requestStream: false,
+    responseStream: true,
+    requestSerialize: (value: SearchBucketRequest): Buffer => Buffer.from(SearchBucketRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): SearchBucketRequest => SearchBucketRequest.decode(value),
+    responseSerialize: (value: SearchBucketResponse): Buffer =>
+      Buffer.from(SearchBucketResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): SearchBucketResponse => SearchBucketResponse.decode(value),
+  },
+  rebuildBucketSearchIndex: {
+    path: "/rpc.rpc.CodeBucket/RebuildBucketSearchIndex",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: RebuildBucketSearchIndexRequest): Buffer =>
+      Buffer.from(RebuildBucketSearchIndexRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): RebuildBucketSearchIndexRequest =>
+      RebuildBucketSearchIndexRequest.decode(value),
+    responseSerialize: (value: RebuildBucketSearchIndexResponse): Buffer =>
+      Buffer.from(RebuildBucketSearchIndexResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): RebuildBucketSearchIndexResponse =>
+      RebuildBucketSearchIndexResponse.decode(value),
+  },
+  watchBucket: {
+    path: "/rpc.rpc.CodeBucket/WatchBucket",
+    requestStream: false,
+    responseStream: true,
+    requestSerialize: (value: WatchBucketRequest): Buffer => Buffer.from(WatchBucketRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): WatchBucketRequest => WatchBucketRequest.decode(value),
+    responseSerialize: (value: BucketEvent): Buffer => Buffer.from(BucketEvent.encode(value).finish()),
+    responseDeserialize: (value: Buffer): BucketEvent => BucketEvent.decode(value),
+  },
   setBucketFiles: {
     path: "/rpc.rpc.CodeBucket/SetBucketFiles",
     requestStream: false,
@@ -2711,6 +11147,17 @@ export const CodeBucketService = {
       Buffer.from(SetBucketFileResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer): SetBucketFileResponse => SetBucketFileResponse.decode(value),
   },
+  applyBucketFileDelta: {
+    path: "/rpc.rpc.CodeBucket/ApplyBucketFileDelta",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ApplyBucketFileDeltaRequest): Buffer =>
+      Buffer.from(ApplyBucketFileDeltaRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): ApplyBucketFileDeltaRequest => ApplyBucketFileDeltaRequest.decode(value),
+    responseSerialize: (value: ApplyBucketFileDeltaResponse): Buffer =>
+      Buffer.from(ApplyBucketFileDeltaResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): ApplyBucketFileDeltaResponse => ApplyBucketFileDeltaResponse.decode(value),
+  },
   deleteBucketFile: {
     path: "/rpc.rpc.CodeBucket/DeleteBucketFile",
     requestStream: false,
@@ -2722,6 +11169,26 @@ export const CodeBucketService = {
       Buffer.from(DeleteBucketFileResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer): DeleteBucketFileResponse => DeleteBucketFileResponse.decode(value),
   },
+  moveBucketFile: {
+    path: "/rpc.rpc.CodeBucket/MoveBucketFile",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: MoveBucketFileRequest): Buffer =>
+      Buffer.from(MoveBucketFileRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): MoveBucketFileRequest => MoveBucketFileRequest.decode(value),
+    responseSerialize: (value: MoveBucketFileResponse): Buffer =>
+      Buffer.from(MoveBucketFileResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): MoveBucketFileResponse => MoveBucketFileResponse.decode(value),
+  },
+  applyPatch: {
+    path: "/rpc.rpc.CodeBucket/ApplyPatch",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ApplyPatchRequest): Buffer => Buffer.from(ApplyPatchRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): ApplyPatchRequest => ApplyPatchRequest.decode(value),
+    responseSerialize: (value: ApplyPatchResponse): Buffer => Buffer.from(ApplyPatchResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): ApplyPatchResponse => ApplyPatchResponse.decode(value),
+  },
   exportBucketToGithub: {
     path: "/rpc.rpc.CodeBucket/ExportBucketToGithub",
     requestStream: false,
@@ -2744,6 +11211,132 @@ export const CodeBucketService = {
       Buffer.from(ExportBucketToGitlabResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer): ExportBucketToGitlabResponse => ExportBucketToGitlabResponse.decode(value),
   },
+  exportBucketToGit: {
+    path: "/rpc.rpc.CodeBucket/ExportBucketToGit",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ExportBucketToGitRequest): Buffer =>
+      Buffer.from(ExportBucketToGitRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): ExportBucketToGitRequest => ExportBucketToGitRequest.decode(value),
+    responseSerialize: (value: ExportBucketToGitResponse): Buffer =>
+      Buffer.from(ExportBucketToGitResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): ExportBucketToGitResponse => ExportBucketToGitResponse.decode(value),
+  },
+  exportBucketToScm: {
+    path: "/rpc.rpc.CodeBucket/ExportBucketToScm",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ExportBucketToScmRequest): Buffer =>
+      Buffer.from(ExportBucketToScmRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): ExportBucketToScmRequest => ExportBucketToScmRequest.decode(value),
+    responseSerialize: (value: ExportBucketToScmResponse): Buffer =>
+      Buffer.from(ExportBucketToScmResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): ExportBucketToScmResponse => ExportBucketToScmResponse.decode(value),
+  },
+  getBucketOverlayChanges: {
+    path: "/rpc.rpc.CodeBucket/GetBucketOverlayChanges",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetBucketOverlayChangesRequest): Buffer =>
+      Buffer.from(GetBucketOverlayChangesRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): GetBucketOverlayChangesRequest => GetBucketOverlayChangesRequest.decode(value),
+    responseSerialize: (value: GetBucketOverlayChangesResponse): Buffer =>
+      Buffer.from(GetBucketOverlayChangesResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): GetBucketOverlayChangesResponse =>
+      GetBucketOverlayChangesResponse.decode(value),
+  },
+  discardBucketOverlay: {
+    path: "/rpc.rpc.CodeBucket/DiscardBucketOverlay",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: DiscardBucketOverlayRequest): Buffer =>
+      Buffer.from(DiscardBucketOverlayRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): DiscardBucketOverlayRequest => DiscardBucketOverlayRequest.decode(value),
+    responseSerialize: (value: DiscardBucketOverlayResponse): Buffer =>
+      Buffer.from(DiscardBucketOverlayResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): DiscardBucketOverlayResponse => DiscardBucketOverlayResponse.decode(value),
+  },
+  commitBucketOverlay: {
+    path: "/rpc.rpc.CodeBucket/CommitBucketOverlay",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CommitBucketOverlayRequest): Buffer =>
+      Buffer.from(CommitBucketOverlayRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): CommitBucketOverlayRequest => CommitBucketOverlayRequest.decode(value),
+    responseSerialize: (value: CommitBucketOverlayResponse): Buffer =>
+      Buffer.from(CommitBucketOverlayResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): CommitBucketOverlayResponse => CommitBucketOverlayResponse.decode(value),
+  },
+  createWebhook: {
+    path: "/rpc.rpc.CodeBucket/CreateWebhook",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CreateWebhookRequest): Buffer => Buffer.from(CreateWebhookRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): CreateWebhookRequest => CreateWebhookRequest.decode(value),
+    responseSerialize: (value: CreateWebhookResponse): Buffer =>
+      Buffer.from(CreateWebhookResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): CreateWebhookResponse => CreateWebhookResponse.decode(value),
+  },
+  listWebhooks: {
+    path: "/rpc.rpc.CodeBucket/ListWebhooks",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ListWebhooksRequest): Buffer => Buffer.from(ListWebhooksRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): ListWebhooksRequest => ListWebhooksRequest.decode(value),
+    responseSerialize: (value: ListWebhooksResponse): Buffer =>
+      Buffer.from(ListWebhooksResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): ListWebhooksResponse => ListWebhooksResponse.decode(value),
+  },
+  deleteWebhook: {
+    path: "/rpc.rpc.CodeBucket/DeleteWebhook",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: DeleteWebhookRequest): Buffer => Buffer.from(DeleteWebhookRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): DeleteWebhookRequest => DeleteWebhookRequest.decode(value),
+    responseSerialize: (value: DeleteWebhookResponse): Buffer =>
+      Buffer.from(DeleteWebhookResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): DeleteWebhookResponse => DeleteWebhookResponse.decode(value),
+  },
+  getWebhookDeliveries: {
+    path: "/rpc.rpc.CodeBucket/GetWebhookDeliveries",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetWebhookDeliveriesRequest): Buffer =>
+      Buffer.from(GetWebhookDeliveriesRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): GetWebhookDeliveriesRequest => GetWebhookDeliveriesRequest.decode(value),
+    responseSerialize: (value: GetWebhookDeliveriesResponse): Buffer =>
+      Buffer.from(GetWebhookDeliveriesResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): GetWebhookDeliveriesResponse => GetWebhookDeliveriesResponse.decode(value),
+  },
+  testWebhook: {
+    path: "/rpc.rpc.CodeBucket/TestWebhook",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: TestWebhookRequest): Buffer => Buffer.from(TestWebhookRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): TestWebhookRequest => TestWebhookRequest.decode(value),
+    responseSerialize: (value: TestWebhookResponse): Buffer => Buffer.from(TestWebhookResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): TestWebhookResponse => TestWebhookResponse.decode(value),
+  },
+  getAuditLog: {
+    path: "/rpc.rpc.CodeBucket/GetAuditLog",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetAuditLogRequest): Buffer => Buffer.from(GetAuditLogRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): GetAuditLogRequest => GetAuditLogRequest.decode(value),
+    responseSerialize: (value: GetAuditLogResponse): Buffer => Buffer.from(GetAuditLogResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): GetAuditLogResponse => GetAuditLogResponse.decode(value),
+  },
+  verifyAuditLog: {
+    path: "/rpc.rpc.CodeBucket/VerifyAuditLog",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: VerifyAuditLogRequest): Buffer =>
+      Buffer.from(VerifyAuditLogRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer): VerifyAuditLogRequest => VerifyAuditLogRequest.decode(value),
+    responseSerialize: (value: VerifyAuditLogResponse): Buffer =>
+      Buffer.from(VerifyAuditLogResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer): VerifyAuditLogResponse => VerifyAuditLogResponse.decode(value),
+  },
 } as const;
 
 export interface CodeBucketServer extends UntypedServiceImplementation {
@@ -2752,16 +11345,45 @@ export interface CodeBucketServer extends UntypedServiceImplementation {
   createBucketFromZip: handleUnaryCall<CreateBucketFromZipRequest, CreateBucketResponse>;
   createBucketFromGithub: handleUnaryCall<CreateBucketFromGithubRequest, CreateBucketResponse>;
   createBucketFromGitlab: handleUnaryCall<CreateBucketFromGitlabRequest, CreateBucketResponse>;
+  createBucketFromGit: handleUnaryCall<CreateBucketFromGitRequest, CreateBucketResponse>;
+  createBucketFromScm: handleUnaryCall<CreateBucketFromScmRequest, CreateBucketResponse>;
+  createBucketOverlay: handleUnaryCall<CreateBucketOverlayRequest, CreateBucketResponse>;
   getBucketToken: handleUnaryCall<GetBucketTokenRequest, GetBucketTokenResponse>;
+  revokeBucketToken: handleUnaryCall<RevokeBucketTokenRequest, RevokeBucketTokenResponse>;
+  revokeAllBucketTokens: handleUnaryCall<RevokeAllBucketTokensRequest, RevokeAllBucketTokensResponse>;
+  getPresignedFileUrl: handleUnaryCall<GetPresignedFileUrlRequest, GetPresignedFileUrlResponse>;
   getBucketFile: handleUnaryCall<GetBucketFileRequest, GetBucketFileResponse>;
   getBucketFiles: handleUnaryCall<GetBucketFilesRequest, GetBucketFilesResponse>;
   getBucketFilesWithContent: handleUnaryCall<GetBucketFilesRequest, GetBucketFilesWithContentResponse>;
   getBucketFilesAsZip: handleUnaryCall<GetBucketFilesAsZipRequest, GetBucketFilesAsZipResponse>;
+  getBucketFilesAsArchive: handleUnaryCall<GetBucketFilesAsArchiveRequest, GetBucketFilesAsArchiveResponse>;
+  getBucketDigest: handleUnaryCall<GetBucketDigestRequest, GetBucketDigestResponse>;
+  getBucketSyncTree: handleUnaryCall<GetBucketSyncTreeRequest, GetBucketSyncTreeResponse>;
+  diffBuckets: handleUnaryCall<DiffBucketsRequest, DiffBucketsResponse>;
+  mergeBuckets: handleUnaryCall<MergeBucketsRequest, MergeBucketsResponse>;
+  searchBucket: handleServerStreamingCall<SearchBucketRequest, SearchBucketResponse>;
+  rebuildBucketSearchIndex: handleUnaryCall<RebuildBucketSearchIndexRequest, RebuildBucketSearchIndexResponse>;
+  watchBucket: handleServerStreamingCall<WatchBucketRequest, BucketEvent>;
   setBucketFiles: handleUnaryCall<SetBucketFilesRequest, SetBucketFilesResponse>;
   setBucketFile: handleUnaryCall<SetBucketFileRequest, SetBucketFileResponse>;
+  applyBucketFileDelta: handleUnaryCall<ApplyBucketFileDeltaRequest, ApplyBucketFileDeltaResponse>;
   deleteBucketFile: handleUnaryCall<DeleteBucketFileRequest, DeleteBucketFileResponse>;
+  moveBucketFile: handleUnaryCall<MoveBucketFileRequest, MoveBucketFileResponse>;
+  applyPatch: handleUnaryCall<ApplyPatchRequest, ApplyPatchResponse>;
   exportBucketToGithub: handleUnaryCall<ExportBucketToGithubRequest, ExportBucketToGithubResponse>;
   exportBucketToGitlab: handleUnaryCall<ExportBucketToGitlabRequest, ExportBucketToGitlabResponse>;
+  exportBucketToGit: handleUnaryCall<ExportBucketToGitRequest, ExportBucketToGitResponse>;
+  exportBucketToScm: handleUnaryCall<ExportBucketToScmRequest, ExportBucketToScmResponse>;
+  getBucketOverlayChanges: handleUnaryCall<GetBucketOverlayChangesRequest, GetBucketOverlayChangesResponse>;
+  discardBucketOverlay: handleUnaryCall<DiscardBucketOverlayRequest, DiscardBucketOverlayResponse>;
+  commitBucketOverlay: handleUnaryCall<CommitBucketOverlayRequest, CommitBucketOverlayResponse>;
+  createWebhook: handleUnaryCall<CreateWebhookRequest, CreateWebhookResponse>;
+  listWebhooks: handleUnaryCall<ListWebhooksRequest, ListWebhooksResponse>;
+  deleteWebhook: handleUnaryCall<DeleteWebhookRequest, DeleteWebhookResponse>;
+  getWebhookDeliveries: handleUnaryCall<GetWebhookDeliveriesRequest, GetWebhookDeliveriesResponse>;
+  testWebhook: handleUnaryCall<TestWebhookRequest, TestWebhookResponse>;
+  getAuditLog: handleUnaryCall<GetAuditLogRequest, GetAuditLogResponse>;
+  verifyAuditLog: handleUnaryCall<VerifyAuditLogRequest, VerifyAuditLogResponse>;
 }
 
 export interface CodeBucketClient extends Client {
@@ -2840,6 +11462,51 @@ export interface CodeBucketClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
   ): ClientUnaryCall;
+  createBucketFromGit(
+    request: CreateBucketFromGitRequest,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketFromGit(
+    request: CreateBucketFromGitRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketFromGit(
+    request: CreateBucketFromGitRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketFromScm(
+    request: CreateBucketFromScmRequest,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketFromScm(
+    request: CreateBucketFromScmRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketFromScm(
+    request: CreateBucketFromScmRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketOverlay(
+    request: CreateBucketOverlayRequest,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketOverlay(
+    request: CreateBucketOverlayRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
+  createBucketOverlay(
+    request: CreateBucketOverlayRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: CreateBucketResponse) => void,
+  ): ClientUnaryCall;
   getBucketToken(
     request: GetBucketTokenRequest,
     callback: (error: ServiceError | null, response: GetBucketTokenResponse) => void,
@@ -2855,6 +11522,51 @@ export interface CodeBucketClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: GetBucketTokenResponse) => void,
   ): ClientUnaryCall;
+  revokeBucketToken(
+    request: RevokeBucketTokenRequest,
+    callback: (error: ServiceError | null, response: RevokeBucketTokenResponse) => void,
+  ): ClientUnaryCall;
+  revokeBucketToken(
+    request: RevokeBucketTokenRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: RevokeBucketTokenResponse) => void,
+  ): ClientUnaryCall;
+  revokeBucketToken(
+    request: RevokeBucketTokenRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: RevokeBucketTokenResponse) => void,
+  ): ClientUnaryCall;
+  revokeAllBucketTokens(
+    request: RevokeAllBucketTokensRequest,
+    callback: (error: ServiceError | null, response: RevokeAllBucketTokensResponse) => void,
+  ): ClientUnaryCall;
+  revokeAllBucketTokens(
+    request: RevokeAllBucketTokensRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: RevokeAllBucketTokensResponse) => void,
+  ): ClientUnaryCall;
+  revokeAllBucketTokens(
+    request: RevokeAllBucketTokensRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: RevokeAllBucketTokensResponse) => void,
+  ): ClientUnaryCall;
+  getPresignedFileUrl(
+    request: GetPresignedFileUrlRequest,
+    callback: (error: ServiceError | null, response: GetPresignedFileUrlResponse) => void,
+  ): ClientUnaryCall;
+  getPresignedFileUrl(
+    request: GetPresignedFileUrlRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: GetPresignedFileUrlResponse) => void,
+  ): ClientUnaryCall;
+  getPresignedFileUrl(
+    request: GetPresignedFileUrlRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: GetPresignedFileUrlResponse) => void,
+  ): ClientUnaryCall;
   getBucketFile(
     request: GetBucketFileRequest,
     callback: (error: ServiceError | null, response: GetBucketFileResponse) => void,
@@ -2915,6 +11627,111 @@ export interface CodeBucketClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: GetBucketFilesAsZipResponse) => void,
   ): ClientUnaryCall;
+  getBucketFilesAsArchive(
+    request: GetBucketFilesAsArchiveRequest,
+    callback: (error: ServiceError | null, response: GetBucketFilesAsArchiveResponse) => void,
+  ): ClientUnaryCall;
+  getBucketFilesAsArchive(
+    request: GetBucketFilesAsArchiveRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: GetBucketFilesAsArchiveResponse) => void,
+  ): ClientUnaryCall;
+  getBucketFilesAsArchive(
+    request: GetBucketFilesAsArchiveRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: GetBucketFilesAsArchiveResponse) => void,
+  ): ClientUnaryCall;
+  getBucketDigest(
+    request: GetBucketDigestRequest,
+    callback: (error: ServiceError | null, response: GetBucketDigestResponse) => void,
+  ): ClientUnaryCall;
+  getBucketDigest(
+    request: GetBucketDigestRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: GetBucketDigestResponse) => void,
+  ): ClientUnaryCall;
+  getBucketDigest(
+    request: GetBucketDigestRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: GetBucketDigestResponse) => void,
+  ): ClientUnaryCall;
+  getBucketSyncTree(
+    request: GetBucketSyncTreeRequest,
+    callback: (error: ServiceError | null, response: GetBucketSyncTreeResponse) => void,
+  ): ClientUnaryCall;
+  getBucketSyncTree(
+    request: GetBucketSyncTreeRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: GetBucketSyncTreeResponse) => void,
+  ): ClientUnaryCall;
+  getBucketSyncTree(
+    request: GetBucketSyncTreeRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: GetBucketSyncTreeResponse) => void,
+  ): ClientUnaryCall;
+  diffBuckets(
+    request: DiffBucketsRequest,
+    callback: (error: ServiceError | null, response: DiffBucketsResponse) => void,
+  ): ClientUnaryCall;
+  diffBuckets(
+    request: DiffBucketsRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: DiffBucketsResponse) => void,
+  ): ClientUnaryCall;
+  diffBuckets(
+    request: DiffBucketsRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: DiffBucketsResponse) => void,
+  ): ClientUnaryCall;
+  mergeBuckets(
+    request: MergeBucketsRequest,
+    callback: (error: ServiceError | null, response: MergeBucketsResponse) => void,
+  ): ClientUnaryCall;
+  mergeBuckets(
+    request: MergeBucketsRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: MergeBucketsResponse) => void,
+  ): ClientUnaryCall;
+  mergeBuckets(
+    request: MergeBucketsRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: MergeBucketsResponse) => void,
+  ): ClientUnaryCall;
+  searchBucket(
+    request: SearchBucketRequest,
+    options?: Partial<CallOptions>,
+  ): ClientReadableStream<SearchBucketResponse>;
+  searchBucket(
+    request: SearchBucketRequest,
+    metadata?: Metadata,
+    options?: Partial<CallOptions>,
+  ): ClientReadableStream<SearchBucketResponse>;
+  rebuildBucketSearchIndex(
+    request: RebuildBucketSearchIndexRequest,
+    callback: (error: ServiceError | null, response: RebuildBucketSearchIndexResponse) => void,
+  ): ClientUnaryCall;
+  rebuildBucketSearchIndex(
+    request: RebuildBucketSearchIndexRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: RebuildBucketSearchIndexResponse) => void,
+  ): ClientUnaryCall;
+  rebuildBucketSearchIndex(
+    request: RebuildBucketSearchIndexRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: RebuildBucketSearchIndexResponse) => void,
+  ): ClientUnaryCall;
+  watchBucket(request: WatchBucketRequest, options?: Partial<CallOptions>): ClientReadableStream<BucketEvent>;
+  watchBucket(
+    request: WatchBucketRequest,
+    metadata?: Metadata,
+    options?: Partial<CallOptions>,
+  ): ClientReadableStream<BucketEvent>;
   setBucketFiles(
     request: SetBucketFilesRequest,
     callback: (error: ServiceError | null, response: SetBucketFilesResponse) => void,
@@ -2945,6 +11762,21 @@ export interface CodeBucketClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: SetBucketFileResponse) => void,
   ): ClientUnaryCall;
+  applyBucketFileDelta(
+    request: ApplyBucketFileDeltaRequest,
+    callback: (error: ServiceError | null, response: ApplyBucketFileDeltaResponse) => void,
+  ): ClientUnaryCall;
+  applyBucketFileDelta(
+    request: ApplyBucketFileDeltaRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ApplyBucketFileDeltaResponse) => void,
+  ): ClientUnaryCall;
+  applyBucketFileDelta(
+    request: ApplyBucketFileDeltaRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ApplyBucketFileDeltaResponse) => void,
+  ): ClientUnaryCall;
   deleteBucketFile(
     request: DeleteBucketFileRequest,
     callback: (error: ServiceError | null, response: DeleteBucketFileResponse) => void,
@@ -2960,6 +11792,36 @@ export interface CodeBucketClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: DeleteBucketFileResponse) => void,
   ): ClientUnaryCall;
+  moveBucketFile(
+    request: MoveBucketFileRequest,
+    callback: (error: ServiceError | null, response: MoveBucketFileResponse) => void,
+  ): ClientUnaryCall;
+  moveBucketFile(
+    request: MoveBucketFileRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: MoveBucketFileResponse) => void,
+  ): ClientUnaryCall;
+  moveBucketFile(
+    request: MoveBucketFileRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: MoveBucketFileResponse) => void,
+  ): ClientUnaryCall;
+  applyPatch(
+    request: ApplyPatchRequest,
+    callback: (error: ServiceError | null, response: ApplyPatchResponse) => void,
+  ): ClientUnaryCall;
+  applyPatch(
+    request: ApplyPatchRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ApplyPatchResponse) => void,
+  ): ClientUnaryCall;
+  applyPatch(
+    request: ApplyPatchRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ApplyPatchResponse) => void,
+  ): ClientUnaryCall;
   exportBucketToGithub(
     request: ExportBucketToGithubRequest,
     callback: (error: ServiceError | null, response: ExportBucketToGithubResponse) => void,
@@ -2990,6 +11852,186 @@ export interface CodeBucketClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: ExportBucketToGitlabResponse) => void,
   ): ClientUnaryCall;
+  exportBucketToGit(
+    request: ExportBucketToGitRequest,
+    callback: (error: ServiceError | null, response: ExportBucketToGitResponse) => void,
+  ): ClientUnaryCall;
+  exportBucketToGit(
+    request: ExportBucketToGitRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ExportBucketToGitResponse) => void,
+  ): ClientUnaryCall;
+  exportBucketToGit(
+    request: ExportBucketToGitRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ExportBucketToGitResponse) => void,
+  ): ClientUnaryCall;
+  exportBucketToScm(
+    request: ExportBucketToScmRequest,
+    callback: (error: ServiceError | null, response: ExportBucketToScmResponse) => void,
+  ): ClientUnaryCall;
+  exportBucketToScm(
+    request: ExportBucketToScmRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ExportBucketToScmResponse) => void,
+  ): ClientUnaryCall;
+  exportBucketToScm(
+    request: ExportBucketToScmRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ExportBucketToScmResponse) => void,
+  ): ClientUnaryCall;
+  getBucketOverlayChanges(
+    request: GetBucketOverlayChangesRequest,
+    callback: (error: ServiceError | null, response: GetBucketOverlayChangesResponse) => void,
+  ): ClientUnaryCall;
+  getBucketOverlayChanges(
+    request: GetBucketOverlayChangesRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: GetBucketOverlayChangesResponse) => void,
+  ): ClientUnaryCall;
+  getBucketOverlayChanges(
+    request: GetBucketOverlayChangesRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: GetBucketOverlayChangesResponse) => void,
+  ): ClientUnaryCall;
+  discardBucketOverlay(
+    request: DiscardBucketOverlayRequest,
+    callback: (error: ServiceError | null, response: DiscardBucketOverlayResponse) => void,
+  ): ClientUnaryCall;
+  discardBucketOverlay(
+    request: DiscardBucketOverlayRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: DiscardBucketOverlayResponse) => void,
+  ): ClientUnaryCall;
+  discardBucketOverlay(
+    request: DiscardBucketOverlayRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: DiscardBucketOverlayResponse) => void,
+  ): ClientUnaryCall;
+  commitBucketOverlay(
+    request: CommitBucketOverlayRequest,
+    callback: (error: ServiceError | null, response: CommitBucketOverlayResponse) => void,
+  ): ClientUnaryCall;
+  commitBucketOverlay(
+    request: CommitBucketOverlayRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: CommitBucketOverlayResponse) => void,
+  ): ClientUnaryCall;
+  commitBucketOverlay(
+    request: CommitBucketOverlayRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: CommitBucketOverlayResponse) => void,
+  ): ClientUnaryCall;
+  createWebhook(
+    request: CreateWebhookRequest,
+    callback: (error: ServiceError | null, response: CreateWebhookResponse) => void,
+  ): ClientUnaryCall;
+  createWebhook(
+    request: CreateWebhookRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: CreateWebhookResponse) => void,
+  ): ClientUnaryCall;
+  createWebhook(
+    request: CreateWebhookRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: CreateWebhookResponse) => void,
+  ): ClientUnaryCall;
+  listWebhooks(
+    request: ListWebhooksRequest,
+    callback: (error: ServiceError | null, response: ListWebhooksResponse) => void,
+  ): ClientUnaryCall;
+  listWebhooks(
+    request: ListWebhooksRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ListWebhooksResponse) => void,
+  ): ClientUnaryCall;
+  listWebhooks(
+    request: ListWebhooksRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ListWebhooksResponse) => void,
+  ): ClientUnaryCall;
+  deleteWebhook(
+    request: DeleteWebhookRequest,
+    callback: (error: ServiceError | null, response: DeleteWebhookResponse) => void,
+  ): ClientUnaryCall;
+  deleteWebhook(
+    request: DeleteWebhookRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: DeleteWebhookResponse) => void,
+  ): ClientUnaryCall;
+  deleteWebhook(
+    request: DeleteWebhookRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: DeleteWebhookResponse) => void,
+  ): ClientUnaryCall;
+  getWebhookDeliveries(
+    request: GetWebhookDeliveriesRequest,
+    callback: (error: ServiceError | null, response: GetWebhookDeliveriesResponse) => void,
+  ): ClientUnaryCall;
+  getWebhookDeliveries(
+    request: GetWebhookDeliveriesRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: GetWebhookDeliveriesResponse) => void,
+  ): ClientUnaryCall;
+  getWebhookDeliveries(
+    request: GetWebhookDeliveriesRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: GetWebhookDeliveriesResponse) => void,
+  ): ClientUnaryCall;
+  testWebhook(
+    request: TestWebhookRequest,
+    callback: (error: ServiceError | null, response: TestWebhookResponse) => void,
+  ): ClientUnaryCall;
+  testWebhook(
+    request: TestWebhookRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: TestWebhookResponse) => void,
+  ): ClientUnaryCall;
+  testWebhook(
+    request: TestWebhookRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: TestWebhookResponse) => void,
+  ): ClientUnaryCall;
+  getAuditLog(
+    request: GetAuditLogRequest,
+    callback: (error: ServiceError | null, response: GetAuditLogResponse) => void,
+  ): ClientUnaryCall;
+  getAuditLog(
+    request: GetAuditLogRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: GetAuditLogResponse) => void,
+  ): ClientUnaryCall;
+  getAuditLog(
+    request: GetAuditLogRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: GetAuditLogResponse) => void,
+  ): ClientUnaryCall;
+  verifyAuditLog(
+    request: VerifyAuditLogRequest,
+    callback: (error: ServiceError | null, response: VerifyAuditLogResponse) => void,
+  ): ClientUnaryCall;
+  verifyAuditLog(
+    request: VerifyAuditLogRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: VerifyAuditLogResponse) => void,
+  ): ClientUnaryCall;
+  verifyAuditLog(
+    request: VerifyAuditLogRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: VerifyAuditLogResponse) => void,
+  ): ClientUnaryCall;
 }
 
 export const CodeBucketClient = makeGenericClientConstructor(CodeBucketService, "rpc.rpc.CodeBucket") as unknown as {